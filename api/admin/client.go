@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
@@ -31,6 +32,8 @@ type Client interface {
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	GetPeerEvents(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]network.PeerEvent, error)
+	GetBandwidth(ctx context.Context, options ...rpc.Option) (*GetBandwidthReply, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -163,3 +166,17 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+func (c *client) GetPeerEvents(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]network.PeerEvent, error) {
+	res := &GetPeerEventsReply{}
+	err := c.requester.SendRequest(ctx, "admin.getPeerEvents", &GetPeerEventsArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res.Events, err
+}
+
+func (c *client) GetBandwidth(ctx context.Context, options ...rpc.Option) (*GetBandwidthReply, error) {
+	res := &GetBandwidthReply{}
+	err := c.requester.SendRequest(ctx, "admin.getBandwidth", struct{}{}, res, options...)
+	return res, err
+}
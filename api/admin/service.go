@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"path"
+	"slices"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -50,6 +52,7 @@ type Config struct {
 	NodeConfig   interface{}
 	DB           database.Database
 	ChainManager chains.Manager
+	Network      network.Network
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
@@ -413,3 +416,96 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+type GetPeerEventsArgs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+type GetPeerEventsReply struct {
+	Events []network.PeerEvent `json:"events"`
+}
+
+// GetPeerEvents returns the most recent peer lifecycle events retained by the
+// network, from oldest to newest.
+// If len([args.NodeIDs]) == 0, returns the events of all peers.
+// Otherwise, returns the events of the peers in [args.NodeIDs].
+func (a *Admin) GetPeerEvents(_ *http.Request, args *GetPeerEventsArgs, reply *GetPeerEventsReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getPeerEvents"),
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+
+	reply.Events = a.Network.PeerEvents(args.NodeIDs)
+	if reply.Events == nil {
+		reply.Events = []network.PeerEvent{}
+	}
+	return nil
+}
+
+type Bandwidth struct {
+	Sent     json.Uint64 `json:"sent"`
+	Received json.Uint64 `json:"received"`
+}
+
+type ChainBandwidth struct {
+	ChainID  ids.ID `json:"chainID"`
+	SubnetID ids.ID `json:"subnetID"`
+	Bandwidth
+}
+
+type SubnetBandwidth struct {
+	SubnetID ids.ID `json:"subnetID"`
+	Bandwidth
+}
+
+type GetBandwidthReply struct {
+	Chains  []ChainBandwidth  `json:"chains"`
+	Subnets []SubnetBandwidth `json:"subnets"`
+}
+
+// GetBandwidth returns the number of message bytes that were sent to and
+// received from peers for each chain, and for each subnet, that this node has
+// created a chain for.
+func (a *Admin) GetBandwidth(_ *http.Request, _ *struct{}, reply *GetBandwidthReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getBandwidth"),
+	)
+
+	chainBandwidth := a.Network.ChainBandwidth()
+	subnetBandwidth := make(map[ids.ID]*SubnetBandwidth)
+	reply.Chains = make([]ChainBandwidth, 0, len(chainBandwidth))
+	for chainID, bandwidth := range chainBandwidth {
+		reply.Chains = append(reply.Chains, ChainBandwidth{
+			ChainID:  chainID,
+			SubnetID: bandwidth.SubnetID,
+			Bandwidth: Bandwidth{
+				Sent:     json.Uint64(bandwidth.Sent),
+				Received: json.Uint64(bandwidth.Received),
+			},
+		})
+
+		subnet, ok := subnetBandwidth[bandwidth.SubnetID]
+		if !ok {
+			subnet = &SubnetBandwidth{
+				SubnetID: bandwidth.SubnetID,
+			}
+			subnetBandwidth[bandwidth.SubnetID] = subnet
+		}
+		subnet.Sent += json.Uint64(bandwidth.Sent)
+		subnet.Received += json.Uint64(bandwidth.Received)
+	}
+	slices.SortFunc(reply.Chains, func(a, b ChainBandwidth) int {
+		return a.ChainID.Compare(b.ChainID)
+	})
+
+	reply.Subnets = make([]SubnetBandwidth, 0, len(subnetBandwidth))
+	for _, subnet := range subnetBandwidth {
+		reply.Subnets = append(reply.Subnets, *subnet)
+	}
+	slices.SortFunc(reply.Subnets, func(a, b SubnetBandwidth) int {
+		return a.SubnetID.Compare(b.SubnetID)
+	})
+	return nil
+}
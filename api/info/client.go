@@ -28,6 +28,7 @@ type Client interface {
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	PeerReportedVersions(context.Context, string, ...rpc.Option) (*PeerReportedVersionsReply, error)
 }
 
 // Client implementation for an Info API Client
@@ -135,3 +136,11 @@ func AwaitBootstrapped(ctx context.Context, c Client, chainID string, freq time.
 		}
 	}
 }
+
+func (c *client) PeerReportedVersions(ctx context.Context, minVersion string, options ...rpc.Option) (*PeerReportedVersionsReply, error) {
+	res := &PeerReportedVersionsReply{}
+	err := c.requester.SendRequest(ctx, "info.peerReportedVersions", &PeerReportedVersionsArgs{
+		MinVersion: minVersion,
+	}, res, options...)
+	return res, err
+}
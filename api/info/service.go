@@ -384,6 +384,99 @@ func (i *Info) Acps(_ *http.Request, _ *struct{}, reply *ACPsReply) error {
 	return nil
 }
 
+type PeerReportedVersionsArgs struct {
+	// MinVersion is an optional application version, such as
+	// "avalanchego/1.11.5". If provided, the weight of the connected
+	// validators running an older version is reported.
+	MinVersion string `json:"minVersion"`
+}
+
+type PeerReportedVersion struct {
+	Weight json.Uint64         `json:"weight"`
+	Nodes  set.Set[ids.NodeID] `json:"nodes"`
+}
+
+type PeerReportedVersionsReply struct {
+	// Versions maps each version reported by a connected primary network
+	// validator to the stake running it.
+	Versions map[string]*PeerReportedVersion `json:"versions"`
+	// BelowMinimumWeight is the stake of the connected validators running a
+	// version older than the requested MinVersion.
+	BelowMinimumWeight json.Uint64 `json:"belowMinimumWeight"`
+	// UnknownWeight is the stake of the validators that are not connected.
+	UnknownWeight json.Uint64 `json:"unknownWeight"`
+}
+
+// PeerReportedVersions returns the distribution of node versions that the
+// connected primary network validators report about themselves over p2p,
+// weighted by stake.
+//
+// The versions are self-reported and unauthenticated, so a peer can report
+// any version. They are a best-effort signal for upgrade coordination, not a
+// source of truth.
+func (i *Info) PeerReportedVersions(_ *http.Request, args *PeerReportedVersionsArgs, reply *PeerReportedVersionsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "peerReportedVersions"),
+	)
+
+	var minVersion *version.Application
+	if args.MinVersion != "" {
+		var err error
+		minVersion, err = version.ParseApplication(args.MinVersion)
+		if err != nil {
+			return fmt.Errorf("couldn't parse minVersion: %w", err)
+		}
+	}
+
+	// The weights are read from a single snapshot of the validator set so that
+	// the connected weight can't exceed the total weight.
+	vdrs := i.validators.GetMap(constants.PrimaryNetworkID)
+	var totalWeight json.Uint64
+	for _, vdr := range vdrs {
+		totalWeight += json.Uint64(vdr.Weight)
+	}
+
+	reply.Versions = make(map[string]*PeerReportedVersion)
+	var (
+		connectedWeight json.Uint64
+		counted         = set.NewSet[ids.NodeID](len(vdrs))
+	)
+	addValidator := func(nodeID ids.NodeID, versionStr string) {
+		vdr, ok := vdrs[nodeID]
+		if !ok || vdr.Weight == 0 || counted.Contains(nodeID) {
+			return
+		}
+		counted.Add(nodeID)
+		weight := json.Uint64(vdr.Weight)
+
+		vdrVersion, ok := reply.Versions[versionStr]
+		if !ok {
+			vdrVersion = &PeerReportedVersion{}
+			reply.Versions[versionStr] = vdrVersion
+		}
+		vdrVersion.Weight += weight
+		vdrVersion.Nodes.Add(nodeID)
+		connectedWeight += weight
+
+		if minVersion == nil {
+			return
+		}
+		vdrAppVersion, err := version.ParseApplication(versionStr)
+		if err != nil || vdrAppVersion.Before(minVersion) {
+			reply.BelowMinimumWeight += weight
+		}
+	}
+
+	addValidator(i.NodeID, i.Version.String())
+	for _, peer := range i.networking.PeerInfo(nil) {
+		addValidator(peer.ID, peer.Version)
+	}
+
+	reply.UnknownWeight = totalWeight - connectedWeight
+	return nil
+}
+
 type GetTxFeeResponse struct {
 	TxFee                         json.Uint64 `json:"txFee"`
 	CreateAssetTxFee              json.Uint64 `json:"createAssetTxFee"`
@@ -11,7 +11,14 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 )
 
@@ -94,3 +101,74 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+type testNetwork struct {
+	network.Network
+	peers []peer.Info
+}
+
+func (n *testNetwork) PeerInfo([]ids.NodeID) []peer.Info {
+	return n.peers
+}
+
+func TestPeerReportedVersions(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		myNodeID           = ids.GenerateTestNodeID()
+		oldNodeID          = ids.GenerateTestNodeID()
+		disconnectedNodeID = ids.GenerateTestNodeID()
+		nonValidatorNodeID = ids.GenerateTestNodeID()
+		myVersion          = &version.Application{
+			Name:  version.Client,
+			Major: 1,
+			Minor: 11,
+			Patch: 5,
+		}
+		oldVersion = &version.Application{
+			Name:  version.Client,
+			Major: 1,
+			Minor: 11,
+			Patch: 4,
+		}
+	)
+
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, myNodeID, nil, ids.Empty, 10))
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, oldNodeID, nil, ids.Empty, 20))
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, disconnectedNodeID, nil, ids.Empty, 30))
+
+	info := &Info{
+		Parameters: Parameters{
+			Version: myVersion,
+			NodeID:  myNodeID,
+		},
+		log:        logging.NoLog{},
+		validators: vdrs,
+		networking: &testNetwork{
+			peers: []peer.Info{
+				{ID: oldNodeID, Version: oldVersion.String()},
+				// A peer reported twice must only be counted once.
+				{ID: oldNodeID, Version: oldVersion.String()},
+				{ID: nonValidatorNodeID, Version: oldVersion.String()},
+			},
+		},
+	}
+
+	reply := PeerReportedVersionsReply{}
+	require.NoError(info.PeerReportedVersions(nil, &PeerReportedVersionsArgs{
+		MinVersion: myVersion.String(),
+	}, &reply))
+	require.Equal(map[string]*PeerReportedVersion{
+		myVersion.String(): {
+			Weight: 10,
+			Nodes:  set.Of(myNodeID),
+		},
+		oldVersion.String(): {
+			Weight: 20,
+			Nodes:  set.Of(oldNodeID),
+		},
+	}, reply.Versions)
+	require.Equal(json.Uint64(20), reply.BelowMinimumWeight)
+	require.Equal(json.Uint64(30), reply.UnknownWeight)
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/linked"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ Cacher[struct{}, any] = (*ttl[struct{}, any])(nil)
+
+// TTLConfig exposes parameters for a TTL cache
+type TTLConfig[K comparable, V any] struct {
+	// Clock is used to determine when entries expire. If nil, the system
+	// clock is used.
+	Clock *mockable.Clock
+	// TTL is the duration after an entry was last put that it expires.
+	TTL time.Duration
+	// MaxSize is the maximum total size of the entries in the cache.
+	MaxSize int
+	// Size returns the size of an entry. If nil, every entry has a size of 1,
+	// which bounds the number of entries to MaxSize.
+	Size func(K, V) int
+	// OnEvict, if non-nil, is called with every entry that is removed from the
+	// cache, whether it expired, was evicted to make room for another entry,
+	// or was explicitly evicted or flushed. It is not called when the value of
+	// an entry is replaced. OnEvict is called while the cache is locked, so it
+	// must not call into the cache.
+	OnEvict func(K, V)
+}
+
+// ttl is a key value store whose entries expire after a fixed duration since
+// they were last put. If the size is attempted to be exceeded, then elements
+// are removed from the cache until the bound is honored, based on evicting
+// the least recently put value.
+type ttl[K comparable, V any] struct {
+	lock        sync.Mutex
+	clock       *mockable.Clock
+	elements    *linked.Hashmap[K, *ttlEntry[V]]
+	ttl         time.Duration
+	maxSize     int
+	currentSize int
+	size        func(K, V) int
+	onEvict     func(K, V)
+}
+
+type ttlEntry[V any] struct {
+	value  V
+	expiry time.Time
+}
+
+// NewTTL returns a cache whose entries expire after [config.TTL].
+func NewTTL[K comparable, V any](config TTLConfig[K, V]) Cacher[K, V] {
+	c := &ttl[K, V]{
+		clock:    config.Clock,
+		elements: linked.NewHashmap[K, *ttlEntry[V]](),
+		ttl:      config.TTL,
+		maxSize:  config.MaxSize,
+		size:     config.Size,
+		onEvict:  config.OnEvict,
+	}
+	if c.clock == nil {
+		c.clock = &mockable.Clock{}
+	}
+	if c.size == nil {
+		c.size = func(K, V) int {
+			return 1
+		}
+	}
+	return c
+}
+
+func (c *ttl[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *ttl[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *ttl[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *ttl[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *ttl[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.removeExpired()
+	return c.elements.Len()
+}
+
+func (c *ttl[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.removeExpired()
+	return float64(c.currentSize) / float64(c.maxSize)
+}
+
+func (c *ttl[K, V]) put(key K, value V) {
+	c.removeExpired()
+
+	newEntrySize := c.size(key, value)
+	if newEntrySize > c.maxSize {
+		c.flush()
+		return
+	}
+
+	if oldEntry, ok := c.elements.Get(key); ok {
+		c.currentSize -= c.size(key, oldEntry.value)
+		c.elements.Delete(key)
+	}
+
+	// Remove elements until the size of elements in the cache <= [c.maxSize].
+	for c.currentSize > c.maxSize-newEntrySize {
+		c.removeOldest()
+	}
+
+	c.elements.Put(key, &ttlEntry[V]{
+		value:  value,
+		expiry: c.clock.Time().Add(c.ttl),
+	})
+	c.currentSize += newEntrySize
+}
+
+func (c *ttl[K, V]) get(key K) (V, bool) {
+	c.removeExpired()
+
+	entry, ok := c.elements.Get(key)
+	if !ok {
+		return utils.Zero[V](), false
+	}
+	return entry.value, true
+}
+
+func (c *ttl[K, _]) evict(key K) {
+	entry, ok := c.elements.Get(key)
+	if !ok {
+		return
+	}
+
+	c.elements.Delete(key)
+	c.currentSize -= c.size(key, entry.value)
+	if c.onEvict != nil {
+		c.onEvict(key, entry.value)
+	}
+}
+
+func (c *ttl[K, V]) flush() {
+	if c.onEvict != nil {
+		it := c.elements.NewIterator()
+		for it.Next() {
+			c.onEvict(it.Key(), it.Value().value)
+		}
+	}
+
+	c.elements.Clear()
+	c.currentSize = 0
+}
+
+// removeExpired removes the entries whose expiry has passed. Because entries
+// are ordered by when they were put, and all entries share the same TTL, only
+// the oldest entries need to be inspected.
+func (c *ttl[_, _]) removeExpired() {
+	now := c.clock.Time()
+	for {
+		_, oldestEntry, ok := c.elements.Oldest()
+		if !ok || now.Before(oldestEntry.expiry) {
+			return
+		}
+		c.removeOldest()
+	}
+}
+
+func (c *ttl[_, _]) removeOldest() {
+	oldestKey, _, ok := c.elements.Oldest()
+	if ok {
+		c.evict(oldestKey)
+	}
+}
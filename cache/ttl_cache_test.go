@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func TestTTL(t *testing.T) {
+	cache := NewTTL(TTLConfig[ids.ID, int64]{
+		TTL:     time.Hour,
+		MaxSize: TestIntSize,
+		Size:    TestIntSizeFunc,
+	})
+
+	TestBasic(t, cache)
+}
+
+func TestTTLExpiry(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(0, 0))
+	cache := NewTTL(TTLConfig[string, int]{
+		Clock:   clock,
+		TTL:     time.Minute,
+		MaxSize: 10,
+	})
+
+	cache.Put("a", 1)
+	clock.Set(clock.Time().Add(30 * time.Second))
+	cache.Put("b", 2)
+
+	// Reading an entry doesn't extend its lifetime.
+	clock.Set(clock.Time().Add(20 * time.Second))
+	value, ok := cache.Get("a")
+	require.True(ok)
+	require.Equal(1, value)
+
+	clock.Set(clock.Time().Add(10 * time.Second))
+	_, ok = cache.Get("a")
+	require.False(ok)
+	value, ok = cache.Get("b")
+	require.True(ok)
+	require.Equal(2, value)
+	require.Equal(1, cache.Len())
+
+	// Putting an entry again extends its lifetime.
+	cache.Put("b", 3)
+	clock.Set(clock.Time().Add(59 * time.Second))
+	value, ok = cache.Get("b")
+	require.True(ok)
+	require.Equal(3, value)
+
+	clock.Set(clock.Time().Add(time.Second))
+	_, ok = cache.Get("b")
+	require.False(ok)
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+}
+
+func TestTTLSizeEviction(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewTTL(TTLConfig[string, struct{}]{
+		TTL:     time.Hour,
+		MaxSize: 3,
+		Size: func(key string, _ struct{}) int {
+			return len(key)
+		},
+	})
+
+	cache.Put("a", struct{}{})
+	cache.Put("b", struct{}{})
+	cache.Put("c", struct{}{})
+
+	// Reading an entry doesn't protect it from eviction.
+	_, ok := cache.Get("a")
+	require.True(ok)
+
+	cache.Put("dd", struct{}{})
+
+	_, ok = cache.Get("a")
+	require.False(ok)
+	_, ok = cache.Get("b")
+	require.False(ok)
+	_, ok = cache.Get("c")
+	require.True(ok)
+	_, ok = cache.Get("dd")
+	require.True(ok)
+	require.Equal(1.0, cache.PortionFilled())
+
+	// Entries that can never fit flush the cache.
+	cache.Put("eeee", struct{}{})
+	require.Zero(cache.Len())
+}
+
+func TestTTLOnEvict(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	evicted := make(map[string]int)
+	cache := NewTTL(TTLConfig[string, int]{
+		Clock:   clock,
+		TTL:     time.Minute,
+		MaxSize: 2,
+		OnEvict: func(key string, value int) {
+			evicted[key] = value
+		},
+	})
+
+	// Replacing a value doesn't evict the entry.
+	cache.Put("a", 0)
+	cache.Put("a", 1)
+	require.Empty(evicted)
+
+	// Expired entries are evicted.
+	clock.Set(clock.Time().Add(time.Minute))
+	require.Zero(cache.Len())
+	require.Equal(map[string]int{"a": 1}, evicted)
+
+	// Entries are evicted to make room for new entries.
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+	require.Equal(map[string]int{"a": 1, "b": 2}, evicted)
+
+	// Explicitly evicted entries are evicted.
+	cache.Evict("c")
+	require.Equal(map[string]int{"a": 1, "b": 2, "c": 3}, evicted)
+
+	// Flushed entries are evicted.
+	cache.Flush()
+	require.Equal(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}, evicted)
+}
@@ -17,6 +17,7 @@ var (
 	ErrExtraSpace                = errors.New("trailing buffer space")
 	ErrMarshalZeroLength         = errors.New("can't marshal zero length value")
 	ErrUnmarshalZeroLength       = errors.New("can't unmarshal zero length value")
+	ErrUnknownTypeID             = errors.New("unknown type ID")
 )
 
 // Codec marshals and unmarshals
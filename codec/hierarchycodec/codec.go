@@ -131,7 +131,7 @@ func (c *hierarchyCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type
 	// Get a type that implements the interface
 	implementingType, ok := c.registeredTypes.GetValue(t)
 	if !ok {
-		return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: unknown type ID %+v", t)
+		return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: %w %+v", codec.ErrUnknownTypeID, t)
 	}
 	// Ensure type actually does implement the interface
 	if !implementingType.Implements(valueType) {
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -72,6 +73,10 @@ var (
 	errInvalidDelegationFee                   = errors.New("delegation fee must be in the range [0, 1,000,000]")
 	errInvalidMinStakeDuration                = errors.New("min stake duration must be > 0")
 	errMinStakeDurationAboveMax               = errors.New("max stake duration can't be less than min stake duration")
+	errInvalidMinDelegationDuration           = errors.New("min delegation duration must be > 0")
+	errMinDelegationDurationAboveMax          = errors.New("max stake duration can't be less than min delegation duration")
+	errInvalidMaxFutureStartTime              = errors.New("max future start time must be > 0")
+	errInvalidDelegationFeeChangeCooldown     = errors.New("delegation fee change cooldown must be >= 0")
 	errStakeMaxConsumptionTooLarge            = fmt.Errorf("max stake consumption must be less than or equal to %d", reward.PercentDenominator)
 	errStakeMaxConsumptionBelowMin            = errors.New("stake max consumption can't be less than min stake consumption")
 	errStakeMintingPeriodBelowMin             = errors.New("stake minting period can't be less than max stake duration")
@@ -284,6 +289,15 @@ func getNetworkConfig(
 		return network.Config{}, errConflictingImplicitACPOpinion
 	}
 
+	supportedFeatures := set.Of(peer.KnownFeatures...)
+	for _, name := range v.GetStringSlice(NetworkDisabledFeaturesKey) {
+		feature, err := peer.ParseFeature(name)
+		if err != nil {
+			return network.Config{}, err
+		}
+		supportedFeatures.Remove(feature)
+	}
+
 	// Because this node version has scheduled these ACPs, we should notify
 	// peers that we support these upgrades.
 	supportedACPs.Union(constants.ScheduledACPs)
@@ -365,12 +379,24 @@ func getNetworkConfig(
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
 		MaximumInboundMessageTimeout: v.GetDuration(NetworkMaximumInboundTimeoutKey),
 
-		SupportedACPs: supportedACPs,
-		ObjectedACPs:  objectedACPs,
+		SupportedACPs:     supportedACPs,
+		ObjectedACPs:      objectedACPs,
+		SupportedFeatures: supportedFeatures,
 
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerEventLogSize:          int(v.GetUint(NetworkPeerEventLogSizeKey)),
+		PeerStoreNumSeeds:         int(v.GetUint(NetworkPeerStoreNumSeedsKey)),
+		MaxPeersPerASN:            int(v.GetUint(NetworkMaxPeersPerASNKey)),
+	}
+
+	if asnDBFile := GetExpandedArg(v, NetworkASNDBFileKey); asnDBFile != "" {
+		asnTable, err := network.LoadASNTable(asnDBFile)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't load %s: %w", NetworkASNDBFileKey, err)
+		}
+		config.ASNLookup = asnTable
 	}
 
 	switch {
@@ -408,6 +434,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.PeerEventLogSize <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerEventLogSizeKey)
 	}
 	return config, nil
 }
@@ -728,7 +756,15 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		config.MaxValidatorStake = v.GetUint64(MaxValidatorStakeKey)
 		config.MinDelegatorStake = v.GetUint64(MinDelegatorStakeKey)
 		config.MinStakeDuration = v.GetDuration(MinStakeDurationKey)
+		// Delegations used to be bound by the min stake duration, so it
+		// remains the default for networks that don't set the new flag.
+		config.MinDelegationDuration = config.MinStakeDuration
+		if v.IsSet(MinDelegationDurationKey) {
+			config.MinDelegationDuration = v.GetDuration(MinDelegationDurationKey)
+		}
 		config.MaxStakeDuration = v.GetDuration(MaxStakeDurationKey)
+		config.MaxFutureStartTime = v.GetDuration(MaxFutureStartTimeKey)
+		config.DelegationFeeChangeCooldown = v.GetDuration(DelegationFeeChangeCooldownKey)
 		config.RewardConfig.MaxConsumptionRate = v.GetUint64(StakeMaxConsumptionRateKey)
 		config.RewardConfig.MinConsumptionRate = v.GetUint64(StakeMinConsumptionRateKey)
 		config.RewardConfig.MintingPeriod = v.GetDuration(StakeMintingPeriodKey)
@@ -745,6 +781,14 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 			return node.StakingConfig{}, errInvalidMinStakeDuration
 		case config.MaxStakeDuration < config.MinStakeDuration:
 			return node.StakingConfig{}, errMinStakeDurationAboveMax
+		case config.MinDelegationDuration <= 0:
+			return node.StakingConfig{}, errInvalidMinDelegationDuration
+		case config.MaxStakeDuration < config.MinDelegationDuration:
+			return node.StakingConfig{}, errMinDelegationDurationAboveMax
+		case config.MaxFutureStartTime <= 0:
+			return node.StakingConfig{}, errInvalidMaxFutureStartTime
+		case config.DelegationFeeChangeCooldown < 0:
+			return node.StakingConfig{}, errInvalidDelegationFeeChangeCooldown
 		case config.RewardConfig.MaxConsumptionRate > reward.PercentDenominator:
 			return node.StakingConfig{}, errStakeMaxConsumptionTooLarge
 		case config.RewardConfig.MaxConsumptionRate < config.RewardConfig.MinConsumptionRate:
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -20,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
 const chainConfigFilenameExtention = ".ex"
@@ -575,3 +577,38 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestGetStakingConfigMinDelegationDuration(t *testing.T) {
+	tests := map[string]struct {
+		minStakeDuration              time.Duration
+		minDelegationDuration         time.Duration
+		expectedMinDelegationDuration time.Duration
+	}{
+		"defaults to min stake duration": {
+			minStakeDuration:              time.Second,
+			expectedMinDelegationDuration: time.Second,
+		},
+		"set": {
+			minStakeDuration:              time.Second,
+			minDelegationDuration:         time.Minute,
+			expectedMinDelegationDuration: time.Minute,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(StakingEphemeralCertEnabledKey, true)
+			v.Set(StakingEphemeralSignerEnabledKey, true)
+			v.Set(MinStakeDurationKey, test.minStakeDuration)
+			if test.minDelegationDuration != 0 {
+				v.Set(MinDelegationDurationKey, test.minDelegationDuration)
+			}
+
+			config, err := getStakingConfig(v, constants.LocalID)
+			require.NoError(err)
+			require.Equal(test.expectedMinDelegationDuration, config.MinDelegationDuration)
+		})
+	}
+}
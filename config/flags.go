@@ -163,6 +163,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerEventLogSizeKey, constants.DefaultNetworkPeerEventLogSize, "Number of recent peer lifecycle events (connects, disconnects, and failed handshakes) to retain in memory")
+	fs.Uint(NetworkPeerStoreNumSeedsKey, constants.DefaultNetworkPeerStoreNumSeeds, "Number of previously connected peers, preferring the most recently connected, to dial on startup")
+	fs.String(NetworkASNDBFileKey, "", "Path to a file mapping IP prefixes to the ASNs that announce them. If provided, the ASNs of peers are reported and used to diversify the peers that are dialed")
+	fs.Uint(NetworkMaxPeersPerASNKey, constants.DefaultNetworkMaxPeersPerASN, "Number of connected peers in an ASN after which dialing more non-validator peers in that ASN is deferred. If 0, dialing is never deferred")
+	fs.StringSlice(NetworkDisabledFeaturesKey, nil, "Optional p2p features to not advertise to peers during the handshake")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -262,8 +267,14 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(MinDelegatorFeeKey, uint64(genesis.LocalParams.MinDelegationFee), "Minimum delegation fee, in the range [0, 1000000], that can be charged for delegation on the primary network")
 	// Minimum Stake Duration
 	fs.Duration(MinStakeDurationKey, genesis.LocalParams.MinStakeDuration, "Minimum staking duration")
+	// Minimum Delegation Duration
+	fs.Duration(MinDelegationDurationKey, genesis.LocalParams.MinDelegationDuration, fmt.Sprintf("Minimum delegation duration on the primary network. Defaults to --%s if not set", MinStakeDurationKey))
 	// Maximum Stake Duration
 	fs.Duration(MaxStakeDurationKey, genesis.LocalParams.MaxStakeDuration, "Maximum staking duration")
+	// Maximum Future Start Time
+	fs.Duration(MaxFutureStartTimeKey, genesis.LocalParams.MaxFutureStartTime, "Maximum amount of time after the chain time that a staker can be scheduled to start before Durango")
+	// Delegation Fee Change Cooldown
+	fs.Duration(DelegationFeeChangeCooldownKey, genesis.LocalParams.DelegationFeeChangeCooldown, "Minimum amount of time between two changes of a validator's delegation fee")
 	// Stake Reward Configs
 	fs.Uint64(StakeMaxConsumptionRateKey, genesis.LocalParams.RewardConfig.MaxConsumptionRate, "Maximum consumption rate of the remaining tokens to mint in the staking function")
 	fs.Uint64(StakeMinConsumptionRateKey, genesis.LocalParams.RewardConfig.MinConsumptionRate, "Minimum consumption rate of the remaining tokens to mint in the staking function")
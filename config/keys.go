@@ -33,7 +33,10 @@ const (
 	MinDelegatorStakeKey             = "min-delegator-stake"
 	MinDelegatorFeeKey               = "min-delegation-fee"
 	MinStakeDurationKey              = "min-stake-duration"
+	MinDelegationDurationKey         = "min-delegation-duration"
 	MaxStakeDurationKey              = "max-stake-duration"
+	MaxFutureStartTimeKey            = "max-future-start-time"
+	DelegationFeeChangeCooldownKey   = "delegation-fee-change-cooldown"
 	StakeMaxConsumptionRateKey       = "stake-max-consumption-rate"
 	StakeMinConsumptionRateKey       = "stake-min-consumption-rate"
 	StakeMintingPeriodKey            = "stake-minting-period"
@@ -104,6 +107,11 @@ const (
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerEventLogSizeKey                         = "network-peer-event-log-size"
+	NetworkPeerStoreNumSeedsKey                        = "network-peer-store-num-seeds"
+	NetworkASNDBFileKey                                = "network-asn-db-file"
+	NetworkMaxPeersPerASNKey                           = "network-max-peers-per-asn"
+	NetworkDisabledFeaturesKey                         = "network-disabled-features"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
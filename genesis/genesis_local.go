@@ -48,13 +48,16 @@ var (
 			AddSubnetDelegatorFee:         units.MilliAvax,
 		},
 		StakingConfig: StakingConfig{
-			UptimeRequirement: .8, // 80%
-			MinValidatorStake: 2 * units.KiloAvax,
-			MaxValidatorStake: 3 * units.MegaAvax,
-			MinDelegatorStake: 25 * units.Avax,
-			MinDelegationFee:  20000, // 2%
-			MinStakeDuration:  24 * time.Hour,
-			MaxStakeDuration:  365 * 24 * time.Hour,
+			UptimeRequirement:           .8, // 80%
+			MinValidatorStake:           2 * units.KiloAvax,
+			MaxValidatorStake:           3 * units.MegaAvax,
+			MinDelegatorStake:           25 * units.Avax,
+			MinDelegationFee:            20000, // 2%
+			MinStakeDuration:            24 * time.Hour,
+			MinDelegationDuration:       24 * time.Hour,
+			MaxStakeDuration:            365 * 24 * time.Hour,
+			MaxFutureStartTime:          2 * 7 * 24 * time.Hour,
+			DelegationFeeChangeCooldown: 24 * time.Hour,
 			RewardConfig: reward.Config{
 				MaxConsumptionRate: .12 * reward.PercentDenominator,
 				MinConsumptionRate: .10 * reward.PercentDenominator,
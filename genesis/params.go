@@ -26,9 +26,18 @@ type StakingConfig struct {
 	// MinStakeDuration is the minimum amount of time a validator can validate
 	// for in a single period.
 	MinStakeDuration time.Duration `json:"minStakeDuration"`
+	// MinDelegationDuration is the minimum amount of time a delegator can
+	// delegate for in a single period.
+	MinDelegationDuration time.Duration `json:"minDelegationDuration"`
 	// MaxStakeDuration is the maximum amount of time a validator can validate
 	// for in a single period.
 	MaxStakeDuration time.Duration `json:"maxStakeDuration"`
+	// MaxFutureStartTime is the maximum amount of time after the chain time
+	// that a staker can be scheduled to start before Durango.
+	MaxFutureStartTime time.Duration `json:"maxFutureStartTime"`
+	// DelegationFeeChangeCooldown is the minimum amount of time between two
+	// changes of a validator's delegation fee.
+	DelegationFeeChangeCooldown time.Duration `json:"delegationFeeChangeCooldown"`
 	// RewardConfig is the config for the reward function.
 	RewardConfig reward.Config `json:"rewardConfig"`
 }
@@ -111,11 +111,15 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// ChainID returns the ID of the chain this message is for, or ids.Empty if
+	// this message isn't for a specific chain
+	ChainID() ids.ID
 }
 
 type outboundMessage struct {
 	bypassThrottling      bool
 	op                    Op
+	chainID               ids.ID
 	bytes                 []byte
 	bytesSavedCompression int
 }
@@ -136,6 +140,10 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
+func (m *outboundMessage) ChainID() ids.ID {
+	return m.chainID
+}
+
 // TODO: add other compression algorithms with extended interface
 type msgBuilder struct {
 	log logging.Logger
@@ -304,9 +312,17 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		return nil, err
 	}
 
+	msg, err := Unwrap(m)
+	if err != nil {
+		return nil, err
+	}
+	// Messages that aren't for a specific chain are reported as ids.Empty.
+	chainID, _ := GetChainID(msg)
+
 	return &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
+		chainID:               chainID,
 		bytes:                 b,
 		bytesSavedCompression: saved,
 	}, nil
@@ -32,6 +32,7 @@ type OutboundMsgBuilder interface {
 		trackedSubnets []ids.ID,
 		supportedACPs []uint32,
 		objectedACPs []uint32,
+		supportedFeatures []byte,
 		knownPeersFilter []byte,
 		knownPeersSalt []byte,
 	) (OutboundMessage, error)
@@ -239,6 +240,7 @@ func (b *outMsgBuilder) Handshake(
 	trackedSubnets []ids.ID,
 	supportedACPs []uint32,
 	objectedACPs []uint32,
+	supportedFeatures []byte,
 	knownPeersFilter []byte,
 	knownPeersSalt []byte,
 ) (OutboundMessage, error) {
@@ -267,7 +269,8 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig: ipBLSSig,
+					IpBlsSig:          ipBLSSig,
+					SupportedFeatures: supportedFeatures,
 				},
 			},
 		},
@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// unknownASN is reserved by RFC 7607 and is used to mark peers whose ASN is
+// unknown.
+const unknownASN uint32 = 0
+
+var (
+	_ ASNLookup = (*ASNTable)(nil)
+
+	errInvalidASNEntry = errors.New("invalid ASN entry")
+)
+
+// ASNLookup maps IPs to the autonomous system that announces them.
+type ASNLookup interface {
+	// LookupASN returns the ASN of [ip] and true, or false if the ASN of [ip]
+	// is unknown.
+	LookupASN(ip net.IP) (uint32, bool)
+}
+
+// ASNTable is an [ASNLookup] backed by a list of IP prefixes. The most
+// specific prefix containing an IP determines its ASN.
+type ASNTable struct {
+	// prefixLengths is sorted in decreasing order so that the most specific
+	// prefix is checked first.
+	prefixLengths []int
+	prefixes      map[netip.Prefix]uint32
+}
+
+// LoadASNTable parses the ASN table stored in the file at [path]. See
+// [ParseASNTable] for the format.
+func LoadASNTable(path string) (*ASNTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseASNTable(f)
+}
+
+// ParseASNTable parses an ASN table. Each line contains an IP prefix in CIDR
+// notation and the ASN that announces it, separated by whitespace or a comma.
+// Empty lines and lines starting with '#' are ignored. For example:
+//
+//	# prefix,asn
+//	1.2.3.0/24,13335
+//	2001:db8::/32,64496
+func ParseASNTable(r io.Reader) (*ASNTable, error) {
+	t := &ASNTable{
+		prefixes: make(map[netip.Prefix]uint32),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w on line %d: expected 2 fields but got %d",
+				errInvalidASNEntry,
+				lineNumber,
+				len(fields),
+			)
+		}
+
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w on line %d: %w", errInvalidASNEntry, lineNumber, err)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w on line %d: %w", errInvalidASNEntry, lineNumber, err)
+		}
+
+		prefix = prefix.Masked()
+		if !slices.Contains(t.prefixLengths, prefix.Bits()) {
+			t.prefixLengths = append(t.prefixLengths, prefix.Bits())
+		}
+		t.prefixes[prefix] = uint32(asn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(t.prefixLengths)
+	slices.Reverse(t.prefixLengths)
+	return t, nil
+}
+
+func (t *ASNTable) LookupASN(ip net.IP) (uint32, bool) {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return unknownASN, false
+	}
+	addr = addr.Unmap()
+
+	for _, bits := range t.prefixLengths {
+		if bits > addr.BitLen() {
+			continue
+		}
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if asn, ok := t.prefixes[prefix]; ok {
+			return asn, true
+		}
+	}
+	return unknownASN, false
+}
+
+// asnTracker tracks the ASNs of the connected peers to measure how diverse
+// the node's connections are.
+type asnTracker struct {
+	lookup ASNLookup
+
+	numDistinctASNs prometheus.Gauge
+	numLargestASN   prometheus.Gauge
+	numUnknownASN   prometheus.Gauge
+	deferredDials   prometheus.Counter
+
+	lock sync.RWMutex
+	// peers maps the connected peers to their ASN.
+	peers map[ids.NodeID]uint32
+	// counts is the number of connected peers in each known ASN.
+	counts map[uint32]int
+	// numUnknown is the number of connected peers whose ASN is unknown.
+	numUnknown int
+}
+
+// newASNTracker returns a tracker that uses [lookup] to find the ASN of peers.
+// If [lookup] is nil, the ASN of every peer is unknown.
+func newASNTracker(
+	lookup ASNLookup,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*asnTracker, error) {
+	t := &asnTracker{
+		lookup: lookup,
+		numDistinctASNs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "peers_distinct_asns",
+			Help:      "Number of distinct ASNs that connected peers are in",
+		}),
+		numLargestASN: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "peers_largest_asn",
+			Help:      "Number of connected peers in the ASN with the most connected peers",
+		}),
+		numUnknownASN: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "peers_unknown_asn",
+			Help:      "Number of connected peers whose ASN is unknown",
+		}),
+		deferredDials: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dials_deferred_asn",
+			Help:      "Times this node deferred dialing a peer because it was connected to too many peers in the peer's ASN",
+		}),
+		peers:  make(map[ids.NodeID]uint32),
+		counts: make(map[uint32]int),
+	}
+	err := utils.Err(
+		registerer.Register(t.numDistinctASNs),
+		registerer.Register(t.numLargestASN),
+		registerer.Register(t.numUnknownASN),
+		registerer.Register(t.deferredDials),
+	)
+	return t, err
+}
+
+// asn returns the ASN of [ip], or [unknownASN] if it is unknown.
+func (t *asnTracker) asn(ip net.IP) uint32 {
+	if t.lookup == nil {
+		return unknownASN
+	}
+	asn, _ := t.lookup.LookupASN(ip)
+	return asn
+}
+
+// connected marks [nodeID] as connected on [ip] and returns the ASN of [ip].
+func (t *asnTracker) connected(nodeID ids.NodeID, ip net.IP) uint32 {
+	asn := t.asn(ip)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.remove(nodeID)
+	t.peers[nodeID] = asn
+	if asn == unknownASN {
+		t.numUnknown++
+	} else {
+		t.counts[asn]++
+	}
+	t.updateMetrics()
+	return asn
+}
+
+func (t *asnTracker) disconnected(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.remove(nodeID)
+	t.updateMetrics()
+}
+
+// numPeers returns the number of connected peers in the ASN of [ip]. If the
+// ASN of [ip] is unknown, 0 is returned.
+func (t *asnTracker) numPeers(ip net.IP) int {
+	asn := t.asn(ip)
+	if asn == unknownASN {
+		return 0
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.counts[asn]
+}
+
+// Assumes the lock is held.
+func (t *asnTracker) remove(nodeID ids.NodeID) {
+	asn, ok := t.peers[nodeID]
+	if !ok {
+		return
+	}
+	delete(t.peers, nodeID)
+	if asn == unknownASN {
+		t.numUnknown--
+		return
+	}
+	t.counts[asn]--
+	if t.counts[asn] <= 0 {
+		delete(t.counts, asn)
+	}
+}
+
+// Assumes the lock is held.
+func (t *asnTracker) updateMetrics() {
+	var largest int
+	for _, count := range t.counts {
+		largest = max(largest, count)
+	}
+	t.numDistinctASNs.Set(float64(len(t.counts)))
+	t.numLargestASN.Set(float64(largest))
+	t.numUnknownASN.Set(float64(t.numUnknown))
+}
+
+// diversifyByASN reorders [records] so that consecutive records are in
+// different ASNs where possible. Records are taken round-robin from each ASN,
+// in the order that the ASNs first appear, while preserving the relative order
+// of the records within each ASN. Each record whose ASN is unknown is treated
+// as being in an ASN of its own.
+func diversifyByASN(records []PeerRecord) []PeerRecord {
+	var (
+		groups      [][]PeerRecord
+		groupByASN  = make(map[uint32]int)
+		diversified = make([]PeerRecord, 0, len(records))
+	)
+	for _, record := range records {
+		index, ok := groupByASN[record.ASN]
+		if !ok {
+			index = len(groups)
+			groups = append(groups, nil)
+			if record.ASN != unknownASN {
+				groupByASN[record.ASN] = index
+			}
+		}
+		groups[index] = append(groups[index], record)
+	}
+
+	for len(diversified) < len(records) {
+		for i, group := range groups {
+			if len(group) == 0 {
+				continue
+			}
+			diversified = append(diversified, group[0])
+			groups[i] = group[1:]
+		}
+	}
+	return diversified
+}
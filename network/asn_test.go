@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const testASNTable = `
+# prefix,asn
+1.2.0.0/16,100
+1.2.3.0/24,200
+5.6.7.8/32 AS300
+
+2001:db8::/32,400
+`
+
+func TestParseASNTable(t *testing.T) {
+	table, err := ParseASNTable(strings.NewReader(testASNTable))
+	require.NoError(t, err)
+
+	tests := []struct {
+		ip          net.IP
+		expectedASN uint32
+		expectedOk  bool
+	}{
+		{
+			ip:          net.IPv4(1, 2, 4, 5),
+			expectedASN: 100,
+			expectedOk:  true,
+		},
+		{
+			ip:          net.IPv4(1, 2, 3, 4),
+			expectedASN: 200,
+			expectedOk:  true,
+		},
+		{
+			ip:          net.IPv4(5, 6, 7, 8),
+			expectedASN: 300,
+			expectedOk:  true,
+		},
+		{
+			ip:          net.IPv4(5, 6, 7, 9),
+			expectedASN: unknownASN,
+			expectedOk:  false,
+		},
+		{
+			ip:          net.ParseIP("2001:db8::1"),
+			expectedASN: 400,
+			expectedOk:  true,
+		},
+		{
+			ip:          nil,
+			expectedASN: unknownASN,
+			expectedOk:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.ip.String(), func(t *testing.T) {
+			asn, ok := table.LookupASN(test.ip)
+			require.Equal(t, test.expectedASN, asn)
+			require.Equal(t, test.expectedOk, ok)
+		})
+	}
+}
+
+func TestParseASNTableInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+	}{
+		{
+			name:  "missing asn",
+			table: "1.2.3.0/24",
+		},
+		{
+			name:  "invalid prefix",
+			table: "1.2.3.0/33,100",
+		},
+		{
+			name:  "invalid asn",
+			table: "1.2.3.0/24,4294967296",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseASNTable(strings.NewReader(test.table))
+			require.ErrorIs(t, err, errInvalidASNEntry)
+		})
+	}
+}
+
+func TestASNTracker(t *testing.T) {
+	require := require.New(t)
+
+	table, err := ParseASNTable(strings.NewReader(testASNTable))
+	require.NoError(err)
+
+	tracker, err := newASNTracker(table, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		nodeID2 = ids.GenerateTestNodeID()
+		nodeID3 = ids.GenerateTestNodeID()
+	)
+	require.Equal(uint32(200), tracker.connected(nodeID0, net.IPv4(1, 2, 3, 4)))
+	require.Equal(uint32(200), tracker.connected(nodeID1, net.IPv4(1, 2, 3, 5)))
+	require.Equal(uint32(100), tracker.connected(nodeID2, net.IPv4(1, 2, 4, 5)))
+	require.Equal(unknownASN, tracker.connected(nodeID3, net.IPv4(9, 9, 9, 9)))
+
+	require.Equal(2, tracker.numPeers(net.IPv4(1, 2, 3, 6)))
+	require.Equal(1, tracker.numPeers(net.IPv4(1, 2, 4, 6)))
+	require.Zero(tracker.numPeers(net.IPv4(9, 9, 9, 9)))
+	require.Equal(float64(2), testutil.ToFloat64(tracker.numDistinctASNs))
+	require.Equal(float64(2), testutil.ToFloat64(tracker.numLargestASN))
+	require.Equal(float64(1), testutil.ToFloat64(tracker.numUnknownASN))
+
+	// Reconnecting on a new IP moves the peer to the new ASN.
+	tracker.connected(nodeID1, net.IPv4(5, 6, 7, 8))
+	require.Equal(1, tracker.numPeers(net.IPv4(1, 2, 3, 6)))
+	require.Equal(float64(3), testutil.ToFloat64(tracker.numDistinctASNs))
+	require.Equal(float64(1), testutil.ToFloat64(tracker.numLargestASN))
+
+	tracker.disconnected(nodeID0)
+	tracker.disconnected(nodeID3)
+	require.Zero(tracker.numPeers(net.IPv4(1, 2, 3, 6)))
+	require.Equal(float64(2), testutil.ToFloat64(tracker.numDistinctASNs))
+	require.Zero(testutil.ToFloat64(tracker.numUnknownASN))
+}
+
+func TestDiversifyByASN(t *testing.T) {
+	nodeIDs := make([]ids.NodeID, 6)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+	}
+	records := []PeerRecord{
+		{NodeID: nodeIDs[0], ASN: 100},
+		{NodeID: nodeIDs[1], ASN: 100},
+		{NodeID: nodeIDs[2], ASN: 100},
+		{NodeID: nodeIDs[3], ASN: 200},
+		{NodeID: nodeIDs[4], ASN: unknownASN},
+		{NodeID: nodeIDs[5], ASN: unknownASN},
+	}
+
+	diversified := diversifyByASN(records)
+	expectedOrder := []ids.NodeID{
+		nodeIDs[0],
+		nodeIDs[3],
+		nodeIDs[4],
+		nodeIDs[5],
+		nodeIDs[1],
+		nodeIDs[2],
+	}
+	order := make([]ids.NodeID, len(diversified))
+	for i, record := range diversified {
+		order[i] = record.NodeID
+	}
+	require.Equal(t, expectedOrder, order)
+}
@@ -8,8 +8,10 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -18,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 // HealthConfig describes parameters for network layer health checks.
@@ -121,6 +124,9 @@ type Config struct {
 	SupportedACPs set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs  set.Set[uint32] `json:"objectedACPs"`
 
+	// SupportedFeatures are the optional p2p features advertised to peers.
+	SupportedFeatures set.Set[peer.Feature] `json:"supportedFeatures"`
+
 	// The compression type to use when compressing outbound messages.
 	// Assumes all peers support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
@@ -166,6 +172,29 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// PeerEventLogSize is the number of recent peer lifecycle events to retain
+	// in memory.
+	PeerEventLogSize int `json:"peerEventLogSize"`
+
+	// PeerStoreDB persists records of previously connected peers across
+	// restarts.
+	PeerStoreDB database.Database `json:"-"`
+
+	// PeerStoreNumSeeds is the number of previously connected peers to dial
+	// on startup.
+	PeerStoreNumSeeds int `json:"peerStoreNumSeeds"`
+
+	// ASNLookup, if non-nil, is used to find the ASN of peers. The ASNs of the
+	// connected peers are reported as metrics and are used to diversify the
+	// peers that are dialed.
+	ASNLookup ASNLookup `json:"-"`
+
+	// MaxPeersPerASN is the number of connected peers in an ASN after which
+	// dialing more peers in that ASN is deferred. Validators of the primary
+	// network and of the tracked subnets are always dialed. If 0, dialing is
+	// never deferred.
+	MaxPeersPerASN int `json:"maxPeersPerASN"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
@@ -176,4 +205,11 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// Clock is used for the timestamps, timeouts and periodic tasks of the
+	// network and its peers. Tests can provide a virtual clock to run the
+	// network deterministically. Connection deadlines are set relative to the
+	// clock, so a virtual clock must not be behind the system clock. Defaults
+	// to the system clock.
+	Clock mockable.Clock `json:"-"`
 }
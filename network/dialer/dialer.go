@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var _ Dialer = (*dialer)(nil)
@@ -30,11 +31,16 @@ type dialer struct {
 	log       logging.Logger
 	network   string
 	throttler throttling.DialThrottler
+	timeout   time.Duration
+	clock     mockable.Clock
 }
 
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+
+	// Clock is used to time out dials. Defaults to the system clock.
+	Clock mockable.Clock `json:"-"`
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -55,10 +61,11 @@ func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
 	)
 	return &dialer{
-		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
 		log:       log,
 		network:   network,
 		throttler: throttler,
+		timeout:   dialerConfig.ConnectionTimeout,
+		clock:     dialerConfig.Clock,
 	}
 }
 
@@ -69,6 +76,23 @@ func (d *dialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
 	d.log.Verbo("dialing",
 		zap.Stringer("ip", ip),
 	)
+
+	// The timeout is enforced with the clock, rather than by [d.dialer], so
+	// that dials can be timed out with a virtual clock.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if d.timeout > 0 {
+		timer := d.clock.NewTimer(d.timeout)
+		defer timer.Stop()
+		go func() {
+			select {
+			case <-timer.C:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
 	if err != nil {
 		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
@@ -20,6 +20,7 @@ type metrics struct {
 	numTracked                      prometheus.Gauge
 	numPeers                        prometheus.Gauge
 	numSubnetPeers                  *prometheus.GaugeVec
+	numFeaturePeers                 *prometheus.GaugeVec
 	timeSinceLastMsgSent            prometheus.Gauge
 	timeSinceLastMsgReceived        prometheus.Gauge
 	sendFailRate                    prometheus.Gauge
@@ -61,6 +62,14 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			},
 			[]string{"subnetID"},
 		),
+		numFeaturePeers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peers_feature",
+				Help:      "Number of peers that a particular optional feature was negotiated with",
+			},
+			[]string{"feature"},
+		),
 		timeSinceLastMsgReceived: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "time_since_last_msg_received",
@@ -151,6 +160,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.numTracked),
 		registerer.Register(m.numPeers),
 		registerer.Register(m.numSubnetPeers),
+		registerer.Register(m.numFeaturePeers),
 		registerer.Register(m.timeSinceLastMsgReceived),
 		registerer.Register(m.timeSinceLastMsgSent),
 		registerer.Register(m.sendFailRate),
@@ -168,6 +178,10 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.peerConnectedLifetimeAverage),
 	)
 
+	for _, feature := range peer.KnownFeatures {
+		m.numFeaturePeers.WithLabelValues(feature.String()).Set(0)
+	}
+
 	// init subnet tracker metrics with tracked subnets
 	for subnetID := range initialSubnetIDs {
 		// no need to track primary network ID
@@ -192,6 +206,9 @@ func (m *metrics) markConnected(peer peer.Peer) {
 	for subnetID := range trackedSubnets {
 		m.numSubnetPeers.WithLabelValues(subnetID.String()).Inc()
 	}
+	for feature := range peer.SupportedFeatures() {
+		m.numFeaturePeers.WithLabelValues(feature.String()).Inc()
+	}
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -209,6 +226,9 @@ func (m *metrics) markDisconnected(peer peer.Peer) {
 	for subnetID := range trackedSubnets {
 		m.numSubnetPeers.WithLabelValues(subnetID.String()).Dec()
 	}
+	for feature := range peer.SupportedFeatures() {
+		m.numFeaturePeers.WithLabelValues(feature.String()).Dec()
+	}
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
@@ -84,6 +85,19 @@ type Network interface {
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// PeerEvents returns the most recent peer lifecycle events, from oldest
+	// to newest. If [nodeIDs] is empty, returns the events of all peers.
+	// Otherwise, returns the events of the peers in [nodeIDs].
+	PeerEvents(nodeIDs []ids.NodeID) []PeerEvent
+
+	// RegisterChain starts attributing the bytes of messages sent and received
+	// for the chain to the chain. It implements chains.Registrant.
+	RegisterChain(chainName string, ctx *snow.ConsensusContext, vm common.VM)
+
+	// ChainBandwidth returns the number of message bytes that were sent and
+	// received for each registered chain.
+	ChainBandwidth() map[ids.ID]peer.ChainBandwidth
+
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
@@ -140,6 +154,14 @@ type network struct {
 
 	sendFailRateCalculator safemath.Averager
 
+	// Retains recent peer lifecycle events to help diagnose connectivity
+	// issues.
+	peerEvents *peerEventLog
+	// Persists previously connected peers to seed dialing after a restart.
+	peerStore *peerStore
+	// Tracks the ASNs of the connected peers.
+	asns *asnTracker
+
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
 	peersLock sync.RWMutex
@@ -239,6 +261,21 @@ func NewNetwork(
 	if err != nil {
 		return nil, fmt.Errorf("initializing ip tracker failed with: %w", err)
 	}
+
+	peerEvents, err := newPeerEventLog(config.PeerEventLogSize)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer event log failed with: %w", err)
+	}
+
+	peerStore, err := newPeerStore(config.PeerStoreDB)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer store failed with: %w", err)
+	}
+
+	asns, err := newASNTracker(config.ASNLookup, config.Namespace, metricsRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("initializing ASN tracker failed with: %w", err)
+	}
 	config.Validators.RegisterSetCallbackListener(constants.PrimaryNetworkID, ipTracker)
 
 	// Track all default bootstrappers to ensure their current IPs are gossiped
@@ -272,9 +309,11 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		SupportedACPs:        config.SupportedACPs.List(),
 		ObjectedACPs:         config.ObjectedACPs.List(),
+		SupportedFeatures:    config.SupportedFeatures,
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		Clock:                config.Clock,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -296,8 +335,11 @@ func NewNetwork(
 		sendFailRateCalculator: safemath.NewSyncAverager(safemath.NewAverager(
 			0,
 			config.SendFailRateHalflife,
-			time.Now(),
+			config.Clock.Time(),
 		)),
+		peerEvents: peerEvents,
+		peerStore:  peerStore,
+		asns:       asns,
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		ipTracker:       ipTracker,
@@ -455,10 +497,31 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		peerIP.TLSSignature,
 	)
 	n.ipTracker.Connected(newIP)
+	asn := n.asns.connected(nodeID, peerIP.IPPort.IP)
 
 	n.metrics.markConnected(peer)
+	n.peerEvents.add(PeerEvent{
+		Time:   n.peerConfig.Clock.Time(),
+		NodeID: nodeID,
+		Type:   PeerConnected,
+		IP:     peerIP.IPPort.String(),
+	})
 
 	peerVersion := peer.Version()
+	err := n.peerStore.connected(
+		nodeID,
+		peerIP.IPPort,
+		asn,
+		peerVersion.String(),
+		n.peerConfig.Clock.Time(),
+	)
+	if err != nil {
+		n.peerConfig.Log.Warn("failed to persist peer record",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+
 	n.router.Connected(nodeID, peerVersion, constants.PrimaryNetworkID)
 	for subnetID := range peer.TrackedSubnets() {
 		n.router.Connected(nodeID, peerVersion, subnetID)
@@ -523,6 +586,7 @@ func (n *network) Peers(except ids.NodeID, knownPeers *bloom.ReadFilter, salt []
 func (n *network) Dispatch() error {
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
+	n.dialStoredPeers()
 	for { // Continuously accept new connections
 		if n.onCloseCtx.Err() != nil {
 			break
@@ -773,6 +837,18 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 
 	// The peer that is disconnecting from us didn't finish the handshake
 	tracked, ok := n.trackedIPs[nodeID]
+
+	event := PeerEvent{
+		Time:   n.peerConfig.Clock.Time(),
+		NodeID: nodeID,
+		Type:   PeerHandshakeFailed,
+		Reason: "disconnected before completing the handshake",
+	}
+	if ok {
+		event.IP = tracked.ip.String()
+	}
+	n.peerEvents.add(event)
+
 	if ok {
 		if n.ipTracker.WantsConnection(nodeID) {
 			tracked := tracked.trackNewIP(tracked.ip)
@@ -789,6 +865,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 
 func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.ipTracker.Disconnected(nodeID)
+	n.asns.disconnected(nodeID)
 	n.router.Disconnected(nodeID)
 
 	n.peersLock.Lock()
@@ -804,6 +881,19 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	}
 
 	n.metrics.markDisconnected(peer)
+	n.peerEvents.add(PeerEvent{
+		Time:   n.peerConfig.Clock.Time(),
+		NodeID: nodeID,
+		Type:   PeerDisconnected,
+		IP:     peer.IP().IPPort.String(),
+	})
+
+	if err := n.peerStore.disconnected(nodeID, n.peerConfig.Clock.Time()); err != nil {
+		n.peerConfig.Log.Warn("failed to persist peer record",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 }
 
 // dial will spin up a new goroutine and attempt to establish a connection with
@@ -835,7 +925,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 		defer n.metrics.numTracked.Dec()
 
 		for {
-			timer := time.NewTimer(ip.getDelay())
+			timer := n.peerConfig.Clock.NewTimer(ip.getDelay())
 
 			select {
 			case <-n.onCloseCtx.Done():
@@ -908,6 +998,20 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Connections to peers in ASNs that this node is already heavily
+			// connected to are deferred to reduce the risk of correlated
+			// failures. The dial is reattempted after the delay.
+			if n.shouldDeferDial(nodeID, ip.ip) {
+				n.asns.deferredDials.Inc()
+				n.peerConfig.Log.Verbo("deferring connection dial",
+					zap.String("reason", "too many peers in the same ASN"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -940,6 +1044,93 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// shouldDeferDial returns true if dialing [nodeID] at [ip] should be deferred
+// because this node is already connected to [MaxPeersPerASN] peers in the ASN
+// of [ip].
+//
+// Validators of the primary network and of the tracked subnets are never
+// deferred, as this node must be connected to them regardless of where they
+// are hosted.
+func (n *network) shouldDeferDial(nodeID ids.NodeID, ip ips.IPPort) bool {
+	if n.config.MaxPeersPerASN <= 0 || n.asns.numPeers(ip.IP) < n.config.MaxPeersPerASN {
+		return false
+	}
+	if _, ok := n.config.Validators.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		return false
+	}
+	for subnetID := range n.config.TrackedSubnets {
+		if _, ok := n.config.Validators.GetValidator(subnetID, nodeID); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// dialStoredPeers attempts to connect once to each of the best previously
+// connected peers. This allows the node to reconnect to the network after a
+// restart without relying solely on the bootstrappers.
+//
+// Connections to peers that the node doesn't otherwise want to be connected to
+// are not reattempted once they fail or are closed.
+func (n *network) dialStoredPeers() {
+	// Peers are spread across ASNs so that the node doesn't rejoin the network
+	// through a single provider.
+	records := n.peerStore.list(-1)
+	for i := range records {
+		records[i].ASN = n.asns.asn(records[i].IP.IP)
+	}
+	records = diversifyByASN(records)
+	if len(records) > n.config.PeerStoreNumSeeds {
+		records = records[:n.config.PeerStoreNumSeeds]
+	}
+
+	for _, record := range records {
+		if record.NodeID == n.config.MyNodeID {
+			continue
+		}
+		if !n.config.AllowPrivateIPs && record.IP.IP.IsPrivate() {
+			continue
+		}
+
+		go func(record PeerRecord) {
+			n.peersLock.RLock()
+			_, connecting := n.connectingPeers.GetByID(record.NodeID)
+			_, connected := n.connectedPeers.GetByID(record.NodeID)
+			_, tracked := n.trackedIPs[record.NodeID]
+			n.peersLock.RUnlock()
+
+			// Peers that are already being dialed will be connected to
+			// regardless of the stored record.
+			if connecting || connected || tracked {
+				return
+			}
+
+			n.peerConfig.Log.Verbo("attempting to dial stored peer",
+				zap.Stringer("nodeID", record.NodeID),
+				zap.Stringer("peerIP", record.IP),
+			)
+
+			conn, err := n.dialer.Dial(n.onCloseCtx, record.IP)
+			if err != nil {
+				n.peerConfig.Log.Verbo("failed to reach stored peer",
+					zap.Stringer("nodeID", record.NodeID),
+					zap.Stringer("peerIP", record.IP),
+					zap.Error(err),
+				)
+				return
+			}
+
+			if err := n.upgrade(conn, n.clientUpgrader); err != nil {
+				n.peerConfig.Log.Verbo("failed to upgrade connection to stored peer",
+					zap.Stringer("nodeID", record.NodeID),
+					zap.Stringer("peerIP", record.IP),
+					zap.Error(err),
+				)
+			}
+		}(record)
+	}
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -964,6 +1155,12 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		n.peerConfig.Log.Verbo("failed to upgrade connection",
 			zap.Error(err),
 		)
+		n.peerEvents.add(PeerEvent{
+			Time:   n.peerConfig.Clock.Time(),
+			Type:   PeerHandshakeFailed,
+			IP:     conn.RemoteAddr().String(),
+			Reason: err.Error(),
+		})
 		return err
 	}
 
@@ -1064,6 +1261,18 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerEvents(nodeIDs []ids.NodeID) []PeerEvent {
+	return n.peerEvents.list(nodeIDs)
+}
+
+func (n *network) RegisterChain(_ string, ctx *snow.ConsensusContext, _ common.VM) {
+	n.peerConfig.Metrics.RegisterChain(ctx.ChainID, ctx.SubnetID)
+}
+
+func (n *network) ChainBandwidth() map[ids.ID]peer.ChainBandwidth {
+	return n.peerConfig.Metrics.ChainBandwidth()
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1153,10 +1362,11 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 }
 
 func (n *network) runTimers() {
-	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
-	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
-	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	pullGossipPeerlists := n.peerConfig.Clock.NewTicker(n.config.PeerListPullGossipFreq)
+	resetPeerListBloom := n.peerConfig.Clock.NewTicker(n.config.PeerListBloomResetFreq)
+	updateUptimes := n.peerConfig.Clock.NewTicker(n.config.UptimeMetricFreq)
 	defer func() {
+		pullGossipPeerlists.Stop()
 		resetPeerListBloom.Stop()
 		updateUptimes.Stop()
 	}()
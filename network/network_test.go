@@ -15,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
@@ -120,6 +121,8 @@ var (
 		RequireValidatorToConnect: false,
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
+		PeerEventLogSize:             constants.DefaultNetworkPeerEventLogSize,
+		PeerStoreNumSeeds:            constants.DefaultNetworkPeerStoreNumSeeds,
 		ResourceTracker:              newDefaultResourceTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
@@ -177,6 +180,7 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 		config.BLSKey = blsKey
+		config.PeerStoreDB = memdb.New()
 
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
@@ -44,6 +44,9 @@ type Config struct {
 	SupportedACPs []uint32
 	ObjectedACPs  []uint32
 
+	// SupportedFeatures are the optional features advertised to peers.
+	SupportedFeatures set.Set[Feature]
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// maxSupportedFeaturesLen restricts the size of the feature bitmask a peer can
+// advertise to prevent excessively large bitmasks from being parsed.
+const maxSupportedFeaturesLen = 32
+
+var (
+	errUnknownFeature = errors.New("unknown feature")
+
+	// KnownFeatures contains every optional feature known by this node.
+	KnownFeatures = []Feature{
+		DeltaPeerListFeature,
+		BloomMempoolSyncFeature,
+	}
+)
+
+// Feature is an optional p2p feature. Features are negotiated during the
+// handshake, which allows new message formats to be rolled out incrementally:
+// a feature may only be used with a peer if both nodes advertised it.
+//
+// The value of a feature is its index in the bitmask sent in the Handshake
+// message, so features must never be renumbered.
+type Feature int
+
+const (
+	// DeltaPeerListFeature allows peer lists to only include the IPs that
+	// changed since the last peer list sent to the peer.
+	DeltaPeerListFeature Feature = iota
+	// BloomMempoolSyncFeature allows mempools to be synced by exchanging bloom
+	// filters of the txs that are already known.
+	BloomMempoolSyncFeature
+)
+
+func (f Feature) String() string {
+	switch f {
+	case DeltaPeerListFeature:
+		return "deltaPeerList"
+	case BloomMempoolSyncFeature:
+		return "bloomMempoolSync"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(f))
+	}
+}
+
+// ParseFeature returns the known feature named [name].
+func ParseFeature(name string) (Feature, error) {
+	for _, feature := range KnownFeatures {
+		if feature.String() == name {
+			return feature, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownFeature, name)
+}
+
+// featuresToBytes returns the bitmask of [features] sent in the Handshake
+// message.
+func featuresToBytes(features set.Set[Feature]) []byte {
+	bits := set.NewBits()
+	for feature := range features {
+		bits.Add(int(feature))
+	}
+	return bits.Bytes()
+}
+
+// negotiateFeatures returns the features of [supported] that are included in
+// the [bitmask] advertised by a peer.
+func negotiateFeatures(supported set.Set[Feature], bitmask []byte) set.Set[Feature] {
+	var (
+		bits       = set.BitsFromBytes(bitmask)
+		negotiated set.Set[Feature]
+	)
+	for feature := range supported {
+		if bits.Contains(int(feature)) {
+			negotiated.Add(feature)
+		}
+	}
+	return negotiated
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestParseFeature(t *testing.T) {
+	require := require.New(t)
+
+	for _, feature := range KnownFeatures {
+		parsedFeature, err := ParseFeature(feature.String())
+		require.NoError(err)
+		require.Equal(feature, parsedFeature)
+	}
+
+	_, err := ParseFeature("unknown")
+	require.ErrorIs(err, errUnknownFeature)
+}
+
+func TestNegotiateFeatures(t *testing.T) {
+	tests := []struct {
+		name       string
+		supported  set.Set[Feature]
+		advertised []byte
+		expected   set.Set[Feature]
+	}{
+		{
+			name:       "nothing advertised",
+			supported:  set.Of(KnownFeatures...),
+			advertised: nil,
+			expected:   nil,
+		},
+		{
+			name:       "nothing supported",
+			supported:  nil,
+			advertised: featuresToBytes(set.Of(KnownFeatures...)),
+			expected:   nil,
+		},
+		{
+			name:       "intersection",
+			supported:  set.Of(DeltaPeerListFeature),
+			advertised: featuresToBytes(set.Of(KnownFeatures...)),
+			expected:   set.Of(DeltaPeerListFeature),
+		},
+		{
+			name:       "unknown features ignored",
+			supported:  set.Of(KnownFeatures...),
+			advertised: set.NewBits(int(BloomMempoolSyncFeature), 100).Bytes(),
+			expected:   set.Of(BloomMempoolSyncFeature),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			negotiated := negotiateFeatures(test.supported, test.advertised)
+			require.Equal(t, test.expected, negotiated)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import "github.com/ava-labs/avalanchego/message"
+
+const (
+	// ConsensusPriority is the priority of messages that the network and
+	// consensus engines need to make progress.
+	ConsensusPriority Priority = iota
+	// GossipPriority is the priority of peer-list and VM gossip.
+	GossipPriority
+	// AppPriority is the priority of VM requests and responses, which are
+	// typically issued on behalf of API clients.
+	AppPriority
+
+	numPriorities = int(AppPriority) + 1
+)
+
+// Priority is the class of an outbound message. A peer always sends all of its
+// queued messages of a higher priority before any message of a lower priority.
+type Priority uint8
+
+// PriorityOf returns the priority of messages with [op].
+func PriorityOf(op message.Op) Priority {
+	switch op {
+	case message.GetPeerListOp, message.PeerListOp, message.AppGossipOp:
+		return GossipPriority
+	case message.AppRequestOp, message.AppResponseOp, message.AppErrorOp:
+		return AppPriority
+	default:
+		return ConsensusPriority
+	}
+}
+
+func (p Priority) String() string {
+	switch p {
+	case ConsensusPriority:
+		return "consensus"
+	case GossipPriority:
+		return "gossip"
+	case AppPriority:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
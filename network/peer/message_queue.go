@@ -5,14 +5,17 @@ package peer
 
 import (
 	"context"
+	"errors"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/linked"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -67,25 +70,38 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.Deque[message.OutboundMessage]
+	// queues of the messages, indexed by their priority
+	// [cond.L] must be held while accessing [queues].
+	queues [numPriorities]buffer.Deque[message.OutboundMessage]
+	// number of messages in [queues]
+	// [cond.L] must be held while accessing [len].
+	len int
+	// number of messages queued by all peers, indexed by priority
+	queued [numPriorities]prometheus.Gauge
 }
 
+// NewThrottledMessageQueue returns a queue that pops messages in order of
+// their priority, and in the order they were pushed within a priority.
 func NewThrottledMessageQueue(
-	onFailed SendFailedCallback,
+	metrics *Metrics,
 	id ids.NodeID,
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
 ) MessageQueue {
-	return &throttledMessageQueue{
-		onFailed:             onFailed,
+	q := &throttledMessageQueue{
+		onFailed:             metrics,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 		cond:                 sync.NewCond(&sync.Mutex{}),
-		queue:                buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
 	}
+	for i := range q.queues {
+		q.queues[i] = buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize)
+		q.queued[i] = metrics.QueuedMessages.With(prometheus.Labels{
+			priorityLabel: Priority(i).String(),
+		})
+	}
+	return q
 }
 
 func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
@@ -131,7 +147,10 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 		return false
 	}
 
-	q.queue.PushRight(msg)
+	priority := PriorityOf(msg.Op())
+	q.queues[priority].PushRight(msg)
+	q.len++
+	q.queued[priority].Inc()
 	q.cond.Signal()
 	return true
 }
@@ -144,7 +163,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.len > 0 {
 			// There is a message
 			break
 		}
@@ -159,7 +178,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.len == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -167,11 +186,22 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// pop removes the oldest message of the highest priority.
+//
+// Invariant: [q.len] > 0
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg, _ := q.queue.PopLeft()
+	for priority, queue := range q.queues {
+		msg, ok := queue.PopLeft()
+		if !ok {
+			continue
+		}
 
-	q.outboundMsgThrottler.Release(msg, q.id)
-	return msg
+		q.len--
+		q.queued[priority].Dec()
+		q.outboundMsgThrottler.Release(msg, q.id)
+		return msg
+	}
+	return nil
 }
 
 func (q *throttledMessageQueue) Close() {
@@ -184,12 +214,10 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.PopLeft()
-		q.outboundMsgThrottler.Release(msg, q.id)
+	for q.len > 0 {
+		msg := q.pop()
 		q.onFailed.SendFailed(msg)
 	}
-	q.queue = nil
 
 	q.cond.Broadcast()
 }
@@ -198,12 +226,8 @@ type blockingMessageQueue struct {
 	onFailed SendFailedCallback
 	log      logging.Logger
 
-	closeOnce   sync.Once
-	closingLock sync.RWMutex
-	closing     chan struct{}
-
 	// queue of the messages
-	queue chan message.OutboundMessage
+	queue *linked.BoundedDeque[message.OutboundMessage]
 }
 
 func NewBlockingMessageQueue(
@@ -214,91 +238,43 @@ func NewBlockingMessageQueue(
 	return &blockingMessageQueue{
 		onFailed: onFailed,
 		log:      log,
-
-		closing: make(chan struct{}),
-		queue:   make(chan message.OutboundMessage, bufferSize),
+		queue:    linked.NewBoundedDeque[message.OutboundMessage](bufferSize),
 	}
 }
 
 func (q *blockingMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
-	q.closingLock.RLock()
-	defer q.closingLock.RUnlock()
-
-	ctxDone := ctx.Done()
-	select {
-	case <-q.closing:
-		q.log.Debug(
-			"dropping message",
-			zap.String("reason", "closed queue"),
-			zap.Stringer("messageOp", msg.Op()),
-		)
-		q.onFailed.SendFailed(msg)
-		return false
-	case <-ctxDone:
-		q.log.Debug(
-			"dropping message",
-			zap.String("reason", "cancelled context"),
-			zap.Stringer("messageOp", msg.Op()),
-		)
-		q.onFailed.SendFailed(msg)
-		return false
-	default:
+	err := ctx.Err()
+	if err == nil {
+		err = q.queue.PushBack(ctx, msg)
 	}
-
-	select {
-	case q.queue <- msg:
+	if err == nil {
 		return true
-	case <-ctxDone:
-		q.log.Debug(
-			"dropping message",
-			zap.String("reason", "cancelled context"),
-			zap.Stringer("messageOp", msg.Op()),
-		)
-		q.onFailed.SendFailed(msg)
-		return false
-	case <-q.closing:
-		q.log.Debug(
-			"dropping message",
-			zap.String("reason", "closed queue"),
-			zap.Stringer("messageOp", msg.Op()),
-		)
-		q.onFailed.SendFailed(msg)
-		return false
 	}
+
+	reason := "cancelled context"
+	if errors.Is(err, linked.ErrDequeClosed) {
+		reason = "closed queue"
+	}
+	q.log.Debug(
+		"dropping message",
+		zap.String("reason", reason),
+		zap.Stringer("messageOp", msg.Op()),
+	)
+	q.onFailed.SendFailed(msg)
+	return false
 }
 
 func (q *blockingMessageQueue) Pop() (message.OutboundMessage, bool) {
-	select {
-	case msg := <-q.queue:
-		return msg, true
-	case <-q.closing:
-		return nil, false
-	}
+	msg, err := q.queue.PopFront(context.Background())
+	return msg, err == nil
 }
 
 func (q *blockingMessageQueue) PopNow() (message.OutboundMessage, bool) {
-	select {
-	case msg := <-q.queue:
-		return msg, true
-	default:
-		return nil, false
-	}
+	return q.queue.TryPopFront()
 }
 
 func (q *blockingMessageQueue) Close() {
-	q.closeOnce.Do(func() {
-		close(q.closing)
-
-		q.closingLock.Lock()
-		defer q.closingLock.Unlock()
-
-		for {
-			select {
-			case msg := <-q.queue:
-				q.onFailed.SendFailed(msg)
-			default:
-				return
-			}
-		}
-	})
+	for _, msg := range q.queue.Close() {
+		q.onFailed.SendFailed(msg)
+	}
 }
@@ -6,11 +6,15 @@ package peer
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -87,3 +91,62 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+func TestThrottledMessageQueuePriority(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	q := NewThrottledMessageQueue(
+		metrics,
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	mc := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+	appRequest, err := mc.AppRequest(chainID, 1, time.Second, nil)
+	require.NoError(err)
+	appGossip, err := mc.AppGossip(chainID, nil)
+	require.NoError(err)
+	peerList, err := mc.PeerList(nil, false)
+	require.NoError(err)
+	chits, err := mc.Chits(chainID, 1, ids.Empty, ids.Empty, ids.Empty)
+	require.NoError(err)
+	pong, err := mc.Pong()
+	require.NoError(err)
+
+	for _, msg := range []message.OutboundMessage{appRequest, appGossip, peerList, chits, pong} {
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	queued := func(p Priority) float64 {
+		return testutil.ToFloat64(metrics.QueuedMessages.With(prometheus.Labels{
+			priorityLabel: p.String(),
+		}))
+	}
+	require.Equal(float64(2), queued(ConsensusPriority))
+	require.Equal(float64(2), queued(GossipPriority))
+	require.Equal(float64(1), queued(AppPriority))
+
+	// Messages are popped by priority, and then in the order they were pushed.
+	for _, expected := range []message.OutboundMessage{chits, pong, appGossip, peerList, appRequest} {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expected, msg)
+	}
+	_, ok := q.PopNow()
+	require.False(ok)
+
+	require.Zero(queued(ConsensusPriority))
+	require.Zero(queued(GossipPriority))
+	require.Zero(queued(AppPriority))
+
+	// Closing the queue drops the remaining messages.
+	require.True(q.Push(context.Background(), appGossip))
+	require.Equal(float64(1), queued(GossipPriority))
+	q.Close()
+	require.Zero(queued(GossipPriority))
+}
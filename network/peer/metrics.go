@@ -5,9 +5,12 @@ package peer
 
 import (
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/utils"
 )
@@ -16,6 +19,9 @@ const (
 	ioLabel         = "io"
 	opLabel         = "op"
 	compressedLabel = "compressed"
+	chainIDLabel    = "chainID"
+	subnetIDLabel   = "subnetID"
+	priorityLabel   = "priority"
 
 	sentLabel     = "sent"
 	receivedLabel = "received"
@@ -25,8 +31,18 @@ var (
 	opLabels             = []string{opLabel}
 	ioOpLabels           = []string{ioLabel, opLabel}
 	ioOpCompressedLabels = []string{ioLabel, opLabel, compressedLabel}
+	ioChainLabels        = []string{ioLabel, chainIDLabel, subnetIDLabel}
+	priorityLabels       = []string{priorityLabel}
 )
 
+// ChainBandwidth is the number of message bytes that were sent and received
+// for a chain.
+type ChainBandwidth struct {
+	SubnetID ids.ID `json:"subnetID"`
+	Sent     uint64 `json:"sent"`
+	Received uint64 `json:"received"`
+}
+
 type Metrics struct {
 	ClockSkewCount prometheus.Counter
 	ClockSkewSum   prometheus.Gauge
@@ -37,6 +53,24 @@ type Metrics struct {
 	Messages   *prometheus.CounterVec // io + op + compressed
 	Bytes      *prometheus.CounterVec // io + op
 	BytesSaved *prometheus.GaugeVec   // io + op
+	ChainBytes *prometheus.CounterVec // io + chainID + subnetID
+
+	QueuedMessages *prometheus.GaugeVec // priority
+
+	// Only the bandwidth of registered chains is tracked so that peers can't
+	// create an unbounded number of metrics.
+	chainsLock sync.RWMutex
+	chains     map[ids.ID]*chainBandwidth
+}
+
+type chainBandwidth struct {
+	subnetID ids.ID
+
+	sent     atomic.Uint64
+	received atomic.Uint64
+
+	sentMetric     prometheus.Counter
+	receivedMetric prometheus.Counter
 }
 
 func NewMetrics(
@@ -91,6 +125,23 @@ func NewMetrics(
 			},
 			ioOpLabels,
 		),
+		ChainBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "chain_msgs_bytes",
+				Help:      "number of message bytes per chain",
+			},
+			ioChainLabels,
+		),
+		QueuedMessages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "outbound_msgs_queued",
+				Help:      "number of outbound messages queued to be sent to peers",
+			},
+			priorityLabels,
+		),
+		chains: make(map[ids.ID]*chainBandwidth),
 	}
 	return m, utils.Err(
 		registerer.Register(m.ClockSkewCount),
@@ -100,9 +151,64 @@ func NewMetrics(
 		registerer.Register(m.Messages),
 		registerer.Register(m.Bytes),
 		registerer.Register(m.BytesSaved),
+		registerer.Register(m.ChainBytes),
+		registerer.Register(m.QueuedMessages),
 	)
 }
 
+// RegisterChain starts tracking the bandwidth of [chainID], which is validated
+// by [subnetID].
+func (m *Metrics) RegisterChain(chainID ids.ID, subnetID ids.ID) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if _, ok := m.chains[chainID]; ok {
+		return
+	}
+
+	var (
+		chainIDStr  = chainID.String()
+		subnetIDStr = subnetID.String()
+	)
+	m.chains[chainID] = &chainBandwidth{
+		subnetID: subnetID,
+		sentMetric: m.ChainBytes.With(prometheus.Labels{
+			ioLabel:       sentLabel,
+			chainIDLabel:  chainIDStr,
+			subnetIDLabel: subnetIDStr,
+		}),
+		receivedMetric: m.ChainBytes.With(prometheus.Labels{
+			ioLabel:       receivedLabel,
+			chainIDLabel:  chainIDStr,
+			subnetIDLabel: subnetIDStr,
+		}),
+	}
+}
+
+// ChainBandwidth returns the bandwidth of each registered chain.
+func (m *Metrics) ChainBandwidth() map[ids.ID]ChainBandwidth {
+	m.chainsLock.RLock()
+	defer m.chainsLock.RUnlock()
+
+	bandwidth := make(map[ids.ID]ChainBandwidth, len(m.chains))
+	for chainID, chain := range m.chains {
+		bandwidth[chainID] = ChainBandwidth{
+			SubnetID: chain.subnetID,
+			Sent:     chain.sent.Load(),
+			Received: chain.received.Load(),
+		}
+	}
+	return bandwidth
+}
+
+func (m *Metrics) getChain(chainID ids.ID) (*chainBandwidth, bool) {
+	m.chainsLock.RLock()
+	defer m.chainsLock.RUnlock()
+
+	chain, ok := m.chains[chainID]
+	return chain, ok
+}
+
 // Sent updates the metrics for having sent [msg].
 func (m *Metrics) Sent(msg message.OutboundMessage) {
 	op := msg.Op().String()
@@ -122,6 +228,12 @@ func (m *Metrics) Sent(msg message.OutboundMessage) {
 	}
 	m.Bytes.With(bytesLabel).Add(float64(len(msg.Bytes())))
 	m.BytesSaved.With(bytesLabel).Add(float64(saved))
+
+	if chain, ok := m.getChain(msg.ChainID()); ok {
+		numBytes := len(msg.Bytes())
+		chain.sent.Add(uint64(numBytes))
+		chain.sentMetric.Add(float64(numBytes))
+	}
 }
 
 func (m *Metrics) MultipleSendsFailed(op message.Op, count int) {
@@ -156,4 +268,14 @@ func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
 	}
 	m.Bytes.With(bytesLabel).Add(float64(msgLen))
 	m.BytesSaved.With(bytesLabel).Add(float64(saved))
+
+	// Messages that aren't for a specific chain aren't attributed to a chain.
+	chainID, err := message.GetChainID(msg.Message())
+	if err != nil {
+		return
+	}
+	if chain, ok := m.getChain(chainID); ok {
+		chain.received.Add(uint64(msgLen))
+		chain.receivedMetric.Add(float64(msgLen))
+	}
 }
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestMetricsChainBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		constants.DefaultNetworkCompressionType,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	var (
+		chainID           = ids.GenerateTestID()
+		subnetID          = ids.GenerateTestID()
+		unregisteredChain = ids.GenerateTestID()
+		nodeID            = ids.GenerateTestNodeID()
+	)
+	metrics.RegisterChain(chainID, subnetID)
+
+	sentMsg, err := mc.GetStateSummaryFrontier(chainID, 1, time.Second)
+	require.NoError(err)
+	require.Equal(chainID, sentMsg.ChainID())
+	metrics.Sent(sentMsg)
+
+	unregisteredMsg, err := mc.GetStateSummaryFrontier(unregisteredChain, 1, time.Second)
+	require.NoError(err)
+	metrics.Sent(unregisteredMsg)
+
+	pingMsg, err := mc.Ping(0, nil)
+	require.NoError(err)
+	require.Equal(ids.Empty, pingMsg.ChainID())
+	metrics.Sent(pingMsg)
+
+	metrics.Received(message.InboundGetStateSummaryFrontier(chainID, 1, time.Second, nodeID), 100)
+	metrics.Received(message.InboundGetStateSummaryFrontier(unregisteredChain, 1, time.Second, nodeID), 100)
+
+	require.Equal(
+		map[ids.ID]ChainBandwidth{
+			chainID: {
+				SubnetID: subnetID,
+				Sent:     uint64(len(sentMsg.Bytes())),
+				Received: 100,
+			},
+		},
+		metrics.ChainBandwidth(),
+	)
+}
@@ -90,6 +90,10 @@ type Peer interface {
 	// be called after [Ready] returns true.
 	TrackedSubnets() set.Set[ids.ID]
 
+	// SupportedFeatures returns the optional features that both this node and
+	// the peer support. It should only be called after [Ready] returns true.
+	SupportedFeatures() set.Set[Feature]
+
 	// ObservedUptime returns the local node's subnet uptime according to the
 	// peer. The value ranges from [0, 100]. It should only be called after
 	// [Ready] returns true.
@@ -145,6 +149,9 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// supportedFeatures is the subset of features the peer sent us in the
+	// Handshake message that we also support.
+	supportedFeatures set.Set[Feature]
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -313,6 +320,10 @@ func (p *peer) TrackedSubnets() set.Set[ids.ID] {
 	return p.trackedSubnets
 }
 
+func (p *peer) SupportedFeatures() set.Set[Feature] {
+	return p.supportedFeatures
+}
+
 func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 	p.observedUptimesLock.RLock()
 	defer p.observedUptimesLock.RUnlock()
@@ -549,6 +560,7 @@ func (p *peer) writeMessages() {
 		p.MySubnets.List(),
 		p.SupportedACPs,
 		p.ObjectedACPs,
+		featuresToBytes(p.Config.SupportedFeatures),
 		knownPeersFilter,
 		knownPeersSalt,
 	)
@@ -632,7 +644,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 }
 
 func (p *peer) sendNetworkMessages() {
-	sendPingsTicker := time.NewTicker(p.PingFrequency)
+	sendPingsTicker := p.Clock.NewTicker(p.PingFrequency)
 	defer func() {
 		sendPingsTicker.Stop()
 
@@ -992,6 +1004,18 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		return
 	}
 
+	if len(msg.SupportedFeatures) > maxSupportedFeaturesLen {
+		p.Log.Debug(malformedMessageLog,
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.HandshakeOp),
+			zap.String("field", "supportedFeatures"),
+			zap.Int("length", len(msg.SupportedFeatures)),
+		)
+		p.StartClose()
+		return
+	}
+	p.supportedFeatures = negotiateFeatures(p.Config.SupportedFeatures, msg.SupportedFeatures)
+
 	var (
 		knownPeers = bloom.EmptyFilter
 		salt       []byte
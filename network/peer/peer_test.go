@@ -386,6 +386,51 @@ func TestPingUptimes(t *testing.T) {
 }
 
 // Test that a peer using the wrong BLS key is disconnected from.
+func TestSupportedFeatures(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, nil)
+	rawPeer0.config.SupportedFeatures = set.Of(DeltaPeerListFeature, BloomMempoolSyncFeature)
+	rawPeer1.config.SupportedFeatures = set.Of(BloomMempoolSyncFeature)
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Only the features supported by both peers are negotiated.
+	expectedFeatures := set.Of(BloomMempoolSyncFeature)
+	require.Equal(expectedFeatures, peer0.SupportedFeatures())
+	require.Equal(expectedFeatures, peer1.SupportedFeatures())
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
 func TestInvalidBLSKeyDisconnects(t *testing.T) {
 	require := require.New(t)
 
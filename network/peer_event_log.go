@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	PeerConnected       PeerEventType = "connected"
+	PeerDisconnected    PeerEventType = "disconnected"
+	PeerHandshakeFailed PeerEventType = "handshakeFailed"
+)
+
+// PeerEventType describes a transition in the lifecycle of a peer connection.
+type PeerEventType string
+
+// PeerEvent is a single peer lifecycle event.
+type PeerEvent struct {
+	Time   time.Time     `json:"time"`
+	NodeID ids.NodeID    `json:"nodeID"`
+	Type   PeerEventType `json:"type"`
+	// IP is the address of the peer, if known.
+	IP string `json:"ip,omitempty"`
+	// Reason optionally describes why the event occurred.
+	Reason string `json:"reason,omitempty"`
+}
+
+// peerEventLog retains the most recent peer lifecycle events. Once full, the
+// oldest event is evicted to make space for each new event.
+type peerEventLog struct {
+	lock   sync.RWMutex
+	events buffer.Queue[PeerEvent]
+}
+
+func newPeerEventLog(size int) (*peerEventLog, error) {
+	events, err := buffer.NewBoundedQueue[PeerEvent](size, nil)
+	return &peerEventLog{
+		events: events,
+	}, err
+}
+
+func (l *peerEventLog) add(event PeerEvent) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.events.Push(event)
+}
+
+// list returns the retained events, from oldest to newest. If [nodeIDs] is
+// non-empty, only events about the provided nodes are returned.
+func (l *peerEventLog) list(nodeIDs []ids.NodeID) []PeerEvent {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	events := l.events.List()
+	if len(nodeIDs) == 0 {
+		return events
+	}
+
+	filter := set.Of(nodeIDs...)
+	filtered := events[:0]
+	for _, event := range events {
+		if filter.Contains(event.NodeID) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
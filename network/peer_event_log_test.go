@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestPeerEventLog(t *testing.T) {
+	require := require.New(t)
+
+	log, err := newPeerEventLog(3)
+	require.NoError(err)
+	require.Empty(log.list(nil))
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		now     = time.Now()
+		events  = []PeerEvent{
+			{
+				Time:   now,
+				NodeID: nodeID0,
+				Type:   PeerConnected,
+			},
+			{
+				Time:   now.Add(time.Second),
+				NodeID: nodeID1,
+				Type:   PeerHandshakeFailed,
+				Reason: "disconnected before completing the handshake",
+			},
+			{
+				Time:   now.Add(2 * time.Second),
+				NodeID: nodeID0,
+				Type:   PeerDisconnected,
+			},
+			{
+				Time:   now.Add(3 * time.Second),
+				NodeID: nodeID1,
+				Type:   PeerConnected,
+			},
+		}
+	)
+	for _, event := range events {
+		log.add(event)
+	}
+
+	// The oldest event should have been evicted.
+	require.Equal(events[1:], log.list(nil))
+	require.Equal([]PeerEvent{events[2]}, log.list([]ids.NodeID{nodeID0}))
+	require.Equal([]PeerEvent{events[1], events[3]}, log.list([]ids.NodeID{nodeID1}))
+	require.Empty(log.list([]ids.NodeID{ids.GenerateTestNodeID()}))
+}
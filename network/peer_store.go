@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+// maxStoredPeers is the maximum number of peer records that are persisted.
+// Once full, the least recently connected peer is evicted to make space for
+// each new peer.
+const maxStoredPeers = 4096
+
+// PeerRecord is the persisted history of a peer that this node has finished a
+// handshake with.
+type PeerRecord struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// IP is the most recent IP this node connected to the peer on.
+	IP ips.IPPort `json:"ip"`
+	// LastConnected is the most recent time the peer finished a handshake.
+	LastConnected time.Time `json:"lastConnected"`
+	// Version is the most recent version the peer reported.
+	Version string `json:"version"`
+	// Uptime is the total amount of time this node has been connected to the
+	// peer, excluding the current connection.
+	Uptime time.Duration `json:"uptime"`
+	// ASN is the autonomous system that announced IP when this node connected
+	// to the peer, or 0 if it is unknown.
+	ASN uint32 `json:"asn,omitempty"`
+}
+
+// peerStore persists records of previously connected peers so that they can
+// be dialed after a restart, before any peer lists are received.
+type peerStore struct {
+	lock    sync.Mutex
+	db      database.Database
+	records map[ids.NodeID]*PeerRecord
+}
+
+func newPeerStore(db database.Database) (*peerStore, error) {
+	s := &peerStore{
+		db:      db,
+		records: make(map[ids.NodeID]*PeerRecord),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		record := &PeerRecord{}
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			return nil, err
+		}
+		s.records[record.NodeID] = record
+	}
+	return s, it.Error()
+}
+
+// connected records that a handshake with [nodeID] finished at [now].
+func (s *peerStore) connected(
+	nodeID ids.NodeID,
+	ip ips.IPPort,
+	asn uint32,
+	version string,
+	now time.Time,
+) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	record, ok := s.records[nodeID]
+	if !ok {
+		if len(s.records) >= maxStoredPeers {
+			if err := s.evict(); err != nil {
+				return err
+			}
+		}
+		record = &PeerRecord{
+			NodeID: nodeID,
+		}
+		s.records[nodeID] = record
+	}
+	record.IP = ip
+	record.ASN = asn
+	record.LastConnected = now
+	record.Version = version
+	return s.put(record)
+}
+
+// disconnected records that the connection to [nodeID] was closed at [now].
+func (s *peerStore) disconnected(nodeID ids.NodeID, now time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	record, ok := s.records[nodeID]
+	if !ok {
+		return nil
+	}
+	if connectedFor := now.Sub(record.LastConnected); connectedFor > 0 {
+		record.Uptime += connectedFor
+	}
+	return s.put(record)
+}
+
+// list returns up to [limit] records, ordered by the quality of the peer. If
+// [limit] is negative, all records are returned.
+//
+// Peers connected to more recently are preferred, as their IPs are the most
+// likely to still be valid. Ties are broken by preferring peers that have
+// been connected to for longer.
+func (s *peerStore) list(limit int) []PeerRecord {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	records := make([]PeerRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, *record)
+	}
+	slices.SortFunc(records, func(a, b PeerRecord) int {
+		if cmp := b.LastConnected.Compare(a.LastConnected); cmp != 0 {
+			return cmp
+		}
+		if a.Uptime != b.Uptime {
+			if a.Uptime > b.Uptime {
+				return -1
+			}
+			return 1
+		}
+		return a.NodeID.Compare(b.NodeID)
+	})
+	if limit >= 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// evict removes the least recently connected peer.
+//
+// Assumes the lock is held.
+func (s *peerStore) evict() error {
+	var oldest *PeerRecord
+	for _, record := range s.records {
+		if oldest == nil || record.LastConnected.Before(oldest.LastConnected) {
+			oldest = record
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+	delete(s.records, oldest.NodeID)
+	return s.db.Delete(oldest.NodeID.Bytes())
+}
+
+// put persists [record].
+//
+// Assumes the lock is held.
+func (s *peerStore) put(record *PeerRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(record.NodeID.Bytes(), recordBytes)
+}
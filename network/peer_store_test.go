@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+func TestPeerStore(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	store, err := newPeerStore(db)
+	require.NoError(err)
+	require.Empty(store.list(-1))
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		ip0     = ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+		ip1     = ips.IPPort{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+		now     = time.Unix(1_000_000, 0)
+	)
+	require.NoError(store.connected(nodeID0, ip0, 13335, "avalanchego/1.0.0", now))
+	require.NoError(store.disconnected(nodeID0, now.Add(time.Hour)))
+	require.NoError(store.connected(nodeID1, ip1, unknownASN, "avalanchego/1.0.1", now.Add(time.Minute)))
+
+	// Disconnecting from an unknown peer is a no-op.
+	require.NoError(store.disconnected(ids.GenerateTestNodeID(), now))
+
+	// Records are persisted.
+	store, err = newPeerStore(db)
+	require.NoError(err)
+
+	records := store.list(-1)
+	require.Len(records, 2)
+	require.Equal(nodeID1, records[0].NodeID)
+	require.True(ip1.Equal(records[0].IP))
+	require.Equal("avalanchego/1.0.1", records[0].Version)
+	require.True(now.Add(time.Minute).Equal(records[0].LastConnected))
+	require.Zero(records[0].Uptime)
+	require.Equal(unknownASN, records[0].ASN)
+
+	require.Equal(nodeID0, records[1].NodeID)
+	require.True(ip0.Equal(records[1].IP))
+	require.Equal("avalanchego/1.0.0", records[1].Version)
+	require.Equal(time.Hour, records[1].Uptime)
+	require.Equal(uint32(13335), records[1].ASN)
+
+	// Uptime accumulates across connections.
+	require.NoError(store.connected(nodeID0, ip1, unknownASN, "avalanchego/1.0.1", now.Add(2*time.Hour)))
+	require.NoError(store.disconnected(nodeID0, now.Add(3*time.Hour)))
+
+	records = store.list(1)
+	require.Len(records, 1)
+	require.Equal(nodeID0, records[0].NodeID)
+	require.True(ip1.Equal(records[0].IP))
+	require.Equal(2*time.Hour, records[0].Uptime)
+}
+
+func TestPeerStoreEviction(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	store, err := newPeerStore(db)
+	require.NoError(err)
+
+	var (
+		ip       = ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+		now      = time.Unix(1_000_000, 0)
+		oldestID = ids.GenerateTestNodeID()
+	)
+	require.NoError(store.connected(oldestID, ip, unknownASN, "", now))
+	for i := 1; i < maxStoredPeers; i++ {
+		require.NoError(store.connected(ids.GenerateTestNodeID(), ip, unknownASN, "", now.Add(time.Duration(i)*time.Second)))
+	}
+	require.Len(store.list(-1), maxStoredPeers)
+
+	newestID := ids.GenerateTestNodeID()
+	require.NoError(store.connected(newestID, ip, unknownASN, "", now.Add(maxStoredPeers*time.Second)))
+
+	store, err = newPeerStore(db)
+	require.NoError(err)
+
+	records := store.list(-1)
+	require.Len(records, maxStoredPeers)
+	require.Equal(newestID, records[0].NodeID)
+	for _, record := range records {
+		require.NotEqual(oldestID, record.NodeID)
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
@@ -172,6 +173,9 @@ func NewTestNetwork(
 		RequireValidatorToConnect: constants.DefaultNetworkRequireValidatorToConnect,
 		PeerReadBufferSize:        constants.DefaultNetworkPeerReadBufferSize,
 		PeerWriteBufferSize:       constants.DefaultNetworkPeerWriteBufferSize,
+		PeerEventLogSize:          constants.DefaultNetworkPeerEventLogSize,
+		PeerStoreDB:               memdb.New(),
+		PeerStoreNumSeeds:         constants.DefaultNetworkPeerStoreNumSeeds,
 	}
 
 	networkConfig.NetworkID = networkID
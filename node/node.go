@@ -95,8 +95,9 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix   = []byte{0x00}
+	keystoreDBPrefix  = []byte("keystore")
+	peerStoreDBPrefix = []byte("peerStore")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -597,6 +598,7 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
+	n.Config.NetworkConfig.PeerStoreDB = prefixdb.New(peerStoreDBPrefix, n.DB)
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 
@@ -1106,6 +1108,9 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+	// Notify the network when new chains are created so that their bandwidth
+	// is tracked
+	n.chainManager.AddRegistrant(n.Net)
 	return nil
 }
 
@@ -1148,7 +1153,10 @@ func (n *Node) initVMs() error {
 				MinDelegatorStake:             n.Config.MinDelegatorStake,
 				MinDelegationFee:              n.Config.MinDelegationFee,
 				MinStakeDuration:              n.Config.MinStakeDuration,
+				MinDelegationDuration:         n.Config.MinDelegationDuration,
 				MaxStakeDuration:              n.Config.MaxStakeDuration,
+				MaxFutureStartTime:            n.Config.MaxFutureStartTime,
+				DelegationFeeChangeCooldown:   n.Config.DelegationFeeChangeCooldown,
 				RewardConfig:                  n.Config.RewardConfig,
 				UpgradeConfig: upgrade.Config{
 					ApricotPhase3Time: version.GetApricotPhase3Time(n.Config.NetworkID),
@@ -1273,6 +1281,7 @@ func (n *Node) initAdminAPI() error {
 			Log:          n.Log,
 			DB:           n.DB,
 			ChainManager: n.chainManager,
+			Network:      n.Net,
 			HTTPServer:   n.APIServer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
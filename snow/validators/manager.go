@@ -91,6 +91,15 @@ type Manager interface {
 	// If sampling the requested size isn't possible, an error will be returned.
 	Sample(subnetID ids.ID, size int) ([]ids.NodeID, error)
 
+	// SampleWithSeed returns a stake-weighted sample of validatorIDs in the
+	// subnet, potentially with duplicates. The sample is a deterministic
+	// function of the validator set and [seed], so every node with the same
+	// validator set will produce the same sample. The current validator set
+	// changes as the chain advances, so nodes only produce the same sample if
+	// they are at the same height.
+	// If sampling the requested size isn't possible, an error will be returned.
+	SampleWithSeed(subnetID ids.ID, size int, seed uint64) ([]ids.NodeID, error)
+
 	// Map of the validators in this subnet
 	GetMap(subnetID ids.ID) map[ids.NodeID]*GetValidatorOutput
 
@@ -264,6 +273,21 @@ func (m *manager) Sample(subnetID ids.ID, size int) ([]ids.NodeID, error) {
 	return set.Sample(size)
 }
 
+func (m *manager) SampleWithSeed(subnetID ids.ID, size int, seed uint64) ([]ids.NodeID, error) {
+	if size == 0 {
+		return nil, nil
+	}
+
+	m.lock.RLock()
+	set, exists := m.subnetToVdrs[subnetID]
+	m.lock.RUnlock()
+	if !exists {
+		return nil, ErrMissingValidators
+	}
+
+	return set.SampleWithSeed(size, seed)
+}
+
 func (m *manager) GetMap(subnetID ids.ID) map[ids.NodeID]*GetValidatorOutput {
 	m.lock.RLock()
 	set, exists := m.subnetToVdrs[subnetID]
@@ -413,6 +413,63 @@ func TestSample(t *testing.T) {
 	require.Equal([]ids.NodeID{nodeID1, nodeID1, nodeID1}, sampled)
 }
 
+func TestSampleWithSeed(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager()
+	subnetID := ids.GenerateTestID()
+
+	sampled, err := m.SampleWithSeed(subnetID, 0, 0)
+	require.NoError(err)
+	require.Empty(sampled)
+
+	_, err = m.SampleWithSeed(subnetID, 1, 0)
+	require.ErrorIs(err, ErrMissingValidators)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	require.NoError(m.AddStaker(subnetID, nodeID0, nil, ids.Empty, 1))
+
+	sampled, err = m.SampleWithSeed(subnetID, 1, 0)
+	require.NoError(err)
+	require.Equal([]ids.NodeID{nodeID0}, sampled)
+
+	_, err = m.SampleWithSeed(subnetID, 2, 0)
+	require.ErrorIs(err, errInsufficientWeight)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	require.NoError(m.AddStaker(subnetID, nodeID1, nil, ids.Empty, math.MaxInt64-1))
+
+	sampled, err = m.SampleWithSeed(subnetID, 3, 0)
+	require.NoError(err)
+	require.Equal([]ids.NodeID{nodeID1, nodeID1, nodeID1}, sampled)
+
+	// The sample must not depend on the order validators were added in.
+	nodeIDs := make([]ids.NodeID, 10)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+	}
+	m0 := NewManager()
+	m1 := NewManager()
+	for i, nodeID := range nodeIDs {
+		require.NoError(m0.AddStaker(subnetID, nodeID, nil, ids.Empty, uint64(i+1)))
+	}
+	for i := len(nodeIDs) - 1; i >= 0; i-- {
+		require.NoError(m1.AddStaker(subnetID, nodeIDs[i], nil, ids.Empty, uint64(i+1)))
+	}
+	for seed := uint64(0); seed < 10; seed++ {
+		sampled0, err := m0.SampleWithSeed(subnetID, 5, seed)
+		require.NoError(err)
+		sampled1, err := m1.SampleWithSeed(subnetID, 5, seed)
+		require.NoError(err)
+		require.Equal(sampled0, sampled1)
+
+		// Sampling a copy of the validator set returns the same sample.
+		sampled2, err := SampleWithSeed(m0.GetMap(subnetID), 5, seed)
+		require.NoError(err)
+		require.Equal(sampled0, sampled2)
+	}
+}
+
 func TestString(t *testing.T) {
 	require := require.New(t)
 
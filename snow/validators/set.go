@@ -11,6 +11,9 @@ import (
 	"strings"
 	"sync"
 
+	"golang.org/x/exp/maps"
+	"gonum.org/v1/gonum/mathext/prng"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -270,6 +273,65 @@ func (s *vdrSet) sample(size int) ([]ids.NodeID, error) {
 	return list, nil
 }
 
+func (s *vdrSet) SampleWithSeed(size int, seed uint64) ([]ids.NodeID, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	// The order of [vdrSlice] depends on the order of additions and removals,
+	// so the validators are sorted by nodeID to be canonical across nodes.
+	vdrs := slices.Clone(s.vdrSlice)
+	slices.SortFunc(vdrs, func(a, b *Validator) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+
+	var (
+		nodeIDs = make([]ids.NodeID, len(vdrs))
+		weights = make([]uint64, len(vdrs))
+	)
+	for i, vdr := range vdrs {
+		nodeIDs[i] = vdr.NodeID
+		weights[i] = vdr.Weight
+	}
+	return sampleWithSeed(nodeIDs, weights, size, seed)
+}
+
+// SampleWithSeed returns a stake-weighted sample of [size] validators from
+// [vdrs], deterministically derived from [seed]. For the same validators and
+// seed, the sample is the same as the one returned by
+// [Manager.SampleWithSeed].
+func SampleWithSeed(vdrs map[ids.NodeID]*GetValidatorOutput, size int, seed uint64) ([]ids.NodeID, error) {
+	nodeIDs := maps.Keys(vdrs)
+	slices.SortFunc(nodeIDs, ids.NodeID.Compare)
+
+	weights := make([]uint64, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		weights[i] = vdrs[nodeID].Weight
+	}
+	return sampleWithSeed(nodeIDs, weights, size, seed)
+}
+
+// sampleWithSeed samples [size] of [nodeIDs] by their [weights]. [nodeIDs]
+// must be sorted so that the sample doesn't depend on their original order.
+func sampleWithSeed(nodeIDs []ids.NodeID, weights []uint64, size int, seed uint64) ([]ids.NodeID, error) {
+	source := prng.NewMT19937_64()
+	source.Seed(seed)
+	sampler := sampler.NewDeterministicWeightedWithoutReplacement(source)
+	if err := sampler.Initialize(weights); err != nil {
+		return nil, err
+	}
+
+	indices, ok := sampler.Sample(size)
+	if !ok {
+		return nil, errInsufficientWeight
+	}
+
+	list := make([]ids.NodeID, size)
+	for i, index := range indices {
+		list[i] = nodeIDs[index]
+	}
+	return list, nil
+}
+
 func (s *vdrSet) TotalWeight() (uint64, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
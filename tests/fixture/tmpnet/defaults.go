@@ -32,6 +32,10 @@ const (
 	// A short minimum stake duration enables testing of staking logic.
 	DefaultMinStakeDuration = time.Second
 
+	// A short minimum delegation duration enables testing of delegation
+	// logic.
+	DefaultMinDelegationDuration = time.Second
+
 	defaultConfigFilename = "config.json"
 )
 
@@ -50,6 +54,7 @@ func DefaultFlags() FlagsMap {
 		config.LogDisplayLevelKey:               logging.Off.String(), // Display logging not needed since nodes run headless
 		config.LogLevelKey:                      logging.Debug.String(),
 		config.MinStakeDurationKey:              DefaultMinStakeDuration.String(),
+		config.MinDelegationDurationKey:         DefaultMinDelegationDuration.String(),
 		config.ProposerVMUseCurrentHeightKey:    true,
 	}
 }
@@ -51,6 +51,9 @@ const (
 	DefaultNetworkRequireValidatorToConnect = false
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
+	DefaultNetworkPeerEventLogSize          = 1024
+	DefaultNetworkPeerStoreNumSeeds         = 32
+	DefaultNetworkMaxPeersPerASN            = 0
 
 	DefaultNetworkTCPProxyEnabled = false
 
@@ -44,6 +44,18 @@ func Format(chainIDAlias string, hrp string, addr []byte) (string, error) {
 	return fmt.Sprintf("%s%s%s", chainIDAlias, addressSep, addrStr), nil
 }
 
+// ChangeChainAlias takes in an address string and returns the same address
+// prefixed with [chainIDAlias]. The HRP and address bytes are preserved, so
+// this can be used to convert an address between chains, such as from the
+// X-Chain to the P-Chain, without any knowledge of the network.
+func ChangeChainAlias(addrStr string, chainIDAlias string) (string, error) {
+	_, hrp, addr, err := Parse(addrStr)
+	if err != nil {
+		return "", err
+	}
+	return Format(chainIDAlias, hrp, addr)
+}
+
 // ParseBech32 takes a bech32 address as input and returns the HRP and data
 // section of a bech32 address
 func ParseBech32(addrStr string) (string, []byte, error) {
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package hll implements HyperLogLog sketches, which estimate the number of
+// distinct elements added to them using a small, fixed amount of memory.
+package hll
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+)
+
+const (
+	MinPrecision = 4
+	MaxPrecision = 16
+)
+
+var (
+	errEmptyBytes        = errors.New("empty bytes")
+	errInvalidPrecision  = errors.New("invalid precision")
+	errInvalidLength     = errors.New("invalid length")
+	errPrecisionMismatch = errors.New("precision mismatch")
+)
+
+// Sketch is a HyperLogLog sketch with 2^precision registers. The standard
+// error of the estimate is approximately 1.04/sqrt(2^precision).
+type Sketch struct {
+	precision uint8
+
+	lock      sync.RWMutex
+	registers []byte
+}
+
+// New creates a new, empty sketch with the specified precision. The returned
+// sketch is safe for concurrent usage.
+func New(precision int) (*Sketch, error) {
+	if err := verifyPrecision(precision); err != nil {
+		return nil, err
+	}
+	return &Sketch{
+		precision: uint8(precision),
+		registers: make([]byte, 1<<precision),
+	}, nil
+}
+
+// Parse [bytes] into a sketch.
+func Parse(bytes []byte) (*Sketch, error) {
+	if len(bytes) == 0 {
+		return nil, errEmptyBytes
+	}
+	precision := int(bytes[0])
+	if err := verifyPrecision(precision); err != nil {
+		return nil, err
+	}
+	if expectedLen := 1 + 1<<precision; len(bytes) != expectedLen {
+		return nil, fmt.Errorf("%w: %d != %d", errInvalidLength, len(bytes), expectedLen)
+	}
+	return &Sketch{
+		precision: uint8(precision),
+		registers: append([]byte(nil), bytes[1:]...),
+	}, nil
+}
+
+// Add records an element by its uniformly distributed 64-bit hash.
+func (s *Sketch) Add(hash uint64) {
+	var (
+		index = hash >> (64 - s.precision)
+		// The guard bit bounds the rank when the remaining bits are all 0.
+		remaining = hash<<s.precision | 1<<(s.precision-1)
+		rank      = byte(bits.LeadingZeros64(remaining) + 1)
+	)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if rank > s.registers[index] {
+		s.registers[index] = rank
+	}
+}
+
+// Count returns the estimated number of distinct elements added to the
+// sketch.
+func (s *Sketch) Count() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var (
+		m        = float64(len(s.registers))
+		sum      float64
+		numZeros int
+	)
+	for _, register := range s.registers {
+		sum += math.Ldexp(1, -int(register))
+		if register == 0 {
+			numZeros++
+		}
+	}
+
+	estimate := alpha(len(s.registers)) * m * m / sum
+	// Use linear counting for small cardinalities, where the raw estimate is
+	// heavily biased.
+	if estimate <= 2.5*m && numZeros > 0 {
+		estimate = m * math.Log(m/float64(numZeros))
+	}
+	return uint64(estimate + .5)
+}
+
+// Merge adds all the elements of [other] into this sketch.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s == other {
+		return nil
+	}
+	if s.precision != other.precision {
+		return fmt.Errorf("%w: %d != %d", errPrecisionMismatch, s.precision, other.precision)
+	}
+
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i, register := range other.registers {
+		s.registers[i] = max(s.registers[i], register)
+	}
+	return nil
+}
+
+func (s *Sketch) Marshal() []byte {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	bytes := make([]byte, 1+len(s.registers))
+	bytes[0] = s.precision
+	copy(bytes[1:], s.registers)
+	return bytes
+}
+
+// Add records [key] in [s].
+func Add(s *Sketch, key []byte) {
+	s.Add(Hash(key))
+}
+
+func Hash(key []byte) uint64 {
+	hash := sha256.Sum256(key)
+	return binary.BigEndian.Uint64(hash[:])
+}
+
+func verifyPrecision(precision int) error {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return fmt.Errorf("%w: %d not in [%d, %d]", errInvalidPrecision, precision, MinPrecision, MaxPrecision)
+	}
+	return nil
+}
+
+func alpha(numRegisters int) float64 {
+	switch numRegisters {
+	case 16:
+		return .673
+	case 32:
+		return .697
+	case 64:
+		return .709
+	default:
+		return .7213 / (1 + 1.079/float64(numRegisters))
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hll
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvalidPrecision(t *testing.T) {
+	_, err := New(MinPrecision - 1)
+	require.ErrorIs(t, err, errInvalidPrecision)
+
+	_, err = New(MaxPrecision + 1)
+	require.ErrorIs(t, err, errInvalidPrecision)
+}
+
+func TestCountEmpty(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(MinPrecision)
+	require.NoError(err)
+	require.Zero(s.Count())
+}
+
+func TestCountAccuracy(t *testing.T) {
+	tests := []struct {
+		precision int
+		numKeys   int
+	}{
+		{
+			precision: 10,
+			numKeys:   100,
+		},
+		{
+			precision: 10,
+			numKeys:   100_000,
+		},
+		{
+			precision: 14,
+			numKeys:   1_000,
+		},
+		{
+			precision: 14,
+			numKeys:   1_000_000,
+		},
+	}
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			require := require.New(t)
+
+			s, err := New(test.precision)
+			require.NoError(err)
+
+			key := make([]byte, 8)
+			for i := 0; i < test.numKeys; i++ {
+				binary.BigEndian.PutUint64(key, uint64(i))
+				Add(s, key)
+				// Adding duplicates must not change the estimate.
+				Add(s, key)
+			}
+
+			// Allow for 4 standard errors.
+			stdErr := 1.04 / math.Sqrt(float64(uint64(1)<<test.precision))
+			maxErr := 4 * stdErr * float64(test.numKeys)
+			require.InDelta(test.numKeys, s.Count(), maxErr)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	require := require.New(t)
+
+	s0, err := New(12)
+	require.NoError(err)
+	s1, err := New(12)
+	require.NoError(err)
+	union, err := New(12)
+	require.NoError(err)
+
+	key := make([]byte, 8)
+	for i := 0; i < 10_000; i++ {
+		binary.BigEndian.PutUint64(key, uint64(i))
+		if i < 6_000 {
+			Add(s0, key)
+		}
+		if i >= 4_000 {
+			Add(s1, key)
+		}
+		Add(union, key)
+	}
+
+	require.NoError(s0.Merge(s1))
+	require.Equal(union.Marshal(), s0.Marshal())
+	require.Equal(union.Count(), s0.Count())
+
+	// Merging a sketch into itself is a noop.
+	require.NoError(s0.Merge(s0))
+	require.Equal(union.Marshal(), s0.Marshal())
+
+	s2, err := New(10)
+	require.NoError(err)
+	err = s0.Merge(s2)
+	require.ErrorIs(err, errPrecisionMismatch)
+}
+
+func TestMarshalParse(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(8)
+	require.NoError(err)
+	for i := 0; i < 1_000; i++ {
+		Add(s, []byte{byte(i), byte(i >> 8)})
+	}
+
+	bytes := s.Marshal()
+	parsed, err := Parse(bytes)
+	require.NoError(err)
+	require.Equal(s.Count(), parsed.Count())
+	require.Equal(bytes, parsed.Marshal())
+
+	// The parsed sketch must not alias the provided bytes.
+	bytes[1]++
+	require.Equal(s.Marshal(), parsed.Marshal())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		bytes       []byte
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			bytes:       nil,
+			expectedErr: errEmptyBytes,
+		},
+		{
+			name:        "precision too low",
+			bytes:       []byte{MinPrecision - 1},
+			expectedErr: errInvalidPrecision,
+		},
+		{
+			name:        "precision too high",
+			bytes:       []byte{MaxPrecision + 1},
+			expectedErr: errInvalidPrecision,
+		},
+		{
+			name:        "too few registers",
+			bytes:       make([]byte, 1<<MinPrecision),
+			expectedErr: errInvalidLength,
+		},
+		{
+			name:        "too many registers",
+			bytes:       make([]byte, 2+1<<MinPrecision),
+			expectedErr: errInvalidLength,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if len(test.bytes) > 1 {
+				test.bytes[0] = MinPrecision
+			}
+			_, err := Parse(test.bytes)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
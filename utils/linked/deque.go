@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package linked
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	ErrDequeFull   = errors.New("deque is full")
+	ErrDequeClosed = errors.New("deque is closed")
+)
+
+// BoundedDeque is a deque that holds at most a fixed number of elements.
+//
+// Pushing onto a full deque blocks until there is space, and popping from an
+// empty deque blocks until there is an element. Blocking calls return early if
+// their context is cancelled or if the deque is closed.
+//
+// While a pop is blocked, one additional element may be pushed past the
+// capacity for it. This means that a deque with a capacity of 0 behaves like an
+// unbuffered channel.
+//
+// BoundedDeque is safe for concurrent use.
+type BoundedDeque[T any] struct {
+	capacity int
+
+	lock   sync.Mutex
+	list   *List[T]
+	closed bool
+	// waiting is the number of pops that are blocked on an element.
+	waiting int
+
+	// pushed and popped are closed, and replaced, to wake up blocked pops and
+	// pushes, respectively. This allows waiters to select on the change along
+	// with their context.
+	pushed chan struct{}
+	popped chan struct{}
+}
+
+// NewBoundedDeque returns an empty deque that holds at most [capacity]
+// elements.
+func NewBoundedDeque[T any](capacity int) *BoundedDeque[T] {
+	return &BoundedDeque[T]{
+		capacity: capacity,
+		list:     NewList[T](),
+		pushed:   make(chan struct{}),
+		popped:   make(chan struct{}),
+	}
+}
+
+// PushFront inserts [v] at the front of the deque, blocking until there is
+// space.
+func (d *BoundedDeque[T]) PushFront(ctx context.Context, v T) error {
+	return d.push(ctx, v, true /*=front*/)
+}
+
+// PushBack inserts [v] at the back of the deque, blocking until there is
+// space.
+func (d *BoundedDeque[T]) PushBack(ctx context.Context, v T) error {
+	return d.push(ctx, v, false /*=front*/)
+}
+
+// TryPushBack inserts [v] at the back of the deque. If the deque is full,
+// ErrDequeFull is returned.
+func (d *BoundedDeque[T]) TryPushBack(v T) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	switch {
+	case d.closed:
+		return ErrDequeClosed
+	case d.full():
+		return ErrDequeFull
+	default:
+		d.insert(v, false /*=front*/)
+		return nil
+	}
+}
+
+// PopFront removes and returns the element at the front of the deque, blocking
+// until there is an element.
+func (d *BoundedDeque[T]) PopFront(ctx context.Context) (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for {
+		if d.closed {
+			return utils.Zero[T](), ErrDequeClosed
+		}
+		if d.list.Len() > 0 {
+			return d.remove(), nil
+		}
+
+		// Blocked pushes may be able to push for this pop.
+		d.waiting++
+		d.popped = notify(d.popped)
+
+		pushed := d.pushed
+		d.lock.Unlock()
+		err := wait(ctx, pushed)
+		d.lock.Lock()
+
+		d.waiting--
+		if err != nil {
+			return utils.Zero[T](), err
+		}
+	}
+}
+
+// TryPopFront removes and returns the element at the front of the deque. If
+// the deque is empty or closed, false is returned.
+func (d *BoundedDeque[T]) TryPopFront() (T, bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.closed || d.list.Len() == 0 {
+		return utils.Zero[T](), false
+	}
+	return d.remove(), true
+}
+
+// Len returns the number of elements in the deque.
+func (d *BoundedDeque[_]) Len() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.list.Len()
+}
+
+// Close empties the deque and prevents further elements from being pushed onto
+// it. The elements that were in the deque are returned in order. After calling
+// Close once, future calls to Close will return nil.
+func (d *BoundedDeque[T]) Close() []T {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.closed {
+		return nil
+	}
+
+	elements := make([]T, 0, d.list.Len())
+	for d.list.Len() > 0 {
+		elements = append(elements, d.remove())
+	}
+	d.closed = true
+	d.pushed = notify(d.pushed)
+	d.popped = notify(d.popped)
+	return elements
+}
+
+func (d *BoundedDeque[T]) push(ctx context.Context, v T, front bool) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for {
+		if d.closed {
+			return ErrDequeClosed
+		}
+		if !d.full() {
+			d.insert(v, front)
+			return nil
+		}
+
+		popped := d.popped
+		d.lock.Unlock()
+		err := wait(ctx, popped)
+		d.lock.Lock()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// full returns true if an element can't currently be pushed.
+//
+// Invariant: [d.lock] must be held.
+func (d *BoundedDeque[_]) full() bool {
+	return d.list.Len() >= d.capacity+d.waiting
+}
+
+// insert adds [v] to the deque and wakes up any blocked pops.
+//
+// Invariant: [d.lock] must be held.
+func (d *BoundedDeque[T]) insert(v T, front bool) {
+	if front {
+		PushFront(d.list, v)
+	} else {
+		PushBack(d.list, v)
+	}
+	d.pushed = notify(d.pushed)
+}
+
+// remove pops the element at the front of the deque and wakes up any blocked
+// pushes.
+//
+// Invariant: [d.lock] must be held and the deque must not be empty.
+func (d *BoundedDeque[T]) remove() T {
+	e := d.list.Front()
+	d.list.Remove(e)
+	d.popped = notify(d.popped)
+	return e.Value
+}
+
+// notify wakes up everyone waiting on [ch] and returns its replacement.
+func notify(ch chan struct{}) chan struct{} {
+	close(ch)
+	return make(chan struct{})
+}
+
+func wait(ctx context.Context, changed <-chan struct{}) error {
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package linked
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundedDeque(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBoundedDeque[int](2)
+	require.Zero(d.Len())
+
+	_, ok := d.TryPopFront()
+	require.False(ok)
+
+	require.NoError(d.PushBack(context.Background(), 1))
+	require.NoError(d.PushFront(context.Background(), 0))
+	require.Equal(2, d.Len())
+
+	err := d.TryPushBack(2)
+	require.ErrorIs(err, ErrDequeFull)
+
+	v, ok := d.TryPopFront()
+	require.True(ok)
+	require.Zero(v)
+
+	require.NoError(d.TryPushBack(2))
+
+	v, err = d.PopFront(context.Background())
+	require.NoError(err)
+	require.Equal(1, v)
+
+	require.Equal([]int{2}, d.Close())
+	require.Zero(d.Len())
+	require.Nil(d.Close())
+
+	err = d.PushBack(context.Background(), 3)
+	require.ErrorIs(err, ErrDequeClosed)
+
+	err = d.TryPushBack(3)
+	require.ErrorIs(err, ErrDequeClosed)
+
+	_, err = d.PopFront(context.Background())
+	require.ErrorIs(err, ErrDequeClosed)
+}
+
+func TestBoundedDequeBlockingPush(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBoundedDeque[int](1)
+	require.NoError(d.PushBack(context.Background(), 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := d.PushBack(ctx, 1)
+	require.ErrorIs(err, context.Canceled)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- d.PushBack(context.Background(), 1)
+	}()
+
+	v, err := d.PopFront(context.Background())
+	require.NoError(err)
+	require.Zero(v)
+	require.NoError(<-pushed)
+
+	v, err = d.PopFront(context.Background())
+	require.NoError(err)
+	require.Equal(1, v)
+}
+
+func TestBoundedDequeBlockingPop(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBoundedDeque[int](1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := d.PopFront(ctx)
+	require.ErrorIs(err, context.Canceled)
+
+	popped := make(chan error)
+	go func() {
+		_, err := d.PopFront(context.Background())
+		popped <- err
+	}()
+
+	// Closing the deque unblocks any waiters.
+	require.Empty(d.Close())
+	err = <-popped
+	require.ErrorIs(err, ErrDequeClosed)
+}
+
+func TestBoundedDequeWithoutCapacity(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBoundedDeque[int](0)
+
+	// Elements can only be pushed while a pop is blocked.
+	err := d.TryPushBack(0)
+	require.ErrorIs(err, ErrDequeFull)
+
+	var (
+		v      int
+		popped = make(chan error)
+	)
+	go func() {
+		var err error
+		v, err = d.PopFront(context.Background())
+		popped <- err
+	}()
+
+	require.NoError(d.PushBack(context.Background(), 1))
+	require.NoError(<-popped)
+	require.Equal(1, v)
+	require.Zero(d.Len())
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ EndpointRequester = (*failoverEndpointRequester)(nil)
+
+	errNoEndpoints = errors.New("no endpoints")
+)
+
+// RetryPolicy configures how a request that failed on every endpoint is
+// retried. Only requests that failed to reach a node, or that were answered
+// with an unsuccessful status code, are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times every endpoint is tried before the
+	// request fails. Values below 1 are treated as 1.
+	MaxAttempts int
+	// InitialBackoff is how long to wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the backoff, which doubles after every retry. If 0,
+	// the backoff isn't bounded.
+	MaxBackoff time.Duration
+}
+
+// FailoverConfig configures a requester that fails over across multiple
+// nodes.
+type FailoverConfig struct {
+	// UnhealthyDuration is how long an endpoint is tried after the healthy
+	// endpoints once it failed a request.
+	UnhealthyDuration time.Duration
+	// RetryPolicy is used for requests that don't specify their own policy
+	// with WithRetryPolicy.
+	RetryPolicy RetryPolicy
+}
+
+type failoverEndpoint struct {
+	uri            string
+	unhealthyUntil time.Time
+}
+
+type failoverEndpointRequester struct {
+	config FailoverConfig
+
+	lock      sync.Mutex
+	endpoints []*failoverEndpoint
+}
+
+// NewFailoverEndpointRequester returns a requester that sends every request
+// to the first healthy endpoint of [uris]. If a node can't be reached, the
+// request fails over to the next endpoint and the node is considered
+// unhealthy for [config.UnhealthyDuration].
+func NewFailoverEndpointRequester(uris []string, config FailoverConfig) EndpointRequester {
+	endpoints := make([]*failoverEndpoint, len(uris))
+	for i, uri := range uris {
+		endpoints[i] = &failoverEndpoint{
+			uri: uri,
+		}
+	}
+	return &failoverEndpointRequester{
+		config:    config,
+		endpoints: endpoints,
+	}
+}
+
+func (e *failoverEndpointRequester) SendRequest(
+	ctx context.Context,
+	method string,
+	params interface{},
+	reply interface{},
+	options ...Option,
+) error {
+	ops := NewOptions(options)
+	policy := e.config.RetryPolicy
+	if ops.retryPolicy != nil {
+		policy = *ops.retryPolicy
+	}
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := e.sendToEndpoints(ctx, method, params, reply, ops.hedgeDelay, options)
+		if !isRetryable(err) || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 {
+			backoff = min(backoff, policy.MaxBackoff)
+		}
+	}
+}
+
+type failoverResult struct {
+	endpoint *failoverEndpoint
+	reply    interface{}
+	err      error
+}
+
+// sendToEndpoints sends the request to the endpoints, ordered by their health,
+// until one of them answers it. If [hedgeDelay] is non-zero, the request is
+// also sent to the next endpoint whenever the outstanding requests haven't
+// completed within [hedgeDelay], and the first answer is used.
+func (e *failoverEndpointRequester) sendToEndpoints(
+	ctx context.Context,
+	method string,
+	params interface{},
+	reply interface{},
+	hedgeDelay time.Duration,
+	options []Option,
+) error {
+	endpoints := e.orderedEndpoints()
+	if len(endpoints) == 0 {
+		return errNoEndpoints
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make(chan failoverResult, len(endpoints))
+		next    int
+		pending int
+		lastErr error
+	)
+	send := func() {
+		endpoint := endpoints[next]
+		next++
+		pending++
+
+		// Every request decodes into its own reply, as hedged requests may
+		// complete concurrently.
+		endpointReply := reflect.New(reflect.TypeOf(reply).Elem()).Interface()
+		go func() {
+			results <- failoverResult{
+				endpoint: endpoint,
+				reply:    endpointReply,
+				err:      sendToEndpoint(ctx, endpoint.uri, method, params, endpointReply, options),
+			}
+		}()
+	}
+
+	var hedgeTimer *time.Timer
+	if hedgeDelay > 0 {
+		hedgeTimer = time.NewTimer(hedgeDelay)
+		defer hedgeTimer.Stop()
+	}
+
+	send()
+	for pending > 0 {
+		var hedge <-chan time.Time
+		if hedgeTimer != nil && next < len(endpoints) {
+			hedge = hedgeTimer.C
+		}
+
+		select {
+		case <-hedge:
+			send()
+			hedgeTimer.Reset(hedgeDelay)
+		case result := <-results:
+			pending--
+			if !isRetryable(result.err) || ctx.Err() != nil {
+				if result.err == nil {
+					reflect.ValueOf(reply).Elem().Set(reflect.ValueOf(result.reply).Elem())
+				}
+				return result.err
+			}
+
+			e.markUnhealthy(result.endpoint)
+			lastErr = result.err
+			if next < len(endpoints) {
+				send()
+			}
+		}
+	}
+	return lastErr
+}
+
+// orderedEndpoints returns the healthy endpoints in their configured order,
+// followed by the unhealthy endpoints ordered by how soon they are considered
+// healthy again.
+func (e *failoverEndpointRequester) orderedEndpoints() []*failoverEndpoint {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var (
+		now       = time.Now()
+		healthy   = make([]*failoverEndpoint, 0, len(e.endpoints))
+		unhealthy []*failoverEndpoint
+	)
+	for _, endpoint := range e.endpoints {
+		if now.Before(endpoint.unhealthyUntil) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	slices.SortStableFunc(unhealthy, func(a, b *failoverEndpoint) int {
+		return a.unhealthyUntil.Compare(b.unhealthyUntil)
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (e *failoverEndpointRequester) markUnhealthy(endpoint *failoverEndpoint) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	endpoint.unhealthyUntil = time.Now().Add(e.config.UnhealthyDuration)
+}
+
+func sendToEndpoint(
+	ctx context.Context,
+	rawURI string,
+	method string,
+	params interface{},
+	reply interface{},
+	options []Option,
+) error {
+	uri, err := url.Parse(rawURI)
+	if err != nil {
+		return err
+	}
+	return SendJSONRequest(ctx, uri, method, params, reply, options...)
+}
+
+// isRetryable returns true if [err] may not occur when the request is sent to
+// a different node.
+func isRetryable(err error) bool {
+	return errors.Is(err, errFailedToIssueRequest) || errors.Is(err, errUnexpectedStatusCode)
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testReply struct {
+	Server string `json:"server"`
+}
+
+// newTestServer returns a server that answers every request with [response]
+// after [delay], or with [statusCode] if it isn't successful.
+func newTestServer(t *testing.T, statusCode int, response string, delay time.Duration) (*httptest.Server, *atomic.Int64) {
+	numRequests := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests.Add(1)
+		// Reading the body allows the server to notice cancelled requests.
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, numRequests
+}
+
+func newTestResult(server string) string {
+	return `{"jsonrpc":"2.0","id":0,"result":{"server":"` + server + `"}}`
+}
+
+func TestFailoverEndpointRequester(t *testing.T) {
+	require := require.New(t)
+
+	unhealthy, unhealthyRequests := newTestServer(t, http.StatusServiceUnavailable, "", 0)
+	healthy, healthyRequests := newTestServer(t, http.StatusOK, newTestResult("healthy"), 0)
+
+	requester := NewFailoverEndpointRequester(
+		[]string{unhealthy.URL, healthy.URL},
+		FailoverConfig{
+			UnhealthyDuration: time.Hour,
+		},
+	)
+
+	reply := &testReply{}
+	require.NoError(requester.SendRequest(context.Background(), "test.method", struct{}{}, reply))
+	require.Equal("healthy", reply.Server)
+	require.Equal(int64(1), unhealthyRequests.Load())
+	require.Equal(int64(1), healthyRequests.Load())
+
+	// The unhealthy endpoint isn't tried first until it's considered healthy
+	// again.
+	reply = &testReply{}
+	require.NoError(requester.SendRequest(context.Background(), "test.method", struct{}{}, reply))
+	require.Equal("healthy", reply.Server)
+	require.Equal(int64(1), unhealthyRequests.Load())
+	require.Equal(int64(2), healthyRequests.Load())
+}
+
+func TestFailoverEndpointRequesterRetries(t *testing.T) {
+	require := require.New(t)
+
+	server1, server1Requests := newTestServer(t, http.StatusServiceUnavailable, "", 0)
+	server2, server2Requests := newTestServer(t, http.StatusInternalServerError, "", 0)
+
+	requester := NewFailoverEndpointRequester(
+		[]string{server1.URL, server2.URL},
+		FailoverConfig{
+			RetryPolicy: RetryPolicy{
+				MaxAttempts:    2,
+				InitialBackoff: time.Millisecond,
+			},
+		},
+	)
+
+	err := requester.SendRequest(context.Background(), "test.method", struct{}{}, &testReply{})
+	require.ErrorIs(err, errUnexpectedStatusCode)
+	require.Equal(int64(2), server1Requests.Load())
+	require.Equal(int64(2), server2Requests.Load())
+
+	// The retry policy can be overridden per request.
+	err = requester.SendRequest(
+		context.Background(),
+		"test.method",
+		struct{}{},
+		&testReply{},
+		WithRetryPolicy(RetryPolicy{
+			MaxAttempts: 1,
+		}),
+	)
+	require.ErrorIs(err, errUnexpectedStatusCode)
+	require.Equal(int64(3), server1Requests.Load())
+	require.Equal(int64(3), server2Requests.Load())
+}
+
+func TestFailoverEndpointRequesterDoesNotFailOverErrors(t *testing.T) {
+	require := require.New(t)
+
+	server1, server1Requests := newTestServer(
+		t,
+		http.StatusOK,
+		`{"jsonrpc":"2.0","id":0,"error":{"code":-32000,"message":"invalid request"}}`,
+		0,
+	)
+	server2, server2Requests := newTestServer(t, http.StatusOK, newTestResult("server2"), 0)
+
+	requester := NewFailoverEndpointRequester(
+		[]string{server1.URL, server2.URL},
+		FailoverConfig{},
+	)
+
+	err := requester.SendRequest(context.Background(), "test.method", struct{}{}, &testReply{})
+	require.ErrorContains(err, "invalid request")
+	require.Equal(int64(1), server1Requests.Load())
+	require.Zero(server2Requests.Load())
+}
+
+func TestFailoverEndpointRequesterHedging(t *testing.T) {
+	require := require.New(t)
+
+	slow, _ := newTestServer(t, http.StatusOK, newTestResult("slow"), time.Minute)
+	fast, fastRequests := newTestServer(t, http.StatusOK, newTestResult("fast"), 0)
+
+	requester := NewFailoverEndpointRequester(
+		[]string{slow.URL, fast.URL},
+		FailoverConfig{},
+	)
+
+	reply := &testReply{}
+	require.NoError(requester.SendRequest(
+		context.Background(),
+		"test.method",
+		struct{}{},
+		reply,
+		WithHedging(time.Millisecond),
+	))
+	require.Equal("fast", reply.Server)
+	require.Equal(int64(1), fastRequests.Load())
+}
+
+func TestFailoverEndpointRequesterNoEndpoints(t *testing.T) {
+	requester := NewFailoverEndpointRequester(nil, FailoverConfig{})
+	err := requester.SendRequest(context.Background(), "test.method", struct{}{}, &testReply{})
+	require.ErrorIs(t, err, errNoEndpoints)
+}
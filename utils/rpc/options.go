@@ -6,6 +6,7 @@ package rpc
 import (
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Option func(*Options)
@@ -13,6 +14,8 @@ type Option func(*Options)
 type Options struct {
 	headers     http.Header
 	queryParams url.Values
+	retryPolicy *RetryPolicy
+	hedgeDelay  time.Duration
 }
 
 func NewOptions(ops []Option) *Options {
@@ -38,6 +41,14 @@ func (o *Options) QueryParams() url.Values {
 	return o.queryParams
 }
 
+func (o *Options) RetryPolicy() *RetryPolicy {
+	return o.retryPolicy
+}
+
+func (o *Options) HedgeDelay() time.Duration {
+	return o.hedgeDelay
+}
+
 func WithHeader(key, val string) Option {
 	return func(o *Options) {
 		o.headers.Set(key, val)
@@ -49,3 +60,21 @@ func WithQueryParam(key, val string) Option {
 		o.queryParams.Set(key, val)
 	}
 }
+
+// WithRetryPolicy overrides the retry policy of a requester that fails over
+// across multiple nodes.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithHedging sends the request to the next node, when using a requester that
+// fails over across multiple nodes, if the outstanding requests haven't
+// completed within [delay]. The first answer is used, so this must only be
+// used for idempotent requests.
+func WithHedging(delay time.Duration) Option {
+	return func(o *Options) {
+		o.hedgeDelay = delay
+	}
+}
@@ -12,16 +12,48 @@ var MaxTime = time.Unix(1<<63-62135596801, 0) // 0 is used because we drop the n
 type Clock struct {
 	faked bool
 	time  time.Time
+
+	// virtual is set if this clock was created by NewVirtualClock. It is
+	// shared by all copies of the clock.
+	virtual *virtualTime
+}
+
+// NewVirtualClock returns a clock that starts at [now] and only moves forward
+// when it is Set or Advanced. Timers and tickers created by the clock, or by
+// any copy of it, fire as the clock passes their deadlines.
+//
+// Unlike a Clock that is faked with Set, a virtual clock is safe for
+// concurrent use, which allows tests to drive the time of components that
+// read the clock on their own goroutines.
+func NewVirtualClock(now time.Time) *Clock {
+	return &Clock{
+		virtual: newVirtualTime(now),
+	}
 }
 
 // Set the time on the clock
-func (c *Clock) Set(time time.Time) { c.faked = true; c.time = time }
+func (c *Clock) Set(time time.Time) {
+	if c.virtual != nil {
+		c.virtual.set(time)
+		return
+	}
+	c.faked = true
+	c.time = time
+}
+
+// Advance moves the time on the clock forward by [d].
+func (c *Clock) Advance(d time.Duration) {
+	c.Set(c.Time().Add(d))
+}
 
-// Sync this clock with global time
+// Sync this clock with global time. Virtual clocks can't be synced.
 func (c *Clock) Sync() { c.faked = false }
 
 // Time returns the time on this clock
 func (c *Clock) Time() time.Time {
+	if c.virtual != nil {
+		return c.virtual.now()
+	}
 	if c.faked {
 		return c.time
 	}
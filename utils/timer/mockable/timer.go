@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mockable
+
+import (
+	"sync"
+	"time"
+)
+
+// Timer sends the time on C once its duration has passed on the clock that
+// created it.
+type Timer struct {
+	C <-chan time.Time
+
+	timer   *time.Timer
+	virtual *virtualTimer
+}
+
+// NewTimer returns a timer that fires after [d] has passed on this clock. If
+// this is not a virtual clock, the timer is backed by a time.Timer.
+func (c *Clock) NewTimer(d time.Duration) *Timer {
+	if c.virtual != nil {
+		t := c.virtual.schedule(d, 0)
+		return &Timer{
+			C:       t.c,
+			virtual: t,
+		}
+	}
+
+	t := time.NewTimer(d)
+	return &Timer{
+		C:     t.C,
+		timer: t,
+	}
+}
+
+// Stop prevents the timer from firing. It returns false if the timer has
+// already fired or been stopped.
+func (t *Timer) Stop() bool {
+	if t.virtual != nil {
+		return t.virtual.stop()
+	}
+	return t.timer.Stop()
+}
+
+// Ticker sends the time on C every period that passes on the clock that
+// created it. Like a time.Ticker, ticks are dropped if the reader falls
+// behind.
+type Ticker struct {
+	C <-chan time.Time
+
+	ticker  *time.Ticker
+	virtual *virtualTimer
+}
+
+// NewTicker returns a ticker that ticks every [d] on this clock. If this is
+// not a virtual clock, the ticker is backed by a time.Ticker.
+//
+// NewTicker panics if [d] is not positive.
+func (c *Clock) NewTicker(d time.Duration) *Ticker {
+	if c.virtual != nil {
+		if d <= 0 {
+			panic("non-positive interval for Clock.NewTicker")
+		}
+		t := c.virtual.schedule(d, d)
+		return &Ticker{
+			C:       t.c,
+			virtual: t,
+		}
+	}
+
+	t := time.NewTicker(d)
+	return &Ticker{
+		C:      t.C,
+		ticker: t,
+	}
+}
+
+// Stop turns off the ticker. No more ticks will be sent after Stop returns.
+func (t *Ticker) Stop() {
+	if t.virtual != nil {
+		t.virtual.stop()
+		return
+	}
+	t.ticker.Stop()
+}
+
+type virtualTime struct {
+	lock    sync.Mutex
+	current time.Time
+	timers  map[*virtualTimer]struct{}
+}
+
+func newVirtualTime(now time.Time) *virtualTime {
+	return &virtualTime{
+		current: now,
+		timers:  make(map[*virtualTimer]struct{}),
+	}
+}
+
+func (v *virtualTime) now() time.Time {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.current
+}
+
+// set moves the time to [now], firing every timer whose deadline is passed in
+// deadline order. Timers are fired at their deadline, so a ticker that is
+// passed multiple times is fired once for each period.
+func (v *virtualTime) set(now time.Time) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for {
+		next := v.nextTimer()
+		if next == nil || next.deadline.After(now) {
+			break
+		}
+
+		v.current = next.deadline
+		select {
+		case next.c <- next.deadline:
+		default:
+		}
+
+		if next.period > 0 {
+			next.deadline = next.deadline.Add(next.period)
+		} else {
+			delete(v.timers, next)
+		}
+	}
+	v.current = now
+}
+
+// nextTimer returns the timer with the earliest deadline, or nil if there are
+// no timers.
+//
+// Invariant: [v.lock] must be held.
+func (v *virtualTime) nextTimer() *virtualTimer {
+	var next *virtualTimer
+	for t := range v.timers {
+		if next == nil || t.deadline.Before(next.deadline) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (v *virtualTime) schedule(d, period time.Duration) *virtualTimer {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	t := &virtualTimer{
+		time:     v,
+		c:        make(chan time.Time, 1),
+		deadline: v.current.Add(d),
+		period:   period,
+	}
+	// Like a time.Timer, a timer without a positive duration fires
+	// immediately.
+	if d <= 0 {
+		t.c <- t.deadline
+		return t
+	}
+	v.timers[t] = struct{}{}
+	return t
+}
+
+type virtualTimer struct {
+	time     *virtualTime
+	c        chan time.Time
+	deadline time.Time
+	// period is the interval between ticks, or 0 if the timer should only
+	// fire once.
+	period time.Duration
+}
+
+func (t *virtualTimer) stop() bool {
+	t.time.lock.Lock()
+	defer t.time.lock.Unlock()
+
+	_, pending := t.time.timers[t]
+	delete(t.time.timers, t)
+	return pending
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mockable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualClockTimer(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+	timer := clock.NewTimer(time.Minute)
+
+	clock.Advance(time.Minute - time.Second)
+	require.Empty(timer.C)
+
+	clock.Advance(time.Hour)
+	require.Equal(start.Add(time.Minute), <-timer.C)
+	require.Equal(start.Add(time.Hour+time.Minute-time.Second), clock.Time())
+
+	// The timer already fired.
+	require.False(timer.Stop())
+}
+
+func TestVirtualClockTimerStop(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewVirtualClock(time.Unix(1000, 0))
+	timer := clock.NewTimer(time.Minute)
+	require.True(timer.Stop())
+
+	clock.Advance(time.Hour)
+	require.Empty(timer.C)
+}
+
+func TestVirtualClockTimerImmediate(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+	timer := clock.NewTimer(0)
+	require.Equal(start, <-timer.C)
+	require.False(timer.Stop())
+}
+
+func TestVirtualClockTicker(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		require.Equal(start.Add(time.Duration(i)*time.Second), <-ticker.C)
+	}
+
+	// Ticks are dropped if they aren't read.
+	clock.Advance(time.Minute)
+	require.Equal(start.Add(4*time.Second), <-ticker.C)
+	require.Empty(ticker.C)
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+	require.Empty(ticker.C)
+}
+
+func TestVirtualClockCopiesShareTime(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+	clockCopy := *clock
+	timer := clockCopy.NewTimer(time.Second)
+
+	clock.Advance(time.Second)
+	require.Equal(start.Add(time.Second), clockCopy.Time())
+	require.Equal(start.Add(time.Second), <-timer.C)
+}
+
+func TestClockTimer(t *testing.T) {
+	clock := Clock{}
+	timer := clock.NewTimer(time.Millisecond)
+	<-timer.C
+	require.False(t, timer.Stop())
+}
@@ -11,8 +11,9 @@ import (
 )
 
 var (
-	errMissingVersionPrefix = errors.New("missing required version prefix")
-	errMissingVersions      = errors.New("missing version numbers")
+	errMissingVersionPrefix     = errors.New("missing required version prefix")
+	errMissingVersions          = errors.New("missing version numbers")
+	errMissingApplicationPrefix = errors.New("missing required application prefix")
 )
 
 func Parse(s string) (*Semantic, error) {
@@ -33,6 +34,27 @@ func Parse(s string) (*Semantic, error) {
 	}, nil
 }
 
+// ParseApplication parses the string representation of an [Application], such
+// as "avalanchego/1.2.3".
+func ParseApplication(s string) (*Application, error) {
+	name, versions, ok := strings.Cut(s, "/")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%w: %q", errMissingApplicationPrefix, s)
+	}
+
+	major, minor, patch, err := parseVersions(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Application{
+		Name:  name,
+		Major: major,
+		Minor: minor,
+		Patch: patch,
+	}, nil
+}
+
 func parseVersions(s string) (int, int, int, error) {
 	splitVersion := strings.SplitN(s, ".", 3)
 	if numSeperators := len(splitVersion); numSeperators != 3 {
@@ -64,3 +64,47 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseApplication(t *testing.T) {
+	v, err := ParseApplication("avalanchego/1.2.3")
+
+	require.NoError(t, err)
+	require.NotNil(t, v)
+	require.Equal(t, "avalanchego/1.2.3", v.String())
+	require.Equal(t, "avalanchego", v.Name)
+	require.Equal(t, 1, v.Major)
+	require.Equal(t, 2, v.Minor)
+	require.Equal(t, 3, v.Patch)
+
+	tests := []struct {
+		version     string
+		expectedErr error
+	}{
+		{
+			version:     "",
+			expectedErr: errMissingApplicationPrefix,
+		},
+		{
+			version:     "1.2.3",
+			expectedErr: errMissingApplicationPrefix,
+		},
+		{
+			version:     "/1.2.3",
+			expectedErr: errMissingApplicationPrefix,
+		},
+		{
+			version:     "avalanchego/1.2",
+			expectedErr: errMissingVersions,
+		},
+		{
+			version:     "avalanchego/z.2.3",
+			expectedErr: strconv.ErrSyntax,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			_, err := ParseApplication(test.version)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
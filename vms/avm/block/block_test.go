@@ -118,3 +118,34 @@ func createTestTxs(cm codec.Manager) ([]*txs.Tx, error) {
 	}
 	return testTxs, nil
 }
+
+func FuzzParseBlock(f *testing.F) {
+	parser, err := NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(f, err)
+
+	cm := parser.Codec()
+	txs, err := createTestTxs(cm)
+	require.NoError(f, err)
+
+	blk, err := NewStandardBlock(ids.GenerateTestID(), 1, time.Unix(1, 0), txs, cm)
+	require.NoError(f, err)
+	f.Add(blk.Bytes())
+
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		require := require.New(t)
+
+		parsed, err := parser.ParseBlock(bytes)
+		if err != nil {
+			return
+		}
+		require.Equal(bytes, parsed.Bytes())
+
+		marshalled, err := cm.Marshal(CodecVersion, &parsed)
+		require.NoError(err)
+		require.Equal(bytes, marshalled)
+	})
+}
@@ -547,7 +547,11 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	metrics, err := metrics.New("", registerer)
 	require.NoError(err)
 
-	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept)
+	onBlockAccepting := func(block.Block) error {
+		return nil
+	}
+	onBlockAccept := func(block.Block) {}
+	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept, onBlockAccepting, onBlockAccept)
 
 	manager.SetPreference(parentBlk.ID())
 
@@ -227,6 +227,10 @@ func (b *Block) Accept(context.Context) error {
 		return fmt.Errorf("%w: %s", ErrBlockNotFound, blkID)
 	}
 
+	if err := b.manager.onBlockAccepting(b.Block); err != nil {
+		return fmt.Errorf("failed to mark block %s as accepted: %w", blkID, err)
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(b.manager.state)
 
@@ -248,6 +252,7 @@ func (b *Block) Accept(context.Context) error {
 	if err := b.manager.metrics.MarkBlockAccepted(b); err != nil {
 		return err
 	}
+	b.manager.onBlockAccept(b.Block)
 
 	txChecksum, utxoChecksum := b.manager.state.Checksums()
 	b.manager.backend.Ctx.Log.Trace(
@@ -611,6 +611,33 @@ func TestBlockAccept(t *testing.T) {
 			},
 			expectedErr: ErrBlockNotFound,
 		},
+		{
+			name: "can't mark block as accepting",
+			blockFunc: func(ctrl *gomock.Controller) *Block {
+				blockID := ids.GenerateTestID()
+				mockBlock := block.NewMockBlock(ctrl)
+				mockBlock.EXPECT().ID().Return(blockID).AnyTimes()
+				mockBlock.EXPECT().Txs().Return([]*txs.Tx{}).AnyTimes()
+
+				mempool := mempool.NewMockMempool(ctrl)
+				mempool.EXPECT().Remove(gomock.Any()).AnyTimes()
+
+				return &Block{
+					Block: mockBlock,
+					manager: &manager{
+						mempool: mempool,
+						backend: defaultTestBackend(false, nil),
+						onBlockAccepting: func(block.Block) error {
+							return errTest
+						},
+						blkIDToState: map[ids.ID]*blockState{
+							blockID: {},
+						},
+					},
+				}
+			},
+			expectedErr: errTest,
+		},
 		{
 			name: "can't get commit batch",
 			blockFunc: func(ctrl *gomock.Controller) *Block {
@@ -635,6 +662,9 @@ func TestBlockAccept(t *testing.T) {
 						state:   mockManagerState,
 						mempool: mempool,
 						backend: defaultTestBackend(false, nil),
+						onBlockAccepting: func(block.Block) error {
+							return nil
+						},
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
@@ -674,6 +704,9 @@ func TestBlockAccept(t *testing.T) {
 						state:   mockManagerState,
 						mempool: mempool,
 						backend: defaultTestBackend(false, mockSharedMemory),
+						onBlockAccepting: func(block.Block) error {
+							return nil
+						},
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
@@ -717,6 +750,9 @@ func TestBlockAccept(t *testing.T) {
 						mempool: mempool,
 						metrics: metrics,
 						backend: defaultTestBackend(false, mockSharedMemory),
+						onBlockAccepting: func(block.Block) error {
+							return nil
+						},
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
@@ -763,6 +799,10 @@ func TestBlockAccept(t *testing.T) {
 						mempool: mempool,
 						metrics: metrics,
 						backend: defaultTestBackend(false, mockSharedMemory),
+						onBlockAccepting: func(block.Block) error {
+							return nil
+						},
+						onBlockAccept: func(block.Block) {},
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {
 								onAcceptState: mockOnAcceptState,
@@ -55,18 +55,22 @@ func NewManager(
 	backend *executor.Backend,
 	clk *mockable.Clock,
 	onAccept func(*txs.Tx) error,
+	onBlockAccepting func(block.Block) error,
+	onBlockAccept func(block.Block),
 ) Manager {
 	lastAccepted := state.GetLastAccepted()
 	return &manager{
-		backend:      backend,
-		state:        state,
-		metrics:      metrics,
-		mempool:      mempool,
-		clk:          clk,
-		onAccept:     onAccept,
-		blkIDToState: map[ids.ID]*blockState{},
-		lastAccepted: lastAccepted,
-		preferred:    lastAccepted,
+		backend:          backend,
+		state:            state,
+		metrics:          metrics,
+		mempool:          mempool,
+		clk:              clk,
+		onAccept:         onAccept,
+		onBlockAccepting: onBlockAccepting,
+		onBlockAccept:    onBlockAccept,
+		blkIDToState:     map[ids.ID]*blockState{},
+		lastAccepted:     lastAccepted,
+		preferred:        lastAccepted,
 	}
 }
 
@@ -80,6 +84,15 @@ type manager struct {
 	// before its state changes are applied.
 	// Invariant: any error returned by onAccept should be considered fatal.
 	onAccept func(*txs.Tx) error
+	// Invariant: onBlockAccepting is called when a block is being accepted,
+	// after onAccept was called on its txs, but before its state changes are
+	// applied. Writes to the state's database are committed with the block.
+	// Invariant: any error returned by onBlockAccepting should be considered
+	// fatal.
+	onBlockAccepting func(block.Block) error
+	// Invariant: onBlockAccept is called when a block is accepted, after its
+	// state changes are committed.
+	onBlockAccept func(block.Block)
 
 	// blkIDToState is a map from a block's ID to the state of the block.
 	// Blocks are put into this map when they are verified.
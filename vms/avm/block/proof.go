@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	ErrEmptyTxProof     = errors.New("tx proof contains no blocks")
+	ErrTxNotInBlock     = errors.New("tx isn't included in the first block of the proof")
+	ErrUnexpectedParent = errors.New("block doesn't extend the previous block of the proof")
+)
+
+// VerifyTxProof verifies that [blocks] prove the acceptance of [txID].
+//
+// The first block must include [txID] and every following block must be the
+// child of the block before it. Because a block's ID is the hash of its bytes,
+// this ties [txID] to every block of the proof. On success, the last block of
+// the proof is returned. The proof is only evidence of acceptance if the
+// returned block is known to be accepted, for example because it matches the
+// accepted chain tip reported by other nodes.
+func VerifyTxProof(parser Parser, txID ids.ID, blocks [][]byte) (Block, error) {
+	if len(blocks) == 0 {
+		return nil, ErrEmptyTxProof
+	}
+
+	blk, err := parser.ParseBlock(blocks[0])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse block 0 of the proof: %w", err)
+	}
+	if !IncludesTx(blk, txID) {
+		return nil, fmt.Errorf("%w: %s not in %s", ErrTxNotInBlock, txID, blk.ID())
+	}
+
+	for i, blkBytes := range blocks[1:] {
+		child, err := parser.ParseBlock(blkBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse block %d of the proof: %w", i+1, err)
+		}
+		if parentID := child.Parent(); parentID != blk.ID() {
+			return nil, fmt.Errorf("%w: block %d has parent %s rather than %s",
+				ErrUnexpectedParent,
+				i+1,
+				parentID,
+				blk.ID(),
+			)
+		}
+		blk = child
+	}
+	return blk, nil
+}
+
+// IncludesTx returns true if [blk] includes [txID].
+func IncludesTx(blk Block, txID ids.ID) bool {
+	for _, tx := range blk.Txs() {
+		if tx.ID() == txID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestVerifyTxProof(t *testing.T) {
+	parser, err := NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(t, err)
+
+	cm := parser.Codec()
+	txs, err := createTestTxs(cm)
+	require.NoError(t, err)
+	txID := txs[0].ID()
+
+	now := time.Now()
+	acceptingBlk, err := NewStandardBlock(ids.GenerateTestID(), 1, now, txs, cm)
+	require.NoError(t, err)
+	childBlk, err := NewStandardBlock(acceptingBlk.ID(), 2, now, nil, cm)
+	require.NoError(t, err)
+	unrelatedBlk, err := NewStandardBlock(ids.GenerateTestID(), 2, now, nil, cm)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		blocks      [][]byte
+		expectedTip ids.ID
+		expectedErr error
+	}{
+		{
+			name:        "empty proof",
+			expectedErr: ErrEmptyTxProof,
+		},
+		{
+			name:        "accepting block",
+			blocks:      [][]byte{acceptingBlk.Bytes()},
+			expectedTip: acceptingBlk.ID(),
+		},
+		{
+			name:        "accepting block and child",
+			blocks:      [][]byte{acceptingBlk.Bytes(), childBlk.Bytes()},
+			expectedTip: childBlk.ID(),
+		},
+		{
+			name:        "tx not in first block",
+			blocks:      [][]byte{childBlk.Bytes()},
+			expectedErr: ErrTxNotInBlock,
+		},
+		{
+			name:        "unrelated block",
+			blocks:      [][]byte{acceptingBlk.Bytes(), unrelatedBlk.Bytes()},
+			expectedErr: ErrUnexpectedParent,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tip, err := VerifyTxProof(parser, txID, test.blocks)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedTip, tip.ID())
+		})
+	}
+}
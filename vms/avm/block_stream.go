@@ -0,0 +1,286 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	blockStreamHeightParam = "height"
+
+	// Time allowed to write a message to the subscriber.
+	blockStreamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	blockStreamPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// blockStreamPongWait.
+	blockStreamPingPeriod = (blockStreamPongWait * 9) / 10
+)
+
+var (
+	errInvalidStartHeight = errors.New("invalid start height")
+
+	blockStreamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  units.KiB,
+		WriteBufferSize: units.KiB,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+// blockStreamMessage is sent to a subscriber for every accepted block.
+type blockStreamMessage struct {
+	Height avajson.Uint64 `json:"height"`
+	Block  string         `json:"block"`
+}
+
+// blockStreamError is sent to a subscriber before the stream is closed due to
+// an error.
+type blockStreamError struct {
+	Error string `json:"error"`
+}
+
+// blockStream streams the accepted blocks to websocket subscribers. Every
+// subscriber is sent the blocks in height order, without gaps, starting from
+// the height it requested.
+//
+// The bytes of the last [window] accepted blocks are buffered, so subscribers
+// that resume the stream after a brief disconnect are served without reading
+// from the database.
+type blockStream struct {
+	log logging.Logger
+	// getBlock returns the bytes of the accepted block at [height].
+	getBlock func(height uint64) ([]byte, error)
+	window   int
+
+	lock sync.Mutex
+	// initialized is true once the height of the last accepted block is known.
+	initialized        bool
+	lastAcceptedHeight uint64
+	// recent holds the bytes of the most recently accepted blocks, in height
+	// order. The last block is at [lastAcceptedHeight].
+	recent buffer.Deque[[]byte]
+	// accepted is closed, and replaced, whenever a block is accepted.
+	accepted chan struct{}
+}
+
+func newBlockStream(
+	log logging.Logger,
+	window int,
+	getBlock func(height uint64) ([]byte, error),
+) *blockStream {
+	return &blockStream{
+		log:      log,
+		getBlock: getBlock,
+		window:   window,
+		recent:   buffer.NewUnboundedDeque[[]byte](window),
+		accepted: make(chan struct{}),
+	}
+}
+
+// setLastAccepted marks [height] as the height of the last accepted block.
+func (s *blockStream) setLastAccepted(height uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.initialized = true
+	s.lastAcceptedHeight = height
+	s.recent = buffer.NewUnboundedDeque[[]byte](s.window)
+	s.notify()
+}
+
+// accept is called with every accepted block, in height order.
+func (s *blockStream) accept(height uint64, blkBytes []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.initialized = true
+	s.lastAcceptedHeight = height
+	if s.window > 0 {
+		if s.recent.Len() == s.window {
+			s.recent.PopLeft()
+		}
+		s.recent.PushRight(blkBytes)
+	}
+	s.notify()
+}
+
+// notify wakes up the subscribers waiting for a block to be accepted.
+//
+// Invariant: [s.lock] must be held.
+func (s *blockStream) notify() {
+	close(s.accepted)
+	s.accepted = make(chan struct{})
+}
+
+// next returns the bytes of the accepted block at [height], waiting until it's
+// accepted.
+func (s *blockStream) next(ctx context.Context, height uint64) ([]byte, error) {
+	for {
+		s.lock.Lock()
+		if s.initialized && height <= s.lastAcceptedHeight {
+			recentStart := s.lastAcceptedHeight + 1 - uint64(s.recent.Len())
+			if height >= recentStart {
+				blkBytes, _ := s.recent.Index(int(height - recentStart))
+				s.lock.Unlock()
+				return blkBytes, nil
+			}
+
+			// The context lock may be grabbed while fetching the block, so
+			// [s.lock] must be released first.
+			s.lock.Unlock()
+			return s.getBlock(height)
+		}
+		accepted := s.accepted
+		s.lock.Unlock()
+
+		select {
+		case <-accepted:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// startHeight returns the height requested by [r]. If no height was requested,
+// the stream starts after the last accepted block.
+func (s *blockStream) startHeight(r *http.Request) (uint64, error) {
+	if heightStr := r.URL.Query().Get(blockStreamHeightParam); heightStr != "" {
+		height, err := strconv.ParseUint(heightStr, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", errInvalidStartHeight, err)
+		}
+		return height, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.initialized {
+		return 0, nil
+	}
+	return s.lastAcceptedHeight + 1, nil
+}
+
+func (s *blockStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	height, err := s.startHeight(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := blockStreamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.readPump(conn, cancel)
+	go s.pingPump(ctx, conn, cancel)
+
+	for ; ; height++ {
+		blkBytes, err := s.next(ctx, height)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Debug("closing block stream",
+					zap.Uint64("height", height),
+					zap.Error(err),
+				)
+				_ = s.write(conn, &blockStreamError{
+					Error: fmt.Sprintf("failed to get block at height %d: %s", height, err),
+				})
+			}
+			return
+		}
+
+		blkStr, err := formatting.Encode(formatting.Hex, blkBytes)
+		if err != nil {
+			return
+		}
+		err = s.write(conn, &blockStreamMessage{
+			Height: avajson.Uint64(height),
+			Block:  blkStr,
+		})
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (*blockStream) write(conn *websocket.Conn, msg interface{}) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(blockStreamWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(msg)
+}
+
+// readPump discards the messages sent by the subscriber so that pongs and close
+// messages are handled. [cancel] is called once the connection is closed.
+func (s *blockStream) readPump(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := conn.SetReadDeadline(time.Now().Add(blockStreamPongWait)); err != nil {
+		return
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(blockStreamPongWait))
+	})
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				s.log.Debug("unexpected close in block stream",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+	}
+}
+
+// pingPump periodically pings the subscriber until [ctx] is cancelled.
+func (*blockStream) pingPump(ctx context.Context, conn *websocket.Conn, cancel context.CancelFunc) {
+	ticker := time.NewTicker(blockStreamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deadline := time.Now().Add(blockStreamWriteWait)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
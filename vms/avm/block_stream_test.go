@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestBlockStream(window int, blocks [][]byte, getBlockHeights *[]uint64) *blockStream {
+	return newBlockStream(logging.NoLog{}, window, func(height uint64) ([]byte, error) {
+		*getBlockHeights = append(*getBlockHeights, height)
+		if height >= uint64(len(blocks)) {
+			return nil, database.ErrNotFound
+		}
+		return blocks[height], nil
+	})
+}
+
+func TestBlockStreamNext(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		blocks          = [][]byte{{0}, {1}, {2}, {3}, {4}, {5}}
+		getBlockHeights []uint64
+		stream          = newTestBlockStream(2, blocks, &getBlockHeights)
+		ctx             = context.Background()
+	)
+	stream.setLastAccepted(1)
+	for height := uint64(2); height <= 4; height++ {
+		stream.accept(height, blocks[height])
+	}
+
+	// Blocks outside of the window are read from the database.
+	for height := uint64(0); height <= 2; height++ {
+		blkBytes, err := stream.next(ctx, height)
+		require.NoError(err)
+		require.Equal(blocks[height], blkBytes)
+	}
+	require.Equal([]uint64{0, 1, 2}, getBlockHeights)
+
+	// Blocks in the window are served from memory.
+	for height := uint64(3); height <= 4; height++ {
+		blkBytes, err := stream.next(ctx, height)
+		require.NoError(err)
+		require.Equal(blocks[height], blkBytes)
+	}
+	require.Len(getBlockHeights, 3)
+
+	// Blocks that haven't been accepted yet are waited for.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := stream.next(cancelledCtx, 5)
+	require.ErrorIs(err, context.Canceled)
+
+	done := make(chan []byte)
+	go func() {
+		blkBytes, _ := stream.next(ctx, 5)
+		done <- blkBytes
+	}()
+	stream.accept(5, blocks[5])
+	require.Equal(blocks[5], <-done)
+}
+
+func TestBlockStreamServeHTTP(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		blocks          = [][]byte{{0}, {1}, {2}, {3}}
+		getBlockHeights []uint64
+		stream          = newTestBlockStream(1, blocks, &getBlockHeights)
+	)
+	stream.setLastAccepted(1)
+
+	server := httptest.NewServer(stream)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	_, resp, err := websocket.DefaultDialer.Dial(url+"?height=-1", nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusBadRequest, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url+"?height=0", nil)
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+	defer conn.Close()
+
+	readBlock := func(height uint64) {
+		var msg blockStreamMessage
+		require.NoError(conn.ReadJSON(&msg))
+		require.Equal(height, uint64(msg.Height))
+
+		blkBytes, err := formatting.Decode(formatting.Hex, msg.Block)
+		require.NoError(err)
+		require.Equal(blocks[height], blkBytes)
+	}
+
+	// The accepted blocks are sent in order, followed by the blocks that are
+	// accepted later.
+	readBlock(0)
+	readBlock(1)
+	stream.accept(2, blocks[2])
+	stream.accept(3, blocks[3])
+	readBlock(2)
+	readBlock(3)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/hll"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const (
+	feeBucketDuration = time.Minute
+	numFeeBuckets     = int(24 * time.Hour / feeBucketDuration)
+
+	// uniqueAddressesPrecision results in a ~1.6% standard error while only
+	// requiring 4KiB of storage.
+	uniqueAddressesPrecision = 12
+)
+
+var (
+	chainStatsPrefix = []byte("chainStats")
+
+	txCountKey       = []byte("txCount")
+	utxosProducedKey = []byte("utxosProduced")
+	utxosConsumedKey = []byte("utxosConsumed")
+	uniqueAddrsKey   = []byte("uniqueAddrs")
+
+	// feeWindows are the windows over which average fees are reported
+	feeWindows = []time.Duration{
+		time.Hour,
+		24 * time.Hour,
+	}
+)
+
+// feeBucket aggregates the fees of the txs accepted during a single
+// [feeBucketDuration] interval.
+type feeBucket struct {
+	// start is the index of the interval this bucket aggregates
+	start   int64
+	numTxs  uint64
+	sumFees uint64
+}
+
+// chainStats incrementally tracks chain-wide statistics as txs are accepted.
+//
+// Counters are persisted once per accepted block so that they survive
+// restarts. Fees are only tracked in memory, so fee averages only include txs
+// accepted since the node started.
+type chainStats struct {
+	db    database.Database
+	clock *mockable.Clock
+
+	txCount       uint64
+	utxosProduced uint64
+	utxosConsumed uint64
+	// uniqueAddrs estimates the number of distinct addresses that have owned
+	// a UTXO
+	uniqueAddrs *hll.Sketch
+	// dirty is true if txs were accepted since the counters were last written
+	dirty bool
+
+	feeBuckets [numFeeBuckets]feeBucket
+}
+
+func newChainStats(db database.Database, clock *mockable.Clock) (*chainStats, error) {
+	s := &chainStats{
+		db:    prefixdb.New(chainStatsPrefix, db),
+		clock: clock,
+	}
+
+	var err error
+	s.txCount, err = getUInt64(s.db, txCountKey)
+	if err != nil {
+		return nil, err
+	}
+	s.utxosProduced, err = getUInt64(s.db, utxosProducedKey)
+	if err != nil {
+		return nil, err
+	}
+	s.utxosConsumed, err = getUInt64(s.db, utxosConsumedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueAddrsBytes, err := s.db.Get(uniqueAddrsKey)
+	switch err {
+	case nil:
+		s.uniqueAddrs, err = hll.Parse(uniqueAddrsBytes)
+	case database.ErrNotFound:
+		s.uniqueAddrs, err = hll.New(uniqueAddressesPrecision)
+	}
+	return s, err
+}
+
+// accept records a tx that consumed the [consumed] UTXOs of this chain,
+// produced the [produced] UTXOs on this chain, and paid [fee].
+//
+// The updated counters are only persisted by the next call to write.
+func (s *chainStats) accept(consumed, produced []*avax.UTXO, fee uint64) {
+	s.dirty = true
+	s.txCount++
+	s.utxosConsumed += uint64(len(consumed))
+	s.utxosProduced += uint64(len(produced))
+
+	for _, utxos := range [][]*avax.UTXO{consumed, produced} {
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			for _, addr := range out.Addresses() {
+				hll.Add(s.uniqueAddrs, addr)
+			}
+		}
+	}
+
+	bucketStart := s.clock.Time().UnixNano() / int64(feeBucketDuration)
+	bucket := &s.feeBuckets[bucketStart%int64(numFeeBuckets)]
+	if bucket.start != bucketStart {
+		*bucket = feeBucket{
+			start: bucketStart,
+		}
+	}
+	bucket.numTxs++
+	bucket.sumFees += fee
+}
+
+// write persists the counters if txs were accepted since they were last
+// written.
+func (s *chainStats) write() error {
+	if !s.dirty {
+		return nil
+	}
+	if err := database.PutUInt64(s.db, txCountKey, s.txCount); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(s.db, utxosProducedKey, s.utxosProduced); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(s.db, utxosConsumedKey, s.utxosConsumed); err != nil {
+		return err
+	}
+	if err := s.db.Put(uniqueAddrsKey, s.uniqueAddrs.Marshal()); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// utxoCount returns the number of UTXOs produced but not yet consumed.
+//
+// If tracking started after genesis, UTXOs produced before tracking started
+// may be consumed, in which case the count is floored at 0.
+func (s *chainStats) utxoCount() uint64 {
+	if s.utxosConsumed > s.utxosProduced {
+		return 0
+	}
+	return s.utxosProduced - s.utxosConsumed
+}
+
+// averageFee returns the number of txs accepted within the last [window] and
+// their average fee.
+func (s *chainStats) averageFee(window time.Duration) (uint64, uint64) {
+	var (
+		currentBucket = s.clock.Time().UnixNano() / int64(feeBucketDuration)
+		numBuckets    = min(int64(window/feeBucketDuration), int64(numFeeBuckets))
+		oldestBucket  = currentBucket - numBuckets
+		numTxs        uint64
+		sumFees       uint64
+	)
+	for _, bucket := range s.feeBuckets {
+		if bucket.start <= oldestBucket || bucket.start > currentBucket {
+			continue
+		}
+		numTxs += bucket.numTxs
+		sumFees += bucket.sumFees
+	}
+	if numTxs == 0 {
+		return 0, 0
+	}
+	return numTxs, sumFees / numTxs
+}
+
+// getUInt64 returns the value stored under [key], defaulting to 0 if the key
+// has never been written.
+func getUInt64(db database.KeyValueReader, key []byte) (uint64, error) {
+	value, err := database.GetUInt64(db, key)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return value, err
+}
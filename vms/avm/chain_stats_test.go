@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newChainStatsTestUTXOs(addrs ...ids.ShortID) []*avax.UTXO {
+	utxos := make([]*avax.UTXO, len(addrs))
+	for i, addr := range addrs {
+		utxos[i] = &avax.UTXO{
+			Out: &secp256k1fx.TransferOutput{
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+	return utxos
+}
+
+func TestChainStats(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(1_000_000, 0))
+
+	stats, err := newChainStats(db, clock)
+	require.NoError(err)
+	require.Zero(stats.txCount)
+	require.Zero(stats.utxoCount())
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+		addr2 = ids.GenerateTestShortID()
+	)
+	stats.accept(
+		newChainStatsTestUTXOs(addr0),
+		newChainStatsTestUTXOs(addr0, addr1, addr1),
+		100,
+	)
+	clock.Set(clock.Time().Add(2 * time.Hour))
+	stats.accept(
+		newChainStatsTestUTXOs(addr0, addr1),
+		newChainStatsTestUTXOs(addr2),
+		200,
+	)
+	stats.accept(
+		nil,
+		newChainStatsTestUTXOs(addr1, addr2),
+		400,
+	)
+
+	require.Equal(uint64(3), stats.txCount)
+	require.Equal(uint64(3), stats.utxoCount())
+	require.Equal(uint64(3), stats.uniqueAddrs.Count())
+
+	txCount, averageFee := stats.averageFee(time.Hour)
+	require.Equal(uint64(2), txCount)
+	require.Equal(uint64(300), averageFee)
+
+	txCount, averageFee = stats.averageFee(24 * time.Hour)
+	require.Equal(uint64(3), txCount)
+	require.Equal(uint64(233), averageFee)
+
+	// Fees older than the tracked window are dropped.
+	clock.Set(clock.Time().Add(25 * time.Hour))
+	txCount, averageFee = stats.averageFee(24 * time.Hour)
+	require.Zero(txCount)
+	require.Zero(averageFee)
+
+	// Counters are only persisted when written.
+	reloaded, err := newChainStats(db, clock)
+	require.NoError(err)
+	require.Zero(reloaded.txCount)
+
+	require.NoError(stats.write())
+	stats, err = newChainStats(db, clock)
+	require.NoError(err)
+	require.Equal(uint64(3), stats.txCount)
+	require.Equal(uint64(3), stats.utxoCount())
+	require.Equal(uint64(3), stats.uniqueAddrs.Count())
+}
+
+func TestChainStatsUTXOCountFloor(t *testing.T) {
+	require := require.New(t)
+
+	stats, err := newChainStats(memdb.New(), &mockable.Clock{})
+	require.NoError(err)
+
+	stats.accept(
+		newChainStatsTestUTXOs(ids.GenerateTestShortID(), ids.GenerateTestShortID()),
+		newChainStatsTestUTXOs(ids.GenerateTestShortID()),
+		0,
+	)
+	require.Zero(stats.utxoCount())
+}
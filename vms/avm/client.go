@@ -30,6 +30,13 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetChainStats returns chain-wide statistics
+	GetChainStats(ctx context.Context, options ...rpc.Option) (*GetChainStatsReply, error)
+	// ValidateAddress returns whether [addr] is a valid address of this
+	// network and, if it is, the chain and short ID it encodes
+	ValidateAddress(ctx context.Context, addr string, options ...rpc.Option) (*ValidateAddressReply, error)
+	// ConvertAddress returns [addr] formatted for [targetChain]
+	ConvertAddress(ctx context.Context, addr string, targetChain string, options ...rpc.Option) (string, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -43,6 +50,9 @@ type Client interface {
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// GetTxProof returns the byte representation of the accepted blocks from
+	// the block that accepted [txID] to the last accepted block
+	GetTxProof(ctx context.Context, txID ids.ID, options ...rpc.Option) ([][]byte, error)
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
 	GetUTXOs(
 		ctx context.Context,
@@ -65,6 +75,8 @@ type Client interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetAssetDescription returns a description of [assetID]
 	GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error)
+	// GetAssetSupply returns the circulating supply of [assetID]
+	GetAssetSupply(ctx context.Context, assetID string, options ...rpc.Option) (uint64, error)
 	// GetBalance returns the balance of [assetID] held by [addr].
 	// If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 	//
@@ -234,6 +246,24 @@ func NewClient(uri, chain string) Client {
 	}
 }
 
+// NewFailoverClient returns an AVM client for interacting with avm [chain] on
+// the first healthy node of [uris]. Requests that fail to reach a node are sent
+// to the next node and retried according to [config].
+func NewFailoverClient(uris []string, chain string, config rpc.FailoverConfig) Client {
+	paths := make([]string, len(uris))
+	for i, uri := range uris {
+		paths[i] = fmt.Sprintf(
+			"%s/ext/%s/%s",
+			uri,
+			constants.ChainAliasPrefix,
+			chain,
+		)
+	}
+	return &client{
+		requester: rpc.NewFailoverEndpointRequester(paths, config),
+	}
+}
+
 func (c *client) GetBlock(ctx context.Context, blkID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	err := c.requester.SendRequest(ctx, "avm.getBlock", &api.GetBlockArgs{
@@ -258,6 +288,29 @@ func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ..
 	return formatting.Decode(res.Encoding, res.Block)
 }
 
+func (c *client) GetChainStats(ctx context.Context, options ...rpc.Option) (*GetChainStatsReply, error) {
+	res := &GetChainStatsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getChainStats", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) ValidateAddress(ctx context.Context, addr string, options ...rpc.Option) (*ValidateAddressReply, error) {
+	res := &ValidateAddressReply{}
+	err := c.requester.SendRequest(ctx, "avm.validateAddress", &ValidateAddressArgs{
+		Address: addr,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ConvertAddress(ctx context.Context, addr string, targetChain string, options ...rpc.Option) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest(ctx, "avm.convertAddress", &ConvertAddressArgs{
+		Address:     addr,
+		TargetChain: targetChain,
+	}, res, options...)
+	return res.Address, err
+}
+
 func (c *client) GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error) {
 	res := &api.GetHeightResponse{}
 	err := c.requester.SendRequest(ctx, "avm.getHeight", struct{}{}, res, options...)
@@ -317,6 +370,26 @@ func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option)
 	return formatting.Decode(res.Encoding, res.Tx)
 }
 
+func (c *client) GetTxProof(ctx context.Context, txID ids.ID, options ...rpc.Option) ([][]byte, error) {
+	res := &GetTxProofReply{}
+	err := c.requester.SendRequest(ctx, "avm.getTxProof", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([][]byte, len(res.Blocks))
+	for i, blk := range res.Blocks {
+		blocks[i], err = formatting.Decode(res.Encoding, blk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
 func (c *client) GetUTXOs(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -376,6 +449,14 @@ func (c *client) GetAssetDescription(ctx context.Context, assetID string, option
 	return res, err
 }
 
+func (c *client) GetAssetSupply(ctx context.Context, assetID string, options ...rpc.Option) (uint64, error) {
+	res := &GetAssetSupplyReply{}
+	err := c.requester.SendRequest(ctx, "avm.getAssetSupply", &GetAssetSupplyArgs{
+		AssetID: assetID,
+	}, res, options...)
+	return uint64(res.Supply), err
+}
+
 func (c *client) GetBalance(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -14,6 +14,7 @@ var DefaultConfig = Config{
 	IndexTransactions:    false,
 	IndexAllowIncomplete: false,
 	ChecksumsEnabled:     false,
+	BlockStreamWindow:    256,
 }
 
 type Config struct {
@@ -21,6 +22,9 @@ type Config struct {
 	IndexTransactions    bool           `json:"index-transactions"`
 	IndexAllowIncomplete bool           `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool           `json:"checksums-enabled"`
+	// BlockStreamWindow is the number of most recently accepted blocks that
+	// are buffered in memory to serve block stream subscribers.
+	BlockStreamWindow int `json:"block-stream-window"`
 }
 
 func ParseConfig(configBytes []byte) (Config, error) {
@@ -31,6 +31,7 @@ func TestParseConfig(t *testing.T) {
 				IndexTransactions:    DefaultConfig.IndexTransactions,
 				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
 				ChecksumsEnabled:     true,
+				BlockStreamWindow:    DefaultConfig.BlockStreamWindow,
 			},
 		},
 		{
@@ -59,6 +60,18 @@ func TestParseConfig(t *testing.T) {
 				IndexTransactions:    DefaultConfig.IndexTransactions,
 				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
 				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				BlockStreamWindow:    DefaultConfig.BlockStreamWindow,
+			},
+		},
+		{
+			name:        "manually specified block stream window",
+			configBytes: []byte(`{"block-stream-window":10}`),
+			expectedConfig: Config{
+				Network:              network.DefaultConfig,
+				IndexTransactions:    DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				BlockStreamWindow:    10,
 			},
 		},
 	}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/metrics"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+)
+
+var _ block.Parser = (*meteredParser)(nil)
+
+// meteredParser records the cause of every failure to parse txs and blocks, so
+// that peers sending malformed bytes can be detected.
+type meteredParser struct {
+	block.Parser
+
+	metrics metrics.Metrics
+}
+
+func (p *meteredParser) ParseTx(bytes []byte) (*txs.Tx, error) {
+	tx, err := p.Parser.ParseTx(bytes)
+	if err != nil {
+		p.metrics.MarkMalformedTx(err)
+	}
+	return tx, err
+}
+
+func (p *meteredParser) ParseBlock(bytes []byte) (block.Block, error) {
+	blk, err := p.Parser.ParseBlock(bytes)
+	if err != nil {
+		p.metrics.MarkMalformedBlock(err)
+	}
+	return blk, err
+}
@@ -4,15 +4,33 @@
 package metrics
 
 import (
+	"errors"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 )
 
-var _ Metrics = (*metrics)(nil)
+const (
+	causeLabel = "cause"
+
+	CauseUnknownCodecVersion = "unknown_codec_version"
+	CauseTruncated           = "truncated"
+	CauseTrailingBytes       = "trailing_bytes"
+	CauseUnknownType         = "unknown_type"
+	CauseTooLarge            = "too_large"
+	CauseOther               = "other"
+)
+
+var (
+	_ Metrics = (*metrics)(nil)
+
+	causeLabels = []string{causeLabel}
+)
 
 type Metrics interface {
 	metric.APIInterceptor
@@ -31,6 +49,12 @@ type Metrics interface {
 	// as MarkBlockAccepted already handles updating transaction related
 	// metrics.
 	MarkTxAccepted(tx *txs.Tx) error
+
+	// MarkMalformedTx records that tx bytes failed to be parsed with [err].
+	MarkMalformedTx(err error)
+	// MarkMalformedBlock records that block bytes failed to be parsed with
+	// [err].
+	MarkMalformedBlock(err error)
 }
 
 type metrics struct {
@@ -38,6 +62,8 @@ type metrics struct {
 
 	numTxRefreshes, numTxRefreshHits, numTxRefreshMisses prometheus.Counter
 
+	numMalformedTxs, numMalformedBlocks *prometheus.CounterVec
+
 	metric.APIInterceptor
 }
 
@@ -66,6 +92,37 @@ func (m *metrics) MarkTxAccepted(tx *txs.Tx) error {
 	return tx.Unsigned.Visit(m.txMetrics)
 }
 
+func (m *metrics) MarkMalformedTx(err error) {
+	m.numMalformedTxs.With(prometheus.Labels{
+		causeLabel: ParseFailureCause(err),
+	}).Inc()
+}
+
+func (m *metrics) MarkMalformedBlock(err error) {
+	m.numMalformedBlocks.With(prometheus.Labels{
+		causeLabel: ParseFailureCause(err),
+	}).Inc()
+}
+
+// ParseFailureCause classifies the error returned when failing to parse tx or
+// block bytes.
+func ParseFailureCause(err error) string {
+	switch {
+	case errors.Is(err, codec.ErrUnknownVersion):
+		return CauseUnknownCodecVersion
+	case errors.Is(err, codec.ErrCantUnpackVersion), errors.Is(err, wrappers.ErrInsufficientLength):
+		return CauseTruncated
+	case errors.Is(err, codec.ErrExtraSpace):
+		return CauseTrailingBytes
+	case errors.Is(err, codec.ErrUnknownTypeID), errors.Is(err, codec.ErrDoesNotImplementInterface):
+		return CauseUnknownType
+	case errors.Is(err, codec.ErrUnmarshalTooBig), errors.Is(err, codec.ErrMaxSliceLenExceeded):
+		return CauseTooLarge
+	default:
+		return CauseOther
+	}
+}
+
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
@@ -91,6 +148,23 @@ func New(
 		Help:      "Number of times unique txs have not been unique and weren't cached",
 	})
 
+	m.numMalformedTxs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "malformed_txs",
+			Help:      "Number of txs that failed to be parsed, by cause",
+		},
+		causeLabels,
+	)
+	m.numMalformedBlocks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "malformed_blocks",
+			Help:      "Number of blocks that failed to be parsed, by cause",
+		},
+		causeLabels,
+	)
+
 	apiRequestMetric, err := metric.NewAPIInterceptor(namespace, registerer)
 	m.APIInterceptor = apiRequestMetric
 	errs.Add(
@@ -98,6 +172,8 @@ func New(
 		registerer.Register(m.numTxRefreshes),
 		registerer.Register(m.numTxRefreshHits),
 		registerer.Register(m.numTxRefreshMisses),
+		registerer.Register(m.numMalformedTxs),
+		registerer.Register(m.numMalformedBlocks),
 	)
 	return m, errs.Err
 }
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestParseFailureCause(t *testing.T) {
+	parser, err := txs.NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		bytes         []byte
+		expectedCause string
+	}{
+		{
+			name:          "empty",
+			bytes:         nil,
+			expectedCause: CauseTruncated,
+		},
+		{
+			name:          "unknown codec version",
+			bytes:         []byte{0x00, 0x01},
+			expectedCause: CauseUnknownCodecVersion,
+		},
+		{
+			name:          "truncated type ID",
+			bytes:         []byte{0x00, 0x00, 0x00},
+			expectedCause: CauseTruncated,
+		},
+		{
+			name:          "unknown type ID",
+			bytes:         []byte{0x00, 0x00, 0x00, 0x00, 0xff, 0xff},
+			expectedCause: CauseUnknownType,
+		},
+		{
+			name: "unsigned tx type",
+			// Type ID 5 is the secp256k1fx.TransferInput, which isn't an
+			// UnsignedTx.
+			bytes:         []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x05},
+			expectedCause: CauseUnknownType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parser.ParseTx(test.bytes)
+			require.Equal(t, test.expectedCause, ParseFailureCause(err))
+		})
+	}
+}
@@ -118,6 +118,30 @@ func (mr *MockMetricsMockRecorder) MarkBlockAccepted(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBlockAccepted", reflect.TypeOf((*MockMetrics)(nil).MarkBlockAccepted), arg0)
 }
 
+// MarkMalformedBlock mocks base method.
+func (m *MockMetrics) MarkMalformedBlock(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkMalformedBlock", arg0)
+}
+
+// MarkMalformedBlock indicates an expected call of MarkMalformedBlock.
+func (mr *MockMetricsMockRecorder) MarkMalformedBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMalformedBlock", reflect.TypeOf((*MockMetrics)(nil).MarkMalformedBlock), arg0)
+}
+
+// MarkMalformedTx mocks base method.
+func (m *MockMetrics) MarkMalformedTx(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkMalformedTx", arg0)
+}
+
+// MarkMalformedTx indicates an expected call of MarkMalformedTx.
+func (mr *MockMetricsMockRecorder) MarkMalformedTx(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMalformedTx", reflect.TypeOf((*MockMetrics)(nil).MarkMalformedTx), arg0)
+}
+
 // MarkTxAccepted mocks base method.
 func (m *MockMetrics) MarkTxAccepted(arg0 *txs.Tx) error {
 	m.ctrl.T.Helper()
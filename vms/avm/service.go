@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
@@ -38,6 +39,10 @@ const (
 
 	// Max number of items allowed in a page
 	maxPageSize uint64 = 1024
+
+	// Max number of blocks, ending at the last accepted block, that are
+	// searched for the block that accepted a tx by GetTxProof
+	maxTxProofBlocks = 256
 )
 
 var (
@@ -52,6 +57,7 @@ var (
 	errNoKeys             = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey  = errors.New("argument 'privateKey' not given")
 	errNotLinearized      = errors.New("chain is not linearized")
+	errTxProofUnavailable = errors.New("tx wasn't accepted in a recent block")
 )
 
 // FormattedAssetID defines a JSON formatted struct containing an assetID as a string
@@ -164,6 +170,50 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	return err
 }
 
+// FeeWindowStats are the fee statistics of the txs accepted within a window
+type FeeWindowStats struct {
+	Window     string         `json:"window"`
+	TxCount    avajson.Uint64 `json:"txCount"`
+	AverageFee avajson.Uint64 `json:"averageFee"`
+}
+
+// GetChainStatsReply defines the GetChainStats replies returned from the API
+type GetChainStatsReply struct {
+	TxCount   avajson.Uint64 `json:"txCount"`
+	UTXOCount avajson.Uint64 `json:"utxoCount"`
+	// UniqueAddresses is an estimate of the number of distinct addresses that
+	// have owned a UTXO
+	UniqueAddresses avajson.Uint64   `json:"uniqueAddresses"`
+	Fees            []FeeWindowStats `json:"fees"`
+}
+
+// GetChainStats returns chain-wide statistics that are computed incrementally
+// as txs are accepted.
+func (s *Service) GetChainStats(_ *http.Request, _ *struct{}, reply *GetChainStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getChainStats"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	stats := s.vm.chainStats
+	reply.TxCount = avajson.Uint64(stats.txCount)
+	reply.UTXOCount = avajson.Uint64(stats.utxoCount())
+	reply.UniqueAddresses = avajson.Uint64(stats.uniqueAddrs.Count())
+	reply.Fees = make([]FeeWindowStats, len(feeWindows))
+	for i, window := range feeWindows {
+		txCount, averageFee := stats.averageFee(window)
+		reply.Fees[i] = FeeWindowStats{
+			Window:     window.String(),
+			TxCount:    avajson.Uint64(txCount),
+			AverageFee: avajson.Uint64(averageFee),
+		}
+	}
+	return nil
+}
+
 // GetHeight returns the height of the last accepted block.
 func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightResponse) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -192,6 +242,74 @@ func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightRe
 	return nil
 }
 
+// ValidateAddressArgs are the arguments for calling ValidateAddress
+type ValidateAddressArgs struct {
+	Address string `json:"address"`
+}
+
+// ValidateAddressReply is the response from calling ValidateAddress
+type ValidateAddressReply struct {
+	Valid bool `json:"valid"`
+	// Reason the address is invalid. Only non-empty if Valid is false.
+	Reason string `json:"reason,omitempty"`
+	// ChainID the address is prefixed with. Only set if Valid is true.
+	ChainID ids.ID `json:"chainID"`
+	// ShortID is the address without the chain prefix and HRP. Only set if
+	// Valid is true.
+	ShortID ids.ShortID `json:"shortID"`
+}
+
+// ValidateAddress reports whether an address is a well-formed bech32 address
+// of this network that is prefixed with the alias of a known chain. An invalid
+// address is not an error; the reason it was rejected is returned instead.
+func (s *Service) ValidateAddress(_ *http.Request, args *ValidateAddressArgs, reply *ValidateAddressReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "validateAddress"),
+		logging.UserString("address", args.Address),
+	)
+
+	chainID, addr, err := s.vm.ParseAddress(args.Address)
+	if err != nil {
+		reply.Reason = err.Error()
+		return nil
+	}
+
+	reply.Valid = true
+	reply.ChainID = chainID
+	reply.ShortID = addr
+	return nil
+}
+
+// ConvertAddressArgs are the arguments for calling ConvertAddress
+type ConvertAddressArgs struct {
+	Address string `json:"address"`
+	// TargetChain is the ID or alias of the chain to format the address for
+	TargetChain string `json:"targetChain"`
+}
+
+// ConvertAddress formats an address of this network for another chain, such as
+// an X-Chain address for the P-Chain.
+func (s *Service) ConvertAddress(_ *http.Request, args *ConvertAddressArgs, reply *api.JSONAddress) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "convertAddress"),
+		logging.UserString("address", args.Address),
+		logging.UserString("targetChain", args.TargetChain),
+	)
+
+	_, addr, err := s.vm.ParseAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+	chainID, err := s.vm.ctx.BCLookup.Lookup(args.TargetChain)
+	if err != nil {
+		return fmt.Errorf("couldn't find chain %q: %w", args.TargetChain, err)
+	}
+	reply.Address, err = s.vm.FormatAddress(chainID, addr)
+	return err
+}
+
 // IssueTx attempts to issue a transaction into consensus
 func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -367,6 +485,71 @@ func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxRe
 	return err
 }
 
+// GetTxProofReply defines the GetTxProof replies returned from the API
+type GetTxProofReply struct {
+	// BlockID is the ID of the block that accepted the tx
+	BlockID ids.ID `json:"blockID"`
+	// Height is the height of the block that accepted the tx
+	Height avajson.Uint64 `json:"height"`
+	// Blocks are the accepted blocks from the block that accepted the tx to
+	// the last accepted block, in order of increasing height
+	Blocks   []string            `json:"blocks"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetTxProof returns the proof that a tx was accepted. The proof is the
+// sequence of accepted blocks from the block that accepted the tx to the last
+// accepted block, which can be verified with [block.VerifyTxProof]. Only txs
+// accepted in one of the [maxTxProofBlocks] most recent blocks can be proven.
+func (s *Service) GetTxProof(_ *http.Request, args *api.GetTxArgs, reply *GetTxProofReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getTxProof"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+	if _, err := s.vm.state.GetTx(args.TxID); err != nil {
+		return err
+	}
+
+	var blocks []block.Block
+	for blkID := s.vm.chainManager.LastAccepted(); len(blocks) < maxTxProofBlocks; {
+		blk, err := s.vm.chainManager.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+		}
+		blocks = append(blocks, blk)
+
+		if block.IncludesTx(blk, args.TxID) {
+			reply.BlockID = blk.ID()
+			reply.Height = avajson.Uint64(blk.Height())
+			reply.Encoding = args.Encoding
+			reply.Blocks = make([]string, len(blocks))
+			for i, blk := range blocks {
+				reply.Blocks[len(blocks)-1-i], err = formatting.Encode(args.Encoding, blk.Bytes())
+				if err != nil {
+					return fmt.Errorf("couldn't encode block %s as string: %w", blk.ID(), err)
+				}
+			}
+			return nil
+		}
+		if blk.Height() == 0 {
+			break
+		}
+		blkID = blk.Parent()
+	}
+	return fmt.Errorf("%w: %s", errTxProofUnavailable, args.TxID)
+}
+
 // GetUTXOs gets all utxos for passed in addresses
 func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -518,6 +701,55 @@ func (s *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescription
 	return nil
 }
 
+// GetAssetSupplyArgs are arguments for passing into GetAssetSupply requests
+type GetAssetSupplyArgs struct {
+	AssetID string `json:"assetID"`
+}
+
+// GetAssetSupplyReply defines the GetAssetSupply replies returned from the API
+type GetAssetSupplyReply struct {
+	FormattedAssetID
+	Supply avajson.Uint64 `json:"supply"`
+}
+
+// GetAssetSupply returns the circulating supply of an asset on this chain,
+// which is the sum of the amounts of its unspent UTXOs.
+func (s *Service) GetAssetSupply(_ *http.Request, args *GetAssetSupplyArgs, reply *GetAssetSupplyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getAssetSupply"),
+		logging.UserString("assetID", args.AssetID),
+	)
+
+	assetID, err := s.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, err := s.vm.state.GetTx(assetID)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.Unsigned.(*txs.CreateAssetTx); !ok {
+		return errTxNotCreateAsset
+	}
+
+	supply, err := s.vm.state.GetAssetSupply(assetID)
+	if err != nil {
+		return fmt.Errorf("problem retrieving supply: %w", err)
+	}
+	if !supply.IsUint64() {
+		return fmt.Errorf("%w: supply of %s is %s", safemath.ErrOverflow, assetID, supply)
+	}
+
+	reply.AssetID = assetID
+	reply.Supply = avajson.Uint64(supply.Uint64())
+	return nil
+}
+
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
 	Address        string `json:"address"`
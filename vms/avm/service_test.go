@@ -1792,6 +1792,56 @@ func buildOperationTxWithOp(chainID ids.ID, op ...*txs.Operation) *txs.Tx {
 	}}
 }
 
+func TestServiceGetTxProof(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: latest,
+	})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetTxProofReply{}
+	err := env.service.GetTxProof(nil, &api.GetTxArgs{}, &reply)
+	require.ErrorIs(err, errNilTxID)
+
+	// The genesis tx isn't included in a block.
+	err = env.service.GetTxProof(nil, &api.GetTxArgs{
+		TxID:     env.genesisTx.ID(),
+		Encoding: formatting.Hex,
+	}, &reply)
+	require.ErrorIs(err, errTxProofUnavailable)
+
+	newTx := newAvaxBaseTxWithOutputs(t, env.genesisBytes, env.vm.ctx.ChainID, env.vm.TxFee, env.vm.parser)
+	issueAndAccept(require, env.vm, env.issuer, newTx)
+
+	require.NoError(env.service.GetTxProof(nil, &api.GetTxArgs{
+		TxID:     newTx.ID(),
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Len(reply.Blocks, 1)
+
+	blocks := make([][]byte, len(reply.Blocks))
+	for i, blk := range reply.Blocks {
+		blocks[i], err = formatting.Decode(reply.Encoding, blk)
+		require.NoError(err)
+	}
+
+	tip, err := block.VerifyTxProof(env.vm.parser, newTx.ID(), blocks)
+	require.NoError(err)
+	require.Equal(reply.BlockID, tip.ID())
+
+	env.vm.ctx.Lock.Lock()
+	lastAcceptedID := env.vm.state.GetLastAccepted()
+	env.vm.ctx.Lock.Unlock()
+	require.Equal(lastAcceptedID, tip.ID())
+}
+
 func TestServiceGetNilTx(t *testing.T) {
 	require := require.New(t)
 
@@ -2110,6 +2160,45 @@ func TestGetAssetDescription(t *testing.T) {
 	require.Equal("SYMB", reply.Symbol)
 }
 
+func TestGetAssetSupply(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: latest,
+	})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	avaxAssetID := env.genesisTx.ID()
+
+	reply := GetAssetSupplyReply{}
+	require.NoError(env.service.GetAssetSupply(nil, &GetAssetSupplyArgs{
+		AssetID: avaxAssetID.String(),
+	}, &reply))
+	require.Equal(avaxAssetID, reply.AssetID)
+	require.Equal(avajson.Uint64(3*startBalance), reply.Supply)
+
+	// Burn the funds of one of the genesis holders.
+	tx := newTx(t, env.genesisBytes, env.vm.ctx.ChainID, env.vm.parser, "AVAX")
+	issueAndAccept(require, env.vm, env.issuer, tx)
+
+	reply = GetAssetSupplyReply{}
+	require.NoError(env.service.GetAssetSupply(nil, &GetAssetSupplyArgs{
+		AssetID: "asset1",
+	}, &reply))
+	require.Equal(avajson.Uint64(2*startBalance), reply.Supply)
+
+	err := env.service.GetAssetSupply(nil, &GetAssetSupplyArgs{
+		AssetID: tx.ID().String(),
+	}, &GetAssetSupplyReply{})
+	require.ErrorIs(err, errTxNotCreateAsset)
+}
+
 func TestGetBalance(t *testing.T) {
 	require := require.New(t)
 
@@ -2647,6 +2736,128 @@ func TestCreateAndListAddresses(t *testing.T) {
 	require.Contains(listReply.Addresses, newAddr)
 }
 
+func TestServiceValidateAddress(t *testing.T) {
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(t, env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	rawAddr := keys[0].PublicKey().Address()
+	hrp := constants.GetHRP(env.vm.ctx.NetworkID)
+	xAddr, err := env.vm.FormatLocalAddress(rawAddr)
+	require.NoError(t, err)
+	pAddr, err := env.vm.FormatAddress(constants.PlatformChainID, rawAddr)
+	require.NoError(t, err)
+	unknownChainAddr, err := address.Format("R", hrp, rawAddr.Bytes())
+	require.NoError(t, err)
+	wrongHRPAddr, err := address.Format("X", "wrong", rawAddr.Bytes())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		address       string
+		expectedReply ValidateAddressReply
+	}{
+		{
+			name:    "local address",
+			address: xAddr,
+			expectedReply: ValidateAddressReply{
+				Valid:   true,
+				ChainID: env.vm.ctx.ChainID,
+				ShortID: rawAddr,
+			},
+		},
+		{
+			name:    "platform address",
+			address: pAddr,
+			expectedReply: ValidateAddressReply{
+				Valid:   true,
+				ChainID: constants.PlatformChainID,
+				ShortID: rawAddr,
+			},
+		},
+		{
+			name:    "unknown chain",
+			address: unknownChainAddr,
+		},
+		{
+			name:    "wrong hrp",
+			address: wrongHRPAddr,
+		},
+		{
+			name:    "bad checksum",
+			address: xAddr[:len(xAddr)-1] + "q",
+		},
+		{
+			name:    "no chain prefix",
+			address: strings.TrimPrefix(xAddr, "X-"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			reply := ValidateAddressReply{}
+			require.NoError(env.service.ValidateAddress(nil, &ValidateAddressArgs{
+				Address: test.address,
+			}, &reply))
+			if test.expectedReply.Valid {
+				require.Equal(test.expectedReply, reply)
+				return
+			}
+			require.False(reply.Valid)
+			require.NotEmpty(reply.Reason)
+		})
+	}
+}
+
+func TestServiceConvertAddress(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	rawAddr := keys[0].PublicKey().Address()
+	xAddr, err := env.vm.FormatLocalAddress(rawAddr)
+	require.NoError(err)
+	pAddr, err := env.vm.FormatAddress(constants.PlatformChainID, rawAddr)
+	require.NoError(err)
+
+	reply := api.JSONAddress{}
+	require.NoError(env.service.ConvertAddress(nil, &ConvertAddressArgs{
+		Address:     xAddr,
+		TargetChain: "P",
+	}, &reply))
+	require.Equal(pAddr, reply.Address)
+
+	require.NoError(env.service.ConvertAddress(nil, &ConvertAddressArgs{
+		Address:     pAddr,
+		TargetChain: env.vm.ctx.ChainID.String(),
+	}, &reply))
+	require.Equal(xAddr, reply.Address)
+
+	// The conversion can also be done without the service.
+	localPAddr, err := address.ChangeChainAlias(xAddr, "P")
+	require.NoError(err)
+	require.Equal(pAddr, localPAddr)
+
+	err = env.service.ConvertAddress(nil, &ConvertAddressArgs{
+		Address:     xAddr,
+		TargetChain: "R",
+	}, &reply)
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+}
+
 func TestImport(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -10,6 +10,7 @@
 package state
 
 import (
+	big "math/big"
 	reflect "reflect"
 	time "time"
 
@@ -345,6 +346,21 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// GetAssetSupply mocks base method.
+func (m *MockState) GetAssetSupply(arg0 ids.ID) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetSupply", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetSupply indicates an expected call of GetAssetSupply.
+func (mr *MockStateMockRecorder) GetAssetSupply(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetSupply", reflect.TypeOf((*MockState)(nil).GetAssetSupply), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockState) GetBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -4,7 +4,9 @@
 package state
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,13 +34,17 @@ var (
 	txPrefix        = []byte("tx")
 	blockIDPrefix   = []byte("blockID")
 	blockPrefix     = []byte("block")
+	supplyPrefix    = []byte("supply")
 	singletonPrefix = []byte("singleton")
 
-	isInitializedKey = []byte{0x00}
-	timestampKey     = []byte{0x01}
-	lastAcceptedKey  = []byte{0x02}
+	isInitializedKey       = []byte{0x00}
+	timestampKey           = []byte{0x01}
+	lastAcceptedKey        = []byte{0x02}
+	suppliesInitializedKey = []byte{0x03}
 
 	_ State = (*state)(nil)
+
+	errNegativeSupply = errors.New("negative supply")
 )
 
 type ReadOnlyChain interface {
@@ -92,6 +98,11 @@ type State interface {
 	// Checksums returns the current TxChecksum and UTXOChecksum.
 	Checksums() (txChecksum ids.ID, utxoChecksum ids.ID)
 
+	// GetAssetSupply returns the sum of the amounts of the unspent UTXOs of
+	// [assetID] as of the last commit. Minting and importing an asset
+	// increases its supply, while burning and exporting it decreases it.
+	GetAssetSupply(assetID ids.ID) (*big.Int, error)
+
 	Close() error
 }
 
@@ -105,10 +116,13 @@ type State interface {
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. supplies
+ * | '-- assetID -> supply
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- suppliesInitializedKey -> nil
  */
 type state struct {
 	parser block.Parser
@@ -130,6 +144,9 @@ type state struct {
 	blockCache  cache.Cacher[ids.ID, block.Block] // cache of blockID -> Block. If the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	supplyChanges map[ids.ID]*big.Int // map of assetID -> change in supply since the last commit
+	supplyDB      database.Database
+
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	timestamp, persistedTimestamp       time.Time
@@ -149,6 +166,7 @@ func New(
 	txDB := prefixdb.New(txPrefix, db)
 	blockIDDB := prefixdb.New(blockIDPrefix, db)
 	blockDB := prefixdb.New(blockPrefix, db)
+	supplyDB := prefixdb.New(supplyPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 
 	txCache, err := metercacher.New[ids.ID, *txs.Tx](
@@ -203,11 +221,17 @@ func New(
 		blockCache:  blockCache,
 		blockDB:     blockDB,
 
+		supplyChanges: make(map[ids.ID]*big.Int),
+		supplyDB:      supplyDB,
+
 		singletonDB: singletonDB,
 
 		trackChecksum: trackChecksums,
 	}
-	return s, s.initTxChecksum()
+	return s, utils.Err(
+		s.initTxChecksum(),
+		s.initSupplies(),
+	)
 }
 
 func (s *state) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
@@ -413,6 +437,7 @@ func (s *state) Close() error {
 		s.txDB.Close(),
 		s.blockIDDB.Close(),
 		s.blockDB.Close(),
+		s.supplyDB.Close(),
 		s.singletonDB.Close(),
 		s.db.Close(),
 	)
@@ -424,6 +449,7 @@ func (s *state) write() error {
 		s.writeTxs(),
 		s.writeBlockIDs(),
 		s.writeBlocks(),
+		s.writeSupplies(),
 		s.writeMetadata(),
 	)
 }
@@ -433,10 +459,18 @@ func (s *state) writeUTXOs() error {
 		delete(s.modifiedUTXOs, utxoID)
 
 		if utxo != nil {
+			addToSupply(s.supplyChanges, utxo, false)
 			if err := s.utxoState.PutUTXO(utxo); err != nil {
 				return fmt.Errorf("failed to add utxo: %w", err)
 			}
 		} else {
+			removedUTXO, err := s.utxoState.GetUTXO(utxoID)
+			switch {
+			case err == nil:
+				addToSupply(s.supplyChanges, removedUTXO, true)
+			case err != database.ErrNotFound:
+				return fmt.Errorf("failed to get removed utxo: %w", err)
+			}
 			if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
 				return fmt.Errorf("failed to remove utxo: %w", err)
 			}
@@ -486,6 +520,21 @@ func (s *state) writeBlocks() error {
 	return nil
 }
 
+func (s *state) writeSupplies() error {
+	for assetID, change := range s.supplyChanges {
+		delete(s.supplyChanges, assetID)
+
+		supply, err := s.GetAssetSupply(assetID)
+		if err != nil {
+			return err
+		}
+		if err := s.putSupply(assetID, supply.Add(supply, change)); err != nil {
+			return fmt.Errorf("failed to write supply: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, timestampKey, s.timestamp); err != nil {
@@ -502,6 +551,78 @@ func (s *state) writeMetadata() error {
 	return nil
 }
 
+func (s *state) GetAssetSupply(assetID ids.ID) (*big.Int, error) {
+	supplyBytes, err := s.supplyDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		return new(big.Int), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(supplyBytes), nil
+}
+
+func (s *state) putSupply(assetID ids.ID, supply *big.Int) error {
+	switch supply.Sign() {
+	case -1:
+		return fmt.Errorf("%w of %s: %s", errNegativeSupply, assetID, supply)
+	case 0:
+		return s.supplyDB.Delete(assetID[:])
+	default:
+		return s.supplyDB.Put(assetID[:], supply.Bytes())
+	}
+}
+
+// initSupplies calculates the supply of every asset from the UTXO set if the
+// supplies have never been tracked. Afterwards, the supplies are updated as
+// UTXOs are written.
+func (s *state) initSupplies() error {
+	initialized, err := s.singletonDB.Has(suppliesInitializedKey)
+	if err != nil || initialized {
+		return err
+	}
+
+	supplies := make(map[ids.ID]*big.Int)
+	err = s.utxoState.VisitUTXOs(func(utxo *avax.UTXO) error {
+		addToSupply(supplies, utxo, false)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to calculate supplies: %w", err)
+	}
+
+	for assetID, supply := range supplies {
+		if err := s.putSupply(assetID, supply); err != nil {
+			return fmt.Errorf("failed to write supply: %w", err)
+		}
+	}
+	return s.singletonDB.Put(suppliesInitializedKey, nil)
+}
+
+// addToSupply adds the amount of [utxo] to the supply of its asset in
+// [supplies], or subtracts it if [remove] is true. UTXOs without an amount,
+// such as NFTs, don't affect the supply.
+func addToSupply(supplies map[ids.ID]*big.Int, utxo *avax.UTXO, remove bool) {
+	out, ok := utxo.Out.(avax.Amounter)
+	if !ok {
+		return
+	}
+
+	assetID := utxo.AssetID()
+	supply, ok := supplies[assetID]
+	if !ok {
+		supply = new(big.Int)
+		supplies[assetID] = supply
+	}
+
+	amount := new(big.Int).SetUint64(out.Amount())
+	if remove {
+		supply.Sub(supply, amount)
+	} else {
+		supply.Add(supply, amount)
+	}
+}
+
 func (s *state) Checksums() (ids.ID, ids.ID) {
 	return s.txChecksum, s.utxoState.Checksum()
 }
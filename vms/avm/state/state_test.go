@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/version"
@@ -278,6 +279,70 @@ func ChainBlockTest(t *testing.T, c Chain) {
 	require.Equal(blk, fetchedBlk)
 }
 
+func TestAssetSupply(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+
+	var (
+		assetID = ids.GenerateTestID()
+		newUTXO = func(amount uint64) *avax.UTXO {
+			return &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID: ids.GenerateTestID(),
+				},
+				Asset: avax.Asset{
+					ID: assetID,
+				},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+				},
+			}
+		}
+		utxo0 = newUTXO(5)
+		utxo1 = newUTXO(7)
+		utxo2 = newUTXO(11)
+	)
+
+	supply, err := s.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Zero(supply.Sign())
+
+	s.AddUTXO(utxo0)
+	s.AddUTXO(utxo1)
+	require.NoError(s.Commit())
+
+	supply, err = s.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Equal(uint64(12), supply.Uint64())
+
+	// A UTXO that is added and removed before being committed doesn't change
+	// the supply.
+	s.DeleteUTXO(utxo0.InputID())
+	s.AddUTXO(utxo2)
+	s.DeleteUTXO(utxo2.InputID())
+	require.NoError(s.Commit())
+
+	supply, err = s.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Equal(uint64(7), supply.Uint64())
+
+	// Supplies are recalculated from the UTXO set if they were never tracked.
+	require.NoError(prefixdb.New(singletonPrefix, vdb).Delete(suppliesInitializedKey))
+	require.NoError(prefixdb.New(supplyPrefix, vdb).Delete(assetID[:]))
+	require.NoError(vdb.Commit())
+
+	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+
+	supply, err = s.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Equal(uint64(7), supply.Uint64())
+}
+
 func TestInitializeChainState(t *testing.T) {
 	require := require.New(t)
 
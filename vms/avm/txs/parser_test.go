@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func FuzzParseTx(f *testing.F) {
+	parser, err := NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(f, err)
+
+	cm := parser.Codec()
+	tx := &Tx{Unsigned: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: chainID,
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 12345,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}},
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.ID{'t', 'x', 'I', 'D'},
+				OutputIndex: 1,
+			},
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 54321,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+	}}}
+	require.NoError(f, tx.SignSECP256K1Fx(cm, [][]*secp256k1.PrivateKey{{keys[0]}}))
+	f.Add(tx.Bytes())
+
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		require := require.New(t)
+
+		parsed, err := parser.ParseTx(bytes)
+		if err != nil {
+			return
+		}
+		require.Equal(bytes, parsed.Bytes())
+
+		marshalled, err := cm.Marshal(CodecVersion, parsed)
+		require.NoError(err)
+		require.Equal(bytes, marshalled)
+
+		unsignedBytes, err := cm.Marshal(CodecVersion, &parsed.Unsigned)
+		require.NoError(err)
+		require.Equal(unsignedBytes, parsed.Unsigned.Bytes())
+	})
+}
@@ -86,6 +86,8 @@ type VM struct {
 
 	pubsub *pubsub.Server
 
+	blockStream *blockStream
+
 	appSender common.AppSender
 
 	// State management
@@ -110,6 +112,8 @@ type VM struct {
 
 	addressTxsIndexer index.AddressTxsIndexer
 
+	chainStats *chainStats
+
 	txBackend *txexecutor.Backend
 
 	// Cancelled on shutdown
@@ -197,6 +201,7 @@ func (vm *VM) Initialize(
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
 
 	vm.pubsub = pubsub.New(ctx.Log)
+	vm.blockStream = newBlockStream(ctx.Log, avmConfig.BlockStreamWindow, vm.getBlockBytesAtHeight)
 
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
@@ -216,7 +221,7 @@ func (vm *VM) Initialize(
 	}
 
 	vm.typeToFxIndex = map[reflect.Type]int{}
-	vm.parser, err = block.NewCustomParser(
+	parser, err := block.NewCustomParser(
 		vm.typeToFxIndex,
 		&vm.clock,
 		ctx.Log,
@@ -225,13 +230,19 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return err
 	}
+	vm.parser = &meteredParser{
+		Parser:  parser,
+		metrics: vm.metrics,
+	}
 
 	codec := vm.parser.Codec()
 	vm.Spender = utxo.NewSpender(&vm.clock, codec)
 
+	// Txs and blocks read from disk are trusted, so their parsing isn't
+	// metered.
 	state, err := state.New(
 		vm.db,
-		vm.parser,
+		parser,
 		vm.registerer,
 		avmConfig.ChecksumsEnabled,
 	)
@@ -245,6 +256,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.chainStats, err = newChainStats(vm.db, &vm.clock)
+	if err != nil {
+		return fmt.Errorf("failed to initialize chain stats: %w", err)
+	}
+
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxs = linked.NewHashmap[ids.ID, *txs.Tx]()
 
@@ -355,6 +371,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		"":        rpcServer,
 		"/wallet": walletServer,
 		"/events": vm.pubsub,
+		"/blocks": vm.blockStream,
 	}, err
 }
 
@@ -402,6 +419,12 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		return err
 	}
 
+	lastAccepted, err := vm.state.GetBlock(vm.state.GetLastAccepted())
+	if err != nil {
+		return err
+	}
+	vm.blockStream.setLastAccepted(lastAccepted.Height())
+
 	mempool, err := xmempool.New("mempool", vm.registerer, toEngine)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
@@ -414,6 +437,8 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.txBackend,
 		&vm.clock,
 		vm.onAccept,
+		vm.onBlockAccepting,
+		vm.onBlockAccept,
 	)
 
 	vm.Builder = blockbuilder.New(
@@ -644,6 +669,39 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
 }
 
+// Invariant: onBlockAccepting is called when a block is being accepted, before
+// its state changes are committed.
+func (vm *VM) onBlockAccepting(block.Block) error {
+	// The chain stats are written once per block, rather than once per tx, and
+	// are committed atomically with the block.
+	if err := vm.chainStats.write(); err != nil {
+		return fmt.Errorf("error writing chain stats: %w", err)
+	}
+	return nil
+}
+
+// Invariant: onBlockAccept is called when [blk] is accepted, after its state
+// changes are committed.
+func (vm *VM) onBlockAccept(blk block.Block) {
+	vm.blockStream.accept(blk.Height(), blk.Bytes())
+}
+
+// getBlockBytesAtHeight returns the bytes of the accepted block at [height].
+func (vm *VM) getBlockBytesAtHeight(height uint64) ([]byte, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	blkID, err := vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := vm.state.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	return blk.Bytes(), nil
+}
+
 // Invariant: onAccept is called when [tx] is being marked as accepted, but
 // before its state changes are applied.
 // Invariant: any error returned by onAccept should be considered fatal.
@@ -682,6 +740,12 @@ func (vm *VM) onAccept(tx *txs.Tx) error {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
 
+	fee := vm.TxFee
+	if _, ok := tx.Unsigned.(*txs.CreateAssetTx); ok {
+		fee = vm.CreateAssetTxFee
+	}
+	vm.chainStats.accept(inputUTXOs, outputUTXOs, fee)
+
 	vm.pubsub.Publish(NewPubSubFilterer(tx))
 	vm.walletService.decided(txID)
 	return nil
@@ -31,6 +31,10 @@ type UTXOState interface {
 	UTXOReader
 	UTXOWriter
 
+	// VisitUTXOs calls [f] with each UTXO in the database, in order of UTXO
+	// ID. Iteration stops at, and returns, the first error returned by [f].
+	VisitUTXOs(f func(utxo *UTXO) error) error
+
 	// Checksum returns the current UTXOChecksum.
 	Checksum() ids.ID
 }
@@ -251,6 +255,22 @@ func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, err
 	return utxoIDs, iter.Error()
 }
 
+func (s *utxoState) VisitUTXOs(f func(utxo *UTXO) error) error {
+	it := s.utxoDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		utxo := &UTXO{}
+		if _, err := s.codec.Unmarshal(it.Value(), utxo); err != nil {
+			return err
+		}
+		if err := f(utxo); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func (s *utxoState) Checksum() ids.ID {
 	return s.checksum
 }
@@ -91,4 +91,13 @@ func TestUTXOState(t *testing.T) {
 	utxoIDs, err = s.UTXOIDs(addr[:], ids.Empty, 5)
 	require.NoError(err)
 	require.Equal([]ids.ID{utxoID}, utxoIDs)
+
+	var visitedUTXOs []*UTXO
+	require.NoError(s.VisitUTXOs(func(utxo *UTXO) error {
+		visitedUTXOs = append(visitedUTXOs, utxo)
+		return nil
+	}))
+	require.Len(visitedUTXOs, 1)
+	require.Equal(utxoID, visitedUTXOs[0].InputID())
+	require.Equal(utxo, visitedUTXOs[0])
 }
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// prefix domain separates attestations from every other message signed
+	// with a validator's BLS key. The only other such messages are warp
+	// messages, which always start with their zero codec version, so no warp
+	// signature can be used as an attestation signature.
+	prefix    = "\xffavalanche attestation\x00"
+	prefixLen = len(prefix)
+
+	// Len is the length of a serialized unsigned attestation.
+	Len = prefixLen + wrappers.IntLen + ids.IDLen + wrappers.LongLen + ids.IDLen
+)
+
+var (
+	ErrInvalidLen    = errors.New("invalid attestation length")
+	ErrInvalidPrefix = errors.New("invalid attestation prefix")
+)
+
+// Unsigned is a claim by a validator of [ChainID] that [Hash] is the only
+// hash accepted at [Height].
+type Unsigned struct {
+	NetworkID uint32
+	ChainID   ids.ID
+	Height    uint64
+	Hash      ids.ID
+}
+
+// Parse returns the attestation serialized in [b].
+func Parse(b []byte) (*Unsigned, error) {
+	if len(b) != Len {
+		return nil, fmt.Errorf("%w: %d != %d", ErrInvalidLen, len(b), Len)
+	}
+	if !bytes.HasPrefix(b, []byte(prefix)) {
+		return nil, ErrInvalidPrefix
+	}
+
+	p := wrappers.Packer{
+		Bytes:  b,
+		Offset: prefixLen,
+	}
+	a := &Unsigned{
+		NetworkID: p.UnpackInt(),
+		ChainID:   ids.ID(p.UnpackFixedBytes(ids.IDLen)),
+		Height:    p.UnpackLong(),
+		Hash:      ids.ID(p.UnpackFixedBytes(ids.IDLen)),
+	}
+	return a, p.Err
+}
+
+// Bytes returns the bytes that are signed to attest to [a].
+func (a *Unsigned) Bytes() []byte {
+	p := wrappers.Packer{
+		MaxSize: Len,
+		Bytes:   make([]byte, 0, Len),
+	}
+	p.PackFixedBytes([]byte(prefix))
+	p.PackInt(a.NetworkID)
+	p.PackFixedBytes(a.ChainID[:])
+	p.PackLong(a.Height)
+	p.PackFixedBytes(a.Hash[:])
+	return p.Bytes
+}
+
+// Conflicts returns true iff [a] and [other] attest to different hashes at the
+// same height of the same chain.
+func (a *Unsigned) Conflicts(other *Unsigned) bool {
+	return a.NetworkID == other.NetworkID &&
+		a.ChainID == other.ChainID &&
+		a.Height == other.Height &&
+		a.Hash != other.Hash
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	msg := &Unsigned{
+		NetworkID: constants.UnitTestID,
+		ChainID:   ids.GenerateTestID(),
+		Height:    10,
+		Hash:      ids.GenerateTestID(),
+	}
+	msgBytes := msg.Bytes()
+	require.Len(msgBytes, Len)
+
+	parsedMsg, err := Parse(msgBytes)
+	require.NoError(err)
+	require.Equal(msg, parsedMsg)
+
+	_, err = Parse(msgBytes[1:])
+	require.ErrorIs(err, ErrInvalidLen)
+}
+
+// Warp messages signed by honest validators must never be parsable as
+// attestations.
+func TestParseWarpMessage(t *testing.T) {
+	require := require.New(t)
+
+	call, err := payload.NewAddressedCall(nil, make([]byte, Len))
+	require.NoError(err)
+
+	// Pad the warp message to the length of an attestation so that only the
+	// prefix can reject it.
+	chainID := ids.GenerateTestID()
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, chainID, call.Bytes())
+	require.NoError(err)
+	msgBytes := msg.Bytes()
+	require.Greater(len(msgBytes), Len)
+
+	_, err = Parse(msgBytes[:Len])
+	require.ErrorIs(err, ErrInvalidPrefix)
+}
+
+func TestConflicts(t *testing.T) {
+	msg := Unsigned{
+		NetworkID: constants.UnitTestID,
+		ChainID:   ids.GenerateTestID(),
+		Height:    10,
+		Hash:      ids.GenerateTestID(),
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*Unsigned)
+		expected bool
+	}{
+		{
+			name:     "same attestation",
+			modify:   func(*Unsigned) {},
+			expected: false,
+		},
+		{
+			name: "different hash",
+			modify: func(u *Unsigned) {
+				u.Hash = ids.GenerateTestID()
+			},
+			expected: true,
+		},
+		{
+			name: "different height",
+			modify: func(u *Unsigned) {
+				u.Height++
+				u.Hash = ids.GenerateTestID()
+			},
+			expected: false,
+		},
+		{
+			name: "different chain",
+			modify: func(u *Unsigned) {
+				u.ChainID = ids.GenerateTestID()
+				u.Hash = ids.GenerateTestID()
+			},
+			expected: false,
+		},
+		{
+			name: "different network",
+			modify: func(u *Unsigned) {
+				u.NetworkID++
+				u.Hash = ids.GenerateTestID()
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := msg
+			test.modify(&other)
+			require.Equal(t, test.expected, msg.Conflicts(&other))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+var (
+	_ Signer = (*signer)(nil)
+
+	ErrWrongChainID           = errors.New("wrong chainID")
+	ErrWrongNetworkID         = errors.New("wrong networkID")
+	ErrConflictingAttestation = errors.New("conflicting attestation")
+)
+
+type Signer interface {
+	// Sign returns this node's BLS signature over [msg].
+	//
+	// An error is returned if the caller does not have the authority to sign
+	// [msg] or if a different hash was already attested to at the same height.
+	Sign(msg *Unsigned) ([]byte, error)
+}
+
+// NewSigner returns a signer that records every attestation in [db] before
+// signing it. [db] must persist across restarts, otherwise a restarted node
+// could attest to conflicting hashes.
+//
+// The node doesn't construct a Signer for its chains. A chain that wants its
+// validators to attest to its blocks must construct one with the node's BLS
+// key; until then, no ConflictingAttestations evidence can be produced.
+func NewSigner(sk *bls.SecretKey, networkID uint32, chainID ids.ID, db database.Database) Signer {
+	return &signer{
+		sk:        sk,
+		networkID: networkID,
+		chainID:   chainID,
+		db:        db,
+	}
+}
+
+type signer struct {
+	sk        *bls.SecretKey
+	networkID uint32
+	chainID   ids.ID
+
+	// lock ensures that concurrent attestations at the same height can't both
+	// be signed.
+	lock sync.Mutex
+	// height -> attested hash
+	db database.Database
+}
+
+func (s *signer) Sign(msg *Unsigned) ([]byte, error) {
+	if msg.ChainID != s.chainID {
+		return nil, ErrWrongChainID
+	}
+	if msg.NetworkID != s.networkID {
+		return nil, ErrWrongNetworkID
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	heightKey := database.PackUInt64(msg.Height)
+	attestedHash, err := database.GetID(s.db, heightKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		if err := database.PutID(s.db, heightKey, msg.Hash); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case attestedHash != msg.Hash:
+		return nil, fmt.Errorf("%w: attested to %s at height %d",
+			ErrConflictingAttestation,
+			attestedHash,
+			msg.Height,
+		)
+	}
+
+	sig := bls.Sign(s.sk, msg.Bytes())
+	return bls.SignatureToBytes(sig), nil
+}
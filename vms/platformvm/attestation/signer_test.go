@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		pk      = bls.PublicFromSecretKey(sk)
+		chainID = ids.GenerateTestID()
+		db      = memdb.New()
+		s       = NewSigner(sk, constants.UnitTestID, chainID, db)
+		msg     = &Unsigned{
+			NetworkID: constants.UnitTestID,
+			ChainID:   chainID,
+			Height:    10,
+			Hash:      ids.GenerateTestID(),
+		}
+	)
+
+	sigBytes, err := s.Sign(msg)
+	require.NoError(err)
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+	require.True(bls.Verify(pk, sig, msg.Bytes()))
+
+	// Attesting to the same hash again is allowed.
+	_, err = s.Sign(msg)
+	require.NoError(err)
+
+	conflicting := *msg
+	conflicting.Hash = ids.GenerateTestID()
+	_, err = s.Sign(&conflicting)
+	require.ErrorIs(err, ErrConflictingAttestation)
+
+	// The attestation must be remembered across restarts.
+	s = NewSigner(sk, constants.UnitTestID, chainID, db)
+	_, err = s.Sign(&conflicting)
+	require.ErrorIs(err, ErrConflictingAttestation)
+
+	next := conflicting
+	next.Height++
+	_, err = s.Sign(&next)
+	require.NoError(err)
+}
+
+func TestSignerWrongChain(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		chainID = ids.GenerateTestID()
+		s       = NewSigner(sk, constants.UnitTestID, chainID, memdb.New())
+	)
+
+	_, err = s.Sign(&Unsigned{
+		NetworkID: constants.UnitTestID,
+		ChainID:   ids.GenerateTestID(),
+	})
+	require.ErrorIs(err, ErrWrongChainID)
+
+	_, err = s.Sign(&Unsigned{
+		NetworkID: constants.UnitTestID + 1,
+		ChainID:   chainID,
+	})
+	require.ErrorIs(err, ErrWrongNetworkID)
+}
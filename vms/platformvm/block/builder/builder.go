@@ -28,9 +28,9 @@ import (
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 )
 
-// targetBlockSize is maximum number of transaction bytes to place into a
+// TargetBlockSize is maximum number of transaction bytes to place into a
 // StandardBlock
-const targetBlockSize = 128 * units.KiB
+const TargetBlockSize = 128 * units.KiB
 
 var (
 	_ Builder = (*builder)(nil)
@@ -63,7 +63,8 @@ type Builder interface {
 
 	// PackBlockTxs returns an array of txs that can fit into a valid block of
 	// size [targetBlockSize]. The returned txs are all verified against the
-	// preferred state.
+	// preferred state. Txs that fail verification are dropped from the
+	// mempool. The returned txs are left in the mempool.
 	//
 	// Note: This function does not call the consensus engine.
 	PackBlockTxs(targetBlockSize int) ([]*txs.Tx, error)
@@ -270,11 +271,14 @@ func buildBlock(
 		builder.txExecutorBackend,
 		builder.blkManager,
 		timestamp,
-		targetBlockSize,
+		TargetBlockSize,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack block txs: %w", err)
 	}
+	// The txs are removed from the mempool while the block is processing. If
+	// the block is rejected, they are re-added.
+	builder.Mempool.Remove(blockTxs...)
 
 	// Try rewarding stakers whose staking period ends at the new chain time.
 	// This is done first to prioritize advancing the timestamp as quickly as
@@ -331,22 +335,8 @@ func packBlockTxs(
 		return nil, err
 	}
 
-	var (
-		blockTxs []*txs.Tx
-		inputs   set.Set[ids.ID]
-	)
-
-	for {
-		tx, exists := mempool.Peek()
-		if !exists {
-			break
-		}
-		txSize := len(tx.Bytes())
-		if txSize > remainingSize {
-			break
-		}
-		mempool.Remove(tx)
-
+	var inputs set.Set[ids.ID]
+	return mempool.ValidSnapshot(remainingSize, func(tx *txs.Tx) (error, error) {
 		// Invariant: [tx] has already been syntactically verified.
 
 		txDiff, err := state.NewDiffOn(stateDiff)
@@ -359,38 +349,21 @@ func packBlockTxs(
 			State:   txDiff,
 			Tx:      tx,
 		}
-
-		err = tx.Unsigned.Visit(executor)
-		if err != nil {
-			txID := tx.ID()
-			mempool.MarkDropped(txID, err)
-			continue
+		if err := tx.Unsigned.Visit(executor); err != nil {
+			return err, nil
 		}
 
 		if inputs.Overlaps(executor.Inputs) {
-			txID := tx.ID()
-			mempool.MarkDropped(txID, blockexecutor.ErrConflictingBlockTxs)
-			continue
+			return blockexecutor.ErrConflictingBlockTxs, nil
 		}
-		err = manager.VerifyUniqueInputs(parentID, executor.Inputs)
-		if err != nil {
-			txID := tx.ID()
-			mempool.MarkDropped(txID, err)
-			continue
+		if err := manager.VerifyUniqueInputs(parentID, executor.Inputs); err != nil {
+			return err, nil
 		}
 		inputs.Union(executor.Inputs)
 
 		txDiff.AddTx(tx, status.Committed)
-		err = txDiff.Apply(stateDiff)
-		if err != nil {
-			return nil, err
-		}
-
-		remainingSize -= txSize
-		blockTxs = append(blockTxs, tx)
-	}
-
-	return blockTxs, nil
+		return nil, txDiff.Apply(stateDiff)
+	})
 }
 
 // getNextStakerToReward returns the next staker txID to remove from the staking
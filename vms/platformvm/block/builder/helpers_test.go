@@ -72,6 +72,7 @@ const (
 var (
 	defaultMinStakingDuration = 24 * time.Hour
 	defaultMaxStakingDuration = 365 * 24 * time.Hour
+	defaultMaxFutureStartTime = 2 * 7 * 24 * time.Hour
 	defaultGenesisTime        = time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC)
 	defaultValidateStartTime  = defaultGenesisTime
 	defaultValidateEndTime    = defaultValidateStartTime.Add(10 * defaultMinStakingDuration)
@@ -179,7 +180,14 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 	metrics, err := metrics.New("", registerer)
 	require.NoError(err)
 
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New(
+		"mempool",
+		registerer,
+		nil,
+		res.ctx.AVAXAssetID,
+		config.DefaultExecutionConfig.MempoolMinFeeBumpPercent,
+		config.DefaultExecutionConfig.MempoolMaxSubnetStakerTxs,
+	)
 	require.NoError(err)
 
 	res.blkManager = blockexecutor.NewManager(
@@ -315,7 +323,9 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 		MaxValidatorStake:      500 * units.MilliAvax,
 		MinDelegatorStake:      1 * units.MilliAvax,
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig: reward.Config{
 			MaxConsumptionRate: .12 * reward.PercentDenominator,
 			MinConsumptionRate: .10 * reward.PercentDenominator,
@@ -9,10 +9,14 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
 
@@ -33,11 +37,11 @@ type acceptor struct {
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
-	return a.optionBlock(b, "banff abort")
+	return a.optionBlock(b, true /*=aborted*/, "banff abort")
 }
 
 func (a *acceptor) BanffCommitBlock(b *block.BanffCommitBlock) error {
-	return a.optionBlock(b, "banff commit")
+	return a.optionBlock(b, false /*=aborted*/, "banff commit")
 }
 
 func (a *acceptor) BanffProposalBlock(b *block.BanffProposalBlock) error {
@@ -50,11 +54,11 @@ func (a *acceptor) BanffStandardBlock(b *block.BanffStandardBlock) error {
 }
 
 func (a *acceptor) ApricotAbortBlock(b *block.ApricotAbortBlock) error {
-	return a.optionBlock(b, "apricot abort")
+	return a.optionBlock(b, true /*=aborted*/, "apricot abort")
 }
 
 func (a *acceptor) ApricotCommitBlock(b *block.ApricotCommitBlock) error {
-	return a.optionBlock(b, "apricot commit")
+	return a.optionBlock(b, false /*=aborted*/, "apricot commit")
 }
 
 func (a *acceptor) ApricotProposalBlock(b *block.ApricotProposalBlock) error {
@@ -84,6 +88,10 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		return err
 	}
 
+	if err := a.addSummary(b.Height(), false /*=aborted*/, b.Txs()); err != nil {
+		return err
+	}
+
 	defer a.state.Abort()
 	batch, err := a.state.CommitBatch()
 	if err != nil {
@@ -94,8 +102,7 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	if err := a.writeBatch(batch, blkState.atomicRequests); err != nil {
 		return fmt.Errorf(
 			"failed to atomically accept tx %s in block %s: %w",
 			b.Tx.ID(),
@@ -104,6 +111,10 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
+	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
+		onAcceptFunc()
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", "apricot atomic"),
@@ -116,7 +127,7 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	return nil
 }
 
-func (a *acceptor) optionBlock(b block.Block, blockType string) error {
+func (a *acceptor) optionBlock(b block.Block, aborted bool, blockType string) error {
 	parentID := b.Parent()
 	parentState, ok := a.blkIDToState[parentID]
 	if !ok {
@@ -154,6 +165,21 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		return err
 	}
 
+	// The decision txs of the proposal block are summarized with the proposal
+	// block, while the proposal tx is summarized with this block.
+	var (
+		parentBlk   = parentState.statelessBlock
+		parentTxs   = parentBlk.Txs()
+		decisionTxs = parentTxs[:len(parentTxs)-1]
+		proposalTx  = parentTxs[len(parentTxs)-1]
+	)
+	if err := a.addSummary(parentBlk.Height(), false /*=aborted*/, decisionTxs); err != nil {
+		return err
+	}
+	if err := a.addSummary(b.Height(), aborted, []*txs.Tx{proposalTx}); err != nil {
+		return err
+	}
+
 	defer a.state.Abort()
 	batch, err := a.state.CommitBatch()
 	if err != nil {
@@ -164,14 +190,16 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(parentState.atomicRequests, batch); err != nil {
+	if err := a.writeBatch(batch, parentState.atomicRequests); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
 	if onAcceptFunc := parentState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
 	}
+	if onCommitFunc := parentState.onCommitFunc; !aborted && onCommitFunc != nil {
+		onCommitFunc()
+	}
 
 	a.ctx.Log.Trace(
 		"accepted block",
@@ -233,6 +261,10 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		return err
 	}
 
+	if err := a.addSummary(b.Height(), false /*=aborted*/, b.Txs()); err != nil {
+		return err
+	}
+
 	defer a.state.Abort()
 	batch, err := a.state.CommitBatch()
 	if err != nil {
@@ -243,8 +275,7 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	if err := a.writeBatch(batch, blkState.atomicRequests); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
@@ -264,6 +295,38 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// writeBatch writes [batch], which commits the VM's state, along with the
+// shared memory [requests].
+func (a *acceptor) writeBatch(batch database.Batch, requests map[ids.ID]*atomic.Requests) error {
+	if len(requests) == 0 {
+		// The batch doesn't need to be written atomically with the shared
+		// memory, so it may be written asynchronously.
+		return batch.Write()
+	}
+
+	// The shared memory is written along with the inner batch, which must
+	// not be written before the previously committed changes.
+	if err := a.state.Flush(); err != nil {
+		return err
+	}
+
+	// Note that this method writes [batch] to the database.
+	return a.ctx.SharedMemory.Apply(requests, batch)
+}
+
+// addSummary records the summary of the [txs] accepted by the block at
+// [height].
+//
+// Invariant: The [txs] must have already been applied to the state.
+func (a *acceptor) addSummary(height uint64, aborted bool, txs []*txs.Tx) error {
+	summary, err := summarize(a.ctx.AVAXAssetID, a.state, aborted, txs)
+	if err != nil {
+		return fmt.Errorf("failed to summarize block at height %d: %w", height, err)
+	}
+	a.state.AddBlockSummary(height, summary)
+	return nil
+}
+
 func (a *acceptor) commonAccept(b block.Block) error {
 	blkID := b.ID()
 
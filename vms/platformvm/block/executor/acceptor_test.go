@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
@@ -124,7 +125,9 @@ func TestAcceptorVisitAtomicBlock(t *testing.T) {
 	// Set [blk]'s state in the map as though it had been verified.
 	onAcceptState := state.NewMockDiff(ctrl)
 	childID := ids.GenerateTestID()
-	atomicRequests := make(map[ids.ID]*atomic.Requests)
+	atomicRequests := map[ids.ID]*atomic.Requests{
+		ids.GenerateTestID(): {},
+	}
 	acceptor.backend.blkIDToState[blk.ID()] = &blockState{
 		onAcceptState:  onAcceptState,
 		atomicRequests: atomicRequests,
@@ -150,6 +153,8 @@ func TestAcceptorVisitAtomicBlock(t *testing.T) {
 	s.EXPECT().CommitBatch().Return(batch, nil).Times(1)
 	s.EXPECT().Abort().Times(1)
 	onAcceptState.EXPECT().Apply(s).Times(1)
+	s.EXPECT().AddBlockSummary(blk.Height(), &state.BlockSummary{StakersAdded: 1}).Times(1)
+	s.EXPECT().Flush().Return(nil).Times(1)
 	sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1)
 	s.EXPECT().Checksum().Return(ids.Empty).Times(1)
 
@@ -238,7 +243,8 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 	s.EXPECT().CommitBatch().Return(batch, nil).Times(1)
 	s.EXPECT().Abort().Times(1)
 	onAcceptState.EXPECT().Apply(s).Times(1)
-	sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1)
+	s.EXPECT().AddBlockSummary(blk.Height(), &state.BlockSummary{StakersAdded: 1}).Times(1)
+	batch.EXPECT().Write().Return(nil).Times(1)
 	s.EXPECT().Checksum().Return(ids.Empty).Times(1)
 
 	require.NoError(acceptor.BanffStandardBlock(blk))
@@ -329,6 +335,17 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 		atomicRequests: parentState.atomicRequests,
 	}
 
+	stakerTxID := ids.GenerateTestID()
+	rewardTx := &txs.Tx{
+		Unsigned: &txs.RewardValidatorTx{
+			TxID: stakerTxID,
+		},
+	}
+	rewardUTXO := &avax.UTXO{
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 5,
+		},
+	}
 	batch := database.NewMockBatch(ctrl)
 
 	// Set expected calls on dependencies.
@@ -345,8 +362,16 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 		s.EXPECT().AddStatelessBlock(blk).Times(1),
 
 		parentOnCommitState.EXPECT().Apply(s).Times(1),
+		parentStatelessBlk.EXPECT().Txs().Return([]*txs.Tx{rewardTx}).Times(1),
+		parentStatelessBlk.EXPECT().Height().Return(blk.Height()-1).Times(1),
+		s.EXPECT().AddBlockSummary(blk.Height()-1, &state.BlockSummary{}).Times(1),
+		s.EXPECT().GetRewardUTXOs(stakerTxID).Return([]*avax.UTXO{rewardUTXO}, nil).Times(1),
+		s.EXPECT().AddBlockSummary(blk.Height(), &state.BlockSummary{
+			RewardsMinted:  5,
+			StakersRemoved: 1,
+		}).Times(1),
 		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
-		sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1),
+		batch.EXPECT().Write().Return(nil).Times(1),
 		s.EXPECT().Checksum().Return(ids.Empty).Times(1),
 		s.EXPECT().Abort().Times(1),
 	)
@@ -439,6 +464,9 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		atomicRequests: parentState.atomicRequests,
 	}
 
+	addDelegatorTx := &txs.Tx{
+		Unsigned: &txs.AddDelegatorTx{},
+	}
 	batch := database.NewMockBatch(ctrl)
 
 	// Set expected calls on dependencies.
@@ -455,8 +483,13 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		s.EXPECT().AddStatelessBlock(blk).Times(1),
 
 		parentOnAbortState.EXPECT().Apply(s).Times(1),
+		parentStatelessBlk.EXPECT().Txs().Return([]*txs.Tx{addDelegatorTx}).Times(1),
+		parentStatelessBlk.EXPECT().Height().Return(blk.Height()-1).Times(1),
+		s.EXPECT().AddBlockSummary(blk.Height()-1, &state.BlockSummary{}).Times(1),
+		// The aborted delegator isn't added.
+		s.EXPECT().AddBlockSummary(blk.Height(), &state.BlockSummary{}).Times(1),
 		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
-		sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1),
+		batch.EXPECT().Write().Return(nil).Times(1),
 		s.EXPECT().Checksum().Return(ids.Empty).Times(1),
 		s.EXPECT().Abort().Times(1),
 	)
@@ -17,6 +17,8 @@ type proposalBlockState struct {
 	onDecisionState state.Diff
 	onCommitState   state.Diff
 	onAbortState    state.Diff
+	// onCommitFunc is called when the proposal tx is committed. May be nil.
+	onCommitFunc func()
 }
 
 // The state of a block.
@@ -331,10 +331,12 @@ func TestBlockOptions(t *testing.T) {
 							Subnet: subnetID,
 						},
 					}
+					currentStakerIterator = state.EmptyIterator
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
 				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(nil, database.ErrNotFound)
 
 				uptimes := uptime.NewMockCalculator(ctrl)
@@ -386,10 +388,12 @@ func TestBlockOptions(t *testing.T) {
 					staker                           = &state.Staker{
 						StartTime: primaryNetworkValidatorStartTime,
 					}
+					currentStakerIterator = state.EmptyIterator
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
 				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil)
 
 				uptimes := uptime.NewMockCalculator(ctrl)
@@ -442,10 +446,12 @@ func TestBlockOptions(t *testing.T) {
 					staker                           = &state.Staker{
 						StartTime: primaryNetworkValidatorStartTime,
 					}
+					currentStakerIterator = state.EmptyIterator
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
 				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(nil, database.ErrNotFound)
 
@@ -479,6 +485,70 @@ func TestBlockOptions(t *testing.T) {
 			},
 			expectedPreferenceType: &block.BanffCommitBlock{},
 		},
+		{
+			name: "banff proposal block; prefers commit of rotated validator",
+			blkF: func(ctrl *gomock.Controller) *Block {
+				var (
+					stakerTxID    = ids.GenerateTestID()
+					nodeID        = ids.GenerateTestNodeID()
+					rotatedNodeID = ids.GenerateTestNodeID()
+					stakerTx      = &txs.Tx{
+						Unsigned: &txs.AddPermissionlessValidatorTx{
+							Validator: txs.Validator{
+								NodeID: nodeID,
+							},
+							Subnet: constants.PrimaryNetworkID,
+						},
+					}
+					primaryNetworkValidatorStartTime = time.Now()
+					staker                           = &state.Staker{
+						TxID:      stakerTxID,
+						NodeID:    rotatedNodeID,
+						StartTime: primaryNetworkValidatorStartTime,
+					}
+					currentStakerIterator = state.NewMockStakerIterator(ctrl)
+				)
+
+				currentStakerIterator.EXPECT().Next().Return(true)
+				currentStakerIterator.EXPECT().Value().Return(staker)
+				currentStakerIterator.EXPECT().Release()
+
+				state := state.NewMockState(ctrl)
+				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
+				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, rotatedNodeID).Return(staker, nil)
+
+				uptimes := uptime.NewMockCalculator(ctrl)
+				uptimes.EXPECT().CalculateUptimePercentFrom(rotatedNodeID, constants.PrimaryNetworkID, primaryNetworkValidatorStartTime).Return(.9, nil)
+
+				manager := &manager{
+					backend: &backend{
+						state: state,
+						ctx:   snowtest.Context(t, snowtest.PChainID),
+					},
+					txExecutorBackend: &executor.Backend{
+						Config: &config.Config{
+							UptimePercentage: .8,
+						},
+						Uptimes: uptimes,
+					},
+				}
+
+				return &Block{
+					Block: &block.BanffProposalBlock{
+						ApricotProposalBlock: block.ApricotProposalBlock{
+							Tx: &txs.Tx{
+								Unsigned: &txs.RewardValidatorTx{
+									TxID: stakerTxID,
+								},
+							},
+						},
+					},
+					manager: manager,
+				}
+			},
+			expectedPreferenceType: &block.BanffCommitBlock{},
+		},
 		{
 			name: "banff proposal block; prefers commit",
 			blkF: func(ctrl *gomock.Controller) *Block {
@@ -503,10 +573,12 @@ func TestBlockOptions(t *testing.T) {
 							UptimeRequirement: .2 * reward.PercentDenominator,
 						},
 					}
+					currentStakerIterator = state.EmptyIterator
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
 				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(transformSubnetTx, nil)
 
@@ -565,10 +637,12 @@ func TestBlockOptions(t *testing.T) {
 							UptimeRequirement: .6 * reward.PercentDenominator,
 						},
 					}
+					currentStakerIterator = state.EmptyIterator
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentStakerIterator().Return(currentStakerIterator, nil)
 				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(transformSubnetTx, nil)
 
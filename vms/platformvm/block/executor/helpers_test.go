@@ -72,6 +72,7 @@ const (
 var (
 	defaultMinStakingDuration = 24 * time.Hour
 	defaultMaxStakingDuration = 365 * 24 * time.Hour
+	defaultMaxFutureStartTime = 2 * 7 * 24 * time.Hour
 	defaultGenesisTime        = time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC)
 	defaultValidateStartTime  = defaultGenesisTime
 	defaultValidateEndTime    = defaultValidateStartTime.Add(10 * defaultMinStakingDuration)
@@ -195,7 +196,14 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 	metrics := metrics.Noop
 
 	var err error
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New(
+		"mempool",
+		registerer,
+		nil,
+		res.ctx.AVAXAssetID,
+		config.DefaultExecutionConfig.MempoolMinFeeBumpPercent,
+		config.DefaultExecutionConfig.MempoolMaxSubnetStakerTxs,
+	)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
@@ -337,7 +345,9 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 		MaxValidatorStake:      500 * units.MilliAvax,
 		MinDelegatorStake:      1 * units.MilliAvax,
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig: reward.Config{
 			MaxConsumptionRate: .12 * reward.PercentDenominator,
 			MinConsumptionRate: .10 * reward.PercentDenominator,
@@ -5,7 +5,10 @@ package executor
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -21,7 +24,10 @@ import (
 var (
 	_ Manager = (*manager)(nil)
 
-	ErrChainNotSynced = errors.New("chain not synced")
+	ErrChainNotSynced           = errors.New("chain not synced")
+	ErrStateUnavailable         = errors.New("state unavailable")
+	ErrBlockNotRecent           = errors.New("block is older than the last accepted block")
+	ErrTimestampBeforeChainTime = errors.New("timestamp is before the chain time")
 )
 
 type Manager interface {
@@ -41,6 +47,24 @@ type Manager interface {
 	// preferred state. This should *not* be used to verify transactions in a block.
 	VerifyTx(tx *txs.Tx) error
 
+	// VerifyTxOnRecentBlock verifies that the transaction could have been
+	// issued on top of the state produced by [blkID], using the chain time of
+	// that state. The provided state is never modified.
+	//
+	// Only the states of the last accepted block and of processing blocks are
+	// kept. Blocks accepted before the last accepted block return
+	// ErrBlockNotRecent, and unknown blocks return ErrStateUnavailable.
+	// Verifying a tx against the state at an arbitrary height would require
+	// rebuilding that state from the historical diffs, which isn't supported.
+	VerifyTxOnRecentBlock(blkID ids.ID, tx *txs.Tx) error
+
+	// VerifyTxAtTime verifies that the transaction could be issued on top of
+	// the currently preferred state if the chain time were [timestamp]. This
+	// allows a transaction to be verified against the rules of an upgrade
+	// before the upgrade activates. The stakers of the preferred state are
+	// not advanced to [timestamp]. The provided state is never modified.
+	VerifyTxAtTime(tx *txs.Tx, timestamp time.Time) error
+
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
@@ -142,6 +166,61 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return err
 	}
 
+	return m.verifyTx(stateDiff, tx)
+}
+
+func (m *manager) VerifyTxOnRecentBlock(blkID ids.ID, tx *txs.Tx) error {
+	if !m.txExecutorBackend.Bootstrapped.Get() {
+		return ErrChainNotSynced
+	}
+
+	if _, ok := m.GetState(blkID); !ok {
+		blk, err := m.GetStatelessBlock(blkID)
+		switch {
+		case err == database.ErrNotFound:
+			return fmt.Errorf("%w for block %s", ErrStateUnavailable, blkID)
+		case err != nil:
+			return err
+		}
+		return fmt.Errorf("%w: block %s at height %d was accepted before %s",
+			ErrBlockNotRecent,
+			blkID,
+			blk.Height(),
+			m.LastAccepted(),
+		)
+	}
+
+	// The diff is discarded after verification, so the state of [blkID] is
+	// never modified.
+	stateDiff, err := state.NewDiff(blkID, m)
+	if err != nil {
+		return err
+	}
+	return m.verifyTx(stateDiff, tx)
+}
+
+func (m *manager) VerifyTxAtTime(tx *txs.Tx, timestamp time.Time) error {
+	if !m.txExecutorBackend.Bootstrapped.Get() {
+		return ErrChainNotSynced
+	}
+
+	stateDiff, err := state.NewDiff(m.preferred, m)
+	if err != nil {
+		return err
+	}
+
+	chainTime := stateDiff.GetTimestamp()
+	if timestamp.Before(chainTime) {
+		return fmt.Errorf("%w: %s < %s", ErrTimestampBeforeChainTime, timestamp, chainTime)
+	}
+
+	// Only the chain time is moved forward so that the tx is verified against
+	// the fork rules of [timestamp].
+	stateDiff.SetTimestamp(timestamp)
+	return m.verifyTx(stateDiff, tx)
+}
+
+func (m *manager) verifyTx(stateDiff state.Diff, tx *txs.Tx) error {
 	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
 		Backend: m.txExecutorBackend,
 		State:   stateDiff,
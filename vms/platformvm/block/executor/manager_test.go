@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,8 +12,15 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func TestGetBlock(t *testing.T) {
@@ -86,3 +94,87 @@ func TestManagerSetPreference(t *testing.T) {
 	require.False(manager.SetPreference(newPreference))
 	require.True(manager.SetPreference(initialPreference))
 }
+
+func TestManagerVerifyTxOnRecentBlockUnavailableState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	lastAcceptedID := ids.GenerateTestID()
+	state := state.NewMockState(ctrl)
+	state.EXPECT().GetLastAccepted().Return(lastAcceptedID).AnyTimes()
+
+	bootstrapped := &utils.Atomic[bool]{}
+	manager := &manager{
+		backend: &backend{
+			state:        state,
+			blkIDToState: map[ids.ID]*blockState{},
+		},
+		txExecutorBackend: &executor.Backend{
+			Bootstrapped: bootstrapped,
+		},
+	}
+
+	tx := &txs.Tx{}
+	err := manager.VerifyTxOnRecentBlock(lastAcceptedID, tx)
+	require.ErrorIs(err, ErrChainNotSynced)
+
+	bootstrapped.Set(true)
+	unknownBlkID := ids.GenerateTestID()
+	state.EXPECT().GetStatelessBlock(unknownBlkID).Return(nil, database.ErrNotFound)
+	err = manager.VerifyTxOnRecentBlock(unknownBlkID, tx)
+	require.ErrorIs(err, ErrStateUnavailable)
+
+	// The state of a block accepted before the last accepted block isn't kept.
+	oldBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 1)
+	require.NoError(err)
+	state.EXPECT().GetStatelessBlock(oldBlk.ID()).Return(oldBlk, nil)
+	err = manager.VerifyTxOnRecentBlock(oldBlk.ID(), tx)
+	require.ErrorIs(err, ErrBlockNotRecent)
+}
+
+func TestManagerVerifyTxOnRecentBlock(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, nil, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	lastAcceptedID := env.state.GetLastAccepted()
+	lastAcceptedBlk, err := env.state.GetStatelessBlock(lastAcceptedID)
+	require.NoError(err)
+
+	// Both txs spend the same UTXOs of the last accepted state.
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.MilliAvax,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}}
+	keys := []*secp256k1.PrivateKey{preFundedKeys[0]}
+	tx, err := env.txBuilder.NewBaseTx(outputs, keys)
+	require.NoError(err)
+	conflictingTx, err := env.txBuilder.NewBaseTx(outputs, keys)
+	require.NoError(err)
+	require.Equal(tx.Unsigned.InputIDs(), conflictingTx.Unsigned.InputIDs())
+
+	statelessBlk, err := block.NewBanffStandardBlock(
+		env.state.GetTimestamp(),
+		lastAcceptedID,
+		lastAcceptedBlk.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+	blk := env.blkManager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+
+	// The conflicting tx was valid on top of the earlier state...
+	require.NoError(env.blkManager.VerifyTxOnRecentBlock(lastAcceptedID, conflictingTx))
+
+	// ...but not on top of the processing block that spent its UTXOs.
+	err = env.blkManager.VerifyTxOnRecentBlock(blk.ID(), conflictingTx)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -11,6 +11,7 @@ package executor
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	snowman "github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -159,6 +160,34 @@ func (mr *MockManagerMockRecorder) VerifyTx(tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTx", reflect.TypeOf((*MockManager)(nil).VerifyTx), tx)
 }
 
+// VerifyTxOnRecentBlock mocks base method.
+func (m *MockManager) VerifyTxOnRecentBlock(blkID ids.ID, tx *txs.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTxOnRecentBlock", blkID, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyTxOnRecentBlock indicates an expected call of VerifyTxOnRecentBlock.
+func (mr *MockManagerMockRecorder) VerifyTxOnRecentBlock(blkID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTxOnRecentBlock", reflect.TypeOf((*MockManager)(nil).VerifyTxOnRecentBlock), blkID, tx)
+}
+
+// VerifyTxAtTime mocks base method.
+func (m *MockManager) VerifyTxAtTime(tx *txs.Tx, timestamp time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTxAtTime", tx, timestamp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyTxAtTime indicates an expected call of VerifyTxAtTime.
+func (mr *MockManagerMockRecorder) VerifyTxAtTime(tx, timestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTxAtTime", reflect.TypeOf((*MockManager)(nil).VerifyTxAtTime), tx, timestamp)
+}
+
 // VerifyUniqueInputs mocks base method.
 func (m *MockManager) VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error {
 	m.ctrl.T.Helper()
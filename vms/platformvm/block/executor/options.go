@@ -9,6 +9,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -156,7 +157,11 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 		return false, fmt.Errorf("%w: %T", errUnexpectedStakerTxType, stakerTx.Unsigned)
 	}
 
-	nodeID := staker.NodeID()
+	nodeID, err := o.currentNodeID(unsignedTx.TxID, staker.NodeID())
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", errFailedFetchingPrimaryStaker, err)
+	}
+
 	primaryNetworkValidator, err := o.state.GetCurrentValidator(
 		constants.PrimaryNetworkID,
 		nodeID,
@@ -187,3 +192,22 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 
 	return uptime >= expectedUptimePercentage, nil
 }
+
+// currentNodeID returns the NodeID that the staker added by [txID] is
+// currently staking with. This may differ from the [nodeID] specified in the
+// staker's tx if the staker has been rotated to a new NodeID.
+func (o *options) currentNodeID(txID ids.ID, nodeID ids.NodeID) (ids.NodeID, error) {
+	stakerIterator, err := o.state.GetCurrentStakerIterator()
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	defer stakerIterator.Release()
+
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		if staker.TxID == txID {
+			return staker.NodeID, nil
+		}
+	}
+	return nodeID, nil
+}
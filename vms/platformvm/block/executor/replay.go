@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
+)
+
+var (
+	_ atomic.SharedMemory = replaySharedMemory{}
+
+	ErrReplayDiverged = errors.New("replay diverged")
+
+	errReplayUnexpectedParent = errors.New("block doesn't build on the last replayed block")
+)
+
+// RecordedSummaries provides the summaries that were recorded when blocks were
+// originally accepted.
+type RecordedSummaries interface {
+	GetBlockSummary(height uint64) (*state.BlockSummary, error)
+}
+
+// Replay re-executes [blks], which must be a contiguous range of accepted
+// blocks building on the last accepted block of [baseState], and accepts them
+// into [baseState].
+//
+// After each decision is accepted, the summary of the changes it produced is
+// compared against the summary in [recorded]. Heights that have no recorded
+// summary are skipped. If [recorded] is nil, only the validity of the blocks
+// is checked. If a block fails verification or produces a different summary,
+// an error wrapping [ErrReplayDiverged] is returned.
+//
+// Blocks are replayed as they are during bootstrapping: shared memory is
+// never read nor written, and no accept hooks are fired.
+func Replay(
+	txExecutorBackend *executor.Backend,
+	blks []block.Block,
+	baseState state.State,
+	recorded RecordedSummaries,
+) error {
+	mempool, err := mempool.New("replay", prometheus.NewRegistry(), nil, txExecutorBackend.Ctx.AVAXAssetID, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	ctx := txExecutorBackend.Ctx
+	backend := &backend{
+		Mempool:      mempool,
+		lastAccepted: baseState.GetLastAccepted(),
+		blkIDToState: map[ids.ID]*blockState{},
+		state:        baseState,
+		ctx: &snow.Context{
+			NetworkID:    ctx.NetworkID,
+			SubnetID:     ctx.SubnetID,
+			ChainID:      ctx.ChainID,
+			NodeID:       ctx.NodeID,
+			AVAXAssetID:  ctx.AVAXAssetID,
+			Log:          ctx.Log,
+			SharedMemory: replaySharedMemory{},
+		},
+	}
+
+	replayBackend := *txExecutorBackend
+	replayBackend.Bootstrapped = &utils.Atomic[bool]{}
+	replayBackend.VerifiedTxs = nil
+	replayBackend.AcceptHooks = nil
+
+	verifier := &verifier{
+		backend:           backend,
+		txExecutorBackend: &replayBackend,
+	}
+	acceptor := &acceptor{
+		backend:      backend,
+		metrics:      metrics.Noop,
+		validators:   validators.TestManager,
+		bootstrapped: replayBackend.Bootstrapped,
+	}
+
+	for _, blk := range blks {
+		blkID := blk.ID()
+		height := blk.Height()
+		if parentID := blk.Parent(); parentID != backend.lastAccepted {
+			return fmt.Errorf("%w: block %s at height %d has parent %s, expected %s",
+				errReplayUnexpectedParent,
+				blkID,
+				height,
+				parentID,
+				backend.lastAccepted,
+			)
+		}
+
+		if err := blk.Visit(verifier); err != nil {
+			return fmt.Errorf("%w: block %s at height %d failed verification: %w",
+				ErrReplayDiverged,
+				blkID,
+				height,
+				err,
+			)
+		}
+		if err := blk.Visit(acceptor); err != nil {
+			return fmt.Errorf("failed to accept block %s at height %d: %w", blkID, height, err)
+		}
+
+		if recorded == nil {
+			continue
+		}
+
+		switch blk.(type) {
+		case *block.BanffProposalBlock, *block.ApricotProposalBlock:
+			// Proposal blocks are summarized when their child is accepted.
+		case *block.BanffCommitBlock, *block.BanffAbortBlock, *block.ApricotCommitBlock, *block.ApricotAbortBlock:
+			if err := compareSummaries(baseState, recorded, height-1); err != nil {
+				return err
+			}
+			if err := compareSummaries(baseState, recorded, height); err != nil {
+				return err
+			}
+		default:
+			if err := compareSummaries(baseState, recorded, height); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func compareSummaries(replayed state.State, recorded RecordedSummaries, height uint64) error {
+	expected, err := recorded.GetBlockSummary(height)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get recorded summary at height %d: %w", height, err)
+	}
+
+	summary, err := replayed.GetBlockSummary(height)
+	if err != nil {
+		return fmt.Errorf("failed to get replayed summary at height %d: %w", height, err)
+	}
+	if *summary != *expected {
+		return fmt.Errorf("%w: summary at height %d is %+v, expected %+v",
+			ErrReplayDiverged,
+			height,
+			*summary,
+			*expected,
+		)
+	}
+	return nil
+}
+
+// replaySharedMemory writes the batches it is given without applying any
+// atomic requests.
+type replaySharedMemory struct{}
+
+func (replaySharedMemory) Get(ids.ID, [][]byte) ([][]byte, error) {
+	return nil, database.ErrNotFound
+}
+
+func (replaySharedMemory) Indexed(ids.ID, [][]byte, []byte, []byte, int) ([][]byte, []byte, []byte, error) {
+	return nil, nil, nil, nil
+}
+
+func (replaySharedMemory) Apply(_ map[ids.ID]*atomic.Requests, batches ...database.Batch) error {
+	for _, batch := range batches {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
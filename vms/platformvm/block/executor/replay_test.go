@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type tamperedSummaries struct {
+	RecordedSummaries
+	height uint64
+}
+
+func (t *tamperedSummaries) GetBlockSummary(height uint64) (*state.BlockSummary, error) {
+	summary, err := t.RecordedSummaries.GetBlockSummary(height)
+	if err != nil || height != t.height {
+		return summary, err
+	}
+	tampered := *summary
+	tampered.FeesBurned++
+	return &tampered, nil
+}
+
+func TestReplay(t *testing.T) {
+	env := newEnvironment(t, nil, durango)
+
+	// Accept a couple of blocks that each issue a tx.
+	blks := make([]block.Block, 2)
+	for i := range blks {
+		tx, err := env.txBuilder.NewBaseTx(
+			[]*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+					},
+				},
+			}},
+			[]*secp256k1.PrivateKey{preFundedKeys[1]},
+		)
+		require.NoError(t, err)
+
+		parentID := env.state.GetLastAccepted()
+		parentBlk, err := env.state.GetStatelessBlock(parentID)
+		require.NoError(t, err)
+		statelessBlk, err := block.NewBanffStandardBlock(
+			env.state.GetTimestamp(),
+			parentID,
+			parentBlk.Height()+1,
+			[]*txs.Tx{tx},
+		)
+		require.NoError(t, err)
+
+		blk := env.blkManager.NewBlock(statelessBlk)
+		require.NoError(t, blk.Verify(context.Background()))
+		require.NoError(t, blk.Accept(context.Background()))
+		blks[i] = statelessBlk
+	}
+
+	// Each replay starts from a fresh state that only contains the genesis.
+	newBaseState := func() state.State {
+		cfg := *env.config
+		cfg.Validators = validators.NewManager()
+		return defaultState(
+			&cfg,
+			env.ctx,
+			versiondb.New(memdb.New()),
+			reward.NewCalculator(cfg.RewardConfig),
+		)
+	}
+
+	t.Run("matches", func(t *testing.T) {
+		require := require.New(t)
+
+		baseState := newBaseState()
+		require.NoError(Replay(env.backend, blks, baseState, env.state))
+		require.Equal(env.state.GetLastAccepted(), baseState.GetLastAccepted())
+		for _, blk := range blks {
+			for _, tx := range blk.Txs() {
+				_, txStatus, err := baseState.GetTx(tx.ID())
+				require.NoError(err)
+				require.Equal(status.Committed, txStatus)
+			}
+		}
+	})
+
+	t.Run("without recorded summaries", func(t *testing.T) {
+		require.NoError(t, Replay(env.backend, blks, newBaseState(), nil))
+	})
+
+	t.Run("diverged summary", func(t *testing.T) {
+		recorded := &tamperedSummaries{
+			RecordedSummaries: env.state,
+			height:            blks[1].Height(),
+		}
+		err := Replay(env.backend, blks, newBaseState(), recorded)
+		require.ErrorIs(t, err, ErrReplayDiverged)
+	})
+
+	t.Run("unexpected parent", func(t *testing.T) {
+		err := Replay(env.backend, blks[1:], newBaseState(), env.state)
+		require.ErrorIs(t, err, errReplayUnexpectedParent)
+	})
+
+	t.Run("invalid block", func(t *testing.T) {
+		require := require.New(t)
+
+		// Spend the inputs of the first block again.
+		invalidBlk, err := block.NewBanffStandardBlock(
+			blks[0].(*block.BanffStandardBlock).Timestamp(),
+			blks[0].ID(),
+			blks[0].Height()+1,
+			blks[0].Txs(),
+		)
+		require.NoError(err)
+
+		err = Replay(env.backend, []block.Block{blks[0], invalidBlk}, newBaseState(), env.state)
+		require.ErrorIs(err, ErrReplayDiverged)
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ txs.Visitor = (*summarizer)(nil)
+
+// summarizer aggregates the effects of accepted txs into a block summary.
+//
+// Fees are calculated as the difference between the AVAX consumed and produced
+// by the txs. Rewards are read from [state], so the txs must have already been
+// applied to it.
+type summarizer struct {
+	avaxAssetID ids.ID
+	state       state.State
+	// aborted is true if the visited txs were proposals that were aborted.
+	aborted bool
+
+	consumed uint64
+	produced uint64
+	summary  state.BlockSummary
+}
+
+// summarize returns the summary of accepting [txs] into [chainState].
+func summarize(
+	avaxAssetID ids.ID,
+	chainState state.State,
+	aborted bool,
+	txs []*txs.Tx,
+) (*state.BlockSummary, error) {
+	s := &summarizer{
+		avaxAssetID: avaxAssetID,
+		state:       chainState,
+		aborted:     aborted,
+	}
+	for _, tx := range txs {
+		if err := tx.Unsigned.Visit(s); err != nil {
+			return nil, fmt.Errorf("failed to summarize tx %s: %w", tx.ID(), err)
+		}
+	}
+
+	feesBurned, err := math.Sub(s.consumed, s.produced)
+	if err != nil {
+		return nil, err
+	}
+	s.summary.FeesBurned = avajson.Uint64(feesBurned)
+	return &s.summary, nil
+}
+
+func (s *summarizer) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, nil)
+}
+
+func (s *summarizer) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) CreateChainTx(tx *txs.CreateChainTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) ImportTx(tx *txs.ImportTx) error {
+	if err := s.consume(tx.ImportedInputs); err != nil {
+		return err
+	}
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) ExportTx(tx *txs.ExportTx) error {
+	if err := s.produce(tx.ExportedOutputs); err != nil {
+		return err
+	}
+	return s.spend(&tx.BaseTx)
+}
+
+func (*summarizer) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (s *summarizer) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	s.summary.StakersRemoved++
+
+	rewardUTXOs, err := s.state.GetRewardUTXOs(tx.TxID)
+	if err != nil {
+		return err
+	}
+	for _, utxo := range rewardUTXOs {
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok || utxo.AssetID() != s.avaxAssetID {
+			continue
+		}
+		rewardsMinted, err := math.Add64(uint64(s.summary.RewardsMinted), out.Amount())
+		if err != nil {
+			return err
+		}
+		s.summary.RewardsMinted = avajson.Uint64(rewardsMinted)
+	}
+	return nil
+}
+
+func (s *summarizer) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	s.summary.StakersRemoved++
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) BaseTx(tx *txs.BaseTx) error {
+	return s.spend(tx)
+}
+
+func (s *summarizer) ChangeDelegationFeeTx(tx *txs.ChangeDelegationFeeTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) RotateValidatorNodeTx(tx *txs.RotateValidatorNodeTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) SetValidatorBLSKeyTx(tx *txs.SetValidatorBLSKeyTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) ReportMisbehaviorTx(tx *txs.ReportMisbehaviorTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) SetSubnetStakingParamsTx(tx *txs.SetSubnetStakingParamsTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) ReduceSubnetValidatorWeightTx(tx *txs.ReduceSubnetValidatorWeightTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) AddSplitRewardsPermissionlessValidatorTx(tx *txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) AddValidatorWithSubnetsTx(tx *txs.AddValidatorWithSubnetsTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) AddVestingPermissionlessValidatorTx(tx *txs.AddVestingPermissionlessValidatorTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+func (s *summarizer) SetSubnetValidatorEpochTx(tx *txs.SetSubnetValidatorEpochTx) error {
+	return s.spend(&tx.BaseTx)
+}
+
+func (s *summarizer) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return s.addStaker(&tx.BaseTx, tx.StakeOuts)
+}
+
+// addStaker accounts for a tx that adds a staker. The stake is refunded if the
+// tx was aborted, so it is treated as produced either way.
+func (s *summarizer) addStaker(tx *txs.BaseTx, stake []*avax.TransferableOutput) error {
+	if !s.aborted {
+		s.summary.StakersAdded++
+	}
+	if err := s.produce(stake); err != nil {
+		return err
+	}
+	return s.spend(tx)
+}
+
+func (s *summarizer) spend(tx *txs.BaseTx) error {
+	if err := s.consume(tx.Ins); err != nil {
+		return err
+	}
+	return s.produce(tx.Outs)
+}
+
+func (s *summarizer) consume(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		if in.AssetID() != s.avaxAssetID {
+			continue
+		}
+		consumed, err := math.Add64(s.consumed, in.In.Amount())
+		if err != nil {
+			return err
+		}
+		s.consumed = consumed
+	}
+	return nil
+}
+
+func (s *summarizer) produce(outs []*avax.TransferableOutput) error {
+	for _, out := range outs {
+		if out.AssetID() != s.avaxAssetID {
+			continue
+		}
+		produced, err := math.Add64(s.produced, out.Out.Amount())
+		if err != nil {
+			return err
+		}
+		s.produced = produced
+	}
+	return nil
+}
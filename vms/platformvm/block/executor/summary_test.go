@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSummarize(t *testing.T) {
+	var (
+		avaxAssetID  = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+	)
+	in := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: amount,
+			},
+		}
+	}
+	out := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+			},
+		}
+	}
+	baseTx := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) txs.BaseTx {
+		return txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		}}
+	}
+
+	var (
+		importTx = &txs.Tx{Unsigned: &txs.ImportTx{
+			BaseTx: baseTx(
+				[]*avax.TransferableInput{in(avaxAssetID, 1)},
+				[]*avax.TransferableOutput{out(avaxAssetID, 5)},
+			),
+			ImportedInputs: []*avax.TransferableInput{in(avaxAssetID, 6)},
+		}}
+		exportTx = &txs.Tx{Unsigned: &txs.ExportTx{
+			BaseTx: baseTx(
+				[]*avax.TransferableInput{in(avaxAssetID, 10), in(otherAssetID, 100)},
+				[]*avax.TransferableOutput{out(avaxAssetID, 3)},
+			),
+			ExportedOutputs: []*avax.TransferableOutput{out(avaxAssetID, 4), out(otherAssetID, 100)},
+		}}
+		delegatorTx = &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{
+			BaseTx: baseTx(
+				[]*avax.TransferableInput{in(avaxAssetID, 10)},
+				nil,
+			),
+			StakeOuts: []*avax.TransferableOutput{out(avaxAssetID, 9)},
+		}}
+	)
+
+	tests := []struct {
+		name            string
+		aborted         bool
+		txs             []*txs.Tx
+		expectedSummary *state.BlockSummary
+	}{
+		{
+			name:            "no txs",
+			expectedSummary: &state.BlockSummary{},
+		},
+		{
+			name: "atomic txs",
+			txs:  []*txs.Tx{importTx, exportTx},
+			expectedSummary: &state.BlockSummary{
+				FeesBurned: 5,
+			},
+		},
+		{
+			name: "staker added",
+			txs:  []*txs.Tx{delegatorTx},
+			expectedSummary: &state.BlockSummary{
+				FeesBurned:   1,
+				StakersAdded: 1,
+			},
+		},
+		{
+			name:    "staker aborted",
+			aborted: true,
+			txs:     []*txs.Tx{delegatorTx},
+			expectedSummary: &state.BlockSummary{
+				FeesBurned: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			summary, err := summarize(avaxAssetID, nil, test.aborted, test.txs)
+			require.NoError(err)
+			require.Equal(test.expectedSummary, summary)
+		})
+	}
+}
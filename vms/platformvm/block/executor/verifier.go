@@ -213,6 +213,7 @@ func (v *verifier) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		statelessBlock: b,
 
 		onAcceptState: atomicExecutor.OnAccept,
+		onAcceptFunc:  v.txExecutorBackend.AcceptHooks.OnAccept(b.Tx),
 
 		inputs:         atomicExecutor.Inputs,
 		timestamp:      atomicExecutor.OnAccept.GetTimestamp(),
@@ -384,6 +385,7 @@ func (v *verifier) proposalBlock(
 			onDecisionState: onDecisionState,
 			onCommitState:   onCommitState,
 			onAbortState:    onAbortState,
+			onCommitFunc:    v.txExecutorBackend.AcceptHooks.OnAccept(b.Tx),
 		},
 
 		statelessBlock: b,
@@ -432,61 +434,20 @@ func (v *verifier) processStandardTxs(txs []*txs.Tx, state state.Diff, parentID
 	func(),
 	error,
 ) {
-	var (
-		onAcceptFunc   func()
-		inputs         set.Set[ids.ID]
-		funcs          = make([]func(), 0, len(txs))
-		atomicRequests = make(map[ids.ID]*atomic.Requests)
-	)
-	for _, tx := range txs {
-		txExecutor := executor.StandardTxExecutor{
-			Backend: v.txExecutorBackend,
-			State:   state,
-			Tx:      tx,
-		}
-		if err := tx.Unsigned.Visit(&txExecutor); err != nil {
-			txID := tx.ID()
-			v.MarkDropped(txID, err) // cache tx as dropped
-			return nil, nil, nil, err
-		}
-		// ensure it doesn't overlap with current input batch
-		if inputs.Overlaps(txExecutor.Inputs) {
-			return nil, nil, nil, ErrConflictingBlockTxs
-		}
-		// Add UTXOs to batch
-		inputs.Union(txExecutor.Inputs)
-
-		state.AddTx(tx, status.Committed)
-		if txExecutor.OnAccept != nil {
-			funcs = append(funcs, txExecutor.OnAccept)
-		}
-
-		for chainID, txRequests := range txExecutor.AtomicRequests {
-			// Add/merge in the atomic requests represented by [tx]
-			chainRequests, exists := atomicRequests[chainID]
-			if !exists {
-				atomicRequests[chainID] = txRequests
-				continue
-			}
-
-			chainRequests.PutRequests = append(chainRequests.PutRequests, txRequests.PutRequests...)
-			chainRequests.RemoveRequests = append(chainRequests.RemoveRequests, txRequests.RemoveRequests...)
-		}
+	inputs, atomicRequests, onAcceptFunc, err := executor.VerifyStandardTxs(v.txExecutorBackend, state, txs)
+	var txErr *executor.TxError
+	switch {
+	case errors.As(err, &txErr):
+		v.MarkDropped(txErr.TxID, txErr.Err) // cache tx as dropped
+		return nil, nil, nil, txErr.Err
+	case errors.Is(err, executor.ErrConflictingTxs):
+		return nil, nil, nil, ErrConflictingBlockTxs
+	case err != nil:
+		return nil, nil, nil, err
 	}
 
 	if err := v.verifyUniqueInputs(parentID, inputs); err != nil {
 		return nil, nil, nil, err
 	}
-
-	if numFuncs := len(funcs); numFuncs == 1 {
-		onAcceptFunc = funcs[0]
-	} else if numFuncs > 1 {
-		onAcceptFunc = func() {
-			for _, f := range funcs {
-				f()
-			}
-		}
-	}
-
 	return inputs, atomicRequests, onAcceptFunc, nil
 }
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+// ExportCheckpoint writes a checkpoint of the state at the last accepted block
+// to [w]. A new node can be initialized from the checkpoint by setting the
+// checkpoint-file execution config option. The ID and height of the last
+// accepted block are returned.
+func (vm *VM) ExportCheckpoint(w io.Writer) (ids.ID, uint64, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	return state.ExportCheckpoint(vm.db, w)
+}
+
+// importCheckpoint initializes the database from the checkpoint at [path] if
+// the database is empty.
+func (vm *VM) importCheckpoint(path string) error {
+	isEmpty, err := database.IsEmpty(vm.db)
+	if err != nil {
+		return err
+	}
+	if !isEmpty {
+		vm.ctx.Log.Info("skipping checkpoint import of initialized database",
+			zap.String("path", path),
+		)
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lastAcceptedID, height, err := state.ImportCheckpoint(f, vm.db)
+	if err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("imported checkpoint",
+		zap.String("path", path),
+		zap.Stringer("lastAcceptedID", lastAcceptedID),
+		zap.Uint64("height", height),
+	)
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
@@ -70,8 +71,16 @@ type Client interface {
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPermissionlessValidator, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system along with the P-chain height
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
+	// GetDelegationRewardPreview returns the reward that a delegation of [weight] for [duration] to the current validator [nodeID] would receive if it started now
+	GetDelegationRewardPreview(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, weight uint64, duration time.Duration, options ...rpc.Option) (*GetDelegationRewardPreviewReply, error)
+	// EstimateStakingReward returns the reward that a staker of [weight] for [duration] on subnet [subnetID] would receive if it started now
+	EstimateStakingReward(ctx context.Context, subnetID ids.ID, weight uint64, duration time.Duration, options ...rpc.Option) (*EstimateStakingRewardReply, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
+	// SampleValidatorsWithSeed returns the nodeIDs of a stake-weighted sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID], deterministically derived from [seed]
+	SampleValidatorsWithSeed(ctx context.Context, subnetID ids.ID, sampleSize uint16, seed uint64, options ...rpc.Option) ([]ids.NodeID, error)
+	// SampleValidatorsAtHeight returns the nodeIDs of a stake-weighted sample of [sampleSize] validators from the validator set for subnet with ID [subnetID] at P-chain [height], deterministically derived from [seed]
+	SampleValidatorsAtHeight(ctx context.Context, subnetID ids.ID, sampleSize uint16, seed uint64, height uint64, options ...rpc.Option) ([]ids.NodeID, error)
 	// GetBlockchainStatus returns the current status of blockchain with ID: [blockchainID]
 	GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error)
 	// ValidatedBy returns the ID of the Subnet that validates [blockchainID]
@@ -82,8 +91,30 @@ type Client interface {
 	//
 	// Deprecated: Blockchains should be fetched from a dedicated indexer.
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
+	// GetSubnetChains returns the chains that were created under [subnetID]
+	GetSubnetChains(ctx context.Context, subnetID ids.ID, options ...rpc.Option) ([]APISubnetChain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// IssueTxWithMaxFee issues the transaction and returns its txID. The
+	// transaction is refused if it burns more than [maxFee] nAVAX.
+	IssueTxWithMaxFee(ctx context.Context, tx []byte, maxFee uint64, options ...rpc.Option) (ids.ID, error)
+	// CheckTx returns whether the signed tx would currently be accepted and, if
+	// not, why
+	CheckTx(ctx context.Context, tx []byte, options ...rpc.Option) (*CheckTxReply, error)
+	// CheckTxAtTime returns whether the signed tx would be accepted if the
+	// chain time were [timestamp] and, if not, why. This allows a tx to be
+	// checked against the rules of an upgrade before the upgrade activates.
+	CheckTxAtTime(ctx context.Context, tx []byte, timestamp time.Time, options ...rpc.Option) (*CheckTxReply, error)
+	// ScheduleTx registers the signed staking tx to be issued once the chain
+	// time reaches [issueTime] and returns its txID
+	ScheduleTx(ctx context.Context, tx []byte, issueTime time.Time, options ...rpc.Option) (ids.ID, error)
+	// CancelScheduledTx cancels the issuance of the scheduled tx [txID]
+	CancelScheduledTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+	// GetScheduledTxs returns the txs that are waiting to be issued
+	GetScheduledTxs(ctx context.Context, options ...rpc.Option) ([]APIScheduledTx, error)
+	// GetPendingTxs returns the txs in the mempool along with an estimate of
+	// whether they will be included in the next block
+	GetPendingTxs(ctx context.Context, options ...rpc.Option) ([]APIPendingTx, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -108,6 +139,13 @@ type Client interface {
 		validatorsOnly bool,
 		options ...rpc.Option,
 	) (map[ids.ID]uint64, [][]byte, error)
+	// GetStakedOutputs returns the outputs owned by [addrs] that are currently
+	// locked as stake by current or pending stakers.
+	GetStakedOutputs(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		options ...rpc.Option,
+	) ([]APIStakedOutput, error)
 	// GetMinStake returns the minimum staking amount in nAVAX for validators
 	// and delegators respectively
 	GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
@@ -127,10 +165,25 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorPublicKey returns the BLS public key that [nodeID] had
+	// registered as a primary network validator at the specified height. If
+	// [nodeID] didn't have a public key, nil is returned.
+	GetValidatorPublicKey(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		height uint64,
+		options ...rpc.Option,
+	) (*bls.PublicKey, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
+	// GetBlockStats returns the aggregated summaries of the accepted blocks
+	// from [startHeight] to [endHeight], inclusive.
+	GetBlockStats(ctx context.Context, startHeight, endHeight uint64, options ...rpc.Option) (*GetBlockStatsReply, error)
+	// GetUTXOsAtHeight returns the byte representation of the UTXOs
+	// controlled by [addrs] after the block at [height] was accepted.
+	GetUTXOsAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) ([][]byte, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -145,6 +198,20 @@ func NewClient(uri string) Client {
 	)}
 }
 
+// NewFailoverClient returns a Client for interacting with the P Chain endpoint
+// of the first healthy node of [uris]. Requests that fail to reach a node are
+// sent to the next node and retried according to [config].
+func NewFailoverClient(uris []string, config rpc.FailoverConfig) Client {
+	paths := make([]string, len(uris))
+	for i, uri := range uris {
+		paths[i] = uri + "/ext/P"
+	}
+	return &client{requester: rpc.NewFailoverEndpointRequester(
+		paths,
+		config,
+	)}
+}
+
 func (c *client) GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error) {
 	res := &api.GetHeightResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getHeight", struct{}{}, res, options...)
@@ -330,6 +397,40 @@ func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options
 	return uint64(res.Supply), uint64(res.Height), err
 }
 
+func (c *client) GetDelegationRewardPreview(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	duration time.Duration,
+	options ...rpc.Option,
+) (*GetDelegationRewardPreviewReply, error) {
+	res := &GetDelegationRewardPreviewReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDelegationRewardPreview", &GetDelegationRewardPreviewArgs{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+		Weight:   json.Uint64(weight),
+		Duration: json.Uint64(duration / time.Second),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) EstimateStakingReward(
+	ctx context.Context,
+	subnetID ids.ID,
+	weight uint64,
+	duration time.Duration,
+	options ...rpc.Option,
+) (*EstimateStakingRewardReply, error) {
+	res := &EstimateStakingRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateStakingReward", &EstimateStakingRewardArgs{
+		SubnetID: subnetID,
+		Weight:   json.Uint64(weight),
+		Duration: json.Uint64(duration / time.Second),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
@@ -339,6 +440,32 @@ func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSi
 	return res.Validators, err
 }
 
+func (c *client) SampleValidatorsWithSeed(ctx context.Context, subnetID ids.ID, sampleSize uint16, seed uint64, options ...rpc.Option) ([]ids.NodeID, error) {
+	res := &SampleValidatorsReply{}
+	jsonSeed := json.Uint64(seed)
+	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
+		SubnetID: subnetID,
+		Size:     json.Uint16(sampleSize),
+		Seed:     &jsonSeed,
+	}, res, options...)
+	return res.Validators, err
+}
+
+func (c *client) SampleValidatorsAtHeight(ctx context.Context, subnetID ids.ID, sampleSize uint16, seed uint64, height uint64, options ...rpc.Option) ([]ids.NodeID, error) {
+	res := &SampleValidatorsReply{}
+	var (
+		jsonSeed   = json.Uint64(seed)
+		jsonHeight = json.Uint64(height)
+	)
+	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
+		SubnetID: subnetID,
+		Size:     json.Uint16(sampleSize),
+		Seed:     &jsonSeed,
+		Height:   &jsonHeight,
+	}, res, options...)
+	return res.Validators, err
+}
+
 func (c *client) GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error) {
 	res := &GetBlockchainStatusReply{}
 	err := c.requester.SendRequest(ctx, "platform.getBlockchainStatus", &GetBlockchainStatusArgs{
@@ -369,6 +496,14 @@ func (c *client) GetBlockchains(ctx context.Context, options ...rpc.Option) ([]A
 	return res.Blockchains, err
 }
 
+func (c *client) GetSubnetChains(ctx context.Context, subnetID ids.ID, options ...rpc.Option) ([]APISubnetChain, error) {
+	res := &GetSubnetChainsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetChains", &GetSubnetChainsArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res.Chains, err
+}
+
 func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
 	txStr, err := formatting.Encode(formatting.Hex, txBytes)
 	if err != nil {
@@ -383,6 +518,92 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) IssueTxWithMaxFee(ctx context.Context, txBytes []byte, maxFee uint64, options ...rpc.Option) (ids.ID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return ids.ID{}, err
+	}
+
+	jsonMaxFee := json.Uint64(maxFee)
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest(ctx, "platform.issueTx", &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		MaxFee: &jsonMaxFee,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) CheckTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*CheckTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CheckTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.checkTx", &CheckTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) CheckTxAtTime(ctx context.Context, txBytes []byte, timestamp time.Time, options ...rpc.Option) (*CheckTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CheckTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.checkTx", &CheckTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		Time: json.Uint64(timestamp.Unix()),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ScheduleTx(ctx context.Context, txBytes []byte, issueTime time.Time, options ...rpc.Option) (ids.ID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return ids.ID{}, err
+	}
+
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest(ctx, "platform.scheduleTx", &ScheduleTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IssueTime: json.Uint64(issueTime.Unix()),
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) CancelScheduledTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "platform.cancelScheduledTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetScheduledTxs(ctx context.Context, options ...rpc.Option) ([]APIScheduledTx, error) {
+	res := &GetScheduledTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getScheduledTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
+func (c *client) GetPendingTxs(ctx context.Context, options ...rpc.Option) ([]APIPendingTx, error) {
+	res := &GetPendingTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getPendingTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -464,6 +685,21 @@ func (c *client) GetStake(
 	return staked, outputs, err
 }
 
+func (c *client) GetStakedOutputs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	options ...rpc.Option,
+) ([]APIStakedOutput, error) {
+	res := &GetStakedOutputsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakedOutputs", &GetStakedOutputsArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: ids.ShortIDsToStrings(addrs),
+		},
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Outputs, err
+}
+
 func (c *client) GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetMinStakeReply{}
 	err := c.requester.SendRequest(ctx, "platform.getMinStake", &GetMinStakeArgs{
@@ -523,6 +759,28 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorPublicKey(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	height uint64,
+	options ...rpc.Option,
+) (*bls.PublicKey, error) {
+	res := &GetValidatorPublicKeyReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorPublicKey", &GetValidatorPublicKeyArgs{
+		NodeID: nodeID,
+		Height: json.Uint64(height),
+	}, res, options...)
+	if err != nil || res.PublicKey == nil {
+		return nil, err
+	}
+
+	pkBytes, err := formatting.Decode(formatting.HexNC, *res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return bls.PublicKeyFromCompressedBytes(pkBytes)
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -545,3 +803,34 @@ func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ..
 	}
 	return formatting.Decode(res.Encoding, res.Block)
 }
+
+func (c *client) GetBlockStats(ctx context.Context, startHeight, endHeight uint64, options ...rpc.Option) (*GetBlockStatsReply, error) {
+	res := &GetBlockStatsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getBlockStats", &GetBlockStatsArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetUTXOsAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) ([][]byte, error) {
+	res := &GetUTXOsAtHeightReply{}
+	err := c.requester.SendRequest(ctx, "platform.getUTXOsAtHeight", &GetUTXOsAtHeightArgs{
+		Addresses: ids.ShortIDsToStrings(addrs),
+		Height:    json.Uint64(height),
+		Encoding:  formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, nil
+}
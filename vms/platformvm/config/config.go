@@ -87,9 +87,22 @@ type Config struct {
 	// Minimum amount of time to allow a staker to stake
 	MinStakeDuration time.Duration
 
+	// Minimum amount of time to allow a delegator to delegate on the primary
+	// network
+	MinDelegationDuration time.Duration
+
 	// Maximum amount of time to allow a staker to stake
 	MaxStakeDuration time.Duration
 
+	// Maximum amount of time after the chain time that a staker can be
+	// scheduled to start. Only enforced before Durango, as stakers start
+	// immediately afterwards.
+	MaxFutureStartTime time.Duration
+
+	// Minimum amount of time between two changes of a validator's delegation
+	// fee
+	DelegationFeeChangeCooldown time.Duration
+
 	// Config for the minting function
 	RewardConfig reward.Config
 
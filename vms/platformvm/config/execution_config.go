@@ -21,8 +21,13 @@ var DefaultExecutionConfig = ExecutionConfig{
 	ChainDBCacheSize:             2048,
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
+	VerifiedTxCacheSize:          2048,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	MempoolMinFeeBumpPercent:     10,
+	MempoolMinSize:               64 * units.MiB,
+	MempoolMaxSize:               64 * units.MiB,
+	MempoolMaxSubnetStakerTxs:    1024,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -36,8 +41,85 @@ type ExecutionConfig struct {
 	ChainDBCacheSize             int            `json:"chain-db-cache-size"`
 	BlockIDCacheSize             int            `json:"block-id-cache-size"`
 	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
-	ChecksumsEnabled             bool           `json:"checksums-enabled"`
-	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	// VerifiedTxCacheSize is the number of txIDs of txs that passed syntactic
+	// verification to remember, so that the txs aren't syntactically verified
+	// again when they are re-parsed.
+	VerifiedTxCacheSize   int           `json:"verified-tx-cache-size"`
+	ChecksumsEnabled      bool          `json:"checksums-enabled"`
+	MempoolPruneFrequency time.Duration `json:"mempool-prune-frequency"`
+	// MempoolMinFeeBumpPercent is the minimum percentage by which the fee of
+	// a tx must exceed the total fee of the mempool txs it conflicts with for
+	// it to replace them.
+	MempoolMinFeeBumpPercent uint64 `json:"mempool-min-fee-bump-percent"`
+	// MempoolMinSize is the number of bytes the mempool may hold while the
+	// node is under memory pressure.
+	MempoolMinSize int `json:"mempool-min-size"`
+	// MempoolMaxSize is the number of bytes the mempool may hold while the
+	// node isn't under memory pressure.
+	MempoolMaxSize int `json:"mempool-max-size"`
+	// MempoolMemoryLimit is the memory usage, in bytes, past which the node is
+	// under memory pressure. The mempool is shrunk by the memory used past
+	// this limit, down to MempoolMinSize, evicting the most recently added
+	// txs. If 0, the mempool always may hold MempoolMaxSize bytes.
+	MempoolMemoryLimit uint64 `json:"mempool-memory-limit"`
+	// MempoolMaxSubnetStakerTxs is the maximum number of staker txs of a
+	// single subnet, other than the primary network, that the mempool may
+	// hold. If 0, the number of staker txs isn't limited.
+	MempoolMaxSubnetStakerTxs int `json:"mempool-max-subnet-staker-txs"`
+	// TxRetentionBlocks is the number of most recently accepted blocks whose
+	// txs are fully retained. The bytes of older txs that are not needed for
+	// execution are discarded, leaving only their status. If 0, all txs are
+	// retained.
+	TxRetentionBlocks uint64 `json:"tx-retention-blocks"`
+	// ValidatorSetConsistencyCheckFrequency is how often a cached validator
+	// set is recomputed from the validator diffs and compared against the
+	// cached value. If 0, the check is disabled.
+	ValidatorSetConsistencyCheckFrequency time.Duration `json:"validator-set-consistency-check-frequency"`
+	// ValidatorCheckpointInterval is the number of blocks between persisted
+	// checkpoints of the validator sets of the primary network and of the
+	// tracked subnets. Historical validator sets are rebuilt from the closest
+	// checkpoint above the requested height, which bounds the number of diffs
+	// that must be applied. If 0, no checkpoints are written.
+	ValidatorCheckpointInterval uint64 `json:"validator-checkpoint-interval"`
+	// ValidatorDiffRetentionBlocks is the number of most recently accepted
+	// blocks whose validator weight and public key diffs are retained. Older
+	// diffs are discarded, so validator sets can't be rebuilt for heights
+	// outside of the retention window. If 0, all diffs are retained.
+	ValidatorDiffRetentionBlocks uint64 `json:"validator-diff-retention-blocks"`
+	// ValidatorSetCacheMaxValidators is the number of validators, summed
+	// across the cached validator sets of a subnet, that may be held in
+	// memory. Once exceeded, the least recently used validator sets are
+	// spilled to disk. If 0, a fixed number of validator sets of each subnet
+	// are held in memory and nothing is spilled.
+	ValidatorSetCacheMaxValidators int `json:"validator-set-cache-max-validators"`
+	// ValidatorSetCacheMaxSpilledSets is the number of validator sets of each
+	// subnet that may be spilled to disk. Once exceeded, the least recently
+	// spilled validator sets are discarded. Only used if
+	// ValidatorSetCacheMaxValidators is set.
+	ValidatorSetCacheMaxSpilledSets int `json:"validator-set-cache-max-spilled-sets"`
+	// CheckpointFile is the path of a state checkpoint to initialize the chain
+	// from. It is only used if the chain's database is empty. If empty, the
+	// chain is initialized from genesis.
+	CheckpointFile string `json:"checkpoint-file"`
+	// UTXOJournalEnabled records the UTXOs added and removed by every
+	// accepted block, which allows the UTXOs of an address to be queried at
+	// any height accepted after the journal was enabled.
+	UTXOJournalEnabled bool `json:"utxo-journal-enabled"`
+	// AsyncCommitQueueSize is the maximum number of committed state changes
+	// that may be waiting to be written to disk. Blocks without atomic
+	// requests are accepted once their state changes are appended to a
+	// write-ahead log in the chain's data directory, without waiting for them
+	// to be written to the database. Queued writes are combined into a single
+	// database write, and logged changes are replayed on startup. If 0, state
+	// changes are written synchronously.
+	AsyncCommitQueueSize int `json:"async-commit-queue-size"`
+	// VerifyDatabase walks the database on startup and checks its referential
+	// integrity: that every staker and subnet was added by a committed tx,
+	// that the supplies cover the outstanding potential rewards, and that the
+	// validator diffs replay to the recorded validator sets. Initialization
+	// fails if any inconsistency is found. This can be used to check a
+	// database before or after an upgrade, but slows down startup.
+	VerifyDatabase bool `json:"verify-database"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
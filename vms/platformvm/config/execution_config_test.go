@@ -69,8 +69,24 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"chain-db-cache-size": 7,
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
+			"verified-tx-cache-size": 14,
 			"checksums-enabled": true,
-			"mempool-prune-frequency": 60000000000
+			"mempool-prune-frequency": 60000000000,
+			"mempool-min-fee-bump-percent": 25,
+			"mempool-min-size": 11,
+			"mempool-max-size": 12,
+			"mempool-memory-limit": 13,
+			"mempool-max-subnet-staker-txs": 18,
+			"tx-retention-blocks": 10,
+			"validator-set-consistency-check-frequency": 300000000000,
+			"validator-checkpoint-interval": 15,
+			"validator-diff-retention-blocks": 16,
+			"validator-set-cache-max-validators": 19,
+			"validator-set-cache-max-spilled-sets": 20,
+			"checkpoint-file": "checkpoint",
+			"utxo-journal-enabled": true,
+			"async-commit-queue-size": 17,
+			"verify-database": true
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -94,16 +110,32 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 				ExpectedBloomFilterFalsePositiveProbability: 16,
 				MaxBloomFilterFalsePositiveProbability:      17,
 			},
-			BlockCacheSize:               1,
-			TxCacheSize:                  2,
-			TransformedSubnetTxCacheSize: 3,
-			RewardUTXOsCacheSize:         5,
-			ChainCacheSize:               6,
-			ChainDBCacheSize:             7,
-			BlockIDCacheSize:             8,
-			FxOwnerCacheSize:             9,
-			ChecksumsEnabled:             true,
-			MempoolPruneFrequency:        time.Minute,
+			BlockCacheSize:                        1,
+			TxCacheSize:                           2,
+			TransformedSubnetTxCacheSize:          3,
+			RewardUTXOsCacheSize:                  5,
+			ChainCacheSize:                        6,
+			ChainDBCacheSize:                      7,
+			BlockIDCacheSize:                      8,
+			FxOwnerCacheSize:                      9,
+			VerifiedTxCacheSize:                   14,
+			ChecksumsEnabled:                      true,
+			MempoolPruneFrequency:                 time.Minute,
+			MempoolMinFeeBumpPercent:              25,
+			MempoolMinSize:                        11,
+			MempoolMaxSize:                        12,
+			MempoolMemoryLimit:                    13,
+			MempoolMaxSubnetStakerTxs:             18,
+			TxRetentionBlocks:                     10,
+			ValidatorSetConsistencyCheckFrequency: 5 * time.Minute,
+			ValidatorCheckpointInterval:           15,
+			ValidatorDiffRetentionBlocks:          16,
+			ValidatorSetCacheMaxValidators:        19,
+			ValidatorSetCacheMaxSpilledSets:       20,
+			CheckpointFile:                        "checkpoint",
+			UTXOJournalEnabled:                    true,
+			AsyncCommitQueueSize:                  17,
+			VerifyDatabase:                        true,
 		}
 		require.Equal(expected, ec)
 	})
@@ -160,8 +192,13 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			ChainDBCacheSize:             7,
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
+			VerifiedTxCacheSize:          DefaultExecutionConfig.VerifiedTxCacheSize,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        30 * time.Minute,
+			MempoolMinFeeBumpPercent:     10,
+			MempoolMinSize:               DefaultExecutionConfig.MempoolMinSize,
+			MempoolMaxSize:               DefaultExecutionConfig.MempoolMaxSize,
+			MempoolMaxSubnetStakerTxs:    DefaultExecutionConfig.MempoolMaxSubnetStakerTxs,
 		}
 		require.Equal(expected, ec)
 	})
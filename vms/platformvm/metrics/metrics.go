@@ -30,6 +30,9 @@ type Metrics interface {
 	// Mark that we computed a validator diff at a height with the given
 	// difference from the top.
 	AddValidatorSetsHeightDiff(uint64)
+	// Mark that a cached validator set didn't match the validator set
+	// recomputed from the state.
+	IncValidatorSetsInconsistent()
 	// Mark that this much stake is staked on the node.
 	SetLocalStake(uint64)
 	// Mark that this much stake is staked in the network.
@@ -91,6 +94,11 @@ func New(
 			Name:      "validator_sets_duration_sum",
 			Help:      "Total amount of time generating validator sets in nanoseconds",
 		}),
+		validatorSetsInconsistent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_sets_inconsistent",
+			Help:      "Total number of cached validator sets that didn't match the validator set recomputed from the state",
+		}),
 	}
 
 	errs := wrappers.Errs{Err: err}
@@ -107,6 +115,7 @@ func New(
 		registerer.Register(m.validatorSetsCached),
 		registerer.Register(m.validatorSetsHeightDiff),
 		registerer.Register(m.validatorSetsDuration),
+		registerer.Register(m.validatorSetsInconsistent),
 	)
 
 	return m, errs.Err
@@ -122,10 +131,11 @@ type metrics struct {
 	localStake             prometheus.Gauge
 	totalStake             prometheus.Gauge
 
-	validatorSetsCached     prometheus.Counter
-	validatorSetsCreated    prometheus.Counter
-	validatorSetsHeightDiff prometheus.Gauge
-	validatorSetsDuration   prometheus.Gauge
+	validatorSetsCached       prometheus.Counter
+	validatorSetsCreated      prometheus.Counter
+	validatorSetsHeightDiff   prometheus.Gauge
+	validatorSetsDuration     prometheus.Gauge
+	validatorSetsInconsistent prometheus.Counter
 }
 
 func (m *metrics) MarkAccepted(b block.Block) error {
@@ -148,6 +158,10 @@ func (m *metrics) AddValidatorSetsHeightDiff(d uint64) {
 	m.validatorSetsHeightDiff.Add(float64(d))
 }
 
+func (m *metrics) IncValidatorSetsInconsistent() {
+	m.validatorSetsInconsistent.Inc()
+}
+
 func (m *metrics) SetLocalStake(s uint64) {
 	m.localStake.Set(float64(s))
 }
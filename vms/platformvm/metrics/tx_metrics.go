@@ -142,3 +142,87 @@ func (m *txMetrics) BaseTx(*txs.BaseTx) error {
 	}).Inc()
 	return nil
 }
+
+func (m *txMetrics) ChangeDelegationFeeTx(*txs.ChangeDelegationFeeTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "change_delegation_fee",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) RotateValidatorNodeTx(*txs.RotateValidatorNodeTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "rotate_validator_node",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "add_capped_permissionless_validator",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) SetValidatorBLSKeyTx(*txs.SetValidatorBLSKeyTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "set_validator_bls_key",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) ReportMisbehaviorTx(*txs.ReportMisbehaviorTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "report_misbehavior",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) SetSubnetStakingParamsTx(*txs.SetSubnetStakingParamsTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "set_subnet_staking_params",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) ReduceSubnetValidatorWeightTx(*txs.ReduceSubnetValidatorWeightTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "reduce_subnet_validator_weight",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) AddSplitRewardsPermissionlessValidatorTx(*txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "add_split_rewards_permissionless_validator",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) AddValidatorWithSubnetsTx(*txs.AddValidatorWithSubnetsTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "add_validator_with_subnets",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) AddVestingPermissionlessValidatorTx(*txs.AddVestingPermissionlessValidatorTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "add_vesting_permissionless_validator",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) SetSubnetValidatorEpochTx(*txs.SetSubnetValidatorEpochTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "set_subnet_validator_epoch",
+	}).Inc()
+	return nil
+}
+
+func (m *txMetrics) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	m.numTxs.With(prometheus.Labels{
+		txLabel: "add_permissionless_validator_with_metadata",
+	}).Inc()
+	return nil
+}
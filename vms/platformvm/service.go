@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"maps"
 	"math"
+	"net"
 	"net/http"
 	"time"
 
+	"github.com/gorilla/rpc/v2/json2"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api"
@@ -25,10 +27,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -36,11 +40,17 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
 
 	avajson "github.com/ava-labs/avalanchego/utils/json"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	pmempool "github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 )
 
 const (
@@ -53,6 +63,9 @@ const (
 	// Max number of items allowed in a page
 	maxPageSize = 1024
 
+	// Max number of blocks that can be summarized by GetBlockStats
+	maxBlockStatsRange = 1024
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -63,6 +76,13 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errInvalidHeightRange         = errors.New("invalid height range")
+	errFeeExceedsMaxFee           = errors.New("fee exceeds max fee")
+	errNoStakingDuration          = errors.New("staking duration must be non-zero")
+	errNoStakingWeight            = errors.New("staking weight must be non-zero")
+	errValidatorNotDelegatable    = errors.New("validator doesn't accept delegations")
+	errDelegationOutlastsVdr      = errors.New("delegation would end after the validator")
+	errHeightWithoutSeed          = errors.New("height requires a seed")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -79,6 +99,7 @@ type stakerAttributes struct {
 	validationRewardsOwner fx.Owner
 	delegationRewardsOwner fx.Owner
 	proofOfPossession      *signer.ProofOfPossession
+	metadata               types.JSONByteSlice
 }
 
 // GetHeight returns the height of the last accepted block
@@ -421,6 +442,68 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	return nil
 }
 
+// GetUTXOsAtHeightArgs are the arguments for calling GetUTXOsAtHeight
+type GetUTXOsAtHeightArgs struct {
+	Addresses []string            `json:"addresses"`
+	Height    avajson.Uint64      `json:"height"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// GetUTXOsAtHeightReply is the response from calling GetUTXOsAtHeight
+type GetUTXOsAtHeightReply struct {
+	// Number of UTXOs returned
+	NumFetched avajson.Uint64 `json:"numFetched"`
+	// The UTXOs
+	UTXOs []string `json:"utxos"`
+	// Encoding specifies the encoding format the UTXOs are returned in
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetUTXOsAtHeight returns the UTXOs controlled by the given addresses after
+// the block at the given height was accepted
+func (s *Service) GetUTXOsAtHeight(_ *http.Request, args *GetUTXOsAtHeightArgs, response *GetUTXOsAtHeightReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getUTXOsAtHeight"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxos, err := s.vm.state.GetUTXOsAtHeight(addrSet, uint64(args.Height))
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	response.UTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
+		}
+		response.UTXOs[i], err = formatting.Encode(args.Encoding, bytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), args.Encoding, err)
+		}
+	}
+	response.NumFetched = avajson.Uint64(len(utxos))
+	response.Encoding = args.Encoding
+	return nil
+}
+
 // GetSubnetArgs are the arguments to GetSubnet
 type GetSubnetArgs struct {
 	// ID of the subnet to retrieve information about
@@ -706,11 +789,24 @@ func (s *Service) loadStakerTxAttributes(txID ids.ID) (*stakerAttributes, error)
 
 	switch stakerTx := tx.Unsigned.(type) {
 	case txs.ValidatorTx:
-		var pop *signer.ProofOfPossession
-		if staker, ok := stakerTx.(*txs.AddPermissionlessValidatorTx); ok {
-			if s, ok := staker.Signer.(*signer.ProofOfPossession); ok {
-				pop = s
-			}
+		var (
+			pop      *signer.ProofOfPossession
+			metadata types.JSONByteSlice
+		)
+		switch staker := stakerTx.(type) {
+		case *txs.AddPermissionlessValidatorTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+		case *txs.AddCappedPermissionlessValidatorTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+		case *txs.AddSplitRewardsPermissionlessValidatorTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+		case *txs.AddValidatorWithSubnetsTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+		case *txs.AddVestingPermissionlessValidatorTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+		case *txs.AddPermissionlessValidatorWithMetadataTx:
+			pop, _ = staker.Signer.(*signer.ProofOfPossession)
+			metadata = staker.Metadata
 		}
 
 		attr = &stakerAttributes{
@@ -718,6 +814,7 @@ func (s *Service) loadStakerTxAttributes(txID ids.ID) (*stakerAttributes, error)
 			validationRewardsOwner: stakerTx.ValidationRewardsOwner(),
 			delegationRewardsOwner: stakerTx.DelegationRewardsOwner(),
 			proofOfPossession:      pop,
+			metadata:               metadata,
 		}
 
 	case txs.DelegatorTx:
@@ -756,17 +853,13 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 	numNodeIDs := nodeIDs.Len()
 	targetStakers := make([]*state.Staker, 0, numNodeIDs)
 	if numNodeIDs == 0 { // Include all nodes
-		currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+		currentStakerIterator, err := s.vm.state.GetCurrentSubnetStakerIterator(args.SubnetID)
 		if err != nil {
 			return err
 		}
 		// TODO: avoid iterating over delegators here.
 		for currentStakerIterator.Next() {
-			staker := currentStakerIterator.Value()
-			if args.SubnetID != staker.SubnetID {
-				continue
-			}
-			targetStakers = append(targetStakers, staker)
+			targetStakers = append(targetStakers, currentStakerIterator.Value())
 		}
 		currentStakerIterator.Release()
 	} else {
@@ -860,6 +953,7 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 				DelegationRewardOwner:  delegationRewardOwner,
 				DelegationFee:          delegationFee,
 				Signer:                 attr.proofOfPossession,
+				Metadata:               attr.metadata,
 			}
 			reply.Validators = append(reply.Validators, vdr)
 
@@ -973,6 +1067,172 @@ func (s *Service) GetCurrentSupply(r *http.Request, args *GetCurrentSupplyArgs,
 	return nil
 }
 
+// GetDelegationRewardPreviewArgs are the arguments for calling
+// GetDelegationRewardPreview
+type GetDelegationRewardPreviewArgs struct {
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	// Weight is the amount that would be delegated
+	Weight avajson.Uint64 `json:"weight"`
+	// Duration is the number of seconds the delegation would last
+	Duration avajson.Uint64 `json:"duration"`
+}
+
+// GetDelegationRewardPreviewReply are the results from calling
+// GetDelegationRewardPreview
+type GetDelegationRewardPreviewReply struct {
+	// PotentialReward is the total reward of the delegation
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	// DelegatorReward is the part of [PotentialReward] paid to the delegator
+	DelegatorReward avajson.Uint64 `json:"delegatorReward"`
+	// DelegateeReward is the part of [PotentialReward] paid to the validator
+	DelegateeReward avajson.Uint64 `json:"delegateeReward"`
+	// DelegationFee is the percentage of [PotentialReward] paid to the
+	// validator
+	DelegationFee avajson.Float32 `json:"delegationFee"`
+	// EffectiveAPR is the annualized percentage return of [DelegatorReward]
+	EffectiveAPR avajson.Float64 `json:"effectiveAPR"`
+	// Supply is the current supply that the reward was calculated against
+	Supply avajson.Uint64 `json:"supply"`
+}
+
+// GetDelegationRewardPreview returns the reward that a delegation to the
+// current validator [args.NodeID], starting at the current chain time, would
+// receive if it were accepted now.
+func (s *Service) GetDelegationRewardPreview(_ *http.Request, args *GetDelegationRewardPreviewArgs, reply *GetDelegationRewardPreviewReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDelegationRewardPreview"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	switch {
+	case args.Weight == 0:
+		return errNoStakingWeight
+	case args.Duration == 0:
+		return errNoStakingDuration
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	validator, err := s.vm.state.GetCurrentValidator(args.SubnetID, args.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get current validator %s: %w", args.NodeID, err)
+	}
+
+	vdrTxIntf, _, err := s.vm.state.GetTx(validator.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get tx %s: %w", validator.TxID, err)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return fmt.Errorf("%w: %s", errValidatorNotDelegatable, args.NodeID)
+	}
+
+	var (
+		chainTime = s.vm.state.GetTimestamp()
+		duration  = time.Duration(args.Duration) * time.Second
+	)
+	if chainTime.Add(duration).After(validator.EndTime) {
+		return fmt.Errorf("%w: %s", errDelegationOutlastsVdr, validator.EndTime)
+	}
+
+	feeChanges, err := s.vm.state.GetDelegationFeeChanges(validator.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get delegation fee changes of %s: %w", validator.TxID, err)
+	}
+	shares := state.DelegationShares(vdrTx.Shares(), feeChanges, chainTime)
+
+	potentialReward, supply, err := s.calculatePotentialReward(args.SubnetID, uint64(args.Weight), duration)
+	if err != nil {
+		return err
+	}
+	delegateeReward, delegatorReward := reward.Split(potentialReward, shares)
+
+	yearsStaked := duration.Hours() / (365 * 24)
+	reply.PotentialReward = avajson.Uint64(potentialReward)
+	reply.DelegatorReward = avajson.Uint64(delegatorReward)
+	reply.DelegateeReward = avajson.Uint64(delegateeReward)
+	reply.DelegationFee = avajson.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
+	reply.EffectiveAPR = avajson.Float64(100 * float64(delegatorReward) / float64(args.Weight) / yearsStaked)
+	reply.Supply = avajson.Uint64(supply)
+	return nil
+}
+
+// EstimateStakingRewardArgs are the arguments for calling
+// EstimateStakingReward
+type EstimateStakingRewardArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// Weight is the amount that would be staked
+	Weight avajson.Uint64 `json:"weight"`
+	// Duration is the number of seconds the staker would stake for
+	Duration avajson.Uint64 `json:"duration"`
+}
+
+// EstimateStakingRewardReply are the results from calling
+// EstimateStakingReward
+type EstimateStakingRewardReply struct {
+	// Reward is the total reward the staker would receive
+	Reward avajson.Uint64 `json:"reward"`
+	// APR is the annualized percentage return of [Reward] on the weight
+	APR avajson.Float64 `json:"apr"`
+	// Supply is the current supply that the reward was calculated against
+	Supply avajson.Uint64 `json:"supply"`
+}
+
+// EstimateStakingReward returns the reward that a staker of [args.Weight] for
+// [args.Duration] would receive if it were accepted now. For a validator, the
+// reward doesn't include the delegation fees it would receive.
+func (s *Service) EstimateStakingReward(_ *http.Request, args *EstimateStakingRewardArgs, reply *EstimateStakingRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateStakingReward"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	switch {
+	case args.Weight == 0:
+		return errNoStakingWeight
+	case args.Duration == 0:
+		return errNoStakingDuration
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	duration := time.Duration(args.Duration) * time.Second
+	reward, supply, err := s.calculatePotentialReward(args.SubnetID, uint64(args.Weight), duration)
+	if err != nil {
+		return err
+	}
+
+	yearsStaked := duration.Hours() / (365 * 24)
+	reply.Reward = avajson.Uint64(reward)
+	reply.APR = avajson.Float64(100 * float64(reward) / float64(args.Weight) / yearsStaked)
+	reply.Supply = avajson.Uint64(supply)
+	return nil
+}
+
+// calculatePotentialReward returns the reward of a staker of [weight] on
+// [subnetID] for [duration] along with the current supply it was calculated
+// against.
+//
+// Invariant: [s.vm.ctx.Lock] must be held.
+func (s *Service) calculatePotentialReward(subnetID ids.ID, weight uint64, duration time.Duration) (uint64, uint64, error) {
+	supply, err := s.vm.state.GetCurrentSupply(subnetID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("fetching current supply failed: %w", err)
+	}
+
+	rewards, err := txexecutor.GetRewardsCalculator(s.vm.txExecutorBackend, s.vm.state, subnetID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rewards.Calculate(duration, weight, supply), supply, nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -981,6 +1241,15 @@ type SampleValidatorsArgs struct {
 	// ID of subnet to sample validators from
 	// If omitted, defaults to the primary network
 	SubnetID ids.ID `json:"subnetID"`
+
+	// Seed for stake-weighted deterministic sampling
+	// If omitted, the sample is random
+	Seed *avajson.Uint64 `json:"seed"`
+
+	// P-chain height of the validator set to sample from. Requires [Seed].
+	// If omitted, the current validator set is sampled, so samples with the
+	// same seed only match between nodes at the same tip.
+	Height *avajson.Uint64 `json:"height"`
 }
 
 // SampleValidatorsReply are the results from calling Sample
@@ -989,14 +1258,27 @@ type SampleValidatorsReply struct {
 }
 
 // SampleValidators returns a sampling of the list of current validators
-func (s *Service) SampleValidators(_ *http.Request, args *SampleValidatorsArgs, reply *SampleValidatorsReply) error {
+func (s *Service) SampleValidators(r *http.Request, args *SampleValidatorsArgs, reply *SampleValidatorsReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
 		zap.String("method", "sampleValidators"),
 		zap.Uint16("size", uint16(args.Size)),
 	)
 
-	sample, err := s.vm.Validators.Sample(args.SubnetID, int(args.Size))
+	var (
+		sample []ids.NodeID
+		err    error
+	)
+	switch {
+	case args.Height != nil && args.Seed == nil:
+		return errHeightWithoutSeed
+	case args.Height != nil:
+		sample, err = s.sampleValidatorsAtHeight(r.Context(), args.SubnetID, int(args.Size), uint64(*args.Seed), uint64(*args.Height))
+	case args.Seed != nil:
+		sample, err = s.vm.Validators.SampleWithSeed(args.SubnetID, int(args.Size), uint64(*args.Seed))
+	default:
+		sample, err = s.vm.Validators.Sample(args.SubnetID, int(args.Size))
+	}
 	if err != nil {
 		return fmt.Errorf("sampling %s errored with %w", args.SubnetID, err)
 	}
@@ -1010,6 +1292,22 @@ func (s *Service) SampleValidators(_ *http.Request, args *SampleValidatorsArgs,
 	return nil
 }
 
+func (s *Service) sampleValidatorsAtHeight(
+	ctx context.Context,
+	subnetID ids.ID,
+	size int,
+	seed uint64,
+	height uint64,
+) ([]ids.NodeID, error) {
+	s.vm.ctx.Lock.Lock()
+	vdrs, err := s.vm.GetValidatorSet(ctx, height, subnetID)
+	s.vm.ctx.Lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set: %w", err)
+	}
+	return validators.SampleWithSeed(vdrs, size, seed)
+}
+
 // GetBlockchainStatusArgs is the arguments for calling GetBlockchainStatus
 // [BlockchainID] is the ID of or an alias of the blockchain to get the status of.
 type GetBlockchainStatusArgs struct {
@@ -1279,7 +1577,195 @@ func (s *Service) GetBlockchains(_ *http.Request, _ *struct{}, response *GetBloc
 	return nil
 }
 
-func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.JSONTxID) error {
+// GetSubnetChainsArgs are the arguments for calling GetSubnetChains
+type GetSubnetChainsArgs struct {
+	// ID of the subnet to list the chains of. If omitted, the chains of the
+	// primary network are listed.
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// APISubnetChain is the representation of a chain created under a subnet
+type APISubnetChain struct {
+	// Blockchain's ID. This is also the ID of the tx that created the chain.
+	ID ids.ID `json:"id"`
+
+	// Blockchain's (non-unique) human-readable name
+	Name string `json:"name"`
+
+	// Virtual Machine the blockchain runs
+	VMID ids.ID `json:"vmID"`
+
+	// Feature extensions the blockchain uses
+	FxIDs []ids.ID `json:"fxIDs"`
+
+	// Hash of the blockchain's genesis data
+	GenesisHash ids.ID `json:"genesisHash"`
+
+	// Unix time of the block that created the blockchain. Omitted if the
+	// blockchain was created before creation times were recorded.
+	CreationTime *avajson.Uint64 `json:"creationTime,omitempty"`
+}
+
+// GetSubnetChainsReply is the response from calling GetSubnetChains
+type GetSubnetChainsReply struct {
+	Chains []APISubnetChain `json:"chains"`
+}
+
+// GetSubnetChains returns the chains that were created under a subnet.
+func (s *Service) GetSubnetChains(_ *http.Request, args *GetSubnetChainsArgs, reply *GetSubnetChainsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetChains"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	chains, err := s.vm.state.GetChains(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't retrieve chains for subnet %q: %w",
+			args.SubnetID,
+			err,
+		)
+	}
+
+	reply.Chains = make([]APISubnetChain, len(chains))
+	for i, chainTx := range chains {
+		chainID := chainTx.ID()
+		chain, ok := chainTx.Unsigned.(*txs.CreateChainTx)
+		if !ok {
+			return fmt.Errorf("expected tx type *txs.CreateChainTx but got %T", chainTx.Unsigned)
+		}
+
+		apiChain := APISubnetChain{
+			ID:          chainID,
+			Name:        chain.ChainName,
+			VMID:        chain.VMID,
+			FxIDs:       chain.FxIDs,
+			GenesisHash: hashing.ComputeHash256Array(chain.GenesisData),
+		}
+		switch creationTime, err := s.vm.state.GetChainCreationTime(args.SubnetID, chainID); err {
+		case nil:
+			unixTime := avajson.Uint64(creationTime.Unix())
+			apiChain.CreationTime = &unixTime
+		case database.ErrNotFound:
+			// The chain was created before creation times were recorded.
+		default:
+			return fmt.Errorf(
+				"couldn't retrieve creation time of chain %q: %w",
+				chainID,
+				err,
+			)
+		}
+		reply.Chains[i] = apiChain
+	}
+	return nil
+}
+
+// IssueTxArgs are the arguments for calling IssueTx
+type IssueTxArgs struct {
+	api.FormattedTx
+	// MaxFee, if provided, is the maximum amount of AVAX, in nAVAX, that the
+	// tx may burn. If the tx burns more, it is not issued.
+	MaxFee *avajson.Uint64 `json:"maxFee,omitempty"`
+}
+
+// StakerLimitErrorData is included in the error returned by IssueTx when the tx
+// violates a staking limit. Durations are reported in seconds.
+type StakerLimitErrorData struct {
+	Code  txexecutor.StakerLimitCode `json:"code"`
+	Got   avajson.Uint64             `json:"got"`
+	Limit avajson.Uint64             `json:"limit"`
+}
+
+// GetStakerLimitError returns the staking limit that was violated if [err] was
+// returned by IssueTx because the tx violated a staking limit.
+func GetStakerLimitError(err error) (*StakerLimitErrorData, bool) {
+	var rpcErr *json2.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Data == nil {
+		return nil, false
+	}
+	dataBytes, err := json.Marshal(rpcErr.Data)
+	if err != nil {
+		return nil, false
+	}
+	data := &StakerLimitErrorData{}
+	if err := json.Unmarshal(dataBytes, data); err != nil || data.Code == "" {
+		return nil, false
+	}
+	return data, true
+}
+
+// FlowCheckErrorData is included in the error returned by IssueTx, and in the
+// reply of CheckTx, when the inputs of the tx can't be spent to produce its
+// outputs.
+type FlowCheckErrorData struct {
+	// FailedInput is the index of the input whose credential failed
+	// verification, if any.
+	FailedInput *avajson.Uint32 `json:"failedInput,omitempty"`
+	// MissingUnlocked is the amount of each asset by which the unlocked
+	// outputs exceed the unlocked inputs.
+	MissingUnlocked map[ids.ID]avajson.Uint64 `json:"missingUnlocked,omitempty"`
+	// MissingLocked is the amount of each asset by which the locked outputs
+	// exceed the locked inputs, after the unlocked inputs are used to cover
+	// the difference.
+	MissingLocked map[ids.ID]avajson.Uint64 `json:"missingLocked,omitempty"`
+}
+
+// GetFlowCheckError returns the reason the flow check failed if [err] was
+// returned by IssueTx because the inputs of the tx can't be spent to produce
+// its outputs.
+func GetFlowCheckError(err error) (*FlowCheckErrorData, bool) {
+	var rpcErr *json2.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Data == nil {
+		return nil, false
+	}
+	dataBytes, err := json.Marshal(rpcErr.Data)
+	if err != nil {
+		return nil, false
+	}
+	data := &FlowCheckErrorData{}
+	if err := json.Unmarshal(dataBytes, data); err != nil {
+		return nil, false
+	}
+	if data.FailedInput == nil && len(data.MissingUnlocked) == 0 && len(data.MissingLocked) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// newFlowCheckErrorData returns the reason the flow check failed if [err] is a
+// flow check failure.
+func newFlowCheckErrorData(err error) (*FlowCheckErrorData, bool) {
+	var flowErr *utxo.FlowCheckError
+	if !errors.As(err, &flowErr) {
+		return nil, false
+	}
+	data := &FlowCheckErrorData{
+		MissingUnlocked: toJSONAmounts(flowErr.MissingUnlocked),
+		MissingLocked:   toJSONAmounts(flowErr.MissingLocked),
+	}
+	if flowErr.FailedInput >= 0 {
+		failedInput := avajson.Uint32(flowErr.FailedInput)
+		data.FailedInput = &failedInput
+	}
+	return data, true
+}
+
+func toJSONAmounts(amounts map[ids.ID]uint64) map[ids.ID]avajson.Uint64 {
+	if len(amounts) == 0 {
+		return nil
+	}
+	jsonAmounts := make(map[ids.ID]avajson.Uint64, len(amounts))
+	for assetID, amount := range amounts {
+		jsonAmounts[assetID] = avajson.Uint64(amount)
+	}
+	return jsonAmounts
+}
+
+func (s *Service) IssueTx(_ *http.Request, args *IssueTxArgs, response *api.JSONTxID) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
 		zap.String("method", "issueTx"),
@@ -1294,14 +1780,292 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 		return fmt.Errorf("couldn't parse tx: %w", err)
 	}
 
+	if args.MaxFee != nil {
+		fee, err := pmempool.BurnedAVAX(s.vm.ctx.AVAXAssetID, tx)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate fee: %w", err)
+		}
+		if maxFee := uint64(*args.MaxFee); fee > maxFee {
+			return fmt.Errorf("%w: %d > %d", errFeeExceedsMaxFee, fee, maxFee)
+		}
+	}
+
 	if err := s.vm.issueTxFromRPC(tx); err != nil {
-		return fmt.Errorf("couldn't issue tx: %w", err)
+		err = fmt.Errorf("couldn't issue tx: %w", err)
+
+		var limitErr *txexecutor.StakerLimitError
+		if errors.As(err, &limitErr) {
+			return &json2.Error{
+				Code:    json2.E_SERVER,
+				Message: err.Error(),
+				Data: &StakerLimitErrorData{
+					Code:  limitErr.Code,
+					Got:   avajson.Uint64(limitErr.Got),
+					Limit: avajson.Uint64(limitErr.Limit),
+				},
+			}
+		}
+		if data, ok := newFlowCheckErrorData(err); ok {
+			return &json2.Error{
+				Code:    json2.E_SERVER,
+				Message: err.Error(),
+				Data:    data,
+			}
+		}
+		return err
+	}
+
+	response.TxID = tx.ID()
+	return nil
+}
+
+// CheckTxArgs are the arguments for calling CheckTx
+type CheckTxArgs struct {
+	api.FormattedTx
+	// Time is the unix time to verify the tx at. If 0, the tx is verified at
+	// the time of the next block.
+	Time avajson.Uint64 `json:"time"`
+}
+
+// CheckTxReply is the response from calling CheckTx
+type CheckTxReply struct {
+	// Valid is true if the tx would currently be accepted into the mempool
+	Valid bool `json:"valid"`
+	// Reason the tx is invalid. Only non-empty if Valid is false.
+	Reason string `json:"reason,omitempty"`
+	// ForkGate is the network upgrade whose activation status rejects the tx.
+	// Only non-empty if the tx is invalid because of a fork gate.
+	ForkGate string `json:"forkGate,omitempty"`
+	// FlowCheck reports why the inputs of the tx can't be spent to produce its
+	// outputs. Only non-nil if the tx is invalid because of a failed flow
+	// check.
+	FlowCheck *FlowCheckErrorData `json:"flowCheck,omitempty"`
+}
+
+// CheckTx verifies a signed tx against the preferred state and the currently
+// active fork rules without issuing it. If a time is provided, the tx is
+// instead verified against the fork rules active at that time. If the tx is
+// invalid because an upgrade is, or isn't yet, active, the upgrade is
+// reported.
+func (s *Service) CheckTx(_ *http.Request, args *CheckTxArgs, reply *CheckTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "checkTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if args.Time == 0 {
+		err = s.vm.manager.VerifyTx(tx)
+	} else {
+		err = s.vm.manager.VerifyTxAtTime(tx, time.Unix(int64(args.Time), 0))
+	}
+	switch {
+	case err == nil:
+		reply.Valid = true
+		return nil
+	case errors.Is(err, blockexecutor.ErrChainNotSynced),
+		errors.Is(err, blockexecutor.ErrTimestampBeforeChainTime):
+		return err
+	}
+
+	reply.Reason = err.Error()
+	reply.ForkGate, _ = txexecutor.ForkGate(err)
+	reply.FlowCheck, _ = newFlowCheckErrorData(err)
+	return nil
+}
+
+// ScheduleTxArgs are the arguments for calling ScheduleTx
+type ScheduleTxArgs struct {
+	api.FormattedTx
+	// IssueTime is the unix time at or after which the chain time must be for
+	// the tx to be issued
+	IssueTime avajson.Uint64 `json:"issueTime"`
+}
+
+// ScheduleTx registers a signed staking tx to be issued by this node once the
+// chain time reaches the requested issue time.
+//
+// The tx is only syntactically verified when it is scheduled, so the number of
+// txs that each caller can schedule is limited.
+func (s *Service) ScheduleTx(r *http.Request, args *ScheduleTxArgs, response *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "scheduleTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	if err := tx.SyntacticVerify(s.vm.ctx); err != nil {
+		return fmt.Errorf("couldn't verify tx: %w", err)
+	}
+
+	issueTime := time.Unix(int64(args.IssueTime), 0)
+	if err := s.vm.scheduler.Add(tx, issueTime, requestCaller(r)); err != nil {
+		return fmt.Errorf("couldn't schedule tx: %w", err)
 	}
 
 	response.TxID = tx.ID()
 	return nil
 }
 
+// CancelScheduledTx removes a tx that was previously registered with
+// ScheduleTx before it is issued.
+func (s *Service) CancelScheduledTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "cancelScheduledTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	return s.vm.scheduler.Remove(args.TxID)
+}
+
+// requestCaller returns the host that sent [r]. Txs scheduled by the same host
+// count towards the same limit.
+func requestCaller(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// APIScheduledTx is a tx that is waiting to be issued
+type APIScheduledTx struct {
+	TxID      ids.ID         `json:"txID"`
+	IssueTime avajson.Uint64 `json:"issueTime"`
+	// LastIssueError is the error of the last failed attempt to issue the tx,
+	// if any
+	LastIssueError string `json:"lastIssueError,omitempty"`
+}
+
+// GetScheduledTxsReply is the response from calling GetScheduledTxs
+type GetScheduledTxsReply struct {
+	Txs []APIScheduledTx `json:"txs"`
+}
+
+// GetScheduledTxs returns the txs that are waiting to be issued, ordered by
+// their issue time.
+func (s *Service) GetScheduledTxs(_ *http.Request, _ *struct{}, response *GetScheduledTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getScheduledTxs"),
+	)
+
+	scheduledTxs := s.vm.scheduler.List()
+	response.Txs = make([]APIScheduledTx, len(scheduledTxs))
+	for i, scheduledTx := range scheduledTxs {
+		response.Txs[i] = APIScheduledTx{
+			TxID:      scheduledTx.Tx.ID(),
+			IssueTime: avajson.Uint64(scheduledTx.IssueTime.Unix()),
+		}
+		if scheduledTx.LastIssueErr != nil {
+			response.Txs[i].LastIssueError = scheduledTx.LastIssueErr.Error()
+		}
+	}
+	return nil
+}
+
+// APIPendingTx is a tx that is waiting in the mempool to be included in a block
+type APIPendingTx struct {
+	TxID ids.ID `json:"txID"`
+	// Fee is the amount of nAVAX burned by the tx
+	Fee  avajson.Uint64 `json:"fee"`
+	Size avajson.Uint64 `json:"size"`
+	// BytesAhead is the number of tx bytes in the mempool that will be packed
+	// before this tx
+	BytesAhead avajson.Uint64 `json:"bytesAhead"`
+	// BlocksAhead is the number of full blocks that are expected to be built
+	// before the block that includes this tx
+	BlocksAhead avajson.Uint64 `json:"blocksAhead"`
+	// InclusionProbability is the estimated likelihood, in [0, 1], of this tx
+	// being included in the next block. Because the packing order is known,
+	// this is currently either 1 or 0.
+	InclusionProbability avajson.Float64 `json:"inclusionProbability"`
+}
+
+// GetPendingTxsReply is the response from calling GetPendingTxs
+type GetPendingTxsReply struct {
+	Txs []APIPendingTx `json:"txs"`
+}
+
+// GetPendingTxs returns the txs in the mempool, in the order they will be
+// packed into blocks, annotated with an estimate of whether they will be
+// included in the next block.
+//
+// Txs are packed in the order they were added to the mempool, so the estimate
+// only depends on the number of bytes ahead of a tx relative to the capacity of
+// a block. It assumes that every tx ahead of it remains valid.
+func (s *Service) GetPendingTxs(_ *http.Request, _ *struct{}, response *GetPendingTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getPendingTxs"),
+	)
+
+	var (
+		pendingTxs     []*txs.Tx
+		bytesAhead     int
+		blocksAhead    int
+		remainingBytes = blockbuilder.TargetBlockSize
+	)
+	s.vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		pendingTxs = append(pendingTxs, tx)
+		return true
+	})
+
+	response.Txs = make([]APIPendingTx, len(pendingTxs))
+	for i, tx := range pendingTxs {
+		fee, err := pmempool.BurnedAVAX(s.vm.ctx.AVAXAssetID, tx)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate fee of %s: %w", tx.ID(), err)
+		}
+
+		// Block packing stops at the first tx that doesn't fit in the
+		// remaining space, so such a tx starts the next block.
+		txSize := tx.Size()
+		if txSize > remainingBytes {
+			blocksAhead++
+			remainingBytes = blockbuilder.TargetBlockSize
+		}
+		remainingBytes -= txSize
+
+		var inclusionProbability float64
+		if blocksAhead == 0 {
+			inclusionProbability = 1
+		}
+		response.Txs[i] = APIPendingTx{
+			TxID:                 tx.ID(),
+			Fee:                  avajson.Uint64(fee),
+			Size:                 avajson.Uint64(txSize),
+			BytesAhead:           avajson.Uint64(bytesAhead),
+			BlocksAhead:          avajson.Uint64(blocksAhead),
+			InclusionProbability: avajson.Float64(inclusionProbability),
+		}
+		bytesAhead += txSize
+	}
+	return nil
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1354,7 +2118,7 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	defer s.vm.ctx.Lock.Unlock()
 
 	_, txStatus, err := s.vm.state.GetTx(args.TxID)
-	if err == nil { // Found the status. Report it.
+	if err == nil || errors.Is(err, state.ErrTxPruned) { // Found the status. Report it.
 		response.Status = txStatus
 		return nil
 	}
@@ -1508,6 +2272,100 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	return nil
 }
 
+// GetStakedOutputsArgs are the arguments for calling GetStakedOutputs.
+type GetStakedOutputsArgs struct {
+	api.JSONAddresses
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// APIStakedOutput is an output that is locked as stake until the staker that
+// staked it is removed.
+type APIStakedOutput struct {
+	// TxID is the ID of the tx that added the staker.
+	TxID ids.ID `json:"txID"`
+	// OutputIndex is the index of the UTXO, produced by [TxID], that the
+	// output will be returned as once it is unlocked.
+	OutputIndex avajson.Uint32 `json:"outputIndex"`
+	NodeID      ids.NodeID     `json:"nodeID"`
+	SubnetID    ids.ID         `json:"subnetID"`
+	// Pending is true if the staker hasn't started staking yet.
+	Pending bool `json:"pending"`
+	// UnlockTime is the time the staker will be removed, which unlocks the
+	// output.
+	UnlockTime avajson.Uint64 `json:"unlockTime"`
+	AssetID    ids.ID         `json:"assetID"`
+	Amount     avajson.Uint64 `json:"amount"`
+	// Output is the string representation of the avax.TransferableOutput.
+	Output string `json:"output"`
+}
+
+// GetStakedOutputsReply is the response from calling GetStakedOutputs.
+type GetStakedOutputsReply struct {
+	Outputs []APIStakedOutput `json:"outputs"`
+	// Encoding of the [Output] of each of [Outputs]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetStakedOutputs returns the outputs owned by [args.Addresses] that are
+// currently locked as stake by current or pending stakers.
+func (s *Service) GetStakedOutputs(_ *http.Request, args *GetStakedOutputsArgs, response *GetStakedOutputsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakedOutputs"),
+	)
+
+	if len(args.Addresses) > maxGetStakeAddrs {
+		return fmt.Errorf("%d addresses provided but this method can take at most %d", len(args.Addresses), maxGetStakeAddrs)
+	}
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer currentStakerIterator.Release()
+
+	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer pendingStakerIterator.Release()
+
+	response.Outputs = []APIStakedOutput{}
+	addStakedOutputs := func(it state.StakerIterator, pending bool) error {
+		for it.Next() {
+			staker := it.Value()
+
+			tx, _, err := s.vm.state.GetTx(staker.TxID)
+			if err != nil {
+				return err
+			}
+
+			outputs, err := getStakedOutputs(tx, staker, addrs, pending, args.Encoding)
+			if err != nil {
+				return err
+			}
+			response.Outputs = append(response.Outputs, outputs...)
+		}
+		return nil
+	}
+	if err := addStakedOutputs(currentStakerIterator, false); err != nil {
+		return err
+	}
+	if err := addStakedOutputs(pendingStakerIterator, true); err != nil {
+		return err
+	}
+	response.Encoding = args.Encoding
+	return nil
+}
+
 // GetMinStakeArgs are the arguments for calling GetMinStake.
 type GetMinStakeArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1748,6 +2606,51 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetValidatorPublicKeyArgs are the arguments for calling
+// GetValidatorPublicKey
+type GetValidatorPublicKeyArgs struct {
+	NodeID ids.NodeID     `json:"nodeID"`
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetValidatorPublicKeyReply is the response from calling
+// GetValidatorPublicKey
+type GetValidatorPublicKeyReply struct {
+	// PublicKey is the hex encoded compressed BLS public key, or nil if the
+	// node didn't have a public key at the requested height.
+	PublicKey *string `json:"publicKey"`
+}
+
+// GetValidatorPublicKey returns the BLS public key that a primary network
+// validator had registered at the specified height.
+func (s *Service) GetValidatorPublicKey(r *http.Request, args *GetValidatorPublicKeyArgs, reply *GetValidatorPublicKeyReply) error {
+	height := uint64(args.Height)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorPublicKey"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("height", height),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	pk, err := s.vm.validatorManager.GetValidatorPublicKey(r.Context(), args.NodeID, height)
+	if err != nil {
+		return fmt.Errorf("failed to get validator public key: %w", err)
+	}
+	if pk == nil {
+		return nil
+	}
+
+	pkStr, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToCompressedBytes(pk))
+	if err != nil {
+		return err
+	}
+	reply.PublicKey = &pkStr
+	return nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1768,7 +2671,10 @@ func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *ap
 	var result any
 	if args.Encoding == formatting.JSON {
 		block.InitCtx(s.vm.ctx)
-		result = block
+		result, err = s.jsonBlock(block)
+		if err != nil {
+			return fmt.Errorf("couldn't get summary of block %s: %w", block.ID(), err)
+		}
 	} else {
 		result, err = formatting.Encode(args.Encoding, block.Bytes())
 		if err != nil {
@@ -1810,7 +2716,10 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	var result any
 	if args.Encoding == formatting.JSON {
 		block.InitCtx(s.vm.ctx)
-		result = block
+		result, err = s.jsonBlock(block)
+		if err != nil {
+			return fmt.Errorf("couldn't get summary of block %s: %w", block.ID(), err)
+		}
 	} else {
 		result, err = formatting.Encode(args.Encoding, block.Bytes())
 		if err != nil {
@@ -1822,6 +2731,91 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	return err
 }
 
+// jsonBlock returns the JSON representation of [blk]. If [blk] was accepted
+// with a summary, the summary is included under the "summary" key.
+func (s *Service) jsonBlock(blk block.Block) (any, error) {
+	height := blk.Height()
+	acceptedID, err := s.vm.state.GetBlockIDAtHeight(height)
+	if err == database.ErrNotFound {
+		return blk, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if acceptedID != blk.ID() {
+		return blk, nil
+	}
+
+	summary, err := s.vm.state.GetBlockSummary(height)
+	if err == database.ErrNotFound {
+		return blk, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blkJSON, err := json.Marshal(blk)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(blkJSON, &fields); err != nil {
+		return nil, err
+	}
+	fields["summary"], err = json.Marshal(summary)
+	return fields, err
+}
+
+// GetBlockStatsArgs are the arguments for calling GetBlockStats
+type GetBlockStatsArgs struct {
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+}
+
+// GetBlockStatsReply is the response from calling GetBlockStats
+type GetBlockStatsReply struct {
+	// NumBlocks is the number of blocks in the range that have a summary
+	NumBlocks avajson.Uint64 `json:"numBlocks"`
+	state.BlockSummary
+}
+
+// GetBlockStats returns the sum of the summaries of the accepted blocks from
+// [StartHeight] to [EndHeight], inclusive. Blocks without a summary are
+// skipped.
+func (s *Service) GetBlockStats(_ *http.Request, args *GetBlockStatsArgs, reply *GetBlockStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlockStats"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if args.EndHeight < args.StartHeight {
+		return fmt.Errorf("%w: start height %d > end height %d", errInvalidHeightRange, args.StartHeight, args.EndHeight)
+	}
+	if args.EndHeight-args.StartHeight >= maxBlockStatsRange {
+		return fmt.Errorf("%w: more than %d blocks requested", errInvalidHeightRange, maxBlockStatsRange)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	for height := uint64(args.StartHeight); height <= uint64(args.EndHeight); height++ {
+		summary, err := s.vm.state.GetBlockSummary(height)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get summary of block at height %d: %w", height, err)
+		}
+		if err := reply.BlockSummary.Add(summary); err != nil {
+			return err
+		}
+		reply.NumBlocks++
+	}
+	return nil
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*avajson.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
@@ -1854,6 +2848,71 @@ func (s *Service) getAPIOwner(owner *secp256k1fx.OutputOwners) (*platformapi.Own
 	return apiOwner, nil
 }
 
+// getStakedOutputs returns the stake outputs of [tx], which added [staker],
+// that are owned by any of [addrs].
+func getStakedOutputs(
+	tx *txs.Tx,
+	staker *state.Staker,
+	addrs set.Set[ids.ShortID],
+	pending bool,
+	encoding formatting.Encoding,
+) ([]APIStakedOutput, error) {
+	stakerTx, ok := tx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return nil, nil
+	}
+
+	// Stake outputs are returned after the outputs of the tx.
+	var (
+		numOutputs = len(stakerTx.Outputs())
+		stake      = stakerTx.Stake()
+		outputs    []APIStakedOutput
+	)
+	for i, output := range stake {
+		out := output.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		secpOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		owned := false
+		for _, addr := range secpOut.Addrs {
+			if addrs.Contains(addr) {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			continue
+		}
+
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, output)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't serialize output %s: %w", output.ID, err)
+		}
+		outputStr, err := formatting.Encode(encoding, bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode output %s as %s: %w", output.ID, encoding, err)
+		}
+
+		outputs = append(outputs, APIStakedOutput{
+			TxID:        staker.TxID,
+			OutputIndex: avajson.Uint32(numOutputs + i),
+			NodeID:      staker.NodeID,
+			SubnetID:    staker.SubnetID,
+			Pending:     pending,
+			UnlockTime:  avajson.Uint64(staker.EndTime.Unix()),
+			AssetID:     output.AssetID(),
+			Amount:      avajson.Uint64(secpOut.Amt),
+			Output:      outputStr,
+		})
+	}
+	return outputs, nil
+}
+
 // Takes in a staker and a set of addresses
 // Returns:
 // 1) The total amount staked by addresses in [addrs]
@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/gorilla/rpc/v2/json2"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -27,14 +29,17 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -362,6 +367,319 @@ func TestGetTx(t *testing.T) {
 	}
 }
 
+func TestIssueTxMaxFee(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+	fee := service.vm.Config.GetCreateBlockchainTxFee(service.vm.clock.Time())
+
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	maxFee := avajson.Uint64(fee - 1)
+	args := &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		MaxFee: &maxFee,
+	}
+	var reply api.JSONTxID
+	err = service.IssueTx(nil, args, &reply)
+	require.ErrorIs(err, errFeeExceedsMaxFee)
+	_, ok := service.vm.Builder.Get(tx.ID())
+	require.False(ok)
+
+	maxFee = avajson.Uint64(fee)
+	require.NoError(service.IssueTx(nil, args, &reply))
+	require.Equal(tx.ID(), reply.TxID)
+	_, ok = service.vm.Builder.Get(tx.ID())
+	require.True(ok)
+}
+
+func TestGetPendingTxs(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+
+	var reply GetPendingTxsReply
+	require.NoError(service.GetPendingTxs(nil, nil, &reply))
+	require.Empty(reply.Txs)
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+	fee := service.vm.Config.GetCreateBlockchainTxFee(service.vm.clock.Time())
+	require.NoError(service.vm.Builder.Add(tx))
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetPendingTxs(nil, nil, &reply))
+	require.Equal([]APIPendingTx{
+		{
+			TxID:                 tx.ID(),
+			Fee:                  avajson.Uint64(fee),
+			Size:                 avajson.Uint64(tx.Size()),
+			BytesAhead:           0,
+			BlocksAhead:          0,
+			InclusionProbability: 1,
+		},
+	}, reply.Txs)
+}
+
+func TestCheckTx(t *testing.T) {
+	service, _, txBuilder := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	createChainTx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(t, err)
+
+	// AddValidatorTxs were replaced by AddPermissionlessValidatorTxs in
+	// Durango.
+	startTime := service.vm.clock.Time().Add(time.Second)
+	addValidatorTx, err := txBuilder.NewAddValidatorTx(
+		&txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+			Wght:   service.vm.MinValidatorStake,
+		},
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+	)
+	require.NoError(t, err)
+	service.vm.ctx.Lock.Unlock()
+
+	tests := []struct {
+		name          string
+		tx            *txs.Tx
+		expectedReply CheckTxReply
+	}{
+		{
+			name: "valid",
+			tx:   createChainTx,
+			expectedReply: CheckTxReply{
+				Valid: true,
+			},
+		},
+		{
+			name: "rejected by fork gate",
+			tx:   addValidatorTx,
+			expectedReply: CheckTxReply{
+				Reason:   txexecutor.ErrAddValidatorTxPostDurango.Error(),
+				ForkGate: txexecutor.DurangoFork,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txStr, err := formatting.Encode(formatting.Hex, test.tx.Bytes())
+			require.NoError(err)
+
+			var reply CheckTxReply
+			require.NoError(service.CheckTx(nil, &CheckTxArgs{
+				FormattedTx: api.FormattedTx{
+					Tx:       txStr,
+					Encoding: formatting.Hex,
+				},
+			}, &reply))
+			require.Equal(test.expectedReply, reply)
+		})
+	}
+
+	// Checking a tx doesn't issue it.
+	_, ok := service.vm.Builder.Get(createChainTx.ID())
+	require.False(t, ok)
+}
+
+func TestCheckTxAtTime(t *testing.T) {
+	require := require.New(t)
+
+	vm, txBuilder, _, _ := defaultVM(t, cortina)
+	service := &Service{
+		vm:          vm,
+		addrManager: avax.NewAddressManager(vm.ctx),
+	}
+
+	vm.ctx.Lock.Lock()
+	chainTime := vm.state.GetTimestamp()
+	durangoTime := chainTime.Add(time.Hour)
+	vm.Config.UpgradeConfig.DurangoTime = durangoTime
+
+	// TransferSubnetOwnershipTxs were introduced in Durango.
+	transferSubnetOwnershipTx, err := txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+	vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, transferSubnetOwnershipTx.Bytes())
+	require.NoError(err)
+
+	checkTxAt := func(timestamp time.Time) (CheckTxReply, error) {
+		var reply CheckTxReply
+		err := service.CheckTx(nil, &CheckTxArgs{
+			FormattedTx: api.FormattedTx{
+				Tx:       txStr,
+				Encoding: formatting.Hex,
+			},
+			Time: avajson.Uint64(timestamp.Unix()),
+		}, &reply)
+		return reply, err
+	}
+
+	reply, err := checkTxAt(durangoTime.Add(-time.Second))
+	require.NoError(err)
+	require.Equal(CheckTxReply{
+		Reason:   txexecutor.ErrDurangoUpgradeNotActive.Error(),
+		ForkGate: txexecutor.DurangoFork,
+	}, reply)
+
+	reply, err = checkTxAt(durangoTime)
+	require.NoError(err)
+	require.Equal(CheckTxReply{
+		Valid: true,
+	}, reply)
+
+	_, err = checkTxAt(chainTime.Add(-time.Second))
+	require.ErrorIs(err, blockexecutor.ErrTimestampBeforeChainTime)
+}
+
+func TestIssueTxStakerLimitError(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	weight := service.vm.MinValidatorStake - 1
+	tx, err := txBuilder.NewAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				Start:  uint64(service.vm.clock.Time().Unix()),
+				End:    uint64(service.vm.clock.Time().Add(defaultMinStakingDuration).Unix()),
+				Wght:   weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		signer.NewProofOfPossession(sk),
+		service.vm.ctx.AVAXAssetID,
+		rewardsOwner,
+		rewardsOwner,
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	args := &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+	}
+	var reply api.JSONTxID
+	err = service.IssueTx(nil, args, &reply)
+	require.IsType(&json2.Error{}, err)
+
+	data, ok := GetStakerLimitError(err)
+	require.True(ok)
+	require.Equal(&StakerLimitErrorData{
+		Code:  txexecutor.StakerLimitWeightTooSmall,
+		Got:   avajson.Uint64(weight),
+		Limit: avajson.Uint64(service.vm.MinValidatorStake),
+	}, data)
+}
+
+func TestIssueTxFlowCheckError(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := txBuilder.NewCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		[]*secp256k1.PrivateKey{keys[0]},
+	)
+	require.NoError(err)
+
+	// Drop the signature of the first input.
+	tx.Creds[0] = &secp256k1fx.Credential{}
+	require.NoError(tx.Initialize(txs.Codec))
+
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	args := &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+	}
+	var reply api.JSONTxID
+	err = service.IssueTx(nil, args, &reply)
+	require.IsType(&json2.Error{}, err)
+
+	_, ok := GetStakerLimitError(err)
+	require.False(ok)
+
+	data, ok := GetFlowCheckError(err)
+	require.True(ok)
+	failedInput := avajson.Uint32(0)
+	require.Equal(&FlowCheckErrorData{
+		FailedInput: &failedInput,
+	}, data)
+}
+
 func TestGetBalance(t *testing.T) {
 	require := require.New(t)
 	service, _, _ := defaultService(t)
@@ -576,6 +894,91 @@ func TestGetStake(t *testing.T) {
 	require.Equal(stakeAmount+oldStake, outputs[0].Out.Amount()+outputs[1].Out.Amount()+outputs[2].Out.Amount())
 }
 
+func TestGetStakedOutputs(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+
+	addr, err := service.addrManager.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+	args := GetStakedOutputsArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: []string{addr},
+		},
+		Encoding: formatting.Hex,
+	}
+	response := GetStakedOutputsReply{}
+	require.NoError(service.GetStakedOutputs(nil, &args, &response))
+	require.Len(response.Outputs, 1)
+
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, genesisNodeIDs[0])
+	require.NoError(err)
+
+	output := response.Outputs[0]
+	require.Equal(validator.TxID, output.TxID)
+	require.Equal(genesisNodeIDs[0], output.NodeID)
+	require.Equal(constants.PrimaryNetworkID, output.SubnetID)
+	require.False(output.Pending)
+	require.Equal(uint64(validator.EndTime.Unix()), uint64(output.UnlockTime))
+	require.Equal(service.vm.ctx.AVAXAssetID, output.AssetID)
+	require.Equal(defaultWeight, uint64(output.Amount))
+
+	outputBytes, err := formatting.Decode(args.Encoding, output.Output)
+	require.NoError(err)
+	var out avax.TransferableOutput
+	_, err = txs.Codec.Unmarshal(outputBytes, &out)
+	require.NoError(err)
+	require.Equal(defaultWeight, out.Out.Amount())
+
+	// Add a pending delegator
+	service.vm.ctx.Lock.Lock()
+
+	stakeAmount := service.vm.MinDelegatorStake + 12345
+	delegatorStartTime := defaultValidateStartTime.Add(time.Hour)
+	delegatorEndTime := delegatorStartTime.Add(defaultMinStakingDuration)
+	tx, err := txBuilder.NewAddDelegatorTx(
+		&txs.Validator{
+			NodeID: genesisNodeIDs[1],
+			Start:  uint64(delegatorStartTime.Unix()),
+			End:    uint64(delegatorEndTime.Unix()),
+			Wght:   stakeAmount,
+		},
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		[]*secp256k1.PrivateKey{keys[0]},
+		common.WithChangeOwner(&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+		}),
+	)
+	require.NoError(err)
+
+	addDelTx := tx.Unsigned.(*txs.AddDelegatorTx)
+	staker, err := state.NewPendingStaker(tx.ID(), addDelTx)
+	require.NoError(err)
+
+	service.vm.state.PutPendingDelegator(staker)
+	service.vm.state.AddTx(tx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetStakedOutputs(nil, &args, &response))
+	require.Len(response.Outputs, 2)
+
+	var pendingAmount uint64
+	for _, output := range response.Outputs[1:] {
+		require.Equal(tx.ID(), output.TxID)
+		require.Equal(uint32(len(addDelTx.Outs)), uint32(output.OutputIndex))
+		require.Equal(genesisNodeIDs[1], output.NodeID)
+		require.True(output.Pending)
+		require.Equal(uint64(delegatorEndTime.Unix()), uint64(output.UnlockTime))
+		pendingAmount += uint64(output.Amount)
+	}
+	require.Equal(stakeAmount, pendingAmount)
+}
+
 func TestGetCurrentValidators(t *testing.T) {
 	require := require.New(t)
 	service, _, txBuilder := defaultService(t)
@@ -709,6 +1112,164 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetCurrentValidatorsMetadata(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = defaultValidateStartTime
+		endTime   = startTime.Add(defaultMinStakingDuration)
+		metadata  = []byte("moniker: validator operator")
+		owner     = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+
+	service.vm.ctx.Lock.Lock()
+
+	vdrTx, err := txs.NewSigned(
+		&txs.AddPermissionlessValidatorWithMetadataTx{
+			AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    service.vm.ctx.NetworkID,
+					BlockchainID: service.vm.ctx.ChainID,
+				}},
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					Start:  uint64(startTime.Unix()),
+					End:    uint64(endTime.Unix()),
+					Wght:   service.vm.MinValidatorStake,
+				},
+				Subnet:                constants.PrimaryNetworkID,
+				Signer:                &signer.Empty{},
+				ValidatorRewardsOwner: owner,
+				DelegatorRewardsOwner: owner,
+			},
+			Metadata: metadata,
+		},
+		txs.Codec,
+		nil,
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		vdrTx.ID(),
+		vdrTx.Unsigned.(*txs.AddPermissionlessValidatorWithMetadataTx),
+		startTime,
+		0,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentValidator(staker)
+	service.vm.state.AddTx(vdrTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	args := GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeIDs:  []ids.NodeID{nodeID},
+	}
+	response := GetCurrentValidatorsReply{}
+	require.NoError(service.GetCurrentValidators(nil, &args, &response))
+	require.Len(response.Validators, 1)
+
+	vdr := response.Validators[0].(pchainapi.PermissionlessValidator)
+	require.Equal(nodeID, vdr.NodeID)
+	require.Equal(metadata, []byte(vdr.Metadata))
+}
+
+func TestGetDelegationRewardPreview(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	nodeID := genesisNodeIDs[0]
+	service.vm.ctx.Lock.Lock()
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	vdrTx, _, err := service.vm.state.GetTx(validator.TxID)
+	require.NoError(err)
+	supply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	var (
+		weight          = service.vm.MinDelegatorStake
+		duration        = defaultMinStakingDuration
+		shares          = vdrTx.Unsigned.(txs.ValidatorTx).Shares()
+		potentialReward = reward.NewCalculator(service.vm.RewardConfig).Calculate(duration, weight, supply)
+
+		delegateeReward, delegatorReward = reward.Split(potentialReward, shares)
+	)
+	require.NotZero(delegatorReward)
+
+	args := GetDelegationRewardPreviewArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeID:   nodeID,
+		Weight:   avajson.Uint64(weight),
+		Duration: avajson.Uint64(duration / time.Second),
+	}
+	var reply GetDelegationRewardPreviewReply
+	require.NoError(service.GetDelegationRewardPreview(nil, &args, &reply))
+	require.Equal(avajson.Uint64(potentialReward), reply.PotentialReward)
+	require.Equal(avajson.Uint64(delegatorReward), reply.DelegatorReward)
+	require.Equal(avajson.Uint64(delegateeReward), reply.DelegateeReward)
+	require.Equal(avajson.Uint64(supply), reply.Supply)
+	require.Positive(float64(reply.EffectiveAPR))
+
+	args.Duration = avajson.Uint64(validator.EndTime.Sub(service.vm.clock.Time())/time.Second + 1)
+	err = service.GetDelegationRewardPreview(nil, &args, &reply)
+	require.ErrorIs(err, errDelegationOutlastsVdr)
+
+	args.Duration = 0
+	err = service.GetDelegationRewardPreview(nil, &args, &reply)
+	require.ErrorIs(err, errNoStakingDuration)
+
+	args.Duration = avajson.Uint64(duration / time.Second)
+	args.NodeID = ids.GenerateTestNodeID()
+	err = service.GetDelegationRewardPreview(nil, &args, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestEstimateStakingReward(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	supply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	var (
+		weight         = service.vm.MinValidatorStake
+		duration       = defaultMinStakingDuration
+		expectedReward = reward.NewCalculator(service.vm.RewardConfig).Calculate(duration, weight, supply)
+	)
+	require.NotZero(expectedReward)
+
+	args := EstimateStakingRewardArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		Weight:   avajson.Uint64(weight),
+		Duration: avajson.Uint64(duration / time.Second),
+	}
+	var reply EstimateStakingRewardReply
+	require.NoError(service.EstimateStakingReward(nil, &args, &reply))
+	require.Equal(avajson.Uint64(expectedReward), reply.Reward)
+	require.Equal(avajson.Uint64(supply), reply.Supply)
+	require.Positive(float64(reply.APR))
+
+	args.Weight = 0
+	err = service.EstimateStakingReward(nil, &args, &reply)
+	require.ErrorIs(err, errNoStakingWeight)
+
+	args.Weight = avajson.Uint64(weight)
+	args.Duration = 0
+	err = service.EstimateStakingReward(nil, &args, &reply)
+	require.ErrorIs(err, errNoStakingDuration)
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _, _ := defaultService(t)
@@ -795,10 +1356,24 @@ func TestGetBlock(t *testing.T) {
 
 			switch {
 			case test.encoding == formatting.JSON:
+				summary, err := service.vm.state.GetBlockSummary(blk.Height())
+				require.NoError(err)
+				require.Equal(
+					avajson.Uint64(service.vm.Config.GetCreateBlockchainTxFee(preferred.Timestamp())),
+					summary.FeesBurned,
+				)
+
 				statelessBlock.InitCtx(service.vm.ctx)
 				expectedBlockJSON, err := json.Marshal(statelessBlock)
 				require.NoError(err)
-				require.Equal(expectedBlockJSON, []byte(response.Block))
+
+				var expectedBlock map[string]json.RawMessage
+				require.NoError(json.Unmarshal(expectedBlockJSON, &expectedBlock))
+				expectedBlock["summary"], err = json.Marshal(summary)
+				require.NoError(err)
+				expectedBlockJSON, err = json.Marshal(expectedBlock)
+				require.NoError(err)
+				require.JSONEq(string(expectedBlockJSON), string(response.Block))
 			default:
 				var blockStr string
 				require.NoError(json.Unmarshal(response.Block, &blockStr))
@@ -812,6 +1387,161 @@ func TestGetBlock(t *testing.T) {
 	}
 }
 
+func TestGetSubnetChains(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	genesisData := []byte("genesis")
+	tx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		genesisData,
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+
+	preferredID := service.vm.manager.Preferred()
+	preferred, err := service.vm.manager.GetBlock(preferredID)
+	require.NoError(err)
+
+	statelessBlock, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	blk := service.vm.manager.NewBlock(statelessBlock)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetSubnetChainsReply{}
+	require.NoError(service.GetSubnetChains(nil, &GetSubnetChainsArgs{
+		SubnetID: testSubnet1.ID(),
+	}, &reply))
+
+	creationTime := avajson.Uint64(preferred.Timestamp().Unix())
+	require.Equal([]APISubnetChain{{
+		ID:           tx.ID(),
+		Name:         "chain name",
+		VMID:         constants.AVMID,
+		FxIDs:        []ids.ID{},
+		GenesisHash:  hashing.ComputeHash256Array(genesisData),
+		CreationTime: &creationTime,
+	}}, reply.Chains)
+
+	// A subnet without chains doesn't report any chains.
+	reply = GetSubnetChainsReply{}
+	require.NoError(service.GetSubnetChains(nil, &GetSubnetChainsArgs{
+		SubnetID: ids.GenerateTestID(),
+	}, &reply))
+	require.Empty(reply.Chains)
+}
+
+func TestGetBlockStats(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+
+	preferredID := service.vm.manager.Preferred()
+	preferred, err := service.vm.manager.GetBlock(preferredID)
+	require.NoError(err)
+
+	statelessBlock, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	blk := service.vm.manager.NewBlock(statelessBlock)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	service.vm.ctx.Lock.Unlock()
+
+	// The genesis block doesn't have a summary.
+	reply := GetBlockStatsReply{}
+	require.NoError(service.GetBlockStats(nil, &GetBlockStatsArgs{
+		StartHeight: 0,
+		EndHeight:   avajson.Uint64(blk.Height()),
+	}, &reply))
+	require.Equal(avajson.Uint64(blk.Height()), reply.NumBlocks)
+
+	reply = GetBlockStatsReply{}
+	require.NoError(service.GetBlockStats(nil, &GetBlockStatsArgs{
+		StartHeight: avajson.Uint64(blk.Height()),
+		EndHeight:   avajson.Uint64(blk.Height()),
+	}, &reply))
+	require.Equal(GetBlockStatsReply{
+		NumBlocks: 1,
+		BlockSummary: state.BlockSummary{
+			FeesBurned: avajson.Uint64(service.vm.Config.GetCreateBlockchainTxFee(preferred.Timestamp())),
+		},
+	}, reply)
+
+	err = service.GetBlockStats(nil, &GetBlockStatsArgs{
+		StartHeight: 1,
+		EndHeight:   0,
+	}, &GetBlockStatsReply{})
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	err = service.GetBlockStats(nil, &GetBlockStatsArgs{
+		StartHeight: 0,
+		EndHeight:   maxBlockStatsRange,
+	}, &GetBlockStatsReply{})
+	require.ErrorIs(err, errInvalidHeightRange)
+}
+
+func TestSampleValidatorsAtHeight(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	height, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	vdrs, err := service.vm.GetValidatorSet(context.Background(), height, constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	seed := avajson.Uint64(1)
+	jsonHeight := avajson.Uint64(height)
+	args := SampleValidatorsArgs{
+		Size:     2,
+		SubnetID: constants.PrimaryNetworkID,
+		Seed:     &seed,
+		Height:   &jsonHeight,
+	}
+	reply := SampleValidatorsReply{}
+	require.NoError(service.SampleValidators(&http.Request{}, &args, &reply))
+
+	expected, err := validators.SampleWithSeed(vdrs, 2, 1)
+	require.NoError(err)
+	utils.Sort(expected)
+	require.Equal(expected, reply.Validators)
+
+	args.Seed = nil
+	err = service.SampleValidators(&http.Request{}, &args, &reply)
+	require.ErrorIs(err, errHeightWithoutSeed)
+}
+
 func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
 	require := require.New(t)
 
@@ -845,6 +1575,28 @@ func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
 	require.Equal(reply, &parsedReply)
 }
 
+func TestGetValidatorPublicKey(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	height, err := service.vm.GetCurrentHeight(context.Background())
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(err)
+
+	// A node that isn't a validator doesn't have a public key.
+	reply := GetValidatorPublicKeyReply{}
+	require.NoError(service.GetValidatorPublicKey(
+		&http.Request{},
+		&GetValidatorPublicKeyArgs{
+			NodeID: ids.GenerateTestNodeID(),
+			Height: avajson.Uint64(height),
+		},
+		&reply,
+	))
+	require.Nil(reply.PublicKey)
+}
+
 func TestServiceGetBlockByHeight(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -905,9 +1657,13 @@ func TestServiceGetBlockByHeight(t *testing.T) {
 			serviceAndExpectedBlockFunc: func(_ *testing.T, ctrl *gomock.Controller) (*Service, interface{}) {
 				block := block.NewMockBlock(ctrl)
 				block.EXPECT().InitCtx(gomock.Any())
+				block.EXPECT().Height().Return(blockHeight)
+				block.EXPECT().ID().Return(blockID)
 
+				// Blocks without a summary are returned as is.
 				state := state.NewMockState(ctrl)
-				state.EXPECT().GetBlockIDAtHeight(blockHeight).Return(blockID, nil)
+				state.EXPECT().GetBlockIDAtHeight(blockHeight).Return(blockID, nil).Times(2)
+				state.EXPECT().GetBlockSummary(blockHeight).Return(nil, database.ErrNotFound)
 
 				manager := blockexecutor.NewMockManager(ctrl)
 				manager.EXPECT().GetStatelessBlock(blockID).Return(block, nil)
@@ -0,0 +1,299 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+)
+
+const (
+	stakerExportHeightParam   = "height"
+	stakerExportSubnetIDParam = "subnetID"
+	stakerExportFormatParam   = "format"
+
+	stakerExportFormatJSON = "json"
+	stakerExportFormatCSV  = "csv"
+
+	exportedValidatorType = "validator"
+	exportedDelegatorType = "delegator"
+)
+
+var (
+	errMissingExportHeight = errors.New("missing height")
+	errInvalidExportHeight = errors.New("invalid height")
+	errInvalidExportSubnet = errors.New("invalid subnetID")
+	errUnknownExportFormat = errors.New("unknown export format")
+	errExportHeightTooHigh = errors.New("height hasn't been accepted")
+
+	// stakerExportUnavailableErrs are returned when the stakers at the
+	// requested height can't be exported by this node.
+	stakerExportUnavailableErrs = []error{
+		errExportHeightTooHigh,
+		state.ErrStakerDiffsUnavailable,
+		state.ErrValidatorDiffsPruned,
+	}
+
+	stakerExportCSVHeader = []string{
+		"txID",
+		"type",
+		"nodeID",
+		"weight",
+		"startTime",
+		"endTime",
+		"potentialReward",
+		"rewardOwnerLocktime",
+		"rewardOwnerThreshold",
+		"rewardOwnerAddresses",
+	}
+)
+
+// ExportedStaker is a validator or delegator of the staker set exported by the
+// stakers endpoint.
+type ExportedStaker struct {
+	TxID            ids.ID         `json:"txID"`
+	Type            string         `json:"type"`
+	NodeID          ids.NodeID     `json:"nodeID"`
+	Weight          avajson.Uint64 `json:"weight"`
+	StartTime       avajson.Uint64 `json:"startTime"`
+	EndTime         avajson.Uint64 `json:"endTime"`
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	// RewardOwner is the owner of the staking rewards. It is nil for
+	// permissioned subnet validators, which aren't rewarded.
+	RewardOwner *platformapi.Owner `json:"rewardOwner,omitempty"`
+}
+
+// stakerExporter streams the current validators and delegators of a subnet as
+// they were after the block at the requested height was accepted.
+type stakerExporter struct {
+	service *Service
+}
+
+func (e *stakerExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	heightStr := query.Get(stakerExportHeightParam)
+	if heightStr == "" {
+		http.Error(w, errMissingExportHeight.Error(), http.StatusBadRequest)
+		return
+	}
+	height, err := strconv.ParseUint(heightStr, 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("%s: %s", errInvalidExportHeight, err), http.StatusBadRequest)
+		return
+	}
+
+	subnetID := constants.PrimaryNetworkID
+	if subnetIDStr := query.Get(stakerExportSubnetIDParam); subnetIDStr != "" {
+		subnetID, err = ids.FromString(subnetIDStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("%s: %s", errInvalidExportSubnet, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	format := query.Get(stakerExportFormatParam)
+	switch format {
+	case "", stakerExportFormatJSON:
+		format = stakerExportFormatJSON
+	case stakerExportFormatCSV:
+	default:
+		http.Error(w, fmt.Sprintf("%s: %q", errUnknownExportFormat, format), http.StatusBadRequest)
+		return
+	}
+
+	e.service.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "stakers"),
+		zap.Uint64("height", height),
+		zap.Stringer("subnetID", subnetID),
+		zap.String("format", format),
+	)
+
+	stakers, err := e.service.getStakersAt(r.Context(), subnetID, height)
+	if err != nil {
+		status := http.StatusInternalServerError
+		for _, unavailableErr := range stakerExportUnavailableErrs {
+			if errors.Is(err, unavailableErr) {
+				status = http.StatusNotFound
+				break
+			}
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if format == stakerExportFormatCSV {
+		err = writeStakersCSV(w, stakers)
+	} else {
+		err = writeStakersJSON(w, stakers)
+	}
+	if err != nil {
+		e.service.vm.ctx.Log.Debug("failed to write stakers",
+			zap.Error(err),
+		)
+	}
+}
+
+// getStakersAt returns the current stakers of [subnetID] after the block at
+// [height] was accepted, sorted by NodeID and then by txID.
+func (s *Service) getStakersAt(ctx context.Context, subnetID ids.ID, height uint64) ([]*ExportedStaker, error) {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	currentHeight, err := s.vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height > currentHeight {
+		return nil, fmt.Errorf("%w: %d is above the last accepted height %d",
+			errExportHeightTooHigh,
+			height,
+			currentHeight,
+		)
+	}
+
+	stakerIterator, err := s.vm.state.GetCurrentSubnetStakerIterator(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	stakers := make(map[ids.ID]*state.Staker)
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		stakers[staker.TxID] = staker
+	}
+	stakerIterator.Release()
+
+	if err := s.vm.state.ApplyStakerDiffs(ctx, stakers, currentHeight, height+1, subnetID); err != nil {
+		return nil, fmt.Errorf("failed to rebuild stakers at height %d: %w", height, err)
+	}
+
+	sortedStakers := make([]*state.Staker, 0, len(stakers))
+	for _, staker := range stakers {
+		sortedStakers = append(sortedStakers, staker)
+	}
+	slices.SortFunc(sortedStakers, func(a, b *state.Staker) int {
+		if cmp := a.NodeID.Compare(b.NodeID); cmp != 0 {
+			return cmp
+		}
+		return a.TxID.Compare(b.TxID)
+	})
+
+	exported := make([]*ExportedStaker, len(sortedStakers))
+	for i, staker := range sortedStakers {
+		exported[i], err = s.exportStaker(staker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export staker %s: %w", staker.TxID, err)
+		}
+	}
+	return exported, nil
+}
+
+func (s *Service) exportStaker(staker *state.Staker) (*ExportedStaker, error) {
+	exported := &ExportedStaker{
+		TxID:            staker.TxID,
+		Type:            exportedValidatorType,
+		NodeID:          staker.NodeID,
+		Weight:          avajson.Uint64(staker.Weight),
+		StartTime:       avajson.Uint64(staker.StartTime.Unix()),
+		EndTime:         avajson.Uint64(staker.EndTime.Unix()),
+		PotentialReward: avajson.Uint64(staker.PotentialReward),
+	}
+	if staker.Priority.IsDelegator() {
+		exported.Type = exportedDelegatorType
+	}
+	if staker.Priority == txs.SubnetPermissionedValidatorCurrentPriority {
+		return exported, nil
+	}
+
+	attr, err := s.loadStakerTxAttributes(staker.TxID)
+	if err != nil {
+		return nil, err
+	}
+	rewardsOwner := attr.validationRewardsOwner
+	if staker.Priority.IsDelegator() {
+		rewardsOwner = attr.rewardsOwner
+	}
+	if owner, ok := rewardsOwner.(*secp256k1fx.OutputOwners); ok {
+		exported.RewardOwner, err = s.getAPIOwner(owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exported, nil
+}
+
+// writeStakersJSON writes [stakers] as a JSON array, one staker at a time.
+func writeStakersJSON(w http.ResponseWriter, stakers []*ExportedStaker) error {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write([]byte("[")); err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	for i, staker := range stakers {
+		if i > 0 {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(staker); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write([]byte("]\n"))
+	return err
+}
+
+// writeStakersCSV writes [stakers] as CSV rows following a header row. The
+// addresses of a reward owner are separated by semicolons.
+func writeStakersCSV(w http.ResponseWriter, stakers []*ExportedStaker) error {
+	w.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(w)
+	if err := writer.Write(stakerExportCSVHeader); err != nil {
+		return err
+	}
+	for _, staker := range stakers {
+		var locktime, threshold, addresses string
+		if owner := staker.RewardOwner; owner != nil {
+			locktime = strconv.FormatUint(uint64(owner.Locktime), 10)
+			threshold = strconv.FormatUint(uint64(owner.Threshold), 10)
+			addresses = strings.Join(owner.Addresses, ";")
+		}
+		err := writer.Write([]string{
+			staker.TxID.String(),
+			staker.Type,
+			staker.NodeID.String(),
+			strconv.FormatUint(uint64(staker.Weight), 10),
+			strconv.FormatUint(uint64(staker.StartTime), 10),
+			strconv.FormatUint(uint64(staker.EndTime), 10),
+			strconv.FormatUint(uint64(staker.PotentialReward), 10),
+			locktime,
+			threshold,
+			addresses,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
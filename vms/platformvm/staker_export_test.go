@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+func exportStakers(exporter *stakerExporter, query string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stakers?"+query, nil))
+	return recorder
+}
+
+func TestStakerExport(t *testing.T) {
+	require := require.New(t)
+	service, _, txBuilder := defaultService(t)
+	exporter := &stakerExporter{service: service}
+
+	service.vm.ctx.Lock.Lock()
+	heightBefore, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+
+	var (
+		startTime = service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+		nodeID    = genesisNodeIDs[0]
+	)
+	tx, err := txBuilder.NewAddSubnetValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   defaultWeight,
+			},
+			Subnet: testSubnet1.ID(),
+		},
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(service.vm.issueTxFromRPC(tx))
+	service.vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(service.vm))
+
+	heightAfter, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	subnetQuery := "subnetID=" + testSubnet1.ID().String()
+
+	// The subnet validator wasn't a staker before its tx was accepted.
+	recorder := exportStakers(exporter, fmt.Sprintf("%s&height=%d", subnetQuery, heightBefore))
+	require.Equal(http.StatusOK, recorder.Code)
+	var stakers []*ExportedStaker
+	require.NoError(json.Unmarshal(recorder.Body.Bytes(), &stakers))
+	require.Empty(stakers)
+
+	recorder = exportStakers(exporter, fmt.Sprintf("%s&height=%d", subnetQuery, heightAfter))
+	require.Equal(http.StatusOK, recorder.Code)
+	require.NoError(json.Unmarshal(recorder.Body.Bytes(), &stakers))
+	require.Equal([]*ExportedStaker{{
+		TxID:      tx.ID(),
+		Type:      exportedValidatorType,
+		NodeID:    nodeID,
+		Weight:    avajson.Uint64(defaultWeight),
+		StartTime: avajson.Uint64(service.vm.state.GetTimestamp().Unix()),
+		EndTime:   avajson.Uint64(endTime.Unix()),
+	}}, stakers)
+
+	// The primary network validators are exported with their reward owners.
+	recorder = exportStakers(exporter, fmt.Sprintf("height=%d&format=csv", heightAfter))
+	require.Equal(http.StatusOK, recorder.Code)
+	require.Equal("text/csv", recorder.Header().Get("Content-Type"))
+	rows, err := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll()
+	require.NoError(err)
+	require.Len(rows, len(genesisNodeIDs)+1)
+	require.Equal(stakerExportCSVHeader, rows[0])
+	nodeIDs := make([]ids.NodeID, 0, len(genesisNodeIDs))
+	for _, row := range rows[1:] {
+		require.Equal(exportedValidatorType, row[1])
+		require.NotEmpty(row[len(row)-1])
+
+		nodeID, err := ids.NodeIDFromString(row[2])
+		require.NoError(err)
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	require.ElementsMatch(genesisNodeIDs, nodeIDs)
+}
+
+func TestStakerExportErrors(t *testing.T) {
+	service, _, _ := defaultService(t)
+	exporter := &stakerExporter{service: service}
+
+	service.vm.ctx.Lock.Lock()
+	currentHeight, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(t, err)
+	service.vm.ctx.Lock.Unlock()
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{
+			name:           "missing height",
+			query:          "format=json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid height",
+			query:          "height=latest",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid subnetID",
+			query:          "height=0&subnetID=subnet",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown format",
+			query:          "height=0&format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "height not accepted",
+			query:          fmt.Sprintf("height=%d&subnetID=%s", currentHeight+1, constants.PrimaryNetworkID),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := exportStakers(exporter, test.query)
+			require.Equal(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,513 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+const (
+	// maxAsyncCommitsPerWrite is the maximum number of queued commits that are
+	// combined into a single write to the underlying database.
+	maxAsyncCommitsPerWrite = 64
+
+	// asyncCommitLogFileName is the name of the file, in the chain's data
+	// directory, that async commits are logged to.
+	asyncCommitLogFileName = "async_commits.log"
+)
+
+var (
+	_ database.Database = (*asyncDB)(nil)
+	_ database.Batch    = (*asyncBatch)(nil)
+)
+
+// asyncDB is a database whose batches are written to the underlying database
+// by a background goroutine, so that writing a batch doesn't wait for the
+// disk.
+//
+// Committing a batch appends it to a commitLog before it is queued, without
+// waiting for the log to be synced to disk. Batches are then written in the
+// order they were committed, and every batch is written atomically, possibly
+// combined with the batches committed after it. The log is synced once before
+// every such combined write rather than once per commit. When the database is
+// opened, the logged batches are written again, so a process crash can't lose
+// a committed batch.
+//
+// Until a batch is written, its values are served from memory. Iterators are
+// only served by the underlying database, so creating an iterator waits for
+// every committed batch to be written.
+type asyncDB struct {
+	db database.Database
+
+	// commitLock ensures that commits are queued in the order of their
+	// indices.
+	commitLock sync.Mutex
+	// logLock ensures that the log isn't truncated between a commit being
+	// appended to it and the commit being counted in [numCommitted]. If both
+	// logLock and lock are held, logLock must be acquired first.
+	logLock sync.Mutex
+	log     *commitLog
+	// queue contains the commits that haven't been written yet. Committing a
+	// batch blocks while the queue is full.
+	queue chan *asyncCommit
+	// done is closed once every queued commit has been written after the
+	// database was closed.
+	done chan struct{}
+
+	// lock protects the fields below.
+	lock sync.Mutex
+	// written is signalled every time queued commits are written.
+	written *sync.Cond
+	// pending maps the keys of the queued commits to their most recently
+	// committed value.
+	pending map[string]asyncValue
+	// numCommitted is the index of the last queued commit.
+	numCommitted uint64
+	// numWritten is the index of the last written commit.
+	numWritten uint64
+	// err is the first error returned by the underlying database when
+	// writing a commit. Once set, no more batches can be committed.
+	err    error
+	closed bool
+}
+
+type asyncCommit struct {
+	index uint64
+	ops   []database.BatchOp
+}
+
+type asyncValue struct {
+	index  uint64
+	value  []byte
+	delete bool
+}
+
+// newAsyncDB returns a database that writes to [db] asynchronously, allowing
+// up to [queueSize] commits to be waiting to be written. Commits are logged to
+// the file at [logPath]. Any commits that were logged but not written before
+// the previous asyncDB using [logPath] stopped are written to [db] before
+// returning.
+func newAsyncDB(db database.Database, logPath string, queueSize int) (*asyncDB, error) {
+	log, commits, err := openCommitLog(logPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := replayCommits(db, log, commits); err != nil {
+		_ = log.Close()
+		return nil, err
+	}
+	return newAsyncDBWithLog(db, log, queueSize), nil
+}
+
+// newAsyncDBWithLog returns a database that writes to [db] asynchronously and
+// logs commits to [log], which must be empty.
+func newAsyncDBWithLog(db database.Database, log *commitLog, queueSize int) *asyncDB {
+	asyncDB := &asyncDB{
+		db:      db,
+		log:     log,
+		queue:   make(chan *asyncCommit, queueSize),
+		done:    make(chan struct{}),
+		pending: make(map[string]asyncValue),
+	}
+	asyncDB.written = sync.NewCond(&asyncDB.lock)
+	go asyncDB.writeLoop()
+	return asyncDB
+}
+
+// replayCommits atomically writes [commits] to [db] and then truncates [log].
+//
+// Some of the commits may already have been written. Because the commits are
+// replayed in order, rewriting them leaves [db] in the state after the last
+// commit.
+func replayCommits(db database.Database, log *commitLog, commits [][]database.BatchOp) error {
+	if len(commits) == 0 {
+		return log.Truncate()
+	}
+
+	batch := db.NewBatch()
+	for _, ops := range commits {
+		for _, op := range ops {
+			var err error
+			if op.Delete {
+				err = batch.Delete(op.Key)
+			} else {
+				err = batch.Put(op.Key, op.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to replay commit log: %w", err)
+	}
+	return log.Truncate()
+}
+
+func (db *asyncDB) Has(key []byte) (bool, error) {
+	db.lock.Lock()
+	closed := db.closed
+	value, ok := db.pending[string(key)]
+	db.lock.Unlock()
+	if closed {
+		return false, database.ErrClosed
+	}
+	if ok {
+		return !value.delete, nil
+	}
+	return db.db.Has(key)
+}
+
+func (db *asyncDB) Get(key []byte) ([]byte, error) {
+	db.lock.Lock()
+	closed := db.closed
+	value, ok := db.pending[string(key)]
+	db.lock.Unlock()
+	if closed {
+		return nil, database.ErrClosed
+	}
+	if !ok {
+		return db.db.Get(key)
+	}
+	if value.delete {
+		return nil, database.ErrNotFound
+	}
+	return slices.Clone(value.value), nil
+}
+
+func (db *asyncDB) Put(key []byte, value []byte) error {
+	batch := db.NewBatch()
+	if err := batch.Put(key, value); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (db *asyncDB) Delete(key []byte) error {
+	batch := db.NewBatch()
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (db *asyncDB) NewBatch() database.Batch {
+	return &asyncBatch{
+		db:    db,
+		inner: db.db.NewBatch(),
+	}
+}
+
+func (db *asyncDB) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *asyncDB) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *asyncDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *asyncDB) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if err := db.Flush(); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return &asyncIterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+func (db *asyncDB) Compact(start []byte, limit []byte) error {
+	if err := db.Flush(); err != nil {
+		return err
+	}
+	return db.db.Compact(start, limit)
+}
+
+// Close writes every queued commit, stops the background goroutine and closes
+// the log. The underlying database isn't closed.
+func (db *asyncDB) Close() error {
+	db.commitLock.Lock()
+	defer db.commitLock.Unlock()
+
+	db.lock.Lock()
+	if db.closed {
+		db.lock.Unlock()
+		return database.ErrClosed
+	}
+	db.closed = true
+	db.lock.Unlock()
+
+	close(db.queue)
+	<-db.done
+
+	db.lock.Lock()
+	err := db.err
+	db.lock.Unlock()
+
+	// If a commit failed to be written, the log is kept so that the commit is
+	// replayed when the database is reopened.
+	return errors.Join(err, db.log.Close())
+}
+
+func (db *asyncDB) HealthCheck(ctx context.Context) (interface{}, error) {
+	if err := db.Flush(); err != nil {
+		return nil, err
+	}
+	return db.db.HealthCheck(ctx)
+}
+
+// Flush blocks until every committed batch has been written to the
+// underlying database and removed from the log. Afterwards, the underlying
+// database can be written to directly without a replay of the log
+// overwriting those writes.
+func (db *asyncDB) Flush() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for db.err == nil && db.numWritten < db.numCommitted {
+		db.written.Wait()
+	}
+	switch {
+	case db.err != nil:
+		return db.err
+	case db.closed:
+		return database.ErrClosed
+	default:
+		return nil
+	}
+}
+
+func (db *asyncDB) isClosed() bool {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.closed
+}
+
+func (db *asyncDB) commit(ops []database.BatchOp) error {
+	db.commitLock.Lock()
+	defer db.commitLock.Unlock()
+
+	db.lock.Lock()
+	switch {
+	case db.closed:
+		db.lock.Unlock()
+		return database.ErrClosed
+	case db.err != nil:
+		err := db.err
+		db.lock.Unlock()
+		return err
+	}
+
+	db.lock.Unlock()
+
+	db.logLock.Lock()
+	if err := db.log.Append(ops); err != nil {
+		db.logLock.Unlock()
+
+		// The log may now end with a partially appended commit, so no more
+		// commits can be appended after it.
+		db.lock.Lock()
+		if db.err == nil {
+			db.err = err
+		}
+		db.lock.Unlock()
+		return err
+	}
+
+	db.lock.Lock()
+	db.numCommitted++
+	commit := &asyncCommit{
+		index: db.numCommitted,
+		ops:   ops,
+	}
+	for _, op := range ops {
+		db.pending[string(op.Key)] = asyncValue{
+			index:  commit.index,
+			value:  op.Value,
+			delete: op.Delete,
+		}
+	}
+	db.lock.Unlock()
+	db.logLock.Unlock()
+
+	db.queue <- commit
+	return nil
+}
+
+func (db *asyncDB) writeLoop() {
+	defer close(db.done)
+
+	for commit := range db.queue {
+		commits := []*asyncCommit{commit}
+	combine:
+		for len(commits) < maxAsyncCommitsPerWrite {
+			select {
+			case commit, ok := <-db.queue:
+				if !ok {
+					break combine
+				}
+				commits = append(commits, commit)
+			default:
+				break combine
+			}
+		}
+
+		// Syncing the log once for all of the combined commits keeps the sync
+		// off of the commit path.
+		err := db.log.Sync()
+		if err == nil {
+			err = db.write(commits)
+		}
+		lastIndex := commits[len(commits)-1].index
+
+		// Holding logLock prevents new commits from being logged, so if every
+		// commit has been written, every logged commit has been written.
+		db.logLock.Lock()
+		if err == nil && db.isDrained(lastIndex) {
+			err = db.log.Truncate()
+		}
+
+		db.lock.Lock()
+		if err != nil && db.err == nil {
+			db.err = err
+		}
+		// If the write failed, the values are kept in memory so that reads
+		// remain consistent with what was committed.
+		if db.err == nil {
+			for _, commit := range commits {
+				for _, op := range commit.ops {
+					key := string(op.Key)
+					if value, ok := db.pending[key]; ok && value.index <= lastIndex {
+						delete(db.pending, key)
+					}
+				}
+			}
+		}
+		db.numWritten = lastIndex
+		db.written.Broadcast()
+		db.lock.Unlock()
+		db.logLock.Unlock()
+	}
+}
+
+// isDrained returns true if the commit at [index] is the last commit.
+func (db *asyncDB) isDrained(index uint64) bool {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return index == db.numCommitted
+}
+
+// write atomically writes [commits] to the underlying database.
+func (db *asyncDB) write(commits []*asyncCommit) error {
+	batch := db.db.NewBatch()
+	for _, commit := range commits {
+		for _, op := range commit.ops {
+			var err error
+			if op.Delete {
+				err = batch.Delete(op.Key)
+			} else {
+				err = batch.Put(op.Key, op.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+// asyncBatch is a batch that is committed to an asyncDB.
+type asyncBatch struct {
+	database.BatchOps
+
+	db *asyncDB
+	// inner contains the same operations as this batch, so that it can be
+	// written atomically with other batches of the underlying database.
+	inner database.Batch
+}
+
+func (b *asyncBatch) Put(key, value []byte) error {
+	if err := b.BatchOps.Put(key, value); err != nil {
+		return err
+	}
+	return b.inner.Put(key, value)
+}
+
+func (b *asyncBatch) Delete(key []byte) error {
+	if err := b.BatchOps.Delete(key); err != nil {
+		return err
+	}
+	return b.inner.Delete(key)
+}
+
+// Write queues the batch to be written to the underlying database.
+func (b *asyncBatch) Write() error {
+	// The batch may be reset and reused after it is written, so the
+	// operations must be copied.
+	return b.db.commit(slices.Clone(b.Ops))
+}
+
+func (b *asyncBatch) Reset() {
+	b.BatchOps.Reset()
+	b.inner.Reset()
+}
+
+// Inner returns a batch of the underlying database with the same operations
+// as this batch. Writing it bypasses the asynchronous writes, so it must only
+// be written after every committed batch has been written.
+func (b *asyncBatch) Inner() database.Batch {
+	return b.inner.Inner()
+}
+
+// asyncIterator is an iterator of the underlying database that stops once the
+// asyncDB is closed.
+type asyncIterator struct {
+	database.Iterator
+	db *asyncDB
+
+	err error
+}
+
+func (it *asyncIterator) Next() bool {
+	if it.db.isClosed() {
+		it.err = database.ErrClosed
+		return false
+	}
+	return it.Iterator.Next()
+}
+
+func (it *asyncIterator) Key() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.Iterator.Key()
+}
+
+func (it *asyncIterator) Value() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.Iterator.Value()
+}
+
+func (it *asyncIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
@@ -0,0 +1,256 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestAsyncDBInterface(t *testing.T) {
+	for name, test := range database.Tests {
+		t.Run(name, func(t *testing.T) {
+			db, err := newAsyncDB(memdb.New(), newAsyncCommitLogPath(t), 1)
+			require.NoError(t, err)
+			test(t, db)
+		})
+	}
+}
+
+func TestAsyncDBWritesInOrder(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newAsyncDB(baseDB, newAsyncCommitLogPath(t), 4)
+	require.NoError(err)
+
+	for i := byte(0); i < 10; i++ {
+		batch := db.NewBatch()
+		require.NoError(batch.Put([]byte{0}, []byte{i}))
+		require.NoError(batch.Put([]byte{i + 1}, []byte{i}))
+		require.NoError(batch.Write())
+	}
+	require.NoError(db.Delete([]byte{1}))
+
+	// Committed values are visible before they are written.
+	value, err := db.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{9}, value)
+	_, err = db.Get([]byte{1})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(db.Flush())
+	value, err = baseDB.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{9}, value)
+	has, err := baseDB.Has([]byte{1})
+	require.NoError(err)
+	require.False(has)
+
+	// Closing the database writes the queued commits.
+	require.NoError(db.Put([]byte{0}, []byte{10}))
+	require.NoError(db.Close())
+	value, err = baseDB.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{10}, value)
+}
+
+func TestAsyncDBReplaysLog(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		baseDB  = memdb.New()
+		logPath = newAsyncCommitLogPath(t)
+		release = make(chan struct{})
+	)
+	stalledDB, err := newAsyncDB(
+		&stalledDatabase{
+			Database: baseDB,
+			release:  release,
+		},
+		logPath,
+		4,
+	)
+	require.NoError(err)
+
+	for i := byte(0); i < 3; i++ {
+		require.NoError(stalledDB.Put([]byte{i}, []byte{i}))
+	}
+	require.NoError(stalledDB.Delete([]byte{1}))
+
+	// None of the commits have been written, which is what the database
+	// looks like if the node crashed before writing them.
+	isEmpty, err := database.IsEmpty(baseDB)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	db, err := newAsyncDB(baseDB, logPath, 4)
+	require.NoError(err)
+
+	// The commits are replayed before the database is opened.
+	value, err := baseDB.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{0}, value)
+	has, err := baseDB.Has([]byte{1})
+	require.NoError(err)
+	require.False(has)
+	value, err = baseDB.Get([]byte{2})
+	require.NoError(err)
+	require.Equal([]byte{2}, value)
+
+	// The replayed commits are removed from the log.
+	logBytes, err := os.ReadFile(logPath)
+	require.NoError(err)
+	require.Empty(logBytes)
+
+	require.NoError(db.Close())
+
+	close(release)
+	require.ErrorIs(stalledDB.Close(), errStalledWrite)
+}
+
+func TestAsyncDBTruncatesLogOnFlush(t *testing.T) {
+	require := require.New(t)
+
+	logPath := newAsyncCommitLogPath(t)
+	db, err := newAsyncDB(memdb.New(), logPath, 4)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte{0}, []byte{0}))
+	require.NoError(db.Flush())
+
+	// Once flushed, the underlying database may be written to directly, so
+	// the log must not be replayed over those writes.
+	logBytes, err := os.ReadFile(logPath)
+	require.NoError(err)
+	require.Empty(logBytes)
+
+	require.NoError(db.Close())
+}
+
+func newAsyncCommitLogPath(t *testing.T) string {
+	return filepath.Join(t.TempDir(), asyncCommitLogFileName)
+}
+
+var errStalledWrite = errors.New("stalled write")
+
+// stalledDatabase is a database whose batches fail to be written once
+// [release] is closed.
+type stalledDatabase struct {
+	database.Database
+	release chan struct{}
+}
+
+func (db *stalledDatabase) NewBatch() database.Batch {
+	return &stalledBatch{
+		Batch:   db.Database.NewBatch(),
+		release: db.release,
+	}
+}
+
+type stalledBatch struct {
+	database.Batch
+	release chan struct{}
+}
+
+func (b *stalledBatch) Write() error {
+	<-b.release
+	return errStalledWrite
+}
+
+func TestAsyncDBCommitDoesntWaitForSync(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		baseDB = memdb.New()
+		file   = &blockingSyncFile{
+			release: make(chan struct{}),
+		}
+		db = newAsyncDBWithLog(baseDB, &commitLog{file: file}, 4)
+	)
+
+	// Every commit returns, and is readable, while the log can't be synced.
+	for i := byte(0); i < 3; i++ {
+		require.NoError(db.Put([]byte{i}, []byte{i}))
+	}
+	value, err := db.Get([]byte{2})
+	require.NoError(err)
+	require.Equal([]byte{2}, value)
+
+	// Nothing is written until the log is synced.
+	isEmpty, err := database.IsEmpty(baseDB)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	close(file.release)
+	require.NoError(db.Flush())
+
+	// The commits are written after at most one sync per combined write.
+	file.lock.Lock()
+	numSyncs := file.numSyncs
+	file.lock.Unlock()
+	require.LessOrEqual(numSyncs, 3)
+	value, err = baseDB.Get([]byte{2})
+	require.NoError(err)
+	require.Equal([]byte{2}, value)
+
+	require.NoError(db.Close())
+}
+
+func BenchmarkAsyncDBCommit(b *testing.B) {
+	db, err := newAsyncDB(memdb.New(), filepath.Join(b.TempDir(), asyncCommitLogFileName), 1024)
+	require.NoError(b, err)
+
+	value := make([]byte, 128)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		batch := db.NewBatch()
+		for j := 0; j < 16; j++ {
+			require.NoError(b, batch.Put([]byte{byte(i), byte(i >> 8), byte(j)}, value))
+		}
+		require.NoError(b, batch.Write())
+	}
+	b.StopTimer()
+
+	require.NoError(b, db.Close())
+}
+
+// blockingSyncFile is a commit log file whose Sync blocks until [release] is
+// closed.
+type blockingSyncFile struct {
+	release chan struct{}
+
+	lock     sync.Mutex
+	numSyncs int
+}
+
+func (*blockingSyncFile) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (f *blockingSyncFile) Sync() error {
+	<-f.release
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.numSyncs++
+	return nil
+}
+
+func (*blockingSyncFile) Truncate(int64) error {
+	return nil
+}
+
+func (*blockingSyncFile) Close() error {
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// BlockSummary aggregates the effects of an accepted block so that they don't
+// need to be recomputed from the block's txs.
+type BlockSummary struct {
+	// FeesBurned is the amount of AVAX that was consumed, but not produced, by
+	// the txs in the block.
+	FeesBurned avajson.Uint64 `serialize:"true" json:"feesBurned"`
+	// RewardsMinted is the amount of AVAX that was paid out as staking
+	// rewards in the block.
+	RewardsMinted avajson.Uint64 `serialize:"true" json:"rewardsMinted"`
+	// StakersAdded is the number of stakers added by the block.
+	StakersAdded avajson.Uint64 `serialize:"true" json:"stakersAdded"`
+	// StakersRemoved is the number of stakers removed by the block.
+	StakersRemoved avajson.Uint64 `serialize:"true" json:"stakersRemoved"`
+}
+
+// Add includes [other] into this summary.
+func (s *BlockSummary) Add(other *BlockSummary) error {
+	feesBurned, err := math.Add64(uint64(s.FeesBurned), uint64(other.FeesBurned))
+	if err != nil {
+		return err
+	}
+	rewardsMinted, err := math.Add64(uint64(s.RewardsMinted), uint64(other.RewardsMinted))
+	if err != nil {
+		return err
+	}
+	s.FeesBurned = avajson.Uint64(feesBurned)
+	s.RewardsMinted = avajson.Uint64(rewardsMinted)
+	s.StakersAdded += other.StakersAdded
+	s.StakersRemoved += other.StakersRemoved
+	return nil
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	checkpointVersion = 0
+
+	// maxCheckpointEntrySize bounds the size of the keys and values read from
+	// a checkpoint so that a malformed checkpoint can't exhaust memory.
+	maxCheckpointEntrySize = 64 * units.MiB
+	// checkpointImportBatchSize is the number of bytes written to the
+	// database at once while importing a checkpoint.
+	checkpointImportBatchSize = units.MiB
+
+	checkpointEntryMarker byte = 1
+	checkpointEndMarker   byte = 0
+)
+
+var (
+	ErrDatabaseNotEmpty    = errors.New("database isn't empty")
+	ErrInvalidCheckpoint   = errors.New("invalid checkpoint")
+	errStateNotInitialized = errors.New("state isn't initialized")
+
+	checkpointMagic = []byte("platformvm checkpoint")
+)
+
+// ExportCheckpoint writes the state stored in [db] at its last accepted block
+// to [w]. [db] must be the database the state was created with, and must not
+// be written to during the export. The ID and height of the last accepted
+// block are returned.
+//
+// Only the data needed to keep executing the chain is exported. Historical
+// blocks, block summaries, validator diffs, and the UTXO journal are omitted,
+// so a node that imports the checkpoint can't serve validator sets, blocks, or
+// UTXOs below the checkpointed height.
+func ExportCheckpoint(db database.Database, w io.Writer) (ids.ID, uint64, error) {
+	initialized, err := db.Has(singletonKey(InitializedKey))
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if !initialized {
+		return ids.Empty, 0, errStateNotInitialized
+	}
+
+	lastAcceptedID, err := database.GetID(db, singletonKey(LastAcceptedKey))
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to get last accepted: %w", err)
+	}
+	blkKey := prefixdb.PrefixKey(prefixdb.MakePrefix(BlockPrefix), lastAcceptedID[:])
+	blkBytes, err := db.Get(blkKey)
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	blk, _, err := parseStoredBlock(blkBytes)
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to parse last accepted block %s: %w", lastAcceptedID, err)
+	}
+	height := blk.Height()
+
+	var (
+		hasher = sha256.New()
+		bw     = bufio.NewWriter(w)
+		cw     = &checkpointWriter{w: io.MultiWriter(bw, hasher)}
+	)
+	cw.write(checkpointMagic)
+	cw.writeUint64(checkpointVersion)
+	cw.write(lastAcceptedID[:])
+	cw.writeUint64(height)
+
+	excludedPrefixes := historicalPrefixes()
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() && cw.err == nil {
+		key := it.Key()
+		if len(key) >= ids.IDLen && excludedPrefixes.Contains(string(key[:ids.IDLen])) {
+			continue
+		}
+		cw.writeEntry(key, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return ids.Empty, 0, err
+	}
+
+	// The last accepted block is the only block that is exported, as it is
+	// needed to build its children.
+	cw.writeEntry(blkKey, blkBytes)
+	cw.writeEntry(
+		prefixdb.PrefixKey(prefixdb.MakePrefix(BlockIDPrefix), database.PackUInt64(height)),
+		lastAcceptedID[:],
+	)
+	cw.write([]byte{checkpointEndMarker})
+	if cw.err != nil {
+		return ids.Empty, 0, cw.err
+	}
+
+	if _, err := bw.Write(hasher.Sum(nil)); err != nil {
+		return ids.Empty, 0, err
+	}
+	return lastAcceptedID, height, bw.Flush()
+}
+
+// ImportCheckpoint initializes the empty [db] with the state read from a
+// checkpoint written by [ExportCheckpoint]. The ID and height of the last
+// accepted block of the checkpoint are returned.
+//
+// The state is only marked as initialized once the whole checkpoint has been
+// verified. If an error is returned, [db] may have been partially written and
+// should be discarded.
+func ImportCheckpoint(r io.Reader, db database.Database) (ids.ID, uint64, error) {
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if !isEmpty {
+		return ids.Empty, 0, ErrDatabaseNotEmpty
+	}
+
+	var (
+		hasher = sha256.New()
+		br     = bufio.NewReader(r)
+		cr     = &checkpointReader{r: io.TeeReader(br, hasher)}
+	)
+	magic := cr.read(uint64(len(checkpointMagic)))
+	version := cr.readUint64()
+	lastAcceptedIDBytes := cr.read(ids.IDLen)
+	height := cr.readUint64()
+	if cr.err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: failed to read header: %w", ErrInvalidCheckpoint, cr.err)
+	}
+	if !bytes.Equal(magic, checkpointMagic) {
+		return ids.Empty, 0, fmt.Errorf("%w: unexpected magic", ErrInvalidCheckpoint)
+	}
+	if version != checkpointVersion {
+		return ids.Empty, 0, fmt.Errorf("%w: unknown version %d", ErrInvalidCheckpoint, version)
+	}
+	lastAcceptedID := ids.ID(lastAcceptedIDBytes)
+
+	var (
+		initializedKey = singletonKey(InitializedKey)
+		batch          = db.NewBatch()
+	)
+	for {
+		key, value, ok := cr.readEntry()
+		if cr.err != nil {
+			return ids.Empty, 0, fmt.Errorf("%w: failed to read entry: %w", ErrInvalidCheckpoint, cr.err)
+		}
+		if !ok {
+			break
+		}
+		// The state is marked as initialized after the checkpoint is verified.
+		if bytes.Equal(key, initializedKey) {
+			continue
+		}
+		if err := batch.Put(key, value); err != nil {
+			return ids.Empty, 0, err
+		}
+		if batch.Size() < checkpointImportBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return ids.Empty, 0, err
+		}
+		batch.Reset()
+	}
+
+	expectedChecksum := hasher.Sum(nil)
+	checksum := make([]byte, len(expectedChecksum))
+	if _, err := io.ReadFull(br, checksum); err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: failed to read checksum: %w", ErrInvalidCheckpoint, err)
+	}
+	if !bytes.Equal(checksum, expectedChecksum) {
+		return ids.Empty, 0, fmt.Errorf("%w: checksum mismatch", ErrInvalidCheckpoint)
+	}
+
+	// The history below the checkpointed height wasn't exported, so it is
+	// reported as pruned.
+	if err := database.PutUInt64(batch, singletonKey(ValidatorDiffsPrunedHeightKey), height); err != nil {
+		return ids.Empty, 0, err
+	}
+	if err := database.PutUInt64(batch, singletonKey(TxsPrunedHeightKey), height); err != nil {
+		return ids.Empty, 0, err
+	}
+	// The UTXO journal wasn't exported, but the UTXOs at the checkpointed
+	// height are known.
+	if err := database.PutUInt64(batch, singletonKey(UTXOJournalStartHeightKey), height); err != nil {
+		return ids.Empty, 0, err
+	}
+	if err := batch.Put(initializedKey, nil); err != nil {
+		return ids.Empty, 0, err
+	}
+	return lastAcceptedID, height, batch.Write()
+}
+
+func singletonKey(key []byte) []byte {
+	return prefixdb.PrefixKey(prefixdb.MakePrefix(SingletonPrefix), key)
+}
+
+// historicalPrefixes returns the prefixes of the databases that only contain
+// data about the history of the chain.
+func historicalPrefixes() set.Set[string] {
+	validatorsPrefix := prefixdb.MakePrefix(ValidatorsPrefix)
+	return set.Of(
+		string(prefixdb.MakePrefix(BlockPrefix)),
+		string(prefixdb.MakePrefix(BlockIDPrefix)),
+		string(prefixdb.MakePrefix(BlockSummaryPrefix)),
+		string(prefixdb.MakePrefix(UTXOJournalPrefix)),
+		string(prefixdb.JoinPrefixes(validatorsPrefix, ValidatorWeightDiffsPrefix)),
+		string(prefixdb.JoinPrefixes(validatorsPrefix, ValidatorPublicKeyDiffsPrefix)),
+		string(prefixdb.JoinPrefixes(validatorsPrefix, StakerDiffsPrefix)),
+		string(prefixdb.JoinPrefixes(validatorsPrefix, ValidatorCheckpointsPrefix)),
+	)
+}
+
+// checkpointWriter writes the fields of a checkpoint, remembering the first
+// error that occurred.
+type checkpointWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *checkpointWriter) write(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *checkpointWriter) writeUint64(v uint64) {
+	w.write(binary.BigEndian.AppendUint64(nil, v))
+}
+
+func (w *checkpointWriter) writeBytes(b []byte) {
+	w.writeUint64(uint64(len(b)))
+	w.write(b)
+}
+
+func (w *checkpointWriter) writeEntry(key, value []byte) {
+	w.write([]byte{checkpointEntryMarker})
+	w.writeBytes(key)
+	w.writeBytes(value)
+}
+
+// checkpointReader reads the fields of a checkpoint, remembering the first
+// error that occurred.
+type checkpointReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *checkpointReader) read(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > maxCheckpointEntrySize {
+		r.err = fmt.Errorf("entry of %d bytes exceeds the maximum of %d bytes", n, maxCheckpointEntrySize)
+		return nil
+	}
+	b := make([]byte, n)
+	_, r.err = io.ReadFull(r.r, b)
+	return b
+}
+
+func (r *checkpointReader) readUint64() uint64 {
+	b := r.read(8)
+	if r.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *checkpointReader) readBytes() []byte {
+	return r.read(r.readUint64())
+}
+
+// readEntry returns the next key/value pair of the checkpoint, or false if
+// there are no more entries.
+func (r *checkpointReader) readEntry() ([]byte, []byte, bool) {
+	marker := r.read(1)
+	if r.err != nil {
+		return nil, nil, false
+	}
+	switch marker[0] {
+	case checkpointEndMarker:
+		return nil, nil, false
+	case checkpointEntryMarker:
+		key := r.readBytes()
+		value := r.readBytes()
+		return key, value, r.err == nil
+	default:
+		r.err = fmt.Errorf("unexpected marker %d", marker[0])
+		return nil, nil, false
+	}
+}
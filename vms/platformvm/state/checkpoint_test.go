@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCheckpoint(t *testing.T) {
+	s, db := newUninitializedState(require.New(t))
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: initialTxID,
+		},
+		Asset: avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Schmeckle,
+		},
+	}
+	genesisBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 0)
+	require.NoError(t, err)
+	require.NoError(t, s.syncGenesis(genesisBlk, &genesis.Genesis{
+		UTXOs: []*genesis.UTXO{
+			{UTXO: *utxo},
+		},
+		Timestamp:     uint64(initialTime.Unix()),
+		InitialSupply: units.Schmeckle,
+	}))
+	require.NoError(t, s.Commit())
+	require.NoError(t, s.doneInit())
+
+	// Accept a block whose history will not be exported.
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(t, s.validatorWeightDiffsDB.Put(
+		marshalDiffKey(constants.PrimaryNetworkID, 1, nodeID),
+		marshalWeightDiff(&ValidatorWeightDiff{
+			Amount: 1,
+		}),
+	))
+	require.NoError(t, s.stakerDiffsDB.Put(
+		marshalDiffKey(constants.PrimaryNetworkID, 1, nodeID),
+		[]byte{0},
+	))
+	require.NoError(t, s.validatorCheckpointsDB.Put(
+		marshalCheckpointKey(constants.PrimaryNetworkID, 1),
+		[]byte{0},
+	))
+	blk, err := block.NewBanffStandardBlock(initialTime, genesisBlk.ID(), 1, []*txs.Tx{})
+	require.NoError(t, err)
+	s.AddStatelessBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	s.SetHeight(1)
+	require.NoError(t, s.Commit())
+
+	var checkpoint bytes.Buffer
+	lastAcceptedID, height, err := ExportCheckpoint(db, &checkpoint)
+	require.NoError(t, err)
+	require.Equal(t, blk.ID(), lastAcceptedID)
+	require.Equal(t, uint64(1), height)
+
+	t.Run("import", func(t *testing.T) {
+		require := require.New(t)
+
+		importedDB := memdb.New()
+		lastAcceptedID, height, err := ImportCheckpoint(bytes.NewReader(checkpoint.Bytes()), importedDB)
+		require.NoError(err)
+		require.Equal(blk.ID(), lastAcceptedID)
+		require.Equal(uint64(1), height)
+
+		imported := newStateFromDB(require, importedDB)
+		require.NoError(imported.sync(nil))
+
+		require.Equal(blk.ID(), imported.GetLastAccepted())
+		require.Equal(initialTime.Unix(), imported.GetTimestamp().Unix())
+		supply, err := imported.GetCurrentSupply(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Equal(uint64(units.Schmeckle), supply)
+		importedUTXO, err := imported.GetUTXO(utxo.InputID())
+		require.NoError(err)
+		require.Equal(utxo.InputID(), importedUTXO.InputID())
+
+		// Only the last accepted block is exported.
+		_, err = imported.GetStatelessBlock(blk.ID())
+		require.NoError(err)
+		blkID, err := imported.GetBlockIDAtHeight(1)
+		require.NoError(err)
+		require.Equal(blk.ID(), blkID)
+		_, err = imported.GetStatelessBlock(genesisBlk.ID())
+		require.ErrorIs(err, database.ErrNotFound)
+
+		// Validator sets can't be rebuilt below the checkpointed height.
+		vdrs := map[ids.NodeID]*validators.GetValidatorOutput{}
+		err = imported.ApplyValidatorWeightDiffs(context.Background(), vdrs, 1, 1, constants.PrimaryNetworkID)
+		require.ErrorIs(err, ErrValidatorDiffsPruned)
+
+		// Neither the staker diffs nor the validator checkpoints are exported.
+		for _, db := range []database.Database{
+			imported.validatorWeightDiffsDB,
+			imported.stakerDiffsDB,
+			imported.validatorCheckpointsDB,
+		} {
+			isEmpty, err := database.IsEmpty(db)
+			require.NoError(err)
+			require.True(isEmpty)
+		}
+	})
+
+	t.Run("database not empty", func(t *testing.T) {
+		_, _, err := ImportCheckpoint(bytes.NewReader(checkpoint.Bytes()), db)
+		require.ErrorIs(t, err, ErrDatabaseNotEmpty)
+	})
+
+	t.Run("corrupted checkpoint", func(t *testing.T) {
+		require := require.New(t)
+
+		corrupted := bytes.Clone(checkpoint.Bytes())
+		corrupted[len(corrupted)-1]++
+
+		importedDB := memdb.New()
+		_, _, err := ImportCheckpoint(bytes.NewReader(corrupted), importedDB)
+		require.ErrorIs(err, ErrInvalidCheckpoint)
+
+		// The state must not be marked as initialized.
+		initialized, err := importedDB.Has(singletonKey(InitializedKey))
+		require.NoError(err)
+		require.False(initialized)
+	})
+
+	t.Run("truncated checkpoint", func(t *testing.T) {
+		truncated := checkpoint.Bytes()[:checkpoint.Len()/2]
+		_, _, err := ImportCheckpoint(bytes.NewReader(truncated), memdb.New())
+		require.ErrorIs(t, err, ErrInvalidCheckpoint)
+	})
+}
+
+func TestExportCheckpointExcludesHistoricalPrefixes(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+	genesisBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 0)
+	require.NoError(err)
+	require.NoError(s.syncGenesis(genesisBlk, &genesis.Genesis{
+		Timestamp: uint64(initialTime.Unix()),
+	}))
+	require.NoError(s.Commit())
+	require.NoError(s.doneInit())
+	blk, err := block.NewBanffStandardBlock(initialTime, genesisBlk.ID(), 1, []*txs.Tx{})
+	require.NoError(err)
+	s.AddStatelessBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Every database that is only written to record the history of the chain
+	// must be listed here. This list is intentionally not derived from
+	// historicalPrefixes.
+	validatorsPrefix := prefixdb.MakePrefix(ValidatorsPrefix)
+	historicalDBPrefixes := map[string][]byte{
+		"block":                   prefixdb.MakePrefix(BlockPrefix),
+		"blockID":                 prefixdb.MakePrefix(BlockIDPrefix),
+		"blockSummary":            prefixdb.MakePrefix(BlockSummaryPrefix),
+		"utxoJournal":             prefixdb.MakePrefix(UTXOJournalPrefix),
+		"validatorWeightDiffs":    prefixdb.JoinPrefixes(validatorsPrefix, ValidatorWeightDiffsPrefix),
+		"validatorPublicKeyDiffs": prefixdb.JoinPrefixes(validatorsPrefix, ValidatorPublicKeyDiffsPrefix),
+		"stakerDiffs":             prefixdb.JoinPrefixes(validatorsPrefix, StakerDiffsPrefix),
+		"validatorCheckpoints":    prefixdb.JoinPrefixes(validatorsPrefix, ValidatorCheckpointsPrefix),
+	}
+	require.Len(historicalPrefixes(), len(historicalDBPrefixes))
+
+	historicalKey := []byte("history")
+	for _, prefix := range historicalDBPrefixes {
+		require.NoError(db.Put(prefixdb.PrefixKey(prefix, historicalKey), historicalKey))
+	}
+
+	var checkpoint bytes.Buffer
+	_, _, err = ExportCheckpoint(db, &checkpoint)
+	require.NoError(err)
+
+	importedDB := memdb.New()
+	_, _, err = ImportCheckpoint(&checkpoint, importedDB)
+	require.NoError(err)
+
+	for name, prefix := range historicalDBPrefixes {
+		has, err := importedDB.Has(prefixdb.PrefixKey(prefix, historicalKey))
+		require.NoError(err)
+		require.False(has, "%s was exported", name)
+	}
+}
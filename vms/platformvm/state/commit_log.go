@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// commitLogHeaderLen is the length of the header of a record, which is the
+// length of the record followed by its checksum.
+const commitLogHeaderLen = 2 * wrappers.IntLen
+
+var errCommitLogRecordTooLarge = errors.New("commit log record too large")
+
+// commitLogFile is the file a commitLog is written to.
+type commitLogFile interface {
+	io.Writer
+
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// commitLog is a write-ahead log of the commits of an asyncDB.
+//
+// Every commit is appended before it is acknowledged. Appending doesn't sync
+// the log to disk. Like the underlying database, which doesn't sync its
+// writes either, an acknowledged commit survives the process crashing but may
+// be lost if the OS crashes before the log is synced. The log is truncated
+// once every logged commit has been written to the underlying database. On
+// startup, the logged commits are replayed, which restores the commits that
+// were acknowledged but not yet written when the node stopped.
+type commitLog struct {
+	file commitLogFile
+}
+
+// openCommitLog opens the log at [path] and returns the commits it contains,
+// in the order they were appended.
+//
+// A record that was only partially appended, because the node stopped while
+// appending it, was never acknowledged, so it and everything after it are
+// ignored.
+func openCommitLog(path string) (*commitLog, [][]database.BatchOp, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, perms.ReadWrite)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open commit log: %w", err)
+	}
+
+	logBytes, err := io.ReadAll(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+
+	var commits [][]database.BatchOp
+	for len(logBytes) >= commitLogHeaderLen {
+		header := wrappers.Packer{Bytes: logBytes}
+		recordLen := int(header.UnpackInt())
+		checksum := header.UnpackInt()
+
+		logBytes = logBytes[commitLogHeaderLen:]
+		if recordLen > len(logBytes) {
+			break
+		}
+		record := logBytes[:recordLen]
+		if crc32.ChecksumIEEE(record) != checksum {
+			break
+		}
+		logBytes = logBytes[recordLen:]
+
+		ops, err := parseCommitLogRecord(record)
+		if err != nil {
+			_ = file.Close()
+			return nil, nil, fmt.Errorf("failed to parse commit log: %w", err)
+		}
+		commits = append(commits, ops)
+	}
+	return &commitLog{file: file}, commits, nil
+}
+
+// Append appends [ops] to the log. The log isn't synced to disk.
+func (l *commitLog) Append(ops []database.BatchOp) error {
+	record := wrappers.Packer{
+		MaxSize: math.MaxInt32,
+		Bytes:   make([]byte, commitLogHeaderLen),
+		Offset:  commitLogHeaderLen,
+	}
+	record.PackInt(uint32(len(ops)))
+	for _, op := range ops {
+		record.PackBool(op.Delete)
+		record.PackBytes(op.Key)
+		record.PackBytes(op.Value)
+	}
+	if record.Err != nil {
+		return fmt.Errorf("%w: %w", errCommitLogRecordTooLarge, record.Err)
+	}
+
+	header := wrappers.Packer{Bytes: record.Bytes}
+	body := record.Bytes[commitLogHeaderLen:]
+	header.PackInt(uint32(len(body)))
+	header.PackInt(crc32.ChecksumIEEE(body))
+
+	if _, err := l.file.Write(record.Bytes); err != nil {
+		return fmt.Errorf("failed to append to commit log: %w", err)
+	}
+	return nil
+}
+
+// Sync syncs every appended commit to disk.
+func (l *commitLog) Sync() error {
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync commit log: %w", err)
+	}
+	return nil
+}
+
+// Truncate removes every commit from the log.
+func (l *commitLog) Truncate() error {
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate commit log: %w", err)
+	}
+	return l.file.Sync()
+}
+
+func (l *commitLog) Close() error {
+	return l.file.Close()
+}
+
+func parseCommitLogRecord(record []byte) ([]database.BatchOp, error) {
+	p := wrappers.Packer{Bytes: record}
+	numOps := p.UnpackInt()
+	// Every op takes at least a byte, which bounds the allocation below.
+	if int(numOps) > len(record) {
+		return nil, wrappers.ErrInsufficientLength
+	}
+
+	ops := make([]database.BatchOp, numOps)
+	for i := range ops {
+		ops[i] = database.BatchOp{
+			Delete: p.UnpackBool(),
+			Key:    p.UnpackBytes(),
+			Value:  p.UnpackBytes(),
+		}
+	}
+	return ops, p.Err
+}
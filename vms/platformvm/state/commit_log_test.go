@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+func TestCommitLog(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), asyncCommitLogFileName)
+	log, commits, err := openCommitLog(path)
+	require.NoError(err)
+	require.Empty(commits)
+
+	expectedCommits := [][]database.BatchOp{
+		{
+			{Key: []byte{1}, Value: []byte{2}},
+			{Key: []byte{3}, Delete: true},
+		},
+		{},
+		{
+			{Key: []byte{4}, Value: []byte{}},
+		},
+	}
+	for _, ops := range expectedCommits {
+		require.NoError(log.Append(ops))
+	}
+	require.NoError(log.Close())
+
+	// Simulate a crash while appending a commit.
+	logBytes, err := os.ReadFile(path)
+	require.NoError(err)
+	require.NoError(os.WriteFile(path, append(logBytes, 0, 0, 0, 5, 1, 2), perms.ReadWrite))
+
+	log, commits, err = openCommitLog(path)
+	require.NoError(err)
+	require.Len(commits, len(expectedCommits))
+	for i, ops := range commits {
+		require.Len(ops, len(expectedCommits[i]))
+		for j, op := range ops {
+			expectedOp := expectedCommits[i][j]
+			require.Equal(expectedOp.Delete, op.Delete)
+			require.Equal(expectedOp.Key, op.Key)
+			if !expectedOp.Delete {
+				require.Equal(expectedOp.Value, op.Value)
+			}
+		}
+	}
+
+	require.NoError(log.Truncate())
+	require.NoError(log.Close())
+
+	log, commits, err = openCommitLog(path)
+	require.NoError(err)
+	require.Empty(commits)
+	require.NoError(log.Close())
+}
+
+func TestCommitLogCorruptRecord(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), asyncCommitLogFileName)
+	log, _, err := openCommitLog(path)
+	require.NoError(err)
+	require.NoError(log.Append([]database.BatchOp{{Key: []byte{1}, Value: []byte{1}}}))
+	require.NoError(log.Append([]database.BatchOp{{Key: []byte{2}, Value: []byte{2}}}))
+	require.NoError(log.Close())
+
+	// Flip a bit in the value of the last record so that its checksum no
+	// longer matches.
+	logBytes, err := os.ReadFile(path)
+	require.NoError(err)
+	logBytes[len(logBytes)-1] ^= 1
+	require.NoError(os.WriteFile(path, logBytes, perms.ReadWrite))
+
+	log, commits, err := openCommitLog(path)
+	require.NoError(err)
+	require.Len(commits, 1)
+	require.Equal([]byte{1}, commits[0][0].Key)
+	require.NoError(log.Close())
+}
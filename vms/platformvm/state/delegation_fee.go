@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "time"
+
+// DelegationFeeChange records that a validator changed the delegation fee it
+// charges to delegators whose staking period starts at or after Time.
+type DelegationFeeChange struct {
+	// Time is the unix timestamp at which the change was made.
+	Time uint64 `serialize:"true" json:"time"`
+	// Shares is the new delegation fee, out of reward.PercentDenominator.
+	Shares uint32 `serialize:"true" json:"shares"`
+}
+
+// DelegationShares returns the delegation fee charged to a delegator whose
+// staking period starts at [startTime]. [initialShares] is the fee the
+// validator was added with and [changes] are the changes made since, in the
+// order they were made.
+func DelegationShares(
+	initialShares uint32,
+	changes []*DelegationFeeChange,
+	startTime time.Time,
+) uint32 {
+	shares := initialShares
+	for _, change := range changes {
+		if change.Time > uint64(startTime.Unix()) {
+			break
+		}
+		shares = change.Shares
+	}
+	return shares
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationShares(t *testing.T) {
+	changes := []*DelegationFeeChange{
+		{
+			Time:   10,
+			Shares: 30_000,
+		},
+		{
+			Time:   20,
+			Shares: 40_000,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		changes        []*DelegationFeeChange
+		startTime      int64
+		expectedShares uint32
+	}{
+		{
+			name:           "no changes",
+			changes:        nil,
+			startTime:      15,
+			expectedShares: 20_000,
+		},
+		{
+			name:           "before first change",
+			changes:        changes,
+			startTime:      9,
+			expectedShares: 20_000,
+		},
+		{
+			name:           "at first change",
+			changes:        changes,
+			startTime:      10,
+			expectedShares: 30_000,
+		},
+		{
+			name:           "between changes",
+			changes:        changes,
+			startTime:      19,
+			expectedShares: 30_000,
+		},
+		{
+			name:           "after last change",
+			changes:        changes,
+			startTime:      25,
+			expectedShares: 40_000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shares := DelegationShares(20_000, test.changes, time.Unix(test.startTime, 0))
+			require.Equal(t, test.expectedShares, shares)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// DelegatorWeightChange is a change to the weight delegated to a validator.
+type DelegatorWeightChange struct {
+	// Time is when the change is applied.
+	Time time.Time
+	// Weight is the amount of weight being delegated or returned.
+	Weight uint64
+	// Added is true if [Weight] starts being delegated at [Time] and false if
+	// [Weight] stops being delegated at [Time].
+	Added bool
+}
+
+// DelegatorWeightSchedule describes the weight delegated to a validator over
+// time.
+type DelegatorWeightSchedule struct {
+	// Current is the weight of the current delegators of the validator.
+	Current uint64
+	// Changes are the future changes to the delegated weight. Changes are
+	// sorted by their time. If changes share the same time, additions are
+	// ordered before removals, matching the order in which the stakers are
+	// moved as chain time advances.
+	Changes []DelegatorWeightChange
+}
+
+// GetDelegatorWeightSchedule returns the schedule of the weight delegated to
+// the validator on [subnetID] with [nodeID].
+//
+// Only the delegators of the requested validator are read, so the cost is
+// independent of the number of other stakers.
+func GetDelegatorWeightSchedule(
+	chain Stakers,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+) (*DelegatorWeightSchedule, error) {
+	currentDelegatorIterator, err := chain.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer currentDelegatorIterator.Release()
+
+	schedule := &DelegatorWeightSchedule{}
+	for currentDelegatorIterator.Next() {
+		delegator := currentDelegatorIterator.Value()
+		schedule.Current, err = math.Add64(schedule.Current, delegator.Weight)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Changes = append(schedule.Changes, DelegatorWeightChange{
+			Time:   delegator.EndTime,
+			Weight: delegator.Weight,
+		})
+	}
+
+	pendingDelegatorIterator, err := chain.GetPendingDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer pendingDelegatorIterator.Release()
+
+	for pendingDelegatorIterator.Next() {
+		delegator := pendingDelegatorIterator.Value()
+		schedule.Changes = append(schedule.Changes,
+			DelegatorWeightChange{
+				Time:   delegator.StartTime,
+				Weight: delegator.Weight,
+				Added:  true,
+			},
+			DelegatorWeightChange{
+				Time:   delegator.EndTime,
+				Weight: delegator.Weight,
+			},
+		)
+	}
+
+	slices.SortStableFunc(schedule.Changes, func(a, b DelegatorWeightChange) int {
+		if cmp := a.Time.Compare(b.Time); cmp != 0 {
+			return cmp
+		}
+		switch {
+		case a.Added == b.Added:
+			return 0
+		case a.Added:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return schedule, nil
+}
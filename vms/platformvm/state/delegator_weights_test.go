@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestGetDelegatorWeightSchedule(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		subnetID = constants.PrimaryNetworkID
+		nodeID   = ids.GenerateTestNodeID()
+		now      = time.Unix(1_000, 0)
+		current  = []*Staker{
+			{
+				Weight:  1,
+				EndTime: now.Add(3 * time.Second),
+			},
+			{
+				Weight:  2,
+				EndTime: now.Add(5 * time.Second),
+			},
+		}
+		pending = []*Staker{
+			{
+				Weight:    4,
+				StartTime: now.Add(3 * time.Second),
+				EndTime:   now.Add(4 * time.Second),
+			},
+			{
+				Weight:    8,
+				StartTime: now.Add(5 * time.Second),
+				EndTime:   now.Add(6 * time.Second),
+			},
+		}
+	)
+
+	chain := NewMockChain(ctrl)
+	chain.EXPECT().GetCurrentDelegatorIterator(subnetID, nodeID).Return(NewSliceIterator(current...), nil)
+	chain.EXPECT().GetPendingDelegatorIterator(subnetID, nodeID).Return(NewSliceIterator(pending...), nil)
+
+	schedule, err := GetDelegatorWeightSchedule(chain, subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(
+		&DelegatorWeightSchedule{
+			Current: 3,
+			Changes: []DelegatorWeightChange{
+				{
+					Time:   now.Add(3 * time.Second),
+					Weight: 4,
+					Added:  true,
+				},
+				{
+					Time:   now.Add(3 * time.Second),
+					Weight: 1,
+				},
+				{
+					Time:   now.Add(4 * time.Second),
+					Weight: 4,
+				},
+				{
+					Time:   now.Add(5 * time.Second),
+					Weight: 8,
+					Added:  true,
+				},
+				{
+					Time:   now.Add(5 * time.Second),
+					Weight: 2,
+				},
+				{
+					Time:   now.Add(6 * time.Second),
+					Weight: 8,
+				},
+			},
+		},
+		schedule,
+	)
+}
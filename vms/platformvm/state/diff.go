@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -46,6 +47,16 @@ type diff struct {
 	addedSubnets []*txs.Tx
 	// Subnet ID --> Owner of the subnet
 	subnetOwners map[ids.ID]fx.Owner
+	// Validator TxID --> Changes to the validator's delegation fee
+	delegationFeeChanges map[ids.ID][]*DelegationFeeChange
+	// Validator TxID --> TxID of the report of the validator's misbehavior
+	misbehaviorReports map[ids.ID]ids.ID
+	// Subnet ID --> Staking parameters that replaced the ones of the subnet's
+	// transformation
+	subnetStakingParams map[ids.ID]*SubnetStakingParams
+	// Subnet ID --> Duration of the epochs the subnet's validator set is
+	// frozen for
+	subnetValidatorEpochs map[ids.ID]time.Duration
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
 
@@ -126,7 +137,7 @@ func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	// validator.
 	newValidator, status := d.currentStakerDiffs.GetValidator(subnetID, nodeID)
 	switch status {
-	case added:
+	case added, modified:
 		return newValidator, nil
 	case deleted:
 		return nil, database.ErrNotFound
@@ -173,6 +184,10 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) UpdateCurrentValidator(staker *Staker) {
+	d.currentStakerDiffs.UpdateValidator(staker)
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -209,6 +224,35 @@ func (d *diff) GetCurrentStakerIterator() (StakerIterator, error) {
 	return d.currentStakerDiffs.GetStakerIterator(parentIterator), nil
 }
 
+func (d *diff) GetCurrentSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetCurrentSubnetStakerIterator(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.currentStakerDiffs.GetSubnetStakerIterator(parentIterator, subnetID), nil
+}
+
+func (d *diff) GetCurrentValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	subnetIDs, err := parentState.GetCurrentValidatorSubnetIDs(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.currentStakerDiffs.ApplyValidatorSubnetIDs(nodeID, &subnetIDs)
+	return subnetIDs, nil
+}
+
 func (d *diff) GetPendingValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -272,6 +316,35 @@ func (d *diff) GetPendingStakerIterator() (StakerIterator, error) {
 	return d.pendingStakerDiffs.GetStakerIterator(parentIterator), nil
 }
 
+func (d *diff) GetPendingSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetPendingSubnetStakerIterator(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingStakerDiffs.GetSubnetStakerIterator(parentIterator, subnetID), nil
+}
+
+func (d *diff) GetPendingValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	subnetIDs, err := parentState.GetPendingValidatorSubnetIDs(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.pendingStakerDiffs.ApplyValidatorSubnetIDs(nodeID, &subnetIDs)
+	return subnetIDs, nil
+}
+
 func (d *diff) AddSubnet(createSubnetTx *txs.Tx) {
 	d.addedSubnets = append(d.addedSubnets, createSubnetTx)
 }
@@ -294,6 +367,93 @@ func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	d.subnetOwners[subnetID] = owner
 }
 
+func (d *diff) GetDelegationFeeChanges(validatorTxID ids.ID) ([]*DelegationFeeChange, error) {
+	changes, exists := d.delegationFeeChanges[validatorTxID]
+	if exists {
+		return changes, nil
+	}
+
+	// If the changes were not modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, ErrMissingParentState
+	}
+	return parentState.GetDelegationFeeChanges(validatorTxID)
+}
+
+func (d *diff) SetDelegationFeeChanges(validatorTxID ids.ID, changes []*DelegationFeeChange) {
+	if d.delegationFeeChanges == nil {
+		d.delegationFeeChanges = make(map[ids.ID][]*DelegationFeeChange)
+	}
+	d.delegationFeeChanges[validatorTxID] = changes
+}
+
+func (d *diff) GetMisbehaviorReport(validatorTxID ids.ID) (ids.ID, error) {
+	if reportTxID, exists := d.misbehaviorReports[validatorTxID]; exists {
+		if reportTxID == ids.Empty {
+			return ids.Empty, database.ErrNotFound
+		}
+		return reportTxID, nil
+	}
+
+	// If the report was not modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return ids.Empty, ErrMissingParentState
+	}
+	return parentState.GetMisbehaviorReport(validatorTxID)
+}
+
+func (d *diff) SetMisbehaviorReport(validatorTxID ids.ID, reportTxID ids.ID) {
+	if d.misbehaviorReports == nil {
+		d.misbehaviorReports = make(map[ids.ID]ids.ID)
+	}
+	d.misbehaviorReports[validatorTxID] = reportTxID
+}
+
+func (d *diff) GetSubnetStakingParams(subnetID ids.ID) (*SubnetStakingParams, error) {
+	if params, exists := d.subnetStakingParams[subnetID]; exists {
+		return params, nil
+	}
+
+	// If the params were not modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, ErrMissingParentState
+	}
+	return parentState.GetSubnetStakingParams(subnetID)
+}
+
+func (d *diff) SetSubnetStakingParams(subnetID ids.ID, params *SubnetStakingParams) {
+	if d.subnetStakingParams == nil {
+		d.subnetStakingParams = make(map[ids.ID]*SubnetStakingParams)
+	}
+	d.subnetStakingParams[subnetID] = params
+}
+
+func (d *diff) GetSubnetValidatorEpoch(subnetID ids.ID) (time.Duration, error) {
+	if epoch, exists := d.subnetValidatorEpochs[subnetID]; exists {
+		if epoch == 0 {
+			return 0, database.ErrNotFound
+		}
+		return epoch, nil
+	}
+
+	// If the epoch was not modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, ErrMissingParentState
+	}
+	return parentState.GetSubnetValidatorEpoch(subnetID)
+}
+
+func (d *diff) SetSubnetValidatorEpoch(subnetID ids.ID, epoch time.Duration) {
+	if d.subnetValidatorEpochs == nil {
+		d.subnetValidatorEpochs = make(map[ids.ID]time.Duration)
+	}
+	d.subnetValidatorEpochs[subnetID] = epoch
+}
+
 func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	tx, exists := d.transformedSubnets[subnetID]
 	if exists {
@@ -411,6 +571,8 @@ func (d *diff) Apply(baseState Chain) error {
 				baseState.PutCurrentValidator(validatorDiff.validator)
 			case deleted:
 				baseState.DeleteCurrentValidator(validatorDiff.validator)
+			case modified:
+				baseState.UpdateCurrentValidator(validatorDiff.validator)
 			}
 
 			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
@@ -480,5 +642,17 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, owner := range d.subnetOwners {
 		baseState.SetSubnetOwner(subnetID, owner)
 	}
+	for validatorTxID, changes := range d.delegationFeeChanges {
+		baseState.SetDelegationFeeChanges(validatorTxID, changes)
+	}
+	for validatorTxID, reportTxID := range d.misbehaviorReports {
+		baseState.SetMisbehaviorReport(validatorTxID, reportTxID)
+	}
+	for subnetID, params := range d.subnetStakingParams {
+		baseState.SetSubnetStakingParams(subnetID, params)
+	}
+	for subnetID, epoch := range d.subnetValidatorEpochs {
+		baseState.SetSubnetValidatorEpoch(subnetID, epoch)
+	}
 	return nil
 }
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	_ database.Database = (*ioCountingDB)(nil)
+	_ database.Batch    = (*ioCountingBatch)(nil)
+	_ database.Iterator = (*ioCountingIterator)(nil)
+
+	ioCountBuckets = prometheus.ExponentialBuckets(1, 4, 12)
+	ioBytesBuckets = prometheus.ExponentialBuckets(64, 4, 12)
+)
+
+// ioStats are the database operations performed since the stats were last
+// taken.
+type ioStats struct {
+	reads        uint64
+	readBytes    uint64
+	writes       uint64
+	writtenBytes uint64
+}
+
+// ioMetrics reports the database operations performed for every accepted
+// block.
+type ioMetrics struct {
+	reads        prometheus.Histogram
+	readBytes    prometheus.Histogram
+	writes       prometheus.Histogram
+	writtenBytes prometheus.Histogram
+}
+
+func newIOMetrics(registerer prometheus.Registerer) (*ioMetrics, error) {
+	m := &ioMetrics{
+		reads: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_db_reads",
+			Help:    "Number of database reads performed for each accepted block",
+			Buckets: ioCountBuckets,
+		}),
+		readBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_db_read_bytes",
+			Help:    "Number of bytes read from the database for each accepted block",
+			Buckets: ioBytesBuckets,
+		}),
+		writes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_db_writes",
+			Help:    "Number of database writes performed for each accepted block",
+			Buckets: ioCountBuckets,
+		}),
+		writtenBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_db_written_bytes",
+			Help:    "Number of bytes written to the database for each accepted block",
+			Buckets: ioBytesBuckets,
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.reads),
+		registerer.Register(m.readBytes),
+		registerer.Register(m.writes),
+		registerer.Register(m.writtenBytes),
+	)
+	return m, errs.Err
+}
+
+func (m *ioMetrics) observe(stats ioStats) {
+	m.reads.Observe(float64(stats.reads))
+	m.readBytes.Observe(float64(stats.readBytes))
+	m.writes.Observe(float64(stats.writes))
+	m.writtenBytes.Observe(float64(stats.writtenBytes))
+}
+
+// ioCountingDB counts the reads and writes performed on the underlying
+// database. Writes are counted when they are added to a batch, rather than
+// when the batch is written, so that the writes of a block can be counted
+// before its batch is written.
+type ioCountingDB struct {
+	database.Database
+
+	reads        atomic.Uint64
+	readBytes    atomic.Uint64
+	writes       atomic.Uint64
+	writtenBytes atomic.Uint64
+}
+
+func newIOCountingDB(db database.Database) *ioCountingDB {
+	return &ioCountingDB{
+		Database: db,
+	}
+}
+
+// takeStats returns the operations performed since the last call to
+// takeStats.
+func (db *ioCountingDB) takeStats() ioStats {
+	return ioStats{
+		reads:        db.reads.Swap(0),
+		readBytes:    db.readBytes.Swap(0),
+		writes:       db.writes.Swap(0),
+		writtenBytes: db.writtenBytes.Swap(0),
+	}
+}
+
+func (db *ioCountingDB) countRead(numBytes int) {
+	db.reads.Add(1)
+	db.readBytes.Add(uint64(numBytes))
+}
+
+func (db *ioCountingDB) countWrite(numBytes int) {
+	db.writes.Add(1)
+	db.writtenBytes.Add(uint64(numBytes))
+}
+
+func (db *ioCountingDB) Has(key []byte) (bool, error) {
+	db.countRead(len(key))
+	return db.Database.Has(key)
+}
+
+func (db *ioCountingDB) Get(key []byte) ([]byte, error) {
+	value, err := db.Database.Get(key)
+	db.countRead(len(key) + len(value))
+	return value, err
+}
+
+func (db *ioCountingDB) Put(key []byte, value []byte) error {
+	db.countWrite(len(key) + len(value))
+	return db.Database.Put(key, value)
+}
+
+func (db *ioCountingDB) Delete(key []byte) error {
+	db.countWrite(len(key))
+	return db.Database.Delete(key)
+}
+
+func (db *ioCountingDB) NewBatch() database.Batch {
+	return &ioCountingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *ioCountingDB) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *ioCountingDB) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *ioCountingDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *ioCountingDB) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &ioCountingIterator{
+		Iterator: db.Database.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+type ioCountingBatch struct {
+	database.Batch
+	db *ioCountingDB
+}
+
+func (b *ioCountingBatch) Put(key, value []byte) error {
+	b.db.countWrite(len(key) + len(value))
+	return b.Batch.Put(key, value)
+}
+
+func (b *ioCountingBatch) Delete(key []byte) error {
+	b.db.countWrite(len(key))
+	return b.Batch.Delete(key)
+}
+
+type ioCountingIterator struct {
+	database.Iterator
+	db *ioCountingDB
+}
+
+func (it *ioCountingIterator) Next() bool {
+	if !it.Iterator.Next() {
+		return false
+	}
+	it.db.countRead(len(it.Iterator.Key()) + len(it.Iterator.Value()))
+	return true
+}
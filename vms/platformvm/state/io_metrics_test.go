@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestIOCountingDBInterface(t *testing.T) {
+	for name, test := range database.Tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newIOCountingDB(memdb.New()))
+		})
+	}
+}
+
+func TestIOCountingDB(t *testing.T) {
+	require := require.New(t)
+
+	db := newIOCountingDB(memdb.New())
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte{1}, []byte{1, 2, 3}))
+	require.NoError(batch.Delete([]byte{2}))
+	require.Equal(ioStats{
+		writes:       2,
+		writtenBytes: 5,
+	}, db.takeStats())
+
+	require.NoError(batch.Write())
+	_, err := db.Get([]byte{1})
+	require.NoError(err)
+	_, err = db.Has([]byte{2})
+	require.NoError(err)
+
+	it := db.NewIterator()
+	require.True(it.Next())
+	require.False(it.Next())
+	it.Release()
+	require.Equal(ioStats{
+		reads:     3,
+		readBytes: 9,
+	}, db.takeStats())
+	require.Equal(ioStats{}, db.takeStats())
+}
+
+func TestStateIOMetrics(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.SetHeight(1)
+	_, err := s.CommitBatch()
+	require.NoError(err)
+
+	// The writes of the block were reported.
+	writes := &dto.Metric{}
+	require.NoError(s.ioMetrics.writes.Write(writes))
+	require.Equal(uint64(1), writes.GetHistogram().GetSampleCount())
+	require.Positive(writes.GetHistogram().GetSampleSum())
+
+	// The stats were reset for the next block.
+	require.Equal(ioStats{}, s.ioDB.takeStats())
+}
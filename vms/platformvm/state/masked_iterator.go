@@ -13,7 +13,9 @@ type maskedIterator struct {
 }
 
 // NewMaskedIterator returns a new iterator that skips the stakers in
-// [parentIterator] that are present in [maskedStakers].
+// [parentIterator] that are present in [maskedStakers]. A staker that was
+// rotated to a different NodeID than the masked staker with its txID isn't
+// skipped.
 func NewMaskedIterator(parentIterator StakerIterator, maskedStakers map[ids.ID]*Staker) StakerIterator {
 	return &maskedIterator{
 		parentIterator: parentIterator,
@@ -24,7 +26,8 @@ func NewMaskedIterator(parentIterator StakerIterator, maskedStakers map[ids.ID]*
 func (i *maskedIterator) Next() bool {
 	for i.parentIterator.Next() {
 		staker := i.parentIterator.Value()
-		if _, ok := i.maskedStakers[staker.TxID]; !ok {
+		masked, ok := i.maskedStakers[staker.TxID]
+		if !ok || masked.NodeID != staker.NodeID {
 			return true
 		}
 	}
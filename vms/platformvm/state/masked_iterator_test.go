@@ -50,3 +50,35 @@ func TestMaskedIterator(t *testing.T) {
 	it.Release()
 	require.False(it.Next())
 }
+
+func TestMaskedIteratorRotatedStaker(t *testing.T) {
+	require := require.New(t)
+	var (
+		txID         = ids.GenerateTestID()
+		maskedStaker = &Staker{
+			TxID:     txID,
+			NodeID:   ids.GenerateTestNodeID(),
+			NextTime: time.Unix(0, 0),
+		}
+		rotatedStaker = &Staker{
+			TxID:     txID,
+			NodeID:   ids.GenerateTestNodeID(),
+			NextTime: time.Unix(0, 0),
+		}
+	)
+
+	// A staker that was rotated to a new NodeID isn't masked by the removal of
+	// its prior NodeID.
+	it := NewMaskedIterator(
+		NewSliceIterator(rotatedStaker),
+		map[ids.ID]*Staker{
+			txID: maskedStaker,
+		},
+	)
+
+	require.True(it.Next())
+	require.Equal(rotatedStaker, it.Value())
+
+	require.False(it.Next())
+	it.Release()
+}
@@ -88,6 +88,13 @@ type validatorState interface {
 		metadata *validatorMetadata,
 	)
 
+	// GetValidatorMetadata returns the metadata of [vdrID] on [subnetID], if
+	// it has been loaded.
+	GetValidatorMetadata(
+		vdrID ids.NodeID,
+		subnetID ids.ID,
+	) (*validatorMetadata, bool)
+
 	// GetUptime returns the current uptime measurements of [vdrID] on
 	// [subnetID].
 	GetUptime(
@@ -162,6 +169,14 @@ func (m *metadata) LoadValidatorMetadata(
 	subnetMetadata[subnetID] = uptime
 }
 
+func (m *metadata) GetValidatorMetadata(
+	vdrID ids.NodeID,
+	subnetID ids.ID,
+) (*validatorMetadata, bool) {
+	metadata, exists := m.metadata[vdrID][subnetID]
+	return metadata, exists
+}
+
 func (m *metadata) GetUptime(
 	vdrID ids.NodeID,
 	subnetID ids.ID,
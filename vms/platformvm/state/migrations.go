@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+var (
+	errUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+	// migrations upgrade the database to the latest schema version. The
+	// migration at index i upgrades the database from version i to version
+	// i+1, so the latest version is the number of migrations.
+	//
+	// Released migrations must never be removed or reordered.
+	migrations []migration
+)
+
+// migration changes the layout of the database. It is applied before the
+// state is loaded, so it must only access the databases directly.
+type migration struct {
+	// description is logged when the migration is applied.
+	description string
+	// migrate applies the migration. Its writes are committed atomically with
+	// the new schema version, unless it commits [s.baseDB] itself. A migration
+	// that commits partial progress must be able to resume from it, as the
+	// schema version is only updated once the migration has finished.
+	migrate func(s *state, progress *migrationProgress) error
+}
+
+// migrationProgress logs the progress of a running migration.
+type migrationProgress struct {
+	log        logging.Logger
+	version    uint64
+	startTime  time.Time
+	nextUpdate time.Time
+}
+
+// Update reports that [completed] out of [total] units of the migration's work
+// are done. The progress is logged at most once every [indexLogFrequency].
+func (p *migrationProgress) Update(completed, total uint64) {
+	now := time.Now()
+	if now.Before(p.nextUpdate) {
+		return
+	}
+	p.nextUpdate = now.Add(indexLogFrequency)
+
+	p.log.Info("migrating state",
+		zap.Uint64("version", p.version),
+		zap.Uint64("completed", completed),
+		zap.Uint64("total", total),
+		zap.Duration("eta", timer.EstimateETA(p.startTime, completed, total)),
+	)
+}
+
+// getSchemaVersion returns the schema version of the database. Databases
+// initialized before the schema version was recorded are at version 0.
+func (s *state) getSchemaVersion() (uint64, error) {
+	version, err := database.GetUInt64(s.singletonDB, SchemaVersionKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return version, err
+}
+
+// migrate applies, in order, each of [migrations] that the database hasn't
+// been upgraded with yet. The schema version is committed after every
+// migration, so a migration that fails is retried on the next startup without
+// re-applying the migrations before it.
+func (s *state) migrate(log logging.Logger, migrations []migration) error {
+	version, err := s.getSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	latestVersion := uint64(len(migrations))
+	if version > latestVersion {
+		return fmt.Errorf("%w: database is at version %d but the latest known version is %d",
+			errUnsupportedSchemaVersion,
+			version,
+			latestVersion,
+		)
+	}
+
+	for ; version < latestVersion; version++ {
+		var (
+			m          = migrations[version]
+			newVersion = version + 1
+			startTime  = time.Now()
+		)
+		log.Info("starting state migration",
+			zap.Uint64("version", newVersion),
+			zap.String("description", m.description),
+		)
+
+		progress := &migrationProgress{
+			log:        log,
+			version:    newVersion,
+			startTime:  startTime,
+			nextUpdate: startTime.Add(indexLogFrequency),
+		}
+		if err := m.migrate(s, progress); err != nil {
+			s.baseDB.Abort()
+			return fmt.Errorf("failed to migrate state to version %d: %w", newVersion, err)
+		}
+		if err := database.PutUInt64(s.singletonDB, SchemaVersionKey, newVersion); err != nil {
+			return fmt.Errorf("failed to write schema version: %w", err)
+		}
+		if err := s.baseDB.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration to version %d: %w", newVersion, err)
+		}
+
+		log.Info("finished state migration",
+			zap.Uint64("version", newVersion),
+			zap.Duration("duration", time.Since(startTime)),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTestMigration = errors.New("test migration failed")
+
+// newTestMigration returns a migration that writes [key] to the singleton
+// database, counts the number of times it was applied, and returns [result].
+func newTestMigration(key string, numApplied *int, result error) migration {
+	return migration{
+		description: key,
+		migrate: func(s *state, progress *migrationProgress) error {
+			*numApplied++
+			progress.Update(1, 1)
+			if err := s.singletonDB.Put([]byte(key), nil); err != nil {
+				return err
+			}
+			return result
+		},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+	version, err := s.getSchemaVersion()
+	require.NoError(err)
+	require.Zero(version)
+
+	var numApplied [3]int
+	testMigrations := []migration{
+		newTestMigration("first", &numApplied[0], nil),
+		newTestMigration("second", &numApplied[1], nil),
+	}
+	require.NoError(s.migrate(logging.NoLog{}, testMigrations))
+	require.Equal([3]int{1, 1, 0}, numApplied)
+
+	// The migrations and the schema version were committed.
+	s = newStateFromDB(require, db)
+	version, err = s.getSchemaVersion()
+	require.NoError(err)
+	require.Equal(uint64(2), version)
+	for _, key := range []string{"first", "second"} {
+		has, err := s.singletonDB.Has([]byte(key))
+		require.NoError(err)
+		require.True(has)
+	}
+
+	// Only the new migration is applied.
+	testMigrations = append(testMigrations, newTestMigration("third", &numApplied[2], nil))
+	require.NoError(s.migrate(logging.NoLog{}, testMigrations))
+	require.Equal([3]int{1, 1, 1}, numApplied)
+
+	version, err = s.getSchemaVersion()
+	require.NoError(err)
+	require.Equal(uint64(3), version)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var numApplied [2]int
+	testMigrations := []migration{
+		newTestMigration("first", &numApplied[0], nil),
+		newTestMigration("second", &numApplied[1], errTestMigration),
+	}
+	err := s.migrate(logging.NoLog{}, testMigrations)
+	require.ErrorIs(err, errTestMigration)
+
+	// The successful migration was committed, but the writes of the failed
+	// migration were discarded.
+	s = newStateFromDB(require, db)
+	version, err := s.getSchemaVersion()
+	require.NoError(err)
+	require.Equal(uint64(1), version)
+	has, err := s.singletonDB.Has([]byte("second"))
+	require.NoError(err)
+	require.False(has)
+
+	// Only the failed migration is retried.
+	testMigrations[1] = newTestMigration("second", &numApplied[1], nil)
+	require.NoError(s.migrate(logging.NoLog{}, testMigrations))
+	require.Equal([2]int{1, 2}, numApplied)
+}
+
+func TestMigrateUnsupportedVersion(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	require.NoError(database.PutUInt64(s.singletonDB, SchemaVersionKey, 2))
+
+	var numApplied int
+	err := s.migrate(logging.NoLog{}, []migration{
+		newTestMigration("first", &numApplied, nil),
+	})
+	require.ErrorIs(err, errUnsupportedSchemaVersion)
+	require.Zero(numApplied)
+}
@@ -19,6 +19,7 @@ import (
 	ids "github.com/ava-labs/avalanchego/ids"
 	validators "github.com/ava-labs/avalanchego/snow/validators"
 	logging "github.com/ava-labs/avalanchego/utils/logging"
+	set "github.com/ava-labs/avalanchego/utils/set"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
 	block "github.com/ava-labs/avalanchego/vms/platformvm/block"
 	fx "github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -212,6 +213,21 @@ func (mr *MockChainMockRecorder) GetCurrentStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentStakerIterator", reflect.TypeOf((*MockChain)(nil).GetCurrentStakerIterator))
 }
 
+// GetCurrentSubnetStakerIterator mocks base method.
+func (m *MockChain) GetCurrentSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSubnetStakerIterator indicates an expected call of GetCurrentSubnetStakerIterator.
+func (mr *MockChainMockRecorder) GetCurrentSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSubnetStakerIterator", reflect.TypeOf((*MockChain)(nil).GetCurrentSubnetStakerIterator), arg0)
+}
+
 // GetCurrentSupply mocks base method.
 func (m *MockChain) GetCurrentSupply(arg0 ids.ID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +258,21 @@ func (mr *MockChainMockRecorder) GetCurrentValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockChain)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidatorSubnetIDs mocks base method.
+func (m *MockChain) GetCurrentValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidatorSubnetIDs indicates an expected call of GetCurrentValidatorSubnetIDs.
+func (mr *MockChainMockRecorder) GetCurrentValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidatorSubnetIDs", reflect.TypeOf((*MockChain)(nil).GetCurrentValidatorSubnetIDs), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockChain) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -257,6 +288,36 @@ func (mr *MockChainMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockChain)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetDelegationFeeChanges mocks base method.
+func (m *MockChain) GetDelegationFeeChanges(arg0 ids.ID) ([]*DelegationFeeChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationFeeChanges", arg0)
+	ret0, _ := ret[0].([]*DelegationFeeChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationFeeChanges indicates an expected call of GetDelegationFeeChanges.
+func (mr *MockChainMockRecorder) GetDelegationFeeChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationFeeChanges", reflect.TypeOf((*MockChain)(nil).GetDelegationFeeChanges), arg0)
+}
+
+// GetMisbehaviorReport mocks base method.
+func (m *MockChain) GetMisbehaviorReport(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMisbehaviorReport", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMisbehaviorReport indicates an expected call of GetMisbehaviorReport.
+func (mr *MockChainMockRecorder) GetMisbehaviorReport(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMisbehaviorReport", reflect.TypeOf((*MockChain)(nil).GetMisbehaviorReport), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -287,6 +348,21 @@ func (mr *MockChainMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockChain)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingSubnetStakerIterator mocks base method.
+func (m *MockChain) GetPendingSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingSubnetStakerIterator indicates an expected call of GetPendingSubnetStakerIterator.
+func (mr *MockChainMockRecorder) GetPendingSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSubnetStakerIterator", reflect.TypeOf((*MockChain)(nil).GetPendingSubnetStakerIterator), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockChain) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +378,21 @@ func (mr *MockChainMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockChain)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetPendingValidatorSubnetIDs mocks base method.
+func (m *MockChain) GetPendingValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingValidatorSubnetIDs indicates an expected call of GetPendingValidatorSubnetIDs.
+func (mr *MockChainMockRecorder) GetPendingValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidatorSubnetIDs", reflect.TypeOf((*MockChain)(nil).GetPendingValidatorSubnetIDs), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -317,6 +408,21 @@ func (mr *MockChainMockRecorder) GetSubnetOwner(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockChain)(nil).GetSubnetOwner), arg0)
 }
 
+// GetSubnetStakingParams mocks base method.
+func (m *MockChain) GetSubnetStakingParams(arg0 ids.ID) (*SubnetStakingParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetStakingParams", arg0)
+	ret0, _ := ret[0].(*SubnetStakingParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetStakingParams indicates an expected call of GetSubnetStakingParams.
+func (mr *MockChainMockRecorder) GetSubnetStakingParams(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetStakingParams", reflect.TypeOf((*MockChain)(nil).GetSubnetStakingParams), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockChain) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -332,6 +438,21 @@ func (mr *MockChainMockRecorder) GetSubnetTransformation(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockChain)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorEpoch mocks base method.
+func (m *MockChain) GetSubnetValidatorEpoch(arg0 ids.ID) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorEpoch", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorEpoch indicates an expected call of GetSubnetValidatorEpoch.
+func (mr *MockChainMockRecorder) GetSubnetValidatorEpoch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorEpoch", reflect.TypeOf((*MockChain)(nil).GetSubnetValidatorEpoch), arg0)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockChain) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -451,6 +572,30 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetDelegationFeeChanges mocks base method.
+func (m *MockChain) SetDelegationFeeChanges(arg0 ids.ID, arg1 []*DelegationFeeChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegationFeeChanges", arg0, arg1)
+}
+
+// SetDelegationFeeChanges indicates an expected call of SetDelegationFeeChanges.
+func (mr *MockChainMockRecorder) SetDelegationFeeChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegationFeeChanges", reflect.TypeOf((*MockChain)(nil).SetDelegationFeeChanges), arg0, arg1)
+}
+
+// SetMisbehaviorReport mocks base method.
+func (m *MockChain) SetMisbehaviorReport(arg0, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMisbehaviorReport", arg0, arg1)
+}
+
+// SetMisbehaviorReport indicates an expected call of SetMisbehaviorReport.
+func (mr *MockChainMockRecorder) SetMisbehaviorReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMisbehaviorReport", reflect.TypeOf((*MockChain)(nil).SetMisbehaviorReport), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -463,6 +608,30 @@ func (mr *MockChainMockRecorder) SetSubnetOwner(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockChain)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetStakingParams mocks base method.
+func (m *MockChain) SetSubnetStakingParams(arg0 ids.ID, arg1 *SubnetStakingParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetStakingParams", arg0, arg1)
+}
+
+// SetSubnetStakingParams indicates an expected call of SetSubnetStakingParams.
+func (mr *MockChainMockRecorder) SetSubnetStakingParams(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetStakingParams", reflect.TypeOf((*MockChain)(nil).SetSubnetStakingParams), arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch mocks base method.
+func (m *MockChain) SetSubnetValidatorEpoch(arg0 ids.ID, arg1 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorEpoch", arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch indicates an expected call of SetSubnetValidatorEpoch.
+func (mr *MockChainMockRecorder) SetSubnetValidatorEpoch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorEpoch", reflect.TypeOf((*MockChain)(nil).SetSubnetValidatorEpoch), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -475,6 +644,18 @@ func (mr *MockChainMockRecorder) SetTimestamp(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockChain)(nil).SetTimestamp), arg0)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockChain) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockChainMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockChain)(nil).UpdateCurrentValidator), arg0)
+}
+
 // MockDiff is a mock of Diff interface.
 type MockDiff struct {
 	ctrl     *gomock.Controller
@@ -674,6 +855,21 @@ func (mr *MockDiffMockRecorder) GetCurrentStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetCurrentStakerIterator))
 }
 
+// GetCurrentSubnetStakerIterator mocks base method.
+func (m *MockDiff) GetCurrentSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSubnetStakerIterator indicates an expected call of GetCurrentSubnetStakerIterator.
+func (mr *MockDiffMockRecorder) GetCurrentSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSubnetStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetCurrentSubnetStakerIterator), arg0)
+}
+
 // GetCurrentSupply mocks base method.
 func (m *MockDiff) GetCurrentSupply(arg0 ids.ID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -704,6 +900,21 @@ func (mr *MockDiffMockRecorder) GetCurrentValidator(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidatorSubnetIDs mocks base method.
+func (m *MockDiff) GetCurrentValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidatorSubnetIDs indicates an expected call of GetCurrentValidatorSubnetIDs.
+func (mr *MockDiffMockRecorder) GetCurrentValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidatorSubnetIDs", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidatorSubnetIDs), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockDiff) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -719,6 +930,36 @@ func (mr *MockDiffMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetDelegationFeeChanges mocks base method.
+func (m *MockDiff) GetDelegationFeeChanges(arg0 ids.ID) ([]*DelegationFeeChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationFeeChanges", arg0)
+	ret0, _ := ret[0].([]*DelegationFeeChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationFeeChanges indicates an expected call of GetDelegationFeeChanges.
+func (mr *MockDiffMockRecorder) GetDelegationFeeChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationFeeChanges", reflect.TypeOf((*MockDiff)(nil).GetDelegationFeeChanges), arg0)
+}
+
+// GetMisbehaviorReport mocks base method.
+func (m *MockDiff) GetMisbehaviorReport(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMisbehaviorReport", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMisbehaviorReport indicates an expected call of GetMisbehaviorReport.
+func (mr *MockDiffMockRecorder) GetMisbehaviorReport(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMisbehaviorReport", reflect.TypeOf((*MockDiff)(nil).GetMisbehaviorReport), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -749,6 +990,21 @@ func (mr *MockDiffMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingSubnetStakerIterator mocks base method.
+func (m *MockDiff) GetPendingSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingSubnetStakerIterator indicates an expected call of GetPendingSubnetStakerIterator.
+func (mr *MockDiffMockRecorder) GetPendingSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSubnetStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetPendingSubnetStakerIterator), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockDiff) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -764,6 +1020,21 @@ func (mr *MockDiffMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockDiff)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetPendingValidatorSubnetIDs mocks base method.
+func (m *MockDiff) GetPendingValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingValidatorSubnetIDs indicates an expected call of GetPendingValidatorSubnetIDs.
+func (mr *MockDiffMockRecorder) GetPendingValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidatorSubnetIDs", reflect.TypeOf((*MockDiff)(nil).GetPendingValidatorSubnetIDs), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockDiff) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -779,6 +1050,21 @@ func (mr *MockDiffMockRecorder) GetSubnetOwner(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).GetSubnetOwner), arg0)
 }
 
+// GetSubnetStakingParams mocks base method.
+func (m *MockDiff) GetSubnetStakingParams(arg0 ids.ID) (*SubnetStakingParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetStakingParams", arg0)
+	ret0, _ := ret[0].(*SubnetStakingParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetStakingParams indicates an expected call of GetSubnetStakingParams.
+func (mr *MockDiffMockRecorder) GetSubnetStakingParams(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetStakingParams", reflect.TypeOf((*MockDiff)(nil).GetSubnetStakingParams), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockDiff) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -794,6 +1080,21 @@ func (mr *MockDiffMockRecorder) GetSubnetTransformation(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockDiff)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorEpoch mocks base method.
+func (m *MockDiff) GetSubnetValidatorEpoch(arg0 ids.ID) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorEpoch", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorEpoch indicates an expected call of GetSubnetValidatorEpoch.
+func (mr *MockDiffMockRecorder) GetSubnetValidatorEpoch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorEpoch", reflect.TypeOf((*MockDiff)(nil).GetSubnetValidatorEpoch), arg0)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockDiff) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -913,6 +1214,30 @@ func (mr *MockDiffMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetDelegationFeeChanges mocks base method.
+func (m *MockDiff) SetDelegationFeeChanges(arg0 ids.ID, arg1 []*DelegationFeeChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegationFeeChanges", arg0, arg1)
+}
+
+// SetDelegationFeeChanges indicates an expected call of SetDelegationFeeChanges.
+func (mr *MockDiffMockRecorder) SetDelegationFeeChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegationFeeChanges", reflect.TypeOf((*MockDiff)(nil).SetDelegationFeeChanges), arg0, arg1)
+}
+
+// SetMisbehaviorReport mocks base method.
+func (m *MockDiff) SetMisbehaviorReport(arg0, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMisbehaviorReport", arg0, arg1)
+}
+
+// SetMisbehaviorReport indicates an expected call of SetMisbehaviorReport.
+func (mr *MockDiffMockRecorder) SetMisbehaviorReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMisbehaviorReport", reflect.TypeOf((*MockDiff)(nil).SetMisbehaviorReport), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -925,6 +1250,30 @@ func (mr *MockDiffMockRecorder) SetSubnetOwner(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetStakingParams mocks base method.
+func (m *MockDiff) SetSubnetStakingParams(arg0 ids.ID, arg1 *SubnetStakingParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetStakingParams", arg0, arg1)
+}
+
+// SetSubnetStakingParams indicates an expected call of SetSubnetStakingParams.
+func (mr *MockDiffMockRecorder) SetSubnetStakingParams(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetStakingParams", reflect.TypeOf((*MockDiff)(nil).SetSubnetStakingParams), arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch mocks base method.
+func (m *MockDiff) SetSubnetValidatorEpoch(arg0 ids.ID, arg1 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorEpoch", arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch indicates an expected call of SetSubnetValidatorEpoch.
+func (mr *MockDiffMockRecorder) SetSubnetValidatorEpoch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorEpoch", reflect.TypeOf((*MockDiff)(nil).SetSubnetValidatorEpoch), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -937,6 +1286,18 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockDiff) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockDiffMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockDiff)(nil).UpdateCurrentValidator), arg0)
+}
+
 // MockState is a mock of State interface.
 type MockState struct {
 	ctrl     *gomock.Controller
@@ -972,6 +1333,18 @@ func (mr *MockStateMockRecorder) Abort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockState)(nil).Abort))
 }
 
+// AddBlockSummary mocks base method.
+func (m *MockState) AddBlockSummary(arg0 uint64, arg1 *BlockSummary) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddBlockSummary", arg0, arg1)
+}
+
+// AddBlockSummary indicates an expected call of AddBlockSummary.
+func (mr *MockStateMockRecorder) AddBlockSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlockSummary", reflect.TypeOf((*MockState)(nil).AddBlockSummary), arg0, arg1)
+}
+
 // AddChain mocks base method.
 func (m *MockState) AddChain(arg0 *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -1056,6 +1429,20 @@ func (mr *MockStateMockRecorder) AddUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockState)(nil).AddUTXO), arg0)
 }
 
+// ApplyStakerDiffs mocks base method.
+func (m *MockState) ApplyStakerDiffs(arg0 context.Context, arg1 map[ids.ID]*Staker, arg2, arg3 uint64, arg4 ids.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyStakerDiffs", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyStakerDiffs indicates an expected call of ApplyStakerDiffs.
+func (mr *MockStateMockRecorder) ApplyStakerDiffs(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyStakerDiffs", reflect.TypeOf((*MockState)(nil).ApplyStakerDiffs), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ApplyValidatorPublicKeyDiffs mocks base method.
 func (m *MockState) ApplyValidatorPublicKeyDiffs(arg0 context.Context, arg1 map[ids.NodeID]*validators.GetValidatorOutput, arg2, arg3 uint64) error {
 	m.ctrl.T.Helper()
@@ -1201,6 +1588,20 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// Flush mocks base method.
+func (m *MockState) Flush() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockStateMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockState)(nil).Flush))
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(arg0 uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -1216,6 +1617,36 @@ func (mr *MockStateMockRecorder) GetBlockIDAtHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockState)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetBlockSummary mocks base method.
+func (m *MockState) GetBlockSummary(arg0 uint64) (*BlockSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockSummary", arg0)
+	ret0, _ := ret[0].(*BlockSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockSummary indicates an expected call of GetBlockSummary.
+func (mr *MockStateMockRecorder) GetBlockSummary(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockSummary", reflect.TypeOf((*MockState)(nil).GetBlockSummary), arg0)
+}
+
+// GetChainCreationTime mocks base method.
+func (m *MockState) GetChainCreationTime(arg0, arg1 ids.ID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainCreationTime", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainCreationTime indicates an expected call of GetChainCreationTime.
+func (mr *MockStateMockRecorder) GetChainCreationTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainCreationTime", reflect.TypeOf((*MockState)(nil).GetChainCreationTime), arg0, arg1)
+}
+
 // GetChains mocks base method.
 func (m *MockState) GetChains(arg0 ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -1261,6 +1692,21 @@ func (mr *MockStateMockRecorder) GetCurrentStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentStakerIterator", reflect.TypeOf((*MockState)(nil).GetCurrentStakerIterator))
 }
 
+// GetCurrentSubnetStakerIterator mocks base method.
+func (m *MockState) GetCurrentSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSubnetStakerIterator indicates an expected call of GetCurrentSubnetStakerIterator.
+func (mr *MockStateMockRecorder) GetCurrentSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSubnetStakerIterator", reflect.TypeOf((*MockState)(nil).GetCurrentSubnetStakerIterator), arg0)
+}
+
 // GetCurrentSupply mocks base method.
 func (m *MockState) GetCurrentSupply(arg0 ids.ID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1291,6 +1737,21 @@ func (mr *MockStateMockRecorder) GetCurrentValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockState)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidatorSubnetIDs mocks base method.
+func (m *MockState) GetCurrentValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidatorSubnetIDs indicates an expected call of GetCurrentValidatorSubnetIDs.
+func (mr *MockStateMockRecorder) GetCurrentValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidatorSubnetIDs", reflect.TypeOf((*MockState)(nil).GetCurrentValidatorSubnetIDs), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockState) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1306,6 +1767,21 @@ func (mr *MockStateMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockState)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetDelegationFeeChanges mocks base method.
+func (m *MockState) GetDelegationFeeChanges(arg0 ids.ID) ([]*DelegationFeeChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationFeeChanges", arg0)
+	ret0, _ := ret[0].([]*DelegationFeeChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationFeeChanges indicates an expected call of GetDelegationFeeChanges.
+func (mr *MockStateMockRecorder) GetDelegationFeeChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationFeeChanges", reflect.TypeOf((*MockState)(nil).GetDelegationFeeChanges), arg0)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -1320,6 +1796,21 @@ func (mr *MockStateMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*MockState)(nil).GetLastAccepted))
 }
 
+// GetMisbehaviorReport mocks base method.
+func (m *MockState) GetMisbehaviorReport(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMisbehaviorReport", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMisbehaviorReport indicates an expected call of GetMisbehaviorReport.
+func (mr *MockStateMockRecorder) GetMisbehaviorReport(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMisbehaviorReport", reflect.TypeOf((*MockState)(nil).GetMisbehaviorReport), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockState) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -1350,6 +1841,21 @@ func (mr *MockStateMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockState)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingSubnetStakerIterator mocks base method.
+func (m *MockState) GetPendingSubnetStakerIterator(arg0 ids.ID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingSubnetStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingSubnetStakerIterator indicates an expected call of GetPendingSubnetStakerIterator.
+func (mr *MockStateMockRecorder) GetPendingSubnetStakerIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSubnetStakerIterator", reflect.TypeOf((*MockState)(nil).GetPendingSubnetStakerIterator), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockState) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -1365,6 +1871,21 @@ func (mr *MockStateMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockState)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetPendingValidatorSubnetIDs mocks base method.
+func (m *MockState) GetPendingValidatorSubnetIDs(arg0 ids.NodeID) (set.Set[ids.ID], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingValidatorSubnetIDs", arg0)
+	ret0, _ := ret[0].(set.Set[ids.ID])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingValidatorSubnetIDs indicates an expected call of GetPendingValidatorSubnetIDs.
+func (mr *MockStateMockRecorder) GetPendingValidatorSubnetIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidatorSubnetIDs", reflect.TypeOf((*MockState)(nil).GetPendingValidatorSubnetIDs), arg0)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockState) GetRewardUTXOs(arg0 ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -1425,6 +1946,21 @@ func (mr *MockStateMockRecorder) GetSubnetOwner(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockState)(nil).GetSubnetOwner), arg0)
 }
 
+// GetSubnetStakingParams mocks base method.
+func (m *MockState) GetSubnetStakingParams(arg0 ids.ID) (*SubnetStakingParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetStakingParams", arg0)
+	ret0, _ := ret[0].(*SubnetStakingParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetStakingParams indicates an expected call of GetSubnetStakingParams.
+func (mr *MockStateMockRecorder) GetSubnetStakingParams(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetStakingParams", reflect.TypeOf((*MockState)(nil).GetSubnetStakingParams), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockState) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -1440,6 +1976,21 @@ func (mr *MockStateMockRecorder) GetSubnetTransformation(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockState)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorEpoch mocks base method.
+func (m *MockState) GetSubnetValidatorEpoch(arg0 ids.ID) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorEpoch", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorEpoch indicates an expected call of GetSubnetValidatorEpoch.
+func (mr *MockStateMockRecorder) GetSubnetValidatorEpoch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorEpoch", reflect.TypeOf((*MockState)(nil).GetSubnetValidatorEpoch), arg0)
+}
+
 // GetSubnets mocks base method.
 func (m *MockState) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -1500,6 +2051,21 @@ func (mr *MockStateMockRecorder) GetUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockState)(nil).GetUTXO), arg0)
 }
 
+// GetUTXOsAtHeight mocks base method.
+func (m *MockState) GetUTXOsAtHeight(arg0 set.Set[ids.ShortID], arg1 uint64) ([]*avax.UTXO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUTXOsAtHeight", arg0, arg1)
+	ret0, _ := ret[0].([]*avax.UTXO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUTXOsAtHeight indicates an expected call of GetUTXOsAtHeight.
+func (mr *MockStateMockRecorder) GetUTXOsAtHeight(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXOsAtHeight", reflect.TypeOf((*MockState)(nil).GetUTXOsAtHeight), arg0, arg1)
+}
+
 // GetUptime mocks base method.
 func (m *MockState) GetUptime(arg0 ids.NodeID, arg1 ids.ID) (time.Duration, time.Time, error) {
 	m.ctrl.T.Helper()
@@ -1516,6 +2082,22 @@ func (mr *MockStateMockRecorder) GetUptime(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), arg0, arg1)
 }
 
+// GetValidatorSetCheckpoint mocks base method.
+func (m *MockState) GetValidatorSetCheckpoint(arg0 ids.ID, arg1 uint64) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorSetCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(map[ids.NodeID]*validators.GetValidatorOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValidatorSetCheckpoint indicates an expected call of GetValidatorSetCheckpoint.
+func (mr *MockStateMockRecorder) GetValidatorSetCheckpoint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorSetCheckpoint", reflect.TypeOf((*MockState)(nil).GetValidatorSetCheckpoint), arg0, arg1)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockState) PutCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -1604,6 +2186,18 @@ func (mr *MockStateMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockState)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetDelegationFeeChanges mocks base method.
+func (m *MockState) SetDelegationFeeChanges(arg0 ids.ID, arg1 []*DelegationFeeChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegationFeeChanges", arg0, arg1)
+}
+
+// SetDelegationFeeChanges indicates an expected call of SetDelegationFeeChanges.
+func (mr *MockStateMockRecorder) SetDelegationFeeChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegationFeeChanges", reflect.TypeOf((*MockState)(nil).SetDelegationFeeChanges), arg0, arg1)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(arg0 uint64) {
 	m.ctrl.T.Helper()
@@ -1628,6 +2222,18 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
 
+// SetMisbehaviorReport mocks base method.
+func (m *MockState) SetMisbehaviorReport(arg0, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMisbehaviorReport", arg0, arg1)
+}
+
+// SetMisbehaviorReport indicates an expected call of SetMisbehaviorReport.
+func (mr *MockStateMockRecorder) SetMisbehaviorReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMisbehaviorReport", reflect.TypeOf((*MockState)(nil).SetMisbehaviorReport), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockState) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -1640,6 +2246,30 @@ func (mr *MockStateMockRecorder) SetSubnetOwner(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockState)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetStakingParams mocks base method.
+func (m *MockState) SetSubnetStakingParams(arg0 ids.ID, arg1 *SubnetStakingParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetStakingParams", arg0, arg1)
+}
+
+// SetSubnetStakingParams indicates an expected call of SetSubnetStakingParams.
+func (mr *MockStateMockRecorder) SetSubnetStakingParams(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetStakingParams", reflect.TypeOf((*MockState)(nil).SetSubnetStakingParams), arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch mocks base method.
+func (m *MockState) SetSubnetValidatorEpoch(arg0 ids.ID, arg1 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorEpoch", arg0, arg1)
+}
+
+// SetSubnetValidatorEpoch indicates an expected call of SetSubnetValidatorEpoch.
+func (mr *MockStateMockRecorder) SetSubnetValidatorEpoch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorEpoch", reflect.TypeOf((*MockState)(nil).SetSubnetValidatorEpoch), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -1681,6 +2311,33 @@ func (mr *MockStateMockRecorder) UTXOIDs(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), arg0, arg1, arg2)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockState) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockStateMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockState)(nil).UpdateCurrentValidator), arg0)
+}
+
+// Verify mocks base method.
+func (m *MockState) Verify(arg0 context.Context) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockStateMockRecorder) Verify(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockState)(nil).Verify), arg0)
+}
+
 // MockVersions is a mock of Versions interface.
 type MockVersions struct {
 	ctrl     *gomock.Controller
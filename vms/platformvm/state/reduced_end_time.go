@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// The end time of a current subnet validator can be reduced in place, so that
+// its removal is scheduled at an epoch boundary. Because stakers are loaded
+// from their txs, the reduced end time is stored separately and overrides the
+// end time specified in the tx.
+
+// applyReducedEndTime overrides the end time of [staker] if it was reduced.
+func applyReducedEndTime(db database.KeyValueReader, staker *Staker) error {
+	endTime, err := database.GetUInt64(db, staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse reduced end time of %s: %w", staker.TxID, err)
+	}
+	staker.EndTime = time.Unix(int64(endTime), 0)
+	staker.NextTime = staker.EndTime
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// The weight of a current subnet validator can be reduced in place. Because
+// stakers are loaded from their txs, the reduced weight is stored separately
+// and overrides the weight specified in the tx.
+
+// applyReducedWeight overrides the weight of [staker] if it was reduced.
+func applyReducedWeight(db database.KeyValueReader, staker *Staker) error {
+	weight, err := database.GetUInt64(db, staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse reduced weight of %s: %w", staker.TxID, err)
+	}
+	staker.Weight = weight
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+var errUnexpectedRotatedNodeIDLength = fmt.Errorf(
+	"expected rotated nodeID length to be %d or %d",
+	ids.NodeIDLen,
+	ids.NodeIDLen+bls.PublicKeyLen,
+)
+
+// A staker is rotated when it is removed and then added back, with the same
+// txID, under a different NodeID. Because stakers are loaded from their txs,
+// the NodeID and BLS public key that the staker was rotated to are stored
+// separately and override the ones specified in the tx. The same override is
+// used when the BLS public key of a validator is updated in place.
+
+// marshalRotatedNodeID returns the bytes of the [nodeID] and, if provided, the
+// compressed [pk] that a staker was rotated to.
+func marshalRotatedNodeID(nodeID ids.NodeID, pk *bls.PublicKey) []byte {
+	if pk == nil {
+		return nodeID.Bytes()
+	}
+	b := make([]byte, 0, ids.NodeIDLen+bls.PublicKeyLen)
+	b = append(b, nodeID.Bytes()...)
+	return append(b, bls.PublicKeyToCompressedBytes(pk)...)
+}
+
+func unmarshalRotatedNodeID(b []byte) (ids.NodeID, *bls.PublicKey, error) {
+	switch len(b) {
+	case ids.NodeIDLen:
+		nodeID, err := ids.ToNodeID(b)
+		return nodeID, nil, err
+	case ids.NodeIDLen + bls.PublicKeyLen:
+		nodeID, err := ids.ToNodeID(b[:ids.NodeIDLen])
+		if err != nil {
+			return ids.EmptyNodeID, nil, err
+		}
+		pk, err := bls.PublicKeyFromCompressedBytes(b[ids.NodeIDLen:])
+		return nodeID, pk, err
+	default:
+		return ids.EmptyNodeID, nil, errUnexpectedRotatedNodeIDLength
+	}
+}
+
+// applyRotatedNodeID overrides the NodeID and public key of [staker] if it was
+// rotated.
+func applyRotatedNodeID(db database.KeyValueReader, staker *Staker) error {
+	b, err := db.Get(staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	nodeID, pk, err := unmarshalRotatedNodeID(b)
+	if err != nil {
+		return fmt.Errorf("failed to parse rotated nodeID of %s: %w", staker.TxID, err)
+	}
+	staker.NodeID = nodeID
+	staker.PublicKey = pk
+	return nil
+}
@@ -65,6 +65,8 @@ type Staker struct {
 //     lesser one.
 //  3. If the priorities are also the same, the one with the lesser txID is
 //     lesser.
+//  4. If the txIDs are also the same, the staker was rotated and the one with
+//     the lesser NodeID is lesser.
 func (s *Staker) Less(than *Staker) bool {
 	if s.NextTime.Before(than.NextTime) {
 		return true
@@ -80,7 +82,10 @@ func (s *Staker) Less(than *Staker) bool {
 		return false
 	}
 
-	return bytes.Compare(s.TxID[:], than.TxID[:]) == -1
+	if s.TxID != than.TxID {
+		return bytes.Compare(s.TxID[:], than.TxID[:]) == -1
+	}
+	return s.NodeID.Compare(than.NodeID) == -1
 }
 
 func NewCurrentStaker(
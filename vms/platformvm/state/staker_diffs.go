@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const (
+	// stakerDiffKey = [subnetID] + [inverseHeight] + [txID]
+	stakerDiffKeyLength = startDiffKeyLength + ids.IDLen
+	// stakerDiffKeyTxIDOffset = [subnetIDLen] + [inverseHeightLen]
+	stakerDiffKeyTxIDOffset = ids.IDLen + database.Uint64Size
+
+	// stakerDiffValue = [nodeID] + [weight] + [startTime] + [endTime] +
+	// [potentialReward] + [priority]
+	stakerDiffValueLength = ids.NodeIDLen + 4*database.Uint64Size + 1
+)
+
+var (
+	errUnexpectedStakerDiffKeyLength   = fmt.Errorf("expected staker diff key length %d", stakerDiffKeyLength)
+	errUnexpectedStakerDiffValueLength = fmt.Errorf("expected staker diff value length 0 or %d", stakerDiffValueLength)
+)
+
+// The staker diffs record, for every height, the current stakers that were
+// added, removed, or modified by the block at that height. A staker that was
+// added is recorded with an empty value. Otherwise, the staker is recorded as
+// it was before the block was accepted. Undoing the diffs from the current
+// height towards the genesis reconstructs the current stakers at any height.
+
+func marshalStakerDiffKey(subnetID ids.ID, height uint64, txID ids.ID) []byte {
+	key := make([]byte, stakerDiffKeyLength)
+	copy(key, subnetID[:])
+	packIterableHeight(key[ids.IDLen:], height)
+	copy(key[stakerDiffKeyTxIDOffset:], txID[:])
+	return key
+}
+
+func unmarshalStakerDiffKey(key []byte) (uint64, ids.ID, error) {
+	if len(key) != stakerDiffKeyLength {
+		return 0, ids.Empty, errUnexpectedStakerDiffKeyLength
+	}
+	height := unpackIterableHeight(key[ids.IDLen:])
+	txID := ids.ID(key[stakerDiffKeyTxIDOffset:])
+	return height, txID, nil
+}
+
+// marshalStakerDiff returns the value recording that [prevStaker] was replaced
+// or removed. If the staker was added, [prevStaker] should be nil.
+func marshalStakerDiff(prevStaker *Staker) []byte {
+	if prevStaker == nil {
+		return nil
+	}
+	value := make([]byte, 0, stakerDiffValueLength)
+	value = append(value, prevStaker.NodeID.Bytes()...)
+	value = binary.BigEndian.AppendUint64(value, prevStaker.Weight)
+	value = binary.BigEndian.AppendUint64(value, uint64(prevStaker.StartTime.Unix()))
+	value = binary.BigEndian.AppendUint64(value, uint64(prevStaker.EndTime.Unix()))
+	value = binary.BigEndian.AppendUint64(value, prevStaker.PotentialReward)
+	return append(value, byte(prevStaker.Priority))
+}
+
+// unmarshalStakerDiff returns the staker recorded in [value], or nil if the
+// staker was added. The public key of the staker isn't recorded.
+func unmarshalStakerDiff(subnetID ids.ID, txID ids.ID, value []byte) (*Staker, error) {
+	switch len(value) {
+	case 0:
+		return nil, nil
+	case stakerDiffValueLength:
+	default:
+		return nil, errUnexpectedStakerDiffValueLength
+	}
+
+	nodeID, err := ids.ToNodeID(value[:ids.NodeIDLen])
+	if err != nil {
+		return nil, err
+	}
+	value = value[ids.NodeIDLen:]
+	endTime := time.Unix(int64(binary.BigEndian.Uint64(value[2*database.Uint64Size:])), 0)
+	return &Staker{
+		TxID:            txID,
+		NodeID:          nodeID,
+		SubnetID:        subnetID,
+		Weight:          binary.BigEndian.Uint64(value),
+		StartTime:       time.Unix(int64(binary.BigEndian.Uint64(value[database.Uint64Size:])), 0),
+		EndTime:         endTime,
+		PotentialReward: binary.BigEndian.Uint64(value[3*database.Uint64Size:]),
+		NextTime:        endTime,
+		Priority:        txs.Priority(value[4*database.Uint64Size]),
+	}, nil
+}
+
+// recordStakerDiff records that the staker [txID] was modified. If the staker
+// was removed and added back at the same height, as happens when it's
+// rotated, the staker is recorded as it was before it was removed.
+func recordStakerDiff(diffs map[ids.ID]*Staker, txID ids.ID, prevStaker *Staker) {
+	if prevStaker != nil || diffs[txID] == nil {
+		diffs[txID] = prevStaker
+	}
+}
@@ -7,6 +7,7 @@ const (
 	unmodified diffValidatorStatus = iota
 	added
 	deleted
+	modified
 )
 
 type diffValidatorStatus uint8
@@ -109,6 +109,22 @@ func TestStakerLess(t *testing.T) {
 			},
 			less: false,
 		},
+		{
+			name: "same txID, left nodeID < right nodeID",
+			left: &Staker{
+				TxID:     ids.ID([32]byte{}),
+				NodeID:   ids.BuildTestNodeID([]byte{0}),
+				NextTime: time.Unix(0, 0),
+				Priority: txs.PrimaryNetworkValidatorCurrentPriority,
+			},
+			right: &Staker{
+				TxID:     ids.ID([32]byte{}),
+				NodeID:   ids.BuildTestNodeID([]byte{1}),
+				NextTime: time.Unix(0, 0),
+				Priority: txs.PrimaryNetworkValidatorCurrentPriority,
+			},
+			less: true,
+		},
 		{
 			name: "equal",
 			left: &Staker{
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 type Stakers interface {
@@ -33,6 +34,14 @@ type CurrentStakers interface {
 	// Invariant: [staker] is currently a CurrentValidator
 	DeleteCurrentValidator(staker *Staker)
 
+	// UpdateCurrentValidator replaces the current validator with the same
+	// txID and nodeID as [staker] with [staker].
+	//
+	// Invariant: [staker] only differs from the current validator by its
+	// PublicKey, by its Weight and by its EndTime and NextTime. The Weight and
+	// the EndTime may only be reduced.
+	UpdateCurrentValidator(staker *Staker)
+
 	// SetDelegateeReward sets the accrued delegation rewards for [nodeID] on
 	// [subnetID] to [amount].
 	SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error
@@ -61,6 +70,14 @@ type CurrentStakers interface {
 	// GetCurrentStakerIterator returns stakers in order of their removal from
 	// the current staker set.
 	GetCurrentStakerIterator() (StakerIterator, error)
+
+	// GetCurrentSubnetStakerIterator returns the stakers of [subnetID] in
+	// order of their removal from the current staker set.
+	GetCurrentSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error)
+
+	// GetCurrentValidatorSubnetIDs returns the IDs of the subnets, including
+	// the primary network, that [nodeID] is a current validator of.
+	GetCurrentValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error)
 }
 
 type PendingStakers interface {
@@ -93,12 +110,22 @@ type PendingStakers interface {
 	// GetPendingStakerIterator returns stakers in order of their removal from
 	// the pending staker set.
 	GetPendingStakerIterator() (StakerIterator, error)
+
+	// GetPendingSubnetStakerIterator returns the stakers of [subnetID] in
+	// order of their removal from the pending staker set.
+	GetPendingSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error)
+
+	// GetPendingValidatorSubnetIDs returns the IDs of the subnets, including
+	// the primary network, that [nodeID] is a pending validator of.
+	GetPendingValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error)
 }
 
 type baseStakers struct {
 	// subnetID --> nodeID --> current state for the validator of the subnet
 	validators map[ids.ID]map[ids.NodeID]*baseStaker
 	stakers    *btree.BTreeG[*Staker]
+	// subnetID --> stakers of the subnet
+	subnetStakers map[ids.ID]*btree.BTreeG[*Staker]
 	// subnetID --> nodeID --> diff for that validator since the last db write
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 }
@@ -112,6 +139,7 @@ func newBaseStakers() *baseStakers {
 	return &baseStakers{
 		validators:     make(map[ids.ID]map[ids.NodeID]*baseStaker),
 		stakers:        btree.NewG(defaultTreeDegree, (*Staker).Less),
+		subnetStakers:  make(map[ids.ID]*btree.BTreeG[*Staker]),
 		validatorDiffs: make(map[ids.ID]map[ids.NodeID]*diffValidator),
 	}
 }
@@ -139,7 +167,7 @@ func (v *baseStakers) PutValidator(staker *Staker) {
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
-	v.stakers.ReplaceOrInsert(staker)
+	v.insertStaker(staker)
 }
 
 func (v *baseStakers) DeleteValidator(staker *Staker) {
@@ -148,10 +176,35 @@ func (v *baseStakers) DeleteValidator(staker *Staker) {
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == modified {
+		// The validator that is being removed from disk is the one that was
+		// there before it was updated.
+		staker = validatorDiff.prevValidator
+	}
 	validatorDiff.validatorStatus = deleted
 	validatorDiff.validator = staker
 
-	v.stakers.Delete(staker)
+	v.removeStaker(staker)
+}
+
+func (v *baseStakers) UpdateValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	prevStaker := validator.validator
+	validator.validator = staker
+	if prevStaker != nil {
+		// The previous staker must be removed explicitly, as the updated staker
+		// may be ordered differently.
+		v.removeStaker(prevStaker)
+	}
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == unmodified {
+		validatorDiff.validatorStatus = modified
+		validatorDiff.prevValidator = prevStaker
+	}
+	validatorDiff.validator = staker
+
+	v.insertStaker(staker)
 }
 
 func (v *baseStakers) GetDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) StakerIterator {
@@ -179,7 +232,7 @@ func (v *baseStakers) PutDelegator(staker *Staker) {
 	}
 	validatorDiff.addedDelegators.ReplaceOrInsert(staker)
 
-	v.stakers.ReplaceOrInsert(staker)
+	v.insertStaker(staker)
 }
 
 func (v *baseStakers) DeleteDelegator(staker *Staker) {
@@ -195,13 +248,55 @@ func (v *baseStakers) DeleteDelegator(staker *Staker) {
 	}
 	validatorDiff.deletedDelegators[staker.TxID] = staker
 
-	v.stakers.Delete(staker)
+	v.removeStaker(staker)
 }
 
 func (v *baseStakers) GetStakerIterator() StakerIterator {
 	return NewTreeIterator(v.stakers)
 }
 
+func (v *baseStakers) GetSubnetStakerIterator(subnetID ids.ID) StakerIterator {
+	return NewTreeIterator(v.subnetStakers[subnetID])
+}
+
+// GetValidatorSubnetIDs returns the IDs of the subnets that [nodeID] is a
+// validator of.
+func (v *baseStakers) GetValidatorSubnetIDs(nodeID ids.NodeID) set.Set[ids.ID] {
+	var subnetIDs set.Set[ids.ID]
+	for subnetID, subnetValidators := range v.validators {
+		if validator, ok := subnetValidators[nodeID]; ok && validator.validator != nil {
+			subnetIDs.Add(subnetID)
+		}
+	}
+	return subnetIDs
+}
+
+// insertStaker adds [staker] to the staker indices.
+func (v *baseStakers) insertStaker(staker *Staker) {
+	v.stakers.ReplaceOrInsert(staker)
+
+	subnetStakers, ok := v.subnetStakers[staker.SubnetID]
+	if !ok {
+		subnetStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+		v.subnetStakers[staker.SubnetID] = subnetStakers
+	}
+	subnetStakers.ReplaceOrInsert(staker)
+}
+
+// removeStaker removes [staker] from the staker indices.
+func (v *baseStakers) removeStaker(staker *Staker) {
+	v.stakers.Delete(staker)
+
+	subnetStakers, ok := v.subnetStakers[staker.SubnetID]
+	if !ok {
+		return
+	}
+	subnetStakers.Delete(staker)
+	if subnetStakers.Len() == 0 {
+		delete(v.subnetStakers, staker.SubnetID)
+	}
+}
+
 func (v *baseStakers) getOrCreateValidator(subnetID ids.ID, nodeID ids.NodeID) *baseStaker {
 	subnetValidators, ok := v.validators[subnetID]
 	if !ok {
@@ -253,7 +348,13 @@ type diffStakers struct {
 	// subnetID --> nodeID --> diff for that validator
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 	addedStakers   *btree.BTreeG[*Staker]
-	deletedStakers map[ids.ID]*Staker
+	// subnetID --> stakers of the subnet in [addedStakers]
+	addedSubnetStakers map[ids.ID]*btree.BTreeG[*Staker]
+	deletedStakers     map[ids.ID]*Staker
+	// updatedStakers are the validators that were updated in place. They are
+	// also in [addedStakers] and replace the parent's version of the
+	// validator.
+	updatedStakers map[ids.ID]*Staker
 }
 
 type diffValidator struct {
@@ -263,6 +364,10 @@ type diffValidator struct {
 	// mean that diffValidator hasn't change, since delegators may have changed.
 	validatorStatus diffValidatorStatus
 	validator       *Staker
+	// prevValidator is the validator that was replaced if validatorStatus is
+	// modified. It is only populated for the base stakers, as the diffs don't
+	// need it.
+	prevValidator *Staker
 
 	addedDelegators   *btree.BTreeG[*Staker]
 	deletedDelegators map[ids.ID]*Staker
@@ -282,10 +387,12 @@ func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 		return nil, unmodified
 	}
 
-	if validatorDiff.validatorStatus == added {
-		return validatorDiff.validator, added
+	switch validatorDiff.validatorStatus {
+	case added, modified:
+		return validatorDiff.validator, validatorDiff.validatorStatus
+	default:
+		return nil, validatorDiff.validatorStatus
 	}
-	return nil, validatorDiff.validatorStatus
 }
 
 func (s *diffStakers) PutValidator(staker *Staker) {
@@ -293,10 +400,7 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
-	if s.addedStakers == nil {
-		s.addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
-	}
-	s.addedStakers.ReplaceOrInsert(staker)
+	s.insertAddedStaker(staker)
 }
 
 func (s *diffStakers) DeleteValidator(staker *Staker) {
@@ -305,9 +409,15 @@ func (s *diffStakers) DeleteValidator(staker *Staker) {
 		// This validator was added and immediately removed in this diff. We
 		// treat it as if it was never added.
 		validatorDiff.validatorStatus = unmodified
-		s.addedStakers.Delete(validatorDiff.validator)
+		s.removeAddedStaker(validatorDiff.validator)
 		validatorDiff.validator = nil
 	} else {
+		if validatorDiff.validatorStatus == modified {
+			// The updated validator is removed along with the parent's
+			// validator.
+			s.removeAddedStaker(validatorDiff.validator)
+			delete(s.updatedStakers, staker.TxID)
+		}
 		validatorDiff.validatorStatus = deleted
 		validatorDiff.validator = staker
 		if s.deletedStakers == nil {
@@ -317,6 +427,26 @@ func (s *diffStakers) DeleteValidator(staker *Staker) {
 	}
 }
 
+func (s *diffStakers) UpdateValidator(staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if prevStaker := validatorDiff.validator; prevStaker != nil {
+		// The staker was already added or updated in this diff. It must be
+		// removed explicitly, as the updated staker may be ordered
+		// differently.
+		s.removeAddedStaker(prevStaker)
+	}
+	if validatorDiff.validatorStatus != added {
+		validatorDiff.validatorStatus = modified
+		if s.updatedStakers == nil {
+			s.updatedStakers = make(map[ids.ID]*Staker)
+		}
+		s.updatedStakers[staker.TxID] = staker
+	}
+	validatorDiff.validator = staker
+
+	s.insertAddedStaker(staker)
+}
+
 func (s *diffStakers) GetDelegatorIterator(
 	parentIterator StakerIterator,
 	subnetID ids.ID,
@@ -349,10 +479,7 @@ func (s *diffStakers) PutDelegator(staker *Staker) {
 	}
 	validatorDiff.addedDelegators.ReplaceOrInsert(staker)
 
-	if s.addedStakers == nil {
-		s.addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
-	}
-	s.addedStakers.ReplaceOrInsert(staker)
+	s.insertAddedStaker(staker)
 }
 
 func (s *diffStakers) DeleteDelegator(staker *Staker) {
@@ -369,6 +496,9 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 }
 
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
+	if len(s.updatedStakers) > 0 {
+		parentIterator = NewMaskedIterator(parentIterator, s.updatedStakers)
+	}
 	return NewMaskedIterator(
 		NewMergedIterator(
 			parentIterator,
@@ -378,6 +508,65 @@ func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIte
 	)
 }
 
+func (s *diffStakers) GetSubnetStakerIterator(parentIterator StakerIterator, subnetID ids.ID) StakerIterator {
+	if len(s.updatedStakers) > 0 {
+		parentIterator = NewMaskedIterator(parentIterator, s.updatedStakers)
+	}
+	return NewMaskedIterator(
+		NewMergedIterator(
+			parentIterator,
+			NewTreeIterator(s.addedSubnetStakers[subnetID]),
+		),
+		s.deletedStakers,
+	)
+}
+
+// ApplyValidatorSubnetIDs updates the [subnetIDs] that [nodeID] was a
+// validator of in the parent state to include the validators added and removed
+// in this diff.
+func (s *diffStakers) ApplyValidatorSubnetIDs(nodeID ids.NodeID, subnetIDs *set.Set[ids.ID]) {
+	for subnetID, subnetValidatorDiffs := range s.validatorDiffs {
+		validatorDiff, ok := subnetValidatorDiffs[nodeID]
+		if !ok {
+			continue
+		}
+		switch validatorDiff.validatorStatus {
+		case added:
+			subnetIDs.Add(subnetID)
+		case deleted:
+			subnetIDs.Remove(subnetID)
+		}
+	}
+}
+
+// insertAddedStaker adds [staker] to the added staker indices.
+func (s *diffStakers) insertAddedStaker(staker *Staker) {
+	if s.addedStakers == nil {
+		s.addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+		s.addedSubnetStakers = make(map[ids.ID]*btree.BTreeG[*Staker])
+	}
+	s.addedStakers.ReplaceOrInsert(staker)
+
+	subnetStakers, ok := s.addedSubnetStakers[staker.SubnetID]
+	if !ok {
+		subnetStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+		s.addedSubnetStakers[staker.SubnetID] = subnetStakers
+	}
+	subnetStakers.ReplaceOrInsert(staker)
+}
+
+// removeAddedStaker removes [staker] from the added staker indices.
+func (s *diffStakers) removeAddedStaker(staker *Staker) {
+	if s.addedStakers == nil {
+		return
+	}
+	s.addedStakers.Delete(staker)
+
+	if subnetStakers, ok := s.addedSubnetStakers[staker.SubnetID]; ok {
+		subnetStakers.Delete(staker)
+	}
+}
+
 func (s *diffStakers) getOrCreateDiff(subnetID ids.ID, nodeID ids.NodeID) *diffValidator {
 	if s.validatorDiffs == nil {
 		s.validatorDiffs = make(map[ids.ID]map[ids.NodeID]*diffValidator)
@@ -11,6 +11,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
@@ -106,6 +108,32 @@ func TestBaseStakersValidator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
 }
 
+func TestBaseStakersValidatorSubnetIDs(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+	subnetStaker := newTestStaker()
+	subnetStaker.NodeID = staker.NodeID
+	delegator := newTestStaker()
+	delegator.NodeID = staker.NodeID
+
+	v := newBaseStakers()
+
+	v.PutValidator(staker)
+	v.PutValidator(subnetStaker)
+	v.PutDelegator(delegator)
+
+	// delegations without a validator shouldn't be reported
+	require.Equal(
+		set.Of(staker.SubnetID, subnetStaker.SubnetID),
+		v.GetValidatorSubnetIDs(staker.NodeID),
+	)
+	require.Empty(v.GetValidatorSubnetIDs(ids.GenerateTestNodeID()))
+
+	v.DeleteValidator(subnetStaker)
+
+	require.Equal(set.Of(staker.SubnetID), v.GetValidatorSubnetIDs(staker.NodeID))
+}
+
 func TestBaseStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -137,6 +165,39 @@ func TestBaseStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestBaseStakersSubnetStakerIterator(t *testing.T) {
+	staker := newTestStaker()
+	delegator := newTestStaker()
+	delegator.SubnetID = staker.SubnetID
+	delegator.NextTime = staker.NextTime.Add(time.Second)
+	otherStaker := newTestStaker()
+
+	v := newBaseStakers()
+
+	stakerIterator := v.GetSubnetStakerIterator(staker.SubnetID)
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+
+	v.PutValidator(staker)
+	v.PutDelegator(delegator)
+	v.PutValidator(otherStaker)
+
+	stakerIterator = v.GetSubnetStakerIterator(staker.SubnetID)
+	assertIteratorsEqual(t, NewSliceIterator(staker, delegator), stakerIterator)
+
+	stakerIterator = v.GetSubnetStakerIterator(otherStaker.SubnetID)
+	assertIteratorsEqual(t, NewSliceIterator(otherStaker), stakerIterator)
+
+	v.DeleteValidator(staker)
+
+	stakerIterator = v.GetSubnetStakerIterator(staker.SubnetID)
+	assertIteratorsEqual(t, NewSliceIterator(delegator), stakerIterator)
+
+	v.DeleteDelegator(delegator)
+
+	stakerIterator = v.GetSubnetStakerIterator(staker.SubnetID)
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
 func TestDiffStakersValidator(t *testing.T) {
 	require := require.New(t)
 	staker := newTestStaker()
@@ -195,6 +256,80 @@ func TestDiffStakersDeleteValidator(t *testing.T) {
 	require.Nil(returnedStaker)
 }
 
+func TestDiffStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	updatedStaker := *staker
+	updatedStaker.PublicKey = bls.PublicFromSecretKey(sk)
+
+	v := diffStakers{}
+	v.UpdateValidator(&updatedStaker)
+
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(modified, status)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	// The updated validator replaces the parent's validator.
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	v.DeleteValidator(staker)
+
+	returnedStaker, status = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(deleted, status)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
+func TestDiffStakersUpdateValidatorEndTime(t *testing.T) {
+	staker := newTestStaker()
+
+	updatedStaker := *staker
+	updatedStaker.EndTime = staker.EndTime.Add(-time.Hour)
+	updatedStaker.NextTime = updatedStaker.EndTime
+
+	rescheduledStaker := updatedStaker
+	rescheduledStaker.EndTime = updatedStaker.EndTime.Add(-time.Hour)
+	rescheduledStaker.NextTime = rescheduledStaker.EndTime
+
+	// Updating the validator twice must not leave the first update in the
+	// diff, even though the updates are ordered differently.
+	v := diffStakers{}
+	v.UpdateValidator(&updatedStaker)
+	v.UpdateValidator(&rescheduledStaker)
+
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(&rescheduledStaker), stakerIterator)
+}
+
+func TestDiffStakersApplyValidatorSubnetIDs(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+	addedStaker := newTestStaker()
+	addedStaker.NodeID = staker.NodeID
+	deletedStaker := newTestStaker()
+	deletedStaker.NodeID = staker.NodeID
+
+	v := diffStakers{}
+
+	v.PutValidator(addedStaker)
+	v.DeleteValidator(deletedStaker)
+
+	subnetIDs := set.Of(staker.SubnetID, deletedStaker.SubnetID)
+	v.ApplyValidatorSubnetIDs(staker.NodeID, &subnetIDs)
+	require.Equal(set.Of(staker.SubnetID, addedStaker.SubnetID), subnetIDs)
+
+	var emptySubnetIDs set.Set[ids.ID]
+	v.ApplyValidatorSubnetIDs(ids.GenerateTestNodeID(), &emptySubnetIDs)
+	require.Empty(emptySubnetIDs)
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -217,6 +352,40 @@ func TestDiffStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestDiffStakersSubnetStakerIterator(t *testing.T) {
+	parentStaker := newTestStaker()
+	deletedStaker := newTestStaker()
+	deletedStaker.SubnetID = parentStaker.SubnetID
+	deletedStaker.NextTime = parentStaker.NextTime.Add(time.Second)
+	addedStaker := newTestStaker()
+	addedStaker.SubnetID = parentStaker.SubnetID
+	addedStaker.NextTime = parentStaker.NextTime.Add(2 * time.Second)
+	otherStaker := newTestStaker()
+
+	updatedStaker := *parentStaker
+	updatedStaker.Weight++
+
+	v := diffStakers{}
+	v.PutValidator(addedStaker)
+	v.PutValidator(otherStaker)
+	v.DeleteValidator(deletedStaker)
+	v.UpdateValidator(&updatedStaker)
+
+	stakerIterator := v.GetSubnetStakerIterator(
+		NewSliceIterator(parentStaker, deletedStaker),
+		parentStaker.SubnetID,
+	)
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker, addedStaker), stakerIterator)
+
+	stakerIterator = v.GetSubnetStakerIterator(EmptyIterator, otherStaker.SubnetID)
+	assertIteratorsEqual(t, NewSliceIterator(otherStaker), stakerIterator)
+
+	v.DeleteValidator(addedStaker)
+
+	stakerIterator = v.GetSubnetStakerIterator(EmptyIterator, parentStaker.SubnetID)
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+}
+
 func newTestStaker() *Staker {
 	startTime := time.Now().Round(time.Second)
 	endTime := startTime.Add(28 * 24 * time.Hour)
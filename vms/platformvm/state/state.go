@@ -8,12 +8,15 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/google/btree"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
@@ -31,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -51,6 +55,15 @@ const (
 	indexIterationSleepMultiplier = 5
 	indexIterationSleepCap        = 10 * time.Second
 	indexLogFrequency             = 30 * time.Second
+
+	// maxTxPruningBlocksPerCommit bounds the number of blocks whose txs are
+	// pruned during a single commit so that enabling pruning on an existing
+	// node doesn't stall block acceptance.
+	maxTxPruningBlocksPerCommit = 1024
+
+	// maxValidatorDiffPruningBlocksPerCommit bounds the number of blocks whose
+	// validator diffs are pruned during a single commit.
+	maxValidatorDiffPruningBlocksPerCommit = 1024
 )
 
 var (
@@ -58,9 +71,27 @@ var (
 
 	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
 	errIsNotSubnet                  = errors.New("is not a subnet")
+	errWeightIncreased              = errors.New("weight of validator was increased in place")
+	errEndTimeIncreased             = errors.New("end time of validator was increased in place")
+	errMissingChainDataDir          = errors.New("async commits require a chain data directory")
+
+	// ErrTxPruned is returned when the bytes of an accepted tx were discarded
+	// because the tx was accepted outside of the configured retention window.
+	ErrTxPruned = errors.New("tx has been pruned")
+
+	// ErrValidatorDiffsPruned is returned when the validator diffs needed to
+	// rebuild a historical validator set were discarded because they were
+	// written outside of the configured retention window.
+	ErrValidatorDiffsPruned = errors.New("validator diffs have been pruned")
+	ErrHeightNotJournaled   = errors.New("height isn't covered by the UTXO journal")
+	// ErrStakerDiffsUnavailable is returned when the stakers at a height can't
+	// be reconstructed because the height was accepted before staker diffs
+	// were recorded.
+	ErrStakerDiffsUnavailable = errors.New("staker diffs aren't available")
 
 	BlockIDPrefix                 = []byte("blockID")
 	BlockPrefix                   = []byte("block")
+	BlockSummaryPrefix            = []byte("blockSummary")
 	ValidatorsPrefix              = []byte("validators")
 	CurrentPrefix                 = []byte("current")
 	PendingPrefix                 = []byte("pending")
@@ -70,11 +101,21 @@ var (
 	SubnetDelegatorPrefix         = []byte("subnetDelegator")
 	ValidatorWeightDiffsPrefix    = []byte("flatValidatorDiffs")
 	ValidatorPublicKeyDiffsPrefix = []byte("flatPublicKeyDiffs")
+	StakerDiffsPrefix             = []byte("stakerDiffs")
+	RotatedNodeIDsPrefix          = []byte("rotatedNodeIDs")
+	ReducedWeightsPrefix          = []byte("reducedWeights")
+	ReducedEndTimesPrefix         = []byte("reducedEndTimes")
+	ValidatorCheckpointsPrefix    = []byte("validatorCheckpoints")
 	TxPrefix                      = []byte("tx")
 	RewardUTXOsPrefix             = []byte("rewardUTXOs")
 	UTXOPrefix                    = []byte("utxo")
+	UTXOJournalPrefix             = []byte("utxoJournal")
 	SubnetPrefix                  = []byte("subnet")
 	SubnetOwnerPrefix             = []byte("subnetOwner")
+	DelegationFeeChangesPrefix    = []byte("delegationFeeChanges")
+	MisbehaviorReportsPrefix      = []byte("misbehaviorReports")
+	SubnetStakingParamsPrefix     = []byte("subnetStakingParams")
+	SubnetValidatorEpochsPrefix   = []byte("subnetValidatorEpochs")
 	TransformedSubnetPrefix       = []byte("transformedSubnet")
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
@@ -86,6 +127,12 @@ var (
 	HeightsIndexedKey  = []byte("heights indexed")
 	InitializedKey     = []byte("initialized")
 	BlocksReindexedKey = []byte("blocks reindexed")
+	TxsPrunedHeightKey = []byte("txs pruned height")
+
+	ValidatorDiffsPrunedHeightKey = []byte("validator diffs pruned height")
+	UTXOJournalStartHeightKey     = []byte("utxo journal start height")
+	StakerDiffsStartHeightKey     = []byte("staker diffs start height")
+	SchemaVersionKey              = []byte("schema version")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -109,6 +156,40 @@ type Chain interface {
 	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
 	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
 
+	// GetDelegationFeeChanges returns the changes made to the delegation fee
+	// of the validator added by [validatorTxID], in the order they were made.
+	GetDelegationFeeChanges(validatorTxID ids.ID) ([]*DelegationFeeChange, error)
+	// SetDelegationFeeChanges replaces the changes made to the delegation fee
+	// of the validator added by [validatorTxID]. If [changes] is empty, the
+	// changes are removed.
+	SetDelegationFeeChanges(validatorTxID ids.ID, changes []*DelegationFeeChange)
+
+	// GetMisbehaviorReport returns the ID of the tx that reported the
+	// misbehavior of the validator added by [validatorTxID]. If the validator
+	// hasn't been reported, database.ErrNotFound is returned.
+	GetMisbehaviorReport(validatorTxID ids.ID) (ids.ID, error)
+	// SetMisbehaviorReport records that the misbehavior of the validator added
+	// by [validatorTxID] was reported by [reportTxID]. If [reportTxID] is
+	// ids.Empty, the report is removed.
+	SetMisbehaviorReport(validatorTxID ids.ID, reportTxID ids.ID)
+
+	// GetSubnetStakingParams returns the staking parameters that were set for
+	// the elastic subnet [subnetID] after its transformation. If the
+	// parameters were never updated, database.ErrNotFound is returned.
+	GetSubnetStakingParams(subnetID ids.ID) (*SubnetStakingParams, error)
+	// SetSubnetStakingParams replaces the staking parameters of the elastic
+	// subnet [subnetID].
+	SetSubnetStakingParams(subnetID ids.ID, params *SubnetStakingParams)
+
+	// GetSubnetValidatorEpoch returns the duration of the epochs that the
+	// validator set of [subnetID] is frozen for. If the validator set isn't
+	// frozen, database.ErrNotFound is returned.
+	GetSubnetValidatorEpoch(subnetID ids.ID) (time.Duration, error)
+	// SetSubnetValidatorEpoch sets the duration of the epochs that the
+	// validator set of [subnetID] is frozen for. If [epoch] is 0, the
+	// validator set is no longer frozen.
+	SetSubnetValidatorEpoch(subnetID ids.ID, epoch time.Duration)
+
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
@@ -133,10 +214,28 @@ type State interface {
 
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
+	// Invariant: [summary] describes the accepted block at [height].
+	AddBlockSummary(height uint64, summary *BlockSummary)
+
+	// GetBlockSummary returns the summary of the accepted block at [height].
+	// Blocks accepted before summaries were recorded don't have a summary.
+	GetBlockSummary(height uint64) (*BlockSummary, error)
+
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
+
+	// GetUTXOsAtHeight returns the UTXOs that referenced at least one of
+	// [addrs] after the block at [height] was accepted, sorted by ID. If the
+	// UTXO journal doesn't cover [height], ErrHeightNotJournaled is returned.
+	GetUTXOsAtHeight(addrs set.Set[ids.ShortID], height uint64) ([]*avax.UTXO, error)
+
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
+	// GetChainCreationTime returns the timestamp of the block that created
+	// [chainID] on [subnetID]. Chains that were created before creation times
+	// were recorded return database.ErrNotFound.
+	GetChainCreationTime(subnetID ids.ID, chainID ids.ID) (time.Time, error)
+
 	// ApplyValidatorWeightDiffs iterates from [startHeight] towards the genesis
 	// block until it has applied all of the diffs up to and including
 	// [endHeight]. Applying the diffs modifies [validators].
@@ -174,6 +273,34 @@ type State interface {
 		endHeight uint64,
 	) error
 
+	// ApplyStakerDiffs iterates from [startHeight] towards the genesis block
+	// until it has undone all of the changes to the current stakers of
+	// [subnetID] up to and including [endHeight]. Undoing the changes modifies
+	// [stakers], which maps each staker's txID to the staker. The public keys
+	// of restored stakers aren't populated.
+	//
+	// Invariant: If attempting to generate the stakers for [endHeight - 1],
+	// [stakers] must initially contain the current stakers for [startHeight].
+	//
+	// If the staker diffs don't cover [endHeight - 1],
+	// ErrStakerDiffsUnavailable is returned.
+	ApplyStakerDiffs(
+		ctx context.Context,
+		stakers map[ids.ID]*Staker,
+		startHeight uint64,
+		endHeight uint64,
+		subnetID ids.ID,
+	) error
+
+	// GetValidatorSetCheckpoint returns the lowest height at or above
+	// [minHeight] at which the validator set of [subnetID] was checkpointed,
+	// along with the validator set at that height. If there is no such
+	// checkpoint, database.ErrNotFound is returned.
+	GetValidatorSetCheckpoint(
+		subnetID ids.ID,
+		minHeight uint64,
+	) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -192,10 +319,24 @@ type State interface {
 
 	// Returns a batch of unwritten changes that, when written, will commit all
 	// pending changes to the base database.
+	//
+	// If async commits are enabled, writing the batch appends it to the
+	// async commit log and queues it to be written to the base database. The
+	// log is replayed on startup. The inner batch must only be written after
+	// Flush has returned.
 	CommitBatch() (database.Batch, error)
 
+	// Flush blocks until every committed change has been written to the base
+	// database.
+	Flush() error
+
 	Checksum() ids.ID
 
+	// Verify checks the referential integrity of the database. Every
+	// inconsistency that is found is returned. If the database can't be read,
+	// an error is returned instead.
+	Verify(ctx context.Context) ([]error, error)
+
 	Close() error
 }
 
@@ -228,24 +369,36 @@ type stateBlk struct {
  * | |-. pending
  * | | |-. validator
  * | | | '-. list
- * | | |   '-- txID -> nil
+ * | | |   '-- txID -> start time or nil
  * | | |-. delegator
  * | | | '-. list
  * | | |   '-- txID -> nil
  * | | |-. subnetValidator
  * | | | '-. list
- * | | |   '-- txID -> nil
+ * | | |   '-- txID -> start time or nil
  * | | '-. subnetDelegator
  * | |   '-. list
  * | |     '-- txID -> nil
  * | |-. weight diffs
  * | | '-- subnet+height+nodeID -> weightChange
- * | '-. pub key diffs
- * |   '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | |-. pub key diffs
+ * | | '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | |-. staker diffs
+ * | | '-- subnet+height+txID -> previous staker or nil if added
+ * | |-. rotated nodeIDs
+ * | | '-- txID -> nodeID + compressed public key or nil
+ * | |-. reduced weights
+ * | | '-- txID -> weight
+ * | |-. reduced end times
+ * | | '-- txID -> end time
+ * | '-. validator checkpoints
+ * |   '-- subnet+height -> validator set
  * |-. blockIDs
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. blockSummaries
+ * | '-- height -> block summary
  * |-. txs
  * | '-- txID -> tx bytes + tx status
  * |- rewardUTXOs
@@ -254,21 +407,33 @@ type stateBlk struct {
  * |     '-- utxoID -> utxo bytes
  * |- utxos
  * | '-- utxoDB
+ * |-. utxoJournal
+ * | '-- height+utxoID -> nil if added or utxo bytes if removed
  * |-. subnets
  * | '-. list
  * |   '-- txID -> nil
  * |-. subnetOwners
  * | '-. subnetID -> owner
+ * |-. delegationFeeChanges
+ * | '-. validatorTxID -> delegation fee changes
+ * |-. misbehaviorReports
+ * | '-. validatorTxID -> reportTxID
+ * |-. subnetStakingParams
+ * | '-. subnetID -> staking params
+ * |-. subnetValidatorEpochs
+ * | '-. subnetID -> epoch duration
  * |-. chains
  * | '-. subnetID
  * |   '-. list
- * |     '-- txID -> nil
+ * |     '-- txID -> creation timestamp or nil
  * '-. singletons
  *   |-- initializedKey -> nil
+ *   |-- schemaVersionKey -> version
  *   |-- blocksReindexedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
+ *   |-- utxoJournalStartHeightKey -> startHeight
  *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
  */
 type state struct {
@@ -281,6 +446,12 @@ type state struct {
 	rewards    reward.Calculator
 
 	baseDB *versiondb.Database
+	// asyncDB is nil if async commits are disabled.
+	asyncDB *asyncDB
+	// ioDB counts the database operations performed for every accepted
+	// block, which are reported to ioMetrics.
+	ioDB      *ioCountingDB
+	ioMetrics *ioMetrics
 
 	currentStakers *baseStakers
 	pendingStakers *baseStakers
@@ -295,6 +466,9 @@ type state struct {
 	blockCache  cache.Cacher[ids.ID, block.Block] // cache of blockID -> Block. If the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	addedBlockSummaries map[uint64]*BlockSummary // map of height -> summary
+	blockSummaryDB      database.Database
+
 	validatorsDB                 database.Database
 	currentValidatorsDB          database.Database
 	currentValidatorBaseDB       database.Database
@@ -317,11 +491,41 @@ type state struct {
 
 	validatorWeightDiffsDB    database.Database
 	validatorPublicKeyDiffsDB database.Database
+	stakerDiffsDB             database.Database
+	rotatedNodeIDsDB          database.Database
+	reducedWeightsDB          database.Database
+	reducedEndTimesDB         database.Database
+	validatorCheckpointsDB    database.Database
+
+	// validatorCheckpointInterval is the number of blocks between validator
+	// set checkpoints. If 0, no checkpoints are written.
+	validatorCheckpointInterval uint64
+
+	// validatorDiffRetentionBlocks is the number of most recently accepted
+	// blocks whose validator diffs are retained. If 0, all diffs are retained.
+	validatorDiffRetentionBlocks uint64
+	// validatorDiffsPrunedHeight is the height of the last block whose
+	// validator diffs were pruned. If 0, no diffs have been pruned.
+	validatorDiffsPrunedHeight          uint64
+	persistedValidatorDiffsPrunedHeight uint64
+
+	// stakerDiffsStartHeight is the lowest height whose stakers can be
+	// reconstructed from the staker diffs. It is only valid if
+	// stakerDiffsStarted is true.
+	stakerDiffsStartHeight uint64
+	stakerDiffsStarted     bool
 
 	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher[ids.ID, *txAndStatus] // txID -> {*txs.Tx, Status}. If the entry is nil, it isn't in the database
 	txDB     database.Database
 
+	// txRetentionBlocks is the number of most recently accepted blocks whose
+	// txs are fully retained. If 0, all txs are retained.
+	txRetentionBlocks uint64
+	// txsPrunedHeight is the height of the last block whose txs were pruned
+	txsPrunedHeight          uint64
+	persistedTxsPrunedHeight uint64
+
 	addedRewardUTXOs map[ids.ID][]*avax.UTXO            // map of txID -> []*UTXO
 	rewardUTXOsCache cache.Cacher[ids.ID, []*avax.UTXO] // txID -> []*UTXO
 	rewardUTXODB     database.Database
@@ -330,6 +534,14 @@ type state struct {
 	utxoDB        database.Database
 	utxoState     avax.UTXOState
 
+	utxoJournalEnabled bool
+	utxoJournalDB      database.Database
+	// utxoJournalStartHeight is the lowest height whose UTXOs can be
+	// reconstructed from the journal. It is only valid if utxoJournalStarted
+	// is true.
+	utxoJournalStartHeight uint64
+	utxoJournalStarted     bool
+
 	cachedSubnets []*txs.Tx // nil if the subnets haven't been loaded
 	addedSubnets  []*txs.Tx
 	subnetBaseDB  database.Database
@@ -340,6 +552,24 @@ type state struct {
 	subnetOwnerCache cache.Cacher[ids.ID, fxOwnerAndSize] // cache of subnetID -> owner if the entry is nil, it is not in the database
 	subnetOwnerDB    database.Database
 
+	// Validator TxID --> Changes to the validator's delegation fee
+	delegationFeeChanges   map[ids.ID][]*DelegationFeeChange
+	delegationFeeChangesDB database.Database
+
+	// Validator TxID --> TxID of the report of the validator's misbehavior
+	misbehaviorReports   map[ids.ID]ids.ID
+	misbehaviorReportsDB database.Database
+
+	// Subnet ID --> Staking parameters that replaced the ones of the
+	// subnet's transformation
+	subnetStakingParams   map[ids.ID]*SubnetStakingParams
+	subnetStakingParamsDB database.Database
+
+	// Subnet ID --> Duration of the epochs the subnet's validator set is
+	// frozen for
+	subnetValidatorEpochs   map[ids.ID]time.Duration
+	subnetValidatorEpochsDB database.Database
+
 	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
 	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
@@ -490,7 +720,25 @@ func newState(
 		return nil, err
 	}
 
-	baseDB := versiondb.New(db)
+	var asyncDB *asyncDB
+	if execCfg.AsyncCommitQueueSize > 0 {
+		if ctx.ChainDataDir == "" {
+			return nil, errMissingChainDataDir
+		}
+
+		logPath := filepath.Join(ctx.ChainDataDir, asyncCommitLogFileName)
+		asyncDB, err = newAsyncDB(db, logPath, execCfg.AsyncCommitQueueSize)
+		if err != nil {
+			return nil, err
+		}
+		db = asyncDB
+	}
+	ioDB := newIOCountingDB(db)
+	ioMetrics, err := newIOMetrics(metricsReg)
+	if err != nil {
+		return nil, err
+	}
+	baseDB := versiondb.New(ioDB)
 
 	validatorsDB := prefixdb.New(ValidatorsPrefix, baseDB)
 
@@ -507,7 +755,12 @@ func newState(
 	pendingSubnetDelegatorBaseDB := prefixdb.New(SubnetDelegatorPrefix, pendingValidatorsDB)
 
 	validatorWeightDiffsDB := prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB)
+	rotatedNodeIDsDB := prefixdb.New(RotatedNodeIDsPrefix, validatorsDB)
+	reducedWeightsDB := prefixdb.New(ReducedWeightsPrefix, validatorsDB)
+	reducedEndTimesDB := prefixdb.New(ReducedEndTimesPrefix, validatorsDB)
 	validatorPublicKeyDiffsDB := prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB)
+	stakerDiffsDB := prefixdb.New(StakerDiffsPrefix, validatorsDB)
+	validatorCheckpointsDB := prefixdb.New(ValidatorCheckpointsPrefix, validatorsDB)
 
 	txCache, err := metercacher.New(
 		"tx_cache",
@@ -593,6 +846,9 @@ func newState(
 		metrics:    metrics,
 		rewards:    rewards,
 		baseDB:     baseDB,
+		asyncDB:    asyncDB,
+		ioDB:       ioDB,
+		ioMetrics:  ioMetrics,
 
 		addedBlockIDs: make(map[uint64]ids.ID),
 		blockIDCache:  blockIDCache,
@@ -602,6 +858,9 @@ func newState(
 		blockCache:  blockCache,
 		blockDB:     prefixdb.New(BlockPrefix, baseDB),
 
+		addedBlockSummaries: make(map[uint64]*BlockSummary),
+		blockSummaryDB:      prefixdb.New(BlockSummaryPrefix, baseDB),
+
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
 
@@ -626,10 +885,18 @@ func newState(
 		pendingSubnetDelegatorList:   linkeddb.NewDefault(pendingSubnetDelegatorBaseDB),
 		validatorWeightDiffsDB:       validatorWeightDiffsDB,
 		validatorPublicKeyDiffsDB:    validatorPublicKeyDiffsDB,
-
-		addedTxs: make(map[ids.ID]*txAndStatus),
-		txDB:     prefixdb.New(TxPrefix, baseDB),
-		txCache:  txCache,
+		stakerDiffsDB:                stakerDiffsDB,
+		rotatedNodeIDsDB:             rotatedNodeIDsDB,
+		reducedWeightsDB:             reducedWeightsDB,
+		reducedEndTimesDB:            reducedEndTimesDB,
+		validatorCheckpointsDB:       validatorCheckpointsDB,
+		validatorCheckpointInterval:  execCfg.ValidatorCheckpointInterval,
+		validatorDiffRetentionBlocks: execCfg.ValidatorDiffRetentionBlocks,
+
+		addedTxs:          make(map[ids.ID]*txAndStatus),
+		txDB:              prefixdb.New(TxPrefix, baseDB),
+		txCache:           txCache,
+		txRetentionBlocks: execCfg.TxRetentionBlocks,
 
 		addedRewardUTXOs: make(map[ids.ID][]*avax.UTXO),
 		rewardUTXODB:     rewardUTXODB,
@@ -639,6 +906,9 @@ func newState(
 		utxoDB:        utxoDB,
 		utxoState:     utxoState,
 
+		utxoJournalEnabled: execCfg.UTXOJournalEnabled,
+		utxoJournalDB:      prefixdb.New(UTXOJournalPrefix, baseDB),
+
 		subnetBaseDB: subnetBaseDB,
 		subnetDB:     linkeddb.NewDefault(subnetBaseDB),
 
@@ -646,6 +916,18 @@ func newState(
 		subnetOwnerDB:    subnetOwnerDB,
 		subnetOwnerCache: subnetOwnerCache,
 
+		delegationFeeChanges:   make(map[ids.ID][]*DelegationFeeChange),
+		delegationFeeChangesDB: prefixdb.New(DelegationFeeChangesPrefix, baseDB),
+
+		misbehaviorReports:   make(map[ids.ID]ids.ID),
+		misbehaviorReportsDB: prefixdb.New(MisbehaviorReportsPrefix, baseDB),
+
+		subnetStakingParams:   make(map[ids.ID]*SubnetStakingParams),
+		subnetStakingParamsDB: prefixdb.New(SubnetStakingParamsPrefix, baseDB),
+
+		subnetValidatorEpochs:   make(map[ids.ID]time.Duration),
+		subnetValidatorEpochsDB: prefixdb.New(SubnetValidatorEpochsPrefix, baseDB),
+
 		transformedSubnets:     make(map[ids.ID]*txs.Tx),
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(TransformedSubnetPrefix, baseDB),
@@ -675,6 +957,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) UpdateCurrentValidator(staker *Staker) {
+	s.currentStakers.UpdateValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -691,6 +977,14 @@ func (s *state) GetCurrentStakerIterator() (StakerIterator, error) {
 	return s.currentStakers.GetStakerIterator(), nil
 }
 
+func (s *state) GetCurrentSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error) {
+	return s.currentStakers.GetSubnetStakerIterator(subnetID), nil
+}
+
+func (s *state) GetCurrentValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error) {
+	return s.currentStakers.GetValidatorSubnetIDs(nodeID), nil
+}
+
 func (s *state) GetPendingValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	return s.pendingStakers.GetValidator(subnetID, nodeID)
 }
@@ -719,6 +1013,14 @@ func (s *state) GetPendingStakerIterator() (StakerIterator, error) {
 	return s.pendingStakers.GetStakerIterator(), nil
 }
 
+func (s *state) GetPendingSubnetStakerIterator(subnetID ids.ID) (StakerIterator, error) {
+	return s.pendingStakers.GetSubnetStakerIterator(subnetID), nil
+}
+
+func (s *state) GetPendingValidatorSubnetIDs(nodeID ids.NodeID) (set.Set[ids.ID], error) {
+	return s.pendingStakers.GetValidatorSubnetIDs(nodeID), nil
+}
+
 func (s *state) shouldInit() (bool, error) {
 	has, err := s.singletonDB.Has(InitializedKey)
 	return !has, err
@@ -813,6 +1115,84 @@ func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	s.subnetOwners[subnetID] = owner
 }
 
+func (s *state) GetDelegationFeeChanges(validatorTxID ids.ID) ([]*DelegationFeeChange, error) {
+	if changes, exists := s.delegationFeeChanges[validatorTxID]; exists {
+		return changes, nil
+	}
+
+	changesBytes, err := s.delegationFeeChangesDB.Get(validatorTxID[:])
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*DelegationFeeChange
+	if _, err := block.GenesisCodec.Unmarshal(changesBytes, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *state) SetDelegationFeeChanges(validatorTxID ids.ID, changes []*DelegationFeeChange) {
+	s.delegationFeeChanges[validatorTxID] = changes
+}
+
+func (s *state) GetMisbehaviorReport(validatorTxID ids.ID) (ids.ID, error) {
+	if reportTxID, exists := s.misbehaviorReports[validatorTxID]; exists {
+		if reportTxID == ids.Empty {
+			return ids.Empty, database.ErrNotFound
+		}
+		return reportTxID, nil
+	}
+	return database.GetID(s.misbehaviorReportsDB, validatorTxID[:])
+}
+
+func (s *state) SetMisbehaviorReport(validatorTxID ids.ID, reportTxID ids.ID) {
+	s.misbehaviorReports[validatorTxID] = reportTxID
+}
+
+func (s *state) GetSubnetStakingParams(subnetID ids.ID) (*SubnetStakingParams, error) {
+	if params, exists := s.subnetStakingParams[subnetID]; exists {
+		return params, nil
+	}
+
+	paramsBytes, err := s.subnetStakingParamsDB.Get(subnetID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	params := &SubnetStakingParams{}
+	if _, err := block.GenesisCodec.Unmarshal(paramsBytes, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (s *state) SetSubnetStakingParams(subnetID ids.ID, params *SubnetStakingParams) {
+	s.subnetStakingParams[subnetID] = params
+}
+
+func (s *state) GetSubnetValidatorEpoch(subnetID ids.ID) (time.Duration, error) {
+	if epoch, exists := s.subnetValidatorEpochs[subnetID]; exists {
+		if epoch == 0 {
+			return 0, database.ErrNotFound
+		}
+		return epoch, nil
+	}
+
+	epochSeconds, err := database.GetUInt64(s.subnetValidatorEpochsDB, subnetID[:])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(epochSeconds) * time.Second, nil
+}
+
+func (s *state) SetSubnetValidatorEpoch(subnetID ids.ID, epoch time.Duration) {
+	s.subnetValidatorEpochs[subnetID] = epoch
+}
+
 func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	if tx, exists := s.transformedSubnets[subnetID]; exists {
 		return tx, nil
@@ -886,6 +1266,26 @@ func (s *state) AddChain(createChainTxIntf *txs.Tx) {
 	}
 }
 
+func (s *state) GetChainCreationTime(subnetID ids.ID, chainID ids.ID) (time.Time, error) {
+	for _, chain := range s.addedChains[subnetID] {
+		if chain.ID() == chainID {
+			// The chain is being created in the block that is currently being
+			// committed.
+			return s.timestamp, nil
+		}
+	}
+
+	chainDB := s.getChainDB(subnetID)
+	creationTimeBytes, err := chainDB.Get(chainID[:])
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(creationTimeBytes) == 0 {
+		return time.Time{}, database.ErrNotFound
+	}
+	return database.ParseTimestamp(creationTimeBytes)
+}
+
 func (s *state) getChainDB(subnetID ids.ID) linkeddb.LinkedDB {
 	if chainDB, cached := s.chainDBCache.Get(subnetID); cached {
 		return chainDB
@@ -918,6 +1318,9 @@ func (s *state) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
 	if _, err := txs.GenesisCodec.Unmarshal(txBytes, &stx); err != nil {
 		return nil, status.Unknown, err
 	}
+	if len(stx.Tx) == 0 {
+		return nil, stx.Status, ErrTxPruned
+	}
 
 	tx, err := txs.Parse(txs.GenesisCodec, stx.Tx)
 	if err != nil {
@@ -983,6 +1386,94 @@ func (s *state) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
 	return s.utxoState.GetUTXO(utxoID)
 }
 
+func (s *state) GetUTXOsAtHeight(addrs set.Set[ids.ShortID], height uint64) ([]*avax.UTXO, error) {
+	if !s.utxoJournalStarted || height < s.utxoJournalStartHeight {
+		return nil, fmt.Errorf("%w: %d", ErrHeightNotJournaled, height)
+	}
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+	if lastAcceptedHeight := lastAccepted.Height(); height > lastAcceptedHeight {
+		return nil, fmt.Errorf("%w: %d is above the last accepted height %d",
+			ErrHeightNotJournaled,
+			height,
+			lastAcceptedHeight,
+		)
+	}
+
+	// The persisted UTXOs are read directly, as the UTXOs modified by
+	// uncommitted blocks aren't journaled yet.
+	currentUTXOs, err := avax.GetAllUTXOs(s.utxoState, addrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UTXOs: %w", err)
+	}
+	utxos := make(map[ids.ID]*avax.UTXO, len(currentUTXOs))
+	for _, utxo := range currentUTXOs {
+		utxos[utxo.InputID()] = utxo
+	}
+
+	// Undo the modifications of the blocks after [height]. Only the earliest
+	// modification of each UTXO determines whether it existed at [height].
+	var (
+		undone set.Set[ids.ID]
+		it     = s.utxoJournalDB.NewIteratorWithStart(database.PackUInt64(height + 1))
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != database.Uint64Size+ids.IDLen {
+			return nil, fmt.Errorf("unexpected UTXO journal key length %d", len(key))
+		}
+		utxoID := ids.ID(key[database.Uint64Size:])
+		if undone.Contains(utxoID) {
+			continue
+		}
+		undone.Add(utxoID)
+
+		utxoBytes := it.Value()
+		if len(utxoBytes) == 0 {
+			// The UTXO was added after [height].
+			delete(utxos, utxoID)
+			continue
+		}
+
+		// The UTXO was removed after [height].
+		utxo := &avax.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to parse journaled UTXO: %w", err)
+		}
+		if isOwnedBy(utxo, addrs) {
+			utxos[utxoID] = utxo
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	result := maps.Values(utxos)
+	slices.SortFunc(result, func(a, b *avax.UTXO) int {
+		return a.InputID().Compare(b.InputID())
+	})
+	return result, nil
+}
+
+// isOwnedBy returns true if [utxo] references at least one of [addrs].
+func isOwnedBy(utxo *avax.UTXO, addrs set.Set[ids.ShortID]) bool {
+	addressable, ok := utxo.Out.(avax.Addressable)
+	if !ok {
+		return false
+	}
+	for _, addr := range addressable.Addresses() {
+		addrID, err := ids.ToShortID(addr)
+		if err == nil && addrs.Contains(addrID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *state) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
 	return s.utxoState.UTXOIDs(addr, start, limit)
 }
@@ -1065,6 +1556,10 @@ func (s *state) ApplyValidatorWeightDiffs(
 	endHeight uint64,
 	subnetID ids.ID,
 ) error {
+	if err := s.verifyValidatorDiffsRetained(startHeight, endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(subnetID, startHeight),
 		subnetID[:],
@@ -1113,6 +1608,19 @@ func (s *state) ApplyValidatorWeightDiffs(
 	return diffIter.Error()
 }
 
+// verifyValidatorDiffsRetained returns an error if applying the diffs from
+// [startHeight] through [endHeight] requires diffs that have been pruned.
+func (s *state) verifyValidatorDiffsRetained(startHeight, endHeight uint64) error {
+	if s.validatorDiffsPrunedHeight == 0 || startHeight < endHeight || endHeight > s.validatorDiffsPrunedHeight {
+		return nil
+	}
+	return fmt.Errorf("%w: height %d is at or below the pruned height %d",
+		ErrValidatorDiffsPruned,
+		endHeight,
+		s.validatorDiffsPrunedHeight,
+	)
+}
+
 func applyWeightDiff(
 	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
 	nodeID ids.NodeID,
@@ -1156,6 +1664,10 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	startHeight uint64,
 	endHeight uint64,
 ) error {
+	if err := s.verifyValidatorDiffsRetained(startHeight, endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
 		constants.PrimaryNetworkID[:],
@@ -1199,6 +1711,61 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	return diffIter.Error()
 }
 
+func (s *state) ApplyStakerDiffs(
+	ctx context.Context,
+	stakers map[ids.ID]*Staker,
+	startHeight uint64,
+	endHeight uint64,
+	subnetID ids.ID,
+) error {
+	if startHeight < endHeight {
+		return nil
+	}
+	if !s.stakerDiffsStarted || endHeight <= s.stakerDiffsStartHeight {
+		return fmt.Errorf("%w: height %d was accepted before staker diffs were recorded",
+			ErrStakerDiffsUnavailable,
+			endHeight-1,
+		)
+	}
+	if err := s.verifyValidatorDiffsRetained(startHeight, endHeight); err != nil {
+		return err
+	}
+
+	diffIter := s.stakerDiffsDB.NewIteratorWithStartAndPrefix(
+		marshalStartDiffKey(subnetID, startHeight),
+		subnetID[:],
+	)
+	defer diffIter.Release()
+
+	for diffIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		parsedHeight, txID, err := unmarshalStakerDiffKey(diffIter.Key())
+		if err != nil {
+			return err
+		}
+		// If the parsedHeight is less than our target endHeight, then we have
+		// fully processed the diffs from startHeight through endHeight.
+		if parsedHeight < endHeight {
+			break
+		}
+
+		prevStaker, err := unmarshalStakerDiff(subnetID, txID, diffIter.Value())
+		if err != nil {
+			return fmt.Errorf("failed to parse staker diff of %s: %w", txID, err)
+		}
+		if prevStaker == nil {
+			// The staker was added at [parsedHeight].
+			delete(stakers, txID)
+			continue
+		}
+		stakers[txID] = prevStaker
+	}
+	return diffIter.Error()
+}
+
 func (s *state) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) error {
 	genesisBlkID := genesisBlk.ID()
 	s.SetLastAccepted(genesisBlkID)
@@ -1307,6 +1874,50 @@ func (s *state) loadMetadata() error {
 	s.persistedLastAccepted = lastAccepted
 	s.lastAccepted = lastAccepted
 
+	txsPrunedHeight, err := database.GetUInt64(s.singletonDB, TxsPrunedHeightKey)
+	switch err {
+	case nil:
+		s.persistedTxsPrunedHeight = txsPrunedHeight
+		s.txsPrunedHeight = txsPrunedHeight
+	case database.ErrNotFound:
+		// Txs have never been pruned.
+	default:
+		return err
+	}
+
+	validatorDiffsPrunedHeight, err := database.GetUInt64(s.singletonDB, ValidatorDiffsPrunedHeightKey)
+	switch err {
+	case nil:
+		s.persistedValidatorDiffsPrunedHeight = validatorDiffsPrunedHeight
+		s.validatorDiffsPrunedHeight = validatorDiffsPrunedHeight
+	case database.ErrNotFound:
+		// Validator diffs have never been pruned.
+	default:
+		return err
+	}
+
+	utxoJournalStartHeight, err := database.GetUInt64(s.singletonDB, UTXOJournalStartHeightKey)
+	switch err {
+	case nil:
+		s.utxoJournalStartHeight = utxoJournalStartHeight
+		s.utxoJournalStarted = true
+	case database.ErrNotFound:
+		// The UTXO journal hasn't been started.
+	default:
+		return err
+	}
+
+	stakerDiffsStartHeight, err := database.GetUInt64(s.singletonDB, StakerDiffsStartHeightKey)
+	switch err {
+	case nil:
+		s.stakerDiffsStartHeight = stakerDiffsStartHeight
+		s.stakerDiffsStarted = true
+	case database.ErrNotFound:
+		// No staker diffs have been written.
+	default:
+		return err
+	}
+
 	// Lookup the most recently indexed range on disk. If we haven't started
 	// indexing the weights, then we keep the indexed heights as nil.
 	indexedHeightsBytes, err := s.singletonDB.Get(HeightsIndexedKey)
@@ -1381,11 +1992,14 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := applyRotatedNodeID(s.rotatedNodeIDsDB, staker); err != nil {
+			return err
+		}
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
-		s.currentStakers.stakers.ReplaceOrInsert(staker)
+		s.currentStakers.insertStaker(staker)
 
 		s.validatorState.LoadValidatorMetadata(staker.NodeID, staker.SubnetID, metadata)
 	}
@@ -1432,10 +2046,17 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := applyReducedWeight(s.reducedWeightsDB, staker); err != nil {
+			return err
+		}
+		if err := applyReducedEndTime(s.reducedEndTimesDB, staker); err != nil {
+			return err
+		}
+
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
-		s.currentStakers.stakers.ReplaceOrInsert(staker)
+		s.currentStakers.insertStaker(staker)
 
 		s.validatorState.LoadValidatorMetadata(staker.NodeID, staker.SubnetID, metadata)
 	}
@@ -1487,6 +2108,9 @@ func (s *state) loadCurrentValidators() error {
 			if err != nil {
 				return err
 			}
+			if err := applyRotatedNodeID(s.rotatedNodeIDsDB, staker); err != nil {
+				return err
+			}
 
 			validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			if validator.delegators == nil {
@@ -1494,7 +2118,7 @@ func (s *state) loadCurrentValidators() error {
 			}
 			validator.delegators.ReplaceOrInsert(staker)
 
-			s.currentStakers.stakers.ReplaceOrInsert(staker)
+			s.currentStakers.insertStaker(staker)
 		}
 	}
 
@@ -1536,11 +2160,19 @@ func (s *state) loadPendingValidators() error {
 			if err != nil {
 				return err
 			}
+			if startTimeBytes := validatorIt.Value(); len(startTimeBytes) != 0 {
+				startTime, err := database.ParseUInt64(startTimeBytes)
+				if err != nil {
+					return fmt.Errorf("failed to parse start time of %s: %w", txID, err)
+				}
+				staker.StartTime = time.Unix(int64(startTime), 0)
+				staker.NextTime = staker.StartTime
+			}
 
 			validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			validator.validator = staker
 
-			s.pendingStakers.stakers.ReplaceOrInsert(staker)
+			s.pendingStakers.insertStaker(staker)
 		}
 	}
 
@@ -1578,7 +2210,7 @@ func (s *state) loadPendingValidators() error {
 			}
 			validator.delegators.ReplaceOrInsert(staker)
 
-			s.pendingStakers.stakers.ReplaceOrInsert(staker)
+			s.pendingStakers.insertStaker(staker)
 		}
 	}
 
@@ -1634,14 +2266,22 @@ func (s *state) write(updateValidators bool, height uint64) error {
 
 	return utils.Err(
 		s.writeBlocks(),
+		s.writeBlockSummaries(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
+		s.writeValidatorCheckpoints(updateValidators, height), // Must be called after writeCurrentStakers
+		s.pruneValidatorDiffs(height),                         // Must be called after writeCurrentStakers
 		s.writePendingStakers(),
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList, codecVersion), // Must be called after writeCurrentStakers
 		s.writeTXs(),
+		s.pruneTXs(height), // Must be called after writeBlocks and writeTXs
 		s.writeRewardUTXOs(),
-		s.writeUTXOs(),
+		s.writeUTXOs(height),
 		s.writeSubnets(),
 		s.writeSubnetOwners(),
+		s.writeDelegationFeeChanges(),
+		s.writeMisbehaviorReports(),
+		s.writeSubnetStakingParams(),
+		s.writeSubnetValidatorEpochs(),
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
@@ -1666,15 +2306,29 @@ func (s *state) Close() error {
 		s.rewardUTXODB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
+		s.delegationFeeChangesDB.Close(),
+		s.misbehaviorReportsDB.Close(),
+		s.subnetStakingParamsDB.Close(),
+		s.subnetValidatorEpochsDB.Close(),
 		s.transformedSubnetDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
+		s.blockSummaryDB.Close(),
+		s.closeAsyncDB(),
 	)
 }
 
+// closeAsyncDB writes the queued commits to the base database.
+func (s *state) closeAsyncDB() error {
+	if s.asyncDB == nil {
+		return nil
+	}
+	return s.asyncDB.Close()
+}
+
 func (s *state) sync(genesis []byte) error {
 	shouldInit, err := s.shouldInit()
 	if err != nil {
@@ -1695,6 +2349,10 @@ func (s *state) sync(genesis []byte) error {
 		}
 	}
 
+	if err := s.migrate(s.ctx.Log, migrations); err != nil {
+		return err
+	}
+
 	if err := s.load(); err != nil {
 		return fmt.Errorf(
 			"failed to load the database state: %w",
@@ -1722,6 +2380,12 @@ func (s *state) init(genesisBytes []byte) error {
 		return err
 	}
 
+	// The genesis state is written with the latest layout, so no migrations
+	// need to be applied to it.
+	if err := database.PutUInt64(s.singletonDB, SchemaVersionKey, uint64(len(migrations))); err != nil {
+		return err
+	}
+
 	if err := s.doneInit(); err != nil {
 		return err
 	}
@@ -1762,6 +2426,13 @@ func (s *state) Abort() {
 	s.baseDB.Abort()
 }
 
+func (s *state) Flush() error {
+	if s.asyncDB == nil {
+		return nil
+	}
+	return s.asyncDB.Flush()
+}
+
 func (s *state) Checksum() ids.ID {
 	return s.utxoState.Checksum()
 }
@@ -1772,7 +2443,12 @@ func (s *state) CommitBatch() (database.Batch, error) {
 	if err := s.write(true /*=updateValidators*/, s.currentHeight); err != nil {
 		return nil, err
 	}
-	return s.baseDB.CommitBatch()
+	batch, err := s.baseDB.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	s.ioMetrics.observe(s.ioDB.takeStats())
+	return batch, nil
 }
 
 func (s *state) writeBlocks() error {
@@ -1857,148 +2533,255 @@ func (s *state) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 	return blkID, nil
 }
 
-func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecVersion uint16) error {
-	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
-		delete(s.currentStakers.validatorDiffs, subnetID)
+func (s *state) AddBlockSummary(height uint64, summary *BlockSummary) {
+	s.addedBlockSummaries[height] = summary
+}
 
-		// Select db to write to
-		validatorDB := s.currentSubnetValidatorList
-		delegatorDB := s.currentSubnetDelegatorList
-		if subnetID == constants.PrimaryNetworkID {
-			validatorDB = s.currentValidatorList
-			delegatorDB = s.currentDelegatorList
+func (s *state) writeBlockSummaries() error {
+	for height, summary := range s.addedBlockSummaries {
+		summaryBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, summary)
+		if err != nil {
+			return fmt.Errorf("failed to serialize block summary: %w", err)
 		}
 
-		// Record the change in weight and/or public key for each validator.
-		for nodeID, validatorDiff := range validatorDiffs {
-			// Copy [nodeID] so it doesn't get overwritten next iteration.
-			nodeID := nodeID
-
-			weightDiff := &ValidatorWeightDiff{
-				Decrease: validatorDiff.validatorStatus == deleted,
-			}
-			switch validatorDiff.validatorStatus {
-			case added:
-				staker := validatorDiff.validator
-				weightDiff.Amount = staker.Weight
-
-				// Invariant: Only the Primary Network contains non-nil public
-				// keys.
-				if staker.PublicKey != nil {
-					// Record that the public key for the validator is being
-					// added. This means the prior value for the public key was
-					// nil.
-					err := s.validatorPublicKeyDiffsDB.Put(
-						marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
-						nil,
-					)
-					if err != nil {
-						return err
-					}
-				}
+		delete(s.addedBlockSummaries, height)
+		if err := s.blockSummaryDB.Put(database.PackUInt64(height), summaryBytes); err != nil {
+			return fmt.Errorf("failed to write block summary at height %d: %w", height, err)
+		}
+	}
+	return nil
+}
 
-				// The validator is being added.
-				//
-				// Invariant: It's impossible for a delegator to have been
-				// rewarded in the same block that the validator was added.
-				startTime := uint64(staker.StartTime.Unix())
-				metadata := &validatorMetadata{
-					txID:        staker.TxID,
-					lastUpdated: staker.StartTime,
-
-					UpDuration:               0,
-					LastUpdated:              startTime,
-					StakerStartTime:          startTime,
-					PotentialReward:          staker.PotentialReward,
-					PotentialDelegateeReward: 0,
-				}
+func (s *state) GetBlockSummary(height uint64) (*BlockSummary, error) {
+	if summary, exists := s.addedBlockSummaries[height]; exists {
+		return summary, nil
+	}
 
-				metadataBytes, err := MetadataCodec.Marshal(codecVersion, metadata)
-				if err != nil {
-					return fmt.Errorf("failed to serialize current validator: %w", err)
+	summaryBytes, err := s.blockSummaryDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &BlockSummary{}
+	if _, err := block.GenesisCodec.Unmarshal(summaryBytes, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecVersion uint16) error {
+	if err := s.writeStakerDiffsStartHeight(height); err != nil {
+		return err
+	}
+
+	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
+		delete(s.currentStakers.validatorDiffs, subnetID)
+
+		// Select db to write to
+		validatorDB := s.currentSubnetValidatorList
+		delegatorDB := s.currentSubnetDelegatorList
+		if subnetID == constants.PrimaryNetworkID {
+			validatorDB = s.currentValidatorList
+			delegatorDB = s.currentDelegatorList
+		}
+
+		// A rotated staker is removed from its old NodeID and added to its new
+		// NodeID with the same txID. Removed validators are written first so
+		// that the entries written for a rotated staker's new NodeID aren't
+		// deleted along with the entries of its old NodeID.
+		var (
+			removedValidators = make(map[ids.ID]*validatorMetadata)
+			removedDelegators = set.Set[ids.ID]{}
+			// txID -> staker before [height] or nil if the staker was added
+			stakerDiffs = make(map[ids.ID]*Staker)
+		)
+		for _, writeRemovedValidators := range []bool{true, false} {
+			// Record the change in weight and/or public key for each validator.
+			for nodeID, validatorDiff := range validatorDiffs {
+				if (validatorDiff.validatorStatus == deleted) != writeRemovedValidators {
+					continue
 				}
 
-				if err = validatorDB.Put(staker.TxID[:], metadataBytes); err != nil {
-					return fmt.Errorf("failed to write current validator to list: %w", err)
+				// Copy [nodeID] so it doesn't get overwritten next iteration.
+				nodeID := nodeID
+
+				weightDiff := &ValidatorWeightDiff{
+					Decrease: validatorDiff.validatorStatus == deleted,
 				}
+				switch validatorDiff.validatorStatus {
+				case added:
+					staker := validatorDiff.validator
+					weightDiff.Amount = staker.Weight
+					recordStakerDiff(stakerDiffs, staker.TxID, nil)
+
+					// Invariant: Only the Primary Network contains non-nil public
+					// keys.
+					if staker.PublicKey != nil {
+						// Record that the public key for the validator is being
+						// added. This means the prior value for the public key was
+						// nil.
+						err := s.validatorPublicKeyDiffsDB.Put(
+							marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+							nil,
+						)
+						if err != nil {
+							return err
+						}
+					}
 
-				s.validatorState.LoadValidatorMetadata(nodeID, subnetID, metadata)
-			case deleted:
-				staker := validatorDiff.validator
-				weightDiff.Amount = staker.Weight
-
-				// Invariant: Only the Primary Network contains non-nil public
-				// keys.
-				if staker.PublicKey != nil {
-					// Record that the public key for the validator is being
-					// removed. This means we must record the prior value of the
-					// public key.
-					//
-					// Note: We store the uncompressed public key here as it is
-					// significantly more efficient to parse when applying
-					// diffs.
-					err := s.validatorPublicKeyDiffsDB.Put(
-						marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
-						bls.PublicKeyToUncompressedBytes(staker.PublicKey),
-					)
+					metadata, rotated := removedValidators[staker.TxID]
+					if rotated {
+						// The validator is being rotated to [nodeID], so its
+						// uptime and rewards are carried over.
+						metadata.LastUpdated = uint64(metadata.lastUpdated.Unix())
+
+						err := s.rotatedNodeIDsDB.Put(
+							staker.TxID[:],
+							marshalRotatedNodeID(nodeID, staker.PublicKey),
+						)
+						if err != nil {
+							return fmt.Errorf("failed to write rotated nodeID: %w", err)
+						}
+					} else {
+						// The validator is being added.
+						//
+						// Invariant: It's impossible for a delegator to have
+						// been rewarded in the same block that the validator
+						// was added.
+						startTime := uint64(staker.StartTime.Unix())
+						metadata = &validatorMetadata{
+							txID:        staker.TxID,
+							lastUpdated: staker.StartTime,
+
+							UpDuration:               0,
+							LastUpdated:              startTime,
+							StakerStartTime:          startTime,
+							PotentialReward:          staker.PotentialReward,
+							PotentialDelegateeReward: 0,
+						}
+					}
+
+					metadataBytes, err := MetadataCodec.Marshal(codecVersion, metadata)
 					if err != nil {
+						return fmt.Errorf("failed to serialize current validator: %w", err)
+					}
+
+					if err = validatorDB.Put(staker.TxID[:], metadataBytes); err != nil {
+						return fmt.Errorf("failed to write current validator to list: %w", err)
+					}
+
+					s.validatorState.LoadValidatorMetadata(nodeID, subnetID, metadata)
+				case deleted:
+					staker := validatorDiff.validator
+					weightDiff.Amount = staker.Weight
+					recordStakerDiff(stakerDiffs, staker.TxID, staker)
+
+					// Invariant: Only the Primary Network contains non-nil public
+					// keys.
+					if staker.PublicKey != nil {
+						// Record that the public key for the validator is being
+						// removed. This means we must record the prior value of the
+						// public key.
+						//
+						// Note: We store the uncompressed public key here as it is
+						// significantly more efficient to parse when applying
+						// diffs.
+						err := s.validatorPublicKeyDiffsDB.Put(
+							marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+							bls.PublicKeyToUncompressedBytes(staker.PublicKey),
+						)
+						if err != nil {
+							return err
+						}
+					}
+
+					// If the validator is being rotated, its metadata will be
+					// carried over when it is added back.
+					if metadata, ok := s.validatorState.GetValidatorMetadata(nodeID, subnetID); ok {
+						removedValidators[staker.TxID] = metadata
+					}
+
+					if err := validatorDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete current staker: %w", err)
+					}
+					if err := s.rotatedNodeIDsDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete rotated nodeID: %w", err)
+					}
+					if err := s.reducedWeightsDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete reduced weight: %w", err)
+					}
+					if err := s.reducedEndTimesDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete reduced end time: %w", err)
+					}
+
+					s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+				case modified:
+					recordStakerDiff(stakerDiffs, validatorDiff.validator.TxID, validatorDiff.prevValidator)
+					if err := s.writeModifiedValidator(updateValidators, height, nodeID, weightDiff, validatorDiff); err != nil {
 						return err
 					}
 				}
 
-				if err := validatorDB.Delete(staker.TxID[:]); err != nil {
-					return fmt.Errorf("failed to delete current staker: %w", err)
+				err := writeCurrentDelegatorDiff(
+					delegatorDB,
+					s.rotatedNodeIDsDB,
+					removedDelegators,
+					stakerDiffs,
+					weightDiff,
+					validatorDiff,
+					codecVersion,
+				)
+				if err != nil {
+					return err
 				}
 
-				s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
-			}
-
-			err := writeCurrentDelegatorDiff(
-				delegatorDB,
-				weightDiff,
-				validatorDiff,
-				codecVersion,
-			)
-			if err != nil {
-				return err
-			}
-
-			if weightDiff.Amount == 0 {
-				// No weight change to record; go to next validator.
-				continue
-			}
+				if weightDiff.Amount == 0 {
+					// No weight change to record; go to next validator.
+					continue
+				}
 
-			err = s.validatorWeightDiffsDB.Put(
-				marshalDiffKey(subnetID, height, nodeID),
-				marshalWeightDiff(weightDiff),
-			)
-			if err != nil {
-				return err
-			}
+				err = s.validatorWeightDiffsDB.Put(
+					marshalDiffKey(subnetID, height, nodeID),
+					marshalWeightDiff(weightDiff),
+				)
+				if err != nil {
+					return err
+				}
 
-			// TODO: Move the validator set management out of the state package
-			if !updateValidators {
-				continue
-			}
+				// TODO: Move the validator set management out of the state package
+				if !updateValidators {
+					continue
+				}
 
-			if weightDiff.Decrease {
-				err = s.validators.RemoveWeight(subnetID, nodeID, weightDiff.Amount)
-			} else {
-				if validatorDiff.validatorStatus == added {
-					staker := validatorDiff.validator
-					err = s.validators.AddStaker(
-						subnetID,
-						nodeID,
-						staker.PublicKey,
-						staker.TxID,
-						weightDiff.Amount,
-					)
+				if weightDiff.Decrease {
+					err = s.validators.RemoveWeight(subnetID, nodeID, weightDiff.Amount)
 				} else {
-					err = s.validators.AddWeight(subnetID, nodeID, weightDiff.Amount)
+					if validatorDiff.validatorStatus == added {
+						staker := validatorDiff.validator
+						err = s.validators.AddStaker(
+							subnetID,
+							nodeID,
+							staker.PublicKey,
+							staker.TxID,
+							weightDiff.Amount,
+						)
+					} else {
+						err = s.validators.AddWeight(subnetID, nodeID, weightDiff.Amount)
+					}
+				}
+				if err != nil {
+					return fmt.Errorf("failed to update validator weight: %w", err)
 				}
 			}
+		}
+
+		for txID, prevStaker := range stakerDiffs {
+			err := s.stakerDiffsDB.Put(
+				marshalStakerDiffKey(subnetID, height, txID),
+				marshalStakerDiff(prevStaker),
+			)
 			if err != nil {
-				return fmt.Errorf("failed to update validator weight: %w", err)
+				return fmt.Errorf("failed to write staker diff: %w", err)
 			}
 		}
 	}
@@ -2020,8 +2803,144 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 	return nil
 }
 
+// writeModifiedValidator writes the weight and public key of a current
+// validator that was updated in place. A change in weight is recorded in
+// [weightDiff] so that it is written along with the changes of the
+// validator's delegators.
+func (s *state) writeModifiedValidator(
+	updateValidators bool,
+	height uint64,
+	nodeID ids.NodeID,
+	weightDiff *ValidatorWeightDiff,
+	validatorDiff *diffValidator,
+) error {
+	var (
+		staker     = validatorDiff.validator
+		prevStaker = validatorDiff.prevValidator
+	)
+	if staker.Weight != prevStaker.Weight {
+		if err := s.writeReducedWeight(weightDiff, staker, prevStaker); err != nil {
+			return err
+		}
+	}
+	if !staker.EndTime.Equal(prevStaker.EndTime) {
+		if err := s.writeReducedEndTime(staker, prevStaker); err != nil {
+			return err
+		}
+	}
+	if staker.PublicKey == prevStaker.PublicKey {
+		return nil
+	}
+
+	// Record the prior value of the public key, which is nil if the validator
+	// didn't have one.
+	var prevPublicKeyBytes []byte
+	if prevStaker.PublicKey != nil {
+		prevPublicKeyBytes = bls.PublicKeyToUncompressedBytes(prevStaker.PublicKey)
+	}
+	err := s.validatorPublicKeyDiffsDB.Put(
+		marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+		prevPublicKeyBytes,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The public key is loaded from the validator's tx, so the new public key
+	// is stored as an override, like the public key of a rotated validator.
+	err = s.rotatedNodeIDsDB.Put(
+		staker.TxID[:],
+		marshalRotatedNodeID(nodeID, staker.PublicKey),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write updated public key: %w", err)
+	}
+
+	// TODO: Move the validator set management out of the state package
+	if !updateValidators {
+		return nil
+	}
+
+	// The validator set doesn't support changing the public key of a
+	// validator, so the validator is removed and added back with the same
+	// weight.
+	weight := s.validators.GetWeight(staker.SubnetID, nodeID)
+	if err := s.validators.RemoveWeight(staker.SubnetID, nodeID, weight); err != nil {
+		return fmt.Errorf("failed to remove validator: %w", err)
+	}
+	if err := s.validators.AddStaker(staker.SubnetID, nodeID, staker.PublicKey, staker.TxID, weight); err != nil {
+		return fmt.Errorf("failed to add validator: %w", err)
+	}
+	return nil
+}
+
+// writeReducedWeight records the reduction of the weight of [staker] from the
+// weight of [prevStaker].
+func (s *state) writeReducedWeight(
+	weightDiff *ValidatorWeightDiff,
+	staker *Staker,
+	prevStaker *Staker,
+) error {
+	if staker.Weight > prevStaker.Weight {
+		return fmt.Errorf("%w: %s", errWeightIncreased, staker.TxID)
+	}
+	if err := weightDiff.Add(true, prevStaker.Weight-staker.Weight); err != nil {
+		return fmt.Errorf("failed to decrease node weight diff: %w", err)
+	}
+
+	// The weight is loaded from the validator's tx, so the reduced weight is
+	// stored as an override.
+	if err := database.PutUInt64(s.reducedWeightsDB, staker.TxID[:], staker.Weight); err != nil {
+		return fmt.Errorf("failed to write reduced weight: %w", err)
+	}
+	return nil
+}
+
+// writeReducedEndTime records the reduction of the end time of [staker] from
+// the end time of [prevStaker].
+func (s *state) writeReducedEndTime(staker *Staker, prevStaker *Staker) error {
+	if staker.EndTime.After(prevStaker.EndTime) {
+		return fmt.Errorf("%w: %s", errEndTimeIncreased, staker.TxID)
+	}
+
+	// The end time is loaded from the validator's tx, so the reduced end time
+	// is stored as an override.
+	if err := database.PutUInt64(s.reducedEndTimesDB, staker.TxID[:], uint64(staker.EndTime.Unix())); err != nil {
+		return fmt.Errorf("failed to write reduced end time: %w", err)
+	}
+	return nil
+}
+
+// writeCurrentDelegatorDiff writes the delegators added and removed in
+// [validatorDiff]. The txIDs of removed delegators are added to
+// [removedDelegators] so that delegators that are added back, after being
+// rotated to a new NodeID, are recorded in [rotatedNodeIDsDB].
+// writeStakerDiffsStartHeight records the lowest height whose stakers can be
+// reconstructed from the staker diffs, if it hasn't been recorded yet. Must be
+// called before the staker diffs of [height] are written.
+func (s *state) writeStakerDiffsStartHeight(height uint64) error {
+	if s.stakerDiffsStarted {
+		return nil
+	}
+
+	// The persisted stakers currently reflect the parent of [height]. At
+	// genesis there is no parent, and the genesis stakers are the stakers at
+	// height 0.
+	if height > 0 {
+		s.stakerDiffsStartHeight = height - 1
+	}
+	s.stakerDiffsStarted = true
+	if err := database.PutUInt64(s.singletonDB, StakerDiffsStartHeightKey, s.stakerDiffsStartHeight); err != nil {
+		return fmt.Errorf("failed to write staker diffs start height: %w", err)
+	}
+	return nil
+}
+
 func writeCurrentDelegatorDiff(
 	currentDelegatorList linkeddb.LinkedDB,
+	rotatedNodeIDsDB database.KeyValueWriterDeleter,
+	removedDelegators set.Set[ids.ID],
+	stakerDiffs map[ids.ID]*Staker,
 	weightDiff *ValidatorWeightDiff,
 	validatorDiff *diffValidator,
 	codecVersion uint16,
@@ -2034,6 +2953,7 @@ func writeCurrentDelegatorDiff(
 		if err := weightDiff.Add(false, staker.Weight); err != nil {
 			return fmt.Errorf("failed to increase node weight diff: %w", err)
 		}
+		recordStakerDiff(stakerDiffs, staker.TxID, nil)
 
 		metadata := &delegatorMetadata{
 			txID:            staker.TxID,
@@ -2043,16 +2963,32 @@ func writeCurrentDelegatorDiff(
 		if err := writeDelegatorMetadata(currentDelegatorList, metadata, codecVersion); err != nil {
 			return fmt.Errorf("failed to write current delegator to list: %w", err)
 		}
+
+		if removedDelegators.Contains(staker.TxID) {
+			// The delegator is being rotated to a new NodeID.
+			err := rotatedNodeIDsDB.Put(
+				staker.TxID[:],
+				marshalRotatedNodeID(staker.NodeID, nil),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to write rotated nodeID: %w", err)
+			}
+		}
 	}
 
 	for _, staker := range validatorDiff.deletedDelegators {
 		if err := weightDiff.Add(true, staker.Weight); err != nil {
 			return fmt.Errorf("failed to decrease node weight diff: %w", err)
 		}
+		recordStakerDiff(stakerDiffs, staker.TxID, staker)
 
 		if err := currentDelegatorList.Delete(staker.TxID[:]); err != nil {
 			return fmt.Errorf("failed to delete current staker: %w", err)
 		}
+		if err := rotatedNodeIDsDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete rotated nodeID: %w", err)
+		}
+		removedDelegators.Add(staker.TxID)
 	}
 	return nil
 }
@@ -2089,7 +3025,10 @@ func writePendingDiff(
 ) error {
 	switch validatorDiff.validatorStatus {
 	case added:
-		err := pendingValidatorList.Put(validatorDiff.validator.TxID[:], nil)
+		// The start time is stored because it may differ from the start time
+		// of the validator's tx.
+		staker := validatorDiff.validator
+		err := pendingValidatorList.Put(staker.TxID[:], database.PackUInt64(uint64(staker.StartTime.Unix())))
 		if err != nil {
 			return fmt.Errorf("failed to add pending validator: %w", err)
 		}
@@ -2146,6 +3085,156 @@ func (s *state) writeTXs() error {
 	return nil
 }
 
+// pruneTXs discards the bytes of the prunable txs that were accepted in blocks
+// that are no longer within the retention window of [height]. The status of
+// pruned txs is retained.
+func (s *state) pruneTXs(height uint64) error {
+	if s.txRetentionBlocks == 0 || height <= s.txRetentionBlocks {
+		return nil
+	}
+
+	pruneHeight := min(
+		height-s.txRetentionBlocks,
+		s.txsPrunedHeight+maxTxPruningBlocksPerCommit,
+	)
+	for s.txsPrunedHeight < pruneHeight {
+		blkHeight := s.txsPrunedHeight + 1
+		blkID, err := s.GetBlockIDAtHeight(blkHeight)
+		switch {
+		case err == database.ErrNotFound:
+			// Blocks that were accepted before the height index was populated
+			// can't be looked up by height, so their txs are retained.
+		case err != nil:
+			return fmt.Errorf("failed to get block at height %d: %w", blkHeight, err)
+		default:
+			blk, err := s.GetStatelessBlock(blkID)
+			if err != nil {
+				return fmt.Errorf("failed to get block %s: %w", blkID, err)
+			}
+			for _, tx := range blk.Txs() {
+				if !isPrunableTx(tx) {
+					continue
+				}
+				if err := s.pruneTX(tx.ID()); err != nil {
+					return err
+				}
+			}
+		}
+		s.txsPrunedHeight = blkHeight
+	}
+	return nil
+}
+
+func (s *state) pruneTX(txID ids.ID) error {
+	txBytes, err := s.txDB.Get(txID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	stx := txBytesAndStatus{}
+	if _, err := txs.GenesisCodec.Unmarshal(txBytes, &stx); err != nil {
+		return fmt.Errorf("failed to parse tx %s: %w", txID, err)
+	}
+	if len(stx.Tx) == 0 {
+		return nil
+	}
+
+	stx.Tx = nil
+	txBytes, err = txs.GenesisCodec.Marshal(txs.CodecVersion, &stx)
+	if err != nil {
+		return fmt.Errorf("failed to serialize pruned tx: %w", err)
+	}
+
+	s.txCache.Evict(txID)
+	if err := s.txDB.Put(txID[:], txBytes); err != nil {
+		return fmt.Errorf("failed to prune tx %s: %w", txID, err)
+	}
+	return nil
+}
+
+// pruneValidatorDiffs discards the validator weight, public key, and staker
+// diffs that were written by blocks that are no longer within the retention
+// window of [height].
+func (s *state) pruneValidatorDiffs(height uint64) error {
+	if s.validatorDiffRetentionBlocks == 0 || height <= s.validatorDiffRetentionBlocks {
+		return nil
+	}
+
+	pruneHeight := min(
+		height-s.validatorDiffRetentionBlocks,
+		s.validatorDiffsPrunedHeight+maxValidatorDiffPruningBlocksPerCommit,
+	)
+	if pruneHeight <= s.validatorDiffsPrunedHeight {
+		return nil
+	}
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return fmt.Errorf("failed to get subnets: %w", err)
+	}
+	subnetIDs := make([]ids.ID, 0, len(subnets)+1)
+	subnetIDs = append(subnetIDs, constants.PrimaryNetworkID)
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID())
+	}
+
+	for _, subnetID := range subnetIDs {
+		if err := deleteDiffsUpTo(s.validatorWeightDiffsDB, subnetID, pruneHeight); err != nil {
+			return fmt.Errorf("failed to prune weight diffs of %s: %w", subnetID, err)
+		}
+		if err := deleteDiffsUpTo(s.stakerDiffsDB, subnetID, pruneHeight); err != nil {
+			return fmt.Errorf("failed to prune staker diffs of %s: %w", subnetID, err)
+		}
+	}
+	if err := deleteDiffsUpTo(s.validatorPublicKeyDiffsDB, constants.PrimaryNetworkID, pruneHeight); err != nil {
+		return fmt.Errorf("failed to prune public key diffs: %w", err)
+	}
+
+	s.validatorDiffsPrunedHeight = pruneHeight
+	return nil
+}
+
+// deleteDiffsUpTo deletes the diffs of [subnetID] in [db] that were written at
+// or below [height].
+func deleteDiffsUpTo(db database.Database, subnetID ids.ID, height uint64) error {
+	// Diffs are iterated from [height] towards the genesis. Because older
+	// diffs were deleted by previous prunings, only the newly prunable diffs
+	// are visited.
+	diffIter := db.NewIteratorWithStartAndPrefix(
+		marshalStartDiffKey(subnetID, height),
+		subnetID[:],
+	)
+	defer diffIter.Release()
+
+	for diffIter.Next() {
+		if err := db.Delete(diffIter.Key()); err != nil {
+			return err
+		}
+	}
+	return diffIter.Error()
+}
+
+// isPrunableTx returns true if the state never needs to read [tx] after it has
+// been accepted. Txs that define stakers, subnets, or chains are looked up
+// from the state by their ID, so they are never pruned.
+func isPrunableTx(tx *txs.Tx) bool {
+	switch tx.Unsigned.(type) {
+	case *txs.BaseTx,
+		*txs.ImportTx,
+		*txs.ExportTx,
+		*txs.AdvanceTimeTx,
+		*txs.RewardValidatorTx,
+		*txs.RemoveSubnetValidatorTx,
+		*txs.TransferSubnetOwnershipTx:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *state) writeRewardUTXOs() error {
 	for txID, utxos := range s.addedRewardUTXOs {
 		delete(s.addedRewardUTXOs, txID)
@@ -2167,7 +3256,11 @@ func (s *state) writeRewardUTXOs() error {
 	return nil
 }
 
-func (s *state) writeUTXOs() error {
+func (s *state) writeUTXOs(height uint64) error {
+	if err := s.writeUTXOJournal(height); err != nil {
+		return err
+	}
+
 	for utxoID, utxo := range s.modifiedUTXOs {
 		delete(s.modifiedUTXOs, utxoID)
 
@@ -2184,6 +3277,75 @@ func (s *state) writeUTXOs() error {
 	return nil
 }
 
+// writeUTXOJournal records the UTXOs modified at [height]. Must be called
+// before the modified UTXOs are written.
+func (s *state) writeUTXOJournal(height uint64) error {
+	if !s.utxoJournalEnabled {
+		// If the journal is re-enabled later, heights accepted while it was
+		// disabled must not be reported as covered.
+		if !s.utxoJournalStarted {
+			return nil
+		}
+		s.utxoJournalStarted = false
+		if err := s.singletonDB.Delete(UTXOJournalStartHeightKey); err != nil {
+			return fmt.Errorf("failed to delete UTXO journal start height: %w", err)
+		}
+		return nil
+	}
+	if len(s.modifiedUTXOs) == 0 {
+		return nil
+	}
+
+	if !s.utxoJournalStarted {
+		// The persisted UTXOs currently reflect the parent of [height]. At
+		// genesis there is no parent, and the genesis UTXOs are the UTXOs at
+		// height 0.
+		if height > 0 {
+			s.utxoJournalStartHeight = height - 1
+		}
+		s.utxoJournalStarted = true
+		if err := database.PutUInt64(s.singletonDB, UTXOJournalStartHeightKey, s.utxoJournalStartHeight); err != nil {
+			return fmt.Errorf("failed to write UTXO journal start height: %w", err)
+		}
+	}
+	if height <= s.utxoJournalStartHeight {
+		return nil
+	}
+
+	heightBytes := database.PackUInt64(height)
+	for utxoID, utxo := range s.modifiedUTXOs {
+		key := make([]byte, 0, database.Uint64Size+ids.IDLen)
+		key = append(key, heightBytes...)
+		key = append(key, utxoID[:]...)
+
+		if utxo != nil {
+			if err := s.utxoJournalDB.Put(key, nil); err != nil {
+				return fmt.Errorf("failed to journal added UTXO: %w", err)
+			}
+			continue
+		}
+
+		// The removed UTXO is journaled so that it can be restored when
+		// querying earlier heights.
+		removedUTXO, err := s.utxoState.GetUTXO(utxoID)
+		if err == database.ErrNotFound {
+			// The UTXO was added and removed at [height].
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get removed UTXO: %w", err)
+		}
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, removedUTXO)
+		if err != nil {
+			return fmt.Errorf("failed to serialize removed UTXO: %w", err)
+		}
+		if err := s.utxoJournalDB.Put(key, utxoBytes); err != nil {
+			return fmt.Errorf("failed to journal removed UTXO: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeSubnets() error {
 	for _, subnet := range s.addedSubnets {
 		subnetID := subnet.ID()
@@ -2219,6 +3381,81 @@ func (s *state) writeSubnetOwners() error {
 	return nil
 }
 
+func (s *state) writeDelegationFeeChanges() error {
+	for validatorTxID, changes := range s.delegationFeeChanges {
+		validatorTxID := validatorTxID
+		delete(s.delegationFeeChanges, validatorTxID)
+
+		if len(changes) == 0 {
+			if err := s.delegationFeeChangesDB.Delete(validatorTxID[:]); err != nil {
+				return fmt.Errorf("failed to delete delegation fee changes: %w", err)
+			}
+			continue
+		}
+
+		changesBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &changes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal delegation fee changes: %w", err)
+		}
+		if err := s.delegationFeeChangesDB.Put(validatorTxID[:], changesBytes); err != nil {
+			return fmt.Errorf("failed to write delegation fee changes: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeMisbehaviorReports() error {
+	for validatorTxID, reportTxID := range s.misbehaviorReports {
+		validatorTxID := validatorTxID
+		delete(s.misbehaviorReports, validatorTxID)
+
+		var err error
+		if reportTxID == ids.Empty {
+			err = s.misbehaviorReportsDB.Delete(validatorTxID[:])
+		} else {
+			err = database.PutID(s.misbehaviorReportsDB, validatorTxID[:], reportTxID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write misbehavior report: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeSubnetStakingParams() error {
+	for subnetID, params := range s.subnetStakingParams {
+		subnetID := subnetID
+		delete(s.subnetStakingParams, subnetID)
+
+		paramsBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, params)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet staking params: %w", err)
+		}
+		if err := s.subnetStakingParamsDB.Put(subnetID[:], paramsBytes); err != nil {
+			return fmt.Errorf("failed to write subnet staking params: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeSubnetValidatorEpochs() error {
+	for subnetID, epoch := range s.subnetValidatorEpochs {
+		subnetID := subnetID
+		delete(s.subnetValidatorEpochs, subnetID)
+
+		var err error
+		if epoch == 0 {
+			err = s.subnetValidatorEpochsDB.Delete(subnetID[:])
+		} else {
+			err = database.PutUInt64(s.subnetValidatorEpochsDB, subnetID[:], uint64(epoch/time.Second))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write subnet validator epoch: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeTransformedSubnets() error {
 	for subnetID, tx := range s.transformedSubnets {
 		txID := tx.ID()
@@ -2252,8 +3489,10 @@ func (s *state) writeChains() error {
 		for _, chain := range chains {
 			chainDB := s.getChainDB(subnetID)
 
+			// The chain index is written along with the block that created
+			// the chain, so [s.timestamp] is the creation time of the chain.
 			chainID := chain.ID()
-			if err := chainDB.Put(chainID[:], nil); err != nil {
+			if err := database.PutTimestamp(chainDB, chainID[:], s.timestamp); err != nil {
 				return fmt.Errorf("failed to write chain: %w", err)
 			}
 		}
@@ -2281,6 +3520,18 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedLastAccepted = s.lastAccepted
 	}
+	if s.persistedTxsPrunedHeight != s.txsPrunedHeight {
+		if err := database.PutUInt64(s.singletonDB, TxsPrunedHeightKey, s.txsPrunedHeight); err != nil {
+			return fmt.Errorf("failed to write txs pruned height: %w", err)
+		}
+		s.persistedTxsPrunedHeight = s.txsPrunedHeight
+	}
+	if s.persistedValidatorDiffsPrunedHeight != s.validatorDiffsPrunedHeight {
+		if err := database.PutUInt64(s.singletonDB, ValidatorDiffsPrunedHeightKey, s.validatorDiffsPrunedHeight); err != nil {
+			return fmt.Errorf("failed to write validator diffs pruned height: %w", err)
+		}
+		s.persistedValidatorDiffsPrunedHeight = s.validatorDiffsPrunedHeight
+	}
 	if s.indexedHeights != nil {
 		indexedHeightsBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, s.indexedHeights)
 		if err != nil {
@@ -21,9 +21,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -1401,6 +1403,265 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.Equal(owner2, owner)
 }
 
+func TestStateDelegationFeeChanges(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		validatorTxID = ids.GenerateTestID()
+		changes       = []*DelegationFeeChange{
+			{
+				Time:   1,
+				Shares: 30_000,
+			},
+			{
+				Time:   2,
+				Shares: 40_000,
+			},
+		}
+	)
+
+	fetchedChanges, err := s.GetDelegationFeeChanges(validatorTxID)
+	require.NoError(err)
+	require.Empty(fetchedChanges)
+
+	s.SetDelegationFeeChanges(validatorTxID, changes)
+	fetchedChanges, err = s.GetDelegationFeeChanges(validatorTxID)
+	require.NoError(err)
+	require.Equal(changes, fetchedChanges)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	fetchedChanges, err = s.GetDelegationFeeChanges(validatorTxID)
+	require.NoError(err)
+	require.Equal(changes, fetchedChanges)
+
+	s.SetDelegationFeeChanges(validatorTxID, nil)
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	fetchedChanges, err = s.GetDelegationFeeChanges(validatorTxID)
+	require.NoError(err)
+	require.Empty(fetchedChanges)
+}
+
+func TestStateMisbehaviorReports(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		validatorTxID = ids.GenerateTestID()
+		reportTxID    = ids.GenerateTestID()
+	)
+
+	_, err := s.GetMisbehaviorReport(validatorTxID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	s.SetMisbehaviorReport(validatorTxID, reportTxID)
+	fetchedReportTxID, err := s.GetMisbehaviorReport(validatorTxID)
+	require.NoError(err)
+	require.Equal(reportTxID, fetchedReportTxID)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	fetchedReportTxID, err = s.GetMisbehaviorReport(validatorTxID)
+	require.NoError(err)
+	require.Equal(reportTxID, fetchedReportTxID)
+
+	s.SetMisbehaviorReport(validatorTxID, ids.Empty)
+	_, err = s.GetMisbehaviorReport(validatorTxID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	_, err = s.GetMisbehaviorReport(validatorTxID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateSubnetStakingParams(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		params   = &SubnetStakingParams{
+			MinValidatorStake:     1,
+			MaxValidatorStake:     2,
+			MinStakeDuration:      3,
+			MaxStakeDuration:      4,
+			MinDelegatorStake:     5,
+			MinDelegationDuration: 6,
+		}
+	)
+
+	_, err := s.GetSubnetStakingParams(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	s.SetSubnetStakingParams(subnetID, params)
+	fetchedParams, err := s.GetSubnetStakingParams(subnetID)
+	require.NoError(err)
+	require.Equal(params, fetchedParams)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	fetchedParams, err = s.GetSubnetStakingParams(subnetID)
+	require.NoError(err)
+	require.Equal(params, fetchedParams)
+}
+
+func TestStateChainCreationTime(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID     = ids.GenerateTestID()
+		creationTime = time.Unix(1_000, 0)
+		createChain  = &txs.Tx{
+			Unsigned: &txs.CreateChainTx{
+				SubnetID:   subnetID,
+				ChainName:  "chain",
+				VMID:       constants.AVMID,
+				SubnetAuth: &secp256k1fx.Input{},
+			},
+		}
+		legacyChainID = ids.GenerateTestID()
+	)
+	require.NoError(createChain.Initialize(txs.Codec))
+	chainID := createChain.ID()
+
+	_, err := s.GetChainCreationTime(subnetID, chainID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	s.SetTimestamp(creationTime)
+	s.AddChain(createChain)
+
+	fetchedCreationTime, err := s.GetChainCreationTime(subnetID, chainID)
+	require.NoError(err)
+	require.Equal(creationTime, fetchedCreationTime)
+
+	// Chains that were indexed before creation times were recorded don't have
+	// a creation time.
+	require.NoError(s.getChainDB(subnetID).Put(legacyChainID[:], nil))
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	fetchedCreationTime, err = s.GetChainCreationTime(subnetID, chainID)
+	require.NoError(err)
+	require.Equal(creationTime.Unix(), fetchedCreationTime.Unix())
+
+	_, err = s.GetChainCreationTime(subnetID, legacyChainID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateRotateValidatorNodeID(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+
+		validatorData = txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    uint64(endTime.Unix()),
+			Wght:   1234,
+		}
+		delegatorData = txs.Validator{
+			NodeID: validatorData.NodeID,
+			End:    uint64(endTime.Unix()),
+			Wght:   567,
+		}
+		newNodeID = ids.GenerateTestNodeID()
+	)
+
+	utxVal := createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, validatorData)
+	addPermValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addPermValTx.Initialize(txs.Codec))
+
+	val, err := NewCurrentStaker(addPermValTx.ID(), utxVal, startTime, 5678)
+	require.NoError(err)
+
+	utxDel := createPermissionlessDelegatorTx(constants.PrimaryNetworkID, delegatorData)
+	addPermDelTx := &txs.Tx{Unsigned: utxDel}
+	require.NoError(addPermDelTx.Initialize(txs.Codec))
+
+	del, err := NewCurrentStaker(addPermDelTx.ID(), utxDel, startTime, 5432)
+	require.NoError(err)
+
+	s.PutCurrentValidator(val)
+	s.AddTx(addPermValTx, status.Committed) // this is currently needed to reload the staker
+	s.PutCurrentDelegator(del)
+	s.AddTx(addPermDelTx, status.Committed) // this is currently needed to reload the staker
+	require.NoError(s.Commit())
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	rotatedVal := *val
+	rotatedVal.NodeID = newNodeID
+	rotatedVal.PublicKey = bls.PublicFromSecretKey(sk)
+	rotatedDel := *del
+	rotatedDel.NodeID = newNodeID
+
+	s.DeleteCurrentValidator(val)
+	s.PutCurrentValidator(&rotatedVal)
+	s.DeleteCurrentDelegator(del)
+	s.PutCurrentDelegator(&rotatedDel)
+	require.NoError(s.Commit())
+
+	// The validator set only contains the new NodeID.
+	vdrs := s.cfg.Validators.GetMap(constants.PrimaryNetworkID)
+	require.Len(vdrs, 1)
+	require.Equal(val.Weight+del.Weight, vdrs[newNodeID].Weight)
+	require.Equal(rotatedVal.PublicKey, vdrs[newNodeID].PublicKey)
+
+	// The rotation is persisted.
+	s = newStateFromDB(require, db)
+	require.NoError(s.loadCurrentValidators())
+	require.NoError(s.initValidatorSets())
+
+	_, err = s.GetCurrentValidator(constants.PrimaryNetworkID, val.NodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	fetchedVal, err := s.GetCurrentValidator(constants.PrimaryNetworkID, newNodeID)
+	require.NoError(err)
+	require.Equal(&rotatedVal, fetchedVal)
+
+	delIt, err := s.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, newNodeID)
+	require.NoError(err)
+	require.True(delIt.Next())
+	require.Equal(&rotatedDel, delIt.Value())
+	require.False(delIt.Next())
+	delIt.Release()
+
+	// The uptime of the validator is carried over.
+	fetchedStartTime, err := s.GetStartTime(newNodeID, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(startTime, fetchedStartTime)
+
+	// Removing the stakers removes their rotated NodeIDs.
+	s.DeleteCurrentValidator(fetchedVal)
+	s.DeleteCurrentDelegator(&rotatedDel)
+	require.NoError(s.Commit())
+
+	has, err := s.rotatedNodeIDsDB.Has(val.TxID[:])
+	require.NoError(err)
+	require.False(has)
+	has, err = s.rotatedNodeIDsDB.Has(del.TxID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
 func makeBlocks(require *require.Assertions) []block.Block {
 	var blks []block.Block
 	{
@@ -1488,3 +1749,604 @@ func makeBlocks(require *require.Assertions) []block.Block {
 	}
 	return blks
 }
+
+func TestStateUpdateValidatorPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+
+		validatorData = txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    uint64(endTime.Unix()),
+			Wght:   1234,
+		}
+	)
+
+	utxVal := createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, validatorData)
+	addPermValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addPermValTx.Initialize(txs.Codec))
+
+	val, err := NewCurrentStaker(addPermValTx.ID(), utxVal, startTime, 5678)
+	require.NoError(err)
+
+	s.SetHeight(1)
+	s.PutCurrentValidator(val)
+	s.AddTx(addPermValTx, status.Committed) // this is currently needed to reload the staker
+	require.NoError(s.Commit())
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	updatedVal := *val
+	updatedVal.PublicKey = bls.PublicFromSecretKey(sk)
+
+	s.SetHeight(2)
+	s.UpdateCurrentValidator(&updatedVal)
+	require.NoError(s.Commit())
+
+	// The validator set uses the new public key.
+	vdr, ok := s.cfg.Validators.GetValidator(constants.PrimaryNetworkID, validatorData.NodeID)
+	require.True(ok)
+	require.Equal(updatedVal.PublicKey, vdr.PublicKey)
+	require.Equal(val.Weight, vdr.Weight)
+
+	// The previous public key can be recovered from the diffs.
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		validatorData.NodeID: {
+			NodeID:    validatorData.NodeID,
+			PublicKey: updatedVal.PublicKey,
+		},
+	}
+	require.NoError(s.ApplyValidatorPublicKeyDiffs(context.Background(), vdrs, 2, 2))
+	require.Equal(val.PublicKey, vdrs[validatorData.NodeID].PublicKey)
+
+	// The new public key is persisted.
+	s = newStateFromDB(require, db)
+	require.NoError(s.loadCurrentValidators())
+	require.NoError(s.initValidatorSets())
+
+	loadedVal, err := s.GetCurrentValidator(constants.PrimaryNetworkID, validatorData.NodeID)
+	require.NoError(err)
+	require.Equal(updatedVal.PublicKey, loadedVal.PublicKey)
+
+	vdr, ok = s.cfg.Validators.GetValidator(constants.PrimaryNetworkID, validatorData.NodeID)
+	require.True(ok)
+	require.Equal(updatedVal.PublicKey, vdr.PublicKey)
+}
+
+func TestStateReduceSubnetValidatorWeight(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+
+		validatorData = txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    uint64(endTime.Unix()),
+			Wght:   1234,
+		}
+	)
+
+	utxVal := &txs.AddSubnetValidatorTx{
+		SubnetValidator: txs.SubnetValidator{
+			Validator: validatorData,
+			Subnet:    subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	addSubnetValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addSubnetValTx.Initialize(txs.Codec))
+
+	val, err := NewCurrentStaker(addSubnetValTx.ID(), utxVal, startTime, 0)
+	require.NoError(err)
+
+	s.SetHeight(1)
+	s.PutCurrentValidator(val)
+	s.AddTx(addSubnetValTx, status.Committed) // this is currently needed to reload the staker
+	require.NoError(s.Commit())
+
+	reducedVal := *val
+	reducedVal.Weight = 234
+
+	s.SetHeight(2)
+	s.UpdateCurrentValidator(&reducedVal)
+	require.NoError(s.Commit())
+
+	// The validator set uses the reduced weight.
+	require.Equal(reducedVal.Weight, s.cfg.Validators.GetWeight(subnetID, validatorData.NodeID))
+
+	// The previous weight can be recovered from the diffs.
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		validatorData.NodeID: {
+			NodeID: validatorData.NodeID,
+			Weight: reducedVal.Weight,
+		},
+	}
+	require.NoError(s.ApplyValidatorWeightDiffs(context.Background(), vdrs, 2, 2, subnetID))
+	require.Equal(val.Weight, vdrs[validatorData.NodeID].Weight)
+
+	// The reduced weight is persisted.
+	s = newStateFromDB(require, db)
+	require.NoError(s.loadCurrentValidators())
+	require.NoError(s.initValidatorSets())
+
+	loadedVal, err := s.GetCurrentValidator(subnetID, validatorData.NodeID)
+	require.NoError(err)
+	require.Equal(reducedVal.Weight, loadedVal.Weight)
+	require.Equal(reducedVal.Weight, s.cfg.Validators.GetWeight(subnetID, validatorData.NodeID))
+
+	// Removing the validator removes its reduced weight.
+	s.SetHeight(3)
+	s.DeleteCurrentValidator(loadedVal)
+	require.NoError(s.Commit())
+
+	_, err = database.GetUInt64(s.reducedWeightsDB, val.TxID[:])
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Zero(s.cfg.Validators.GetWeight(subnetID, validatorData.NodeID))
+}
+
+func TestStateReduceSubnetValidatorEndTime(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+
+		validatorData = txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    uint64(endTime.Unix()),
+			Wght:   1234,
+		}
+	)
+
+	utxVal := &txs.AddSubnetValidatorTx{
+		SubnetValidator: txs.SubnetValidator{
+			Validator: validatorData,
+			Subnet:    subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	addSubnetValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addSubnetValTx.Initialize(txs.Codec))
+
+	val, err := NewCurrentStaker(addSubnetValTx.ID(), utxVal, startTime, 0)
+	require.NoError(err)
+
+	s.SetHeight(1)
+	s.PutCurrentValidator(val)
+	s.AddTx(addSubnetValTx, status.Committed) // this is currently needed to reload the staker
+	require.NoError(s.Commit())
+
+	reducedVal := *val
+	reducedVal.EndTime = startTime.Add(time.Hour)
+	reducedVal.NextTime = reducedVal.EndTime
+
+	s.SetHeight(2)
+	s.UpdateCurrentValidator(&reducedVal)
+	require.NoError(s.Commit())
+
+	// The staker is ordered by its reduced end time.
+	stakerIterator, err := s.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(stakerIterator.Next())
+	require.Equal(&reducedVal, stakerIterator.Value())
+	require.False(stakerIterator.Next())
+	stakerIterator.Release()
+
+	// The reduced end time is persisted.
+	s = newStateFromDB(require, db)
+	require.NoError(s.loadCurrentValidators())
+
+	loadedVal, err := s.GetCurrentValidator(subnetID, validatorData.NodeID)
+	require.NoError(err)
+	require.Equal(reducedVal.EndTime, loadedVal.EndTime)
+	require.Equal(reducedVal.EndTime, loadedVal.NextTime)
+
+	// The end time can't be increased in place.
+	increasedVal := *loadedVal
+	increasedVal.EndTime = endTime
+	increasedVal.NextTime = endTime
+
+	s.SetHeight(3)
+	s.UpdateCurrentValidator(&increasedVal)
+	err = s.Commit()
+	require.ErrorIs(err, errEndTimeIncreased)
+}
+
+func TestStatePendingValidatorStartTime(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+	)
+
+	utxVal := &txs.AddSubnetValidatorTx{
+		SubnetValidator: txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   1234,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	addSubnetValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addSubnetValTx.Initialize(txs.Codec))
+
+	// The validator starts after the start time of its tx.
+	val, err := NewPendingStaker(addSubnetValTx.ID(), utxVal)
+	require.NoError(err)
+	val.StartTime = startTime.Add(time.Hour)
+	val.NextTime = val.StartTime
+
+	s.SetHeight(1)
+	s.PutPendingValidator(val)
+	s.AddTx(addSubnetValTx, status.Committed) // this is currently needed to reload the staker
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.loadPendingValidators())
+
+	loadedVal, err := s.GetPendingValidator(subnetID, utxVal.NodeID())
+	require.NoError(err)
+	require.Equal(val, loadedVal)
+}
+
+func TestStateSubnetValidatorEpoch(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		epoch    = 24 * time.Hour
+	)
+
+	_, err := s.GetSubnetValidatorEpoch(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	s.SetSubnetValidatorEpoch(subnetID, epoch)
+	fetchedEpoch, err := s.GetSubnetValidatorEpoch(subnetID)
+	require.NoError(err)
+	require.Equal(epoch, fetchedEpoch)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	fetchedEpoch, err = s.GetSubnetValidatorEpoch(subnetID)
+	require.NoError(err)
+	require.Equal(epoch, fetchedEpoch)
+
+	// Setting the epoch to 0 unfreezes the validator set.
+	s.SetSubnetValidatorEpoch(subnetID, 0)
+	_, err = s.GetSubnetValidatorEpoch(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	_, err = s.GetSubnetValidatorEpoch(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStatePruneTxs(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.txRetentionBlocks = 2
+
+	var (
+		prunableTxs    = make([]*txs.Tx, 4)
+		nonPrunableTxs = make([]*txs.Tx, 4)
+		parentID       = ids.GenerateTestID()
+	)
+	for i := range prunableTxs {
+		height := uint64(i + 1)
+
+		prunableTxs[i] = &txs.Tx{Unsigned: &txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Memo: database.PackUInt64(height),
+			},
+		}}
+		require.NoError(prunableTxs[i].Initialize(txs.Codec))
+
+		nonPrunableTxs[i] = &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					Memo: database.PackUInt64(height),
+				},
+			},
+			Owner: &secp256k1fx.OutputOwners{},
+		}}
+		require.NoError(nonPrunableTxs[i].Initialize(txs.Codec))
+
+		blk, err := block.NewBanffStandardBlock(
+			initialTime,
+			parentID,
+			height,
+			[]*txs.Tx{prunableTxs[i], nonPrunableTxs[i]},
+		)
+		require.NoError(err)
+		parentID = blk.ID()
+
+		s.AddStatelessBlock(blk)
+		s.AddTx(prunableTxs[i], status.Committed)
+		s.AddTx(nonPrunableTxs[i], status.Committed)
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+	}
+
+	// Only the prunable txs outside of the retention window should have been
+	// pruned.
+	for i, tx := range prunableTxs {
+		_, txStatus, err := s.GetTx(tx.ID())
+		if i < 2 {
+			require.ErrorIs(err, ErrTxPruned)
+		} else {
+			require.NoError(err)
+		}
+		require.Equal(status.Committed, txStatus)
+	}
+	for _, tx := range nonPrunableTxs {
+		_, txStatus, err := s.GetTx(tx.ID())
+		require.NoError(err)
+		require.Equal(status.Committed, txStatus)
+	}
+
+	// The pruning progress should be persisted.
+	txsPrunedHeight, err := database.GetUInt64(s.singletonDB, TxsPrunedHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(2), txsPrunedHeight)
+}
+
+func TestStatePruneValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.validatorDiffRetentionBlocks = 2
+
+	nodeID := ids.GenerateTestNodeID()
+	for height := uint64(1); height <= 4; height++ {
+		require.NoError(s.validatorWeightDiffsDB.Put(
+			marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+			marshalWeightDiff(&ValidatorWeightDiff{
+				Decrease: false,
+				Amount:   1,
+			}),
+		))
+		require.NoError(s.validatorPublicKeyDiffsDB.Put(
+			marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+			nil,
+		))
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+	}
+
+	// Only the diffs outside of the retention window should have been pruned.
+	for height := uint64(1); height <= 4; height++ {
+		key := marshalDiffKey(constants.PrimaryNetworkID, height, nodeID)
+		hasWeightDiff, err := s.validatorWeightDiffsDB.Has(key)
+		require.NoError(err)
+		hasPublicKeyDiff, err := s.validatorPublicKeyDiffsDB.Has(key)
+		require.NoError(err)
+		require.Equal(height > 2, hasWeightDiff)
+		require.Equal(height > 2, hasPublicKeyDiff)
+	}
+
+	// Validator sets can still be rebuilt within the retention window.
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {
+			NodeID: nodeID,
+			Weight: 4,
+		},
+	}
+	require.NoError(s.ApplyValidatorWeightDiffs(context.Background(), vdrs, 4, 3, constants.PrimaryNetworkID))
+	require.Equal(uint64(2), vdrs[nodeID].Weight)
+	require.NoError(s.ApplyValidatorPublicKeyDiffs(context.Background(), vdrs, 4, 3))
+
+	// Rebuilding validator sets outside of the retention window fails.
+	err := s.ApplyValidatorWeightDiffs(context.Background(), vdrs, 2, 2, constants.PrimaryNetworkID)
+	require.ErrorIs(err, ErrValidatorDiffsPruned)
+	err = s.ApplyValidatorPublicKeyDiffs(context.Background(), vdrs, 2, 2)
+	require.ErrorIs(err, ErrValidatorDiffsPruned)
+
+	// The pruning progress should be persisted.
+	validatorDiffsPrunedHeight, err := database.GetUInt64(s.singletonDB, ValidatorDiffsPrunedHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(2), validatorDiffsPrunedHeight)
+}
+
+func TestStateGetUTXOsAtHeight(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.utxoJournalEnabled = true
+
+	var (
+		addr      = ids.GenerateTestShortID()
+		otherAddr = ids.GenerateTestShortID()
+		addrs     = set.Of(addr)
+	)
+	newUTXO := func(owner ids.ShortID) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: initialTxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Schmeckle,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{owner},
+				},
+			},
+		}
+	}
+
+	_, err := s.GetUTXOsAtHeight(addrs, 0)
+	require.ErrorIs(err, ErrHeightNotJournaled)
+
+	genesisUTXO := newUTXO(addr)
+	genesisBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 0)
+	require.NoError(err)
+	require.NoError(s.syncGenesis(genesisBlk, &genesis.Genesis{
+		UTXOs: []*genesis.UTXO{
+			{UTXO: *genesisUTXO},
+		},
+		Timestamp:     uint64(initialTime.Unix()),
+		InitialSupply: units.Schmeckle,
+	}))
+	require.NoError(s.Commit())
+
+	acceptBlock := func(height uint64, parentID ids.ID) ids.ID {
+		blk, err := block.NewBanffStandardBlock(initialTime, parentID, height, []*txs.Tx{})
+		require.NoError(err)
+		s.AddStatelessBlock(blk)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+		return blk.ID()
+	}
+
+	// Height 1 adds a UTXO.
+	addedUTXO := newUTXO(addr)
+	s.AddUTXO(addedUTXO)
+	blkID := acceptBlock(1, genesisBlk.ID())
+
+	// Height 2 spends the genesis UTXO and adds a UTXO to another address.
+	s.DeleteUTXO(genesisUTXO.InputID())
+	s.AddUTXO(newUTXO(otherAddr))
+	acceptBlock(2, blkID)
+
+	sortedIDs := func(utxos ...*avax.UTXO) []ids.ID {
+		utxoIDs := make([]ids.ID, len(utxos))
+		for i, utxo := range utxos {
+			utxoIDs[i] = utxo.InputID()
+		}
+		utils.Sort(utxoIDs)
+		return utxoIDs
+	}
+	utxoIDs := func(height uint64) []ids.ID {
+		utxos, err := s.GetUTXOsAtHeight(addrs, height)
+		require.NoError(err)
+		return sortedIDs(utxos...)
+	}
+	require.Equal(sortedIDs(genesisUTXO), utxoIDs(0))
+	require.Equal(sortedIDs(genesisUTXO, addedUTXO), utxoIDs(1))
+	require.Equal(sortedIDs(addedUTXO), utxoIDs(2))
+
+	// Heights that haven't been accepted can't be queried.
+	_, err = s.GetUTXOsAtHeight(addrs, 3)
+	require.ErrorIs(err, ErrHeightNotJournaled)
+
+	// Disabling the journal stops the earlier heights from being queried, as
+	// the heights accepted while it is disabled aren't journaled.
+	s.utxoJournalEnabled = false
+	require.NoError(s.Commit())
+	_, err = s.GetUTXOsAtHeight(addrs, 2)
+	require.ErrorIs(err, ErrHeightNotJournaled)
+	hasStartHeight, err := s.singletonDB.Has(UTXOJournalStartHeightKey)
+	require.NoError(err)
+	require.False(hasStartHeight)
+}
+
+func TestStateApplyStakerDiffs(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(14 * 24 * time.Hour)
+	)
+
+	utxVal := &txs.AddSubnetValidatorTx{
+		SubnetValidator: txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				End:    uint64(endTime.Unix()),
+				Wght:   1234,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	addSubnetValTx := &txs.Tx{Unsigned: utxVal}
+	require.NoError(addSubnetValTx.Initialize(txs.Codec))
+
+	val, err := NewCurrentStaker(addSubnetValTx.ID(), utxVal, startTime, 0)
+	require.NoError(err)
+
+	// The validator is added at height 1, has its weight reduced at height 2,
+	// and is removed at height 3.
+	s.SetHeight(1)
+	s.PutCurrentValidator(val)
+	s.AddTx(addSubnetValTx, status.Committed)
+	require.NoError(s.Commit())
+
+	reducedVal := *val
+	reducedVal.Weight = 234
+
+	s.SetHeight(2)
+	s.UpdateCurrentValidator(&reducedVal)
+	require.NoError(s.Commit())
+
+	s.SetHeight(3)
+	s.DeleteCurrentValidator(&reducedVal)
+	require.NoError(s.Commit())
+
+	tests := []struct {
+		height          uint64
+		expectedStakers map[ids.ID]*Staker
+	}{
+		{
+			height:          3,
+			expectedStakers: map[ids.ID]*Staker{},
+		},
+		{
+			height: 2,
+			expectedStakers: map[ids.ID]*Staker{
+				val.TxID: &reducedVal,
+			},
+		},
+		{
+			height: 1,
+			expectedStakers: map[ids.ID]*Staker{
+				val.TxID: val,
+			},
+		},
+		{
+			height:          0,
+			expectedStakers: map[ids.ID]*Staker{},
+		},
+	}
+	for _, test := range tests {
+		stakers := map[ids.ID]*Staker{}
+		require.NoError(s.ApplyStakerDiffs(context.Background(), stakers, 3, test.height+1, subnetID))
+		require.Equal(test.expectedStakers, stakers)
+	}
+
+	// The stakers at heights accepted before staker diffs were recorded can't
+	// be reconstructed.
+	s.stakerDiffsStartHeight = 1
+	err = s.ApplyStakerDiffs(context.Background(), map[ids.ID]*Staker{}, 3, 1, subnetID)
+	require.ErrorIs(err, ErrStakerDiffsUnavailable)
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+// SubnetStakingParams are the staking parameters of an elastic subnet that
+// replaced the ones set when the subnet was transformed.
+type SubnetStakingParams struct {
+	MinValidatorStake uint64 `serialize:"true" json:"minValidatorStake"`
+	MaxValidatorStake uint64 `serialize:"true" json:"maxValidatorStake"`
+	// MinStakeDuration is the minimum number of seconds a validator can
+	// validate for in a single period.
+	MinStakeDuration uint32 `serialize:"true" json:"minStakeDuration"`
+	// MaxStakeDuration is the maximum number of seconds a staker can stake for
+	// in a single period.
+	MaxStakeDuration  uint32 `serialize:"true" json:"maxStakeDuration"`
+	MinDelegatorStake uint64 `serialize:"true" json:"minDelegatorStake"`
+	// MinDelegationDuration is the minimum number of seconds a delegator can
+	// delegate for in a single period.
+	MinDelegationDuration uint32 `serialize:"true" json:"minDelegationDuration"`
+	// MinSelfStakeRatio is the minimum portion, out of
+	// [reward.PercentDenominator], of a validator's total weight that must be
+	// the validator's own stake for it to accept a delegation. If 0, no
+	// minimum is enforced.
+	MinSelfStakeRatio uint32 `serialize:"true" json:"minSelfStakeRatio"`
+	// FeeConversionRate is the number of units of the subnet's staking asset
+	// that are charged in place of 1 AVAX for the fees of txs that add
+	// stakers to the subnet without consuming AVAX. If 0, fees must be paid in
+	// AVAX.
+	FeeConversionRate uint64 `serialize:"true" json:"feeConversionRate"`
+}
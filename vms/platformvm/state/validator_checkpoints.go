@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+const checkpointKeyLength = ids.IDLen + wrappers.LongLen
+
+var errUnexpectedCheckpointKeyLength = fmt.Errorf("expected checkpoint key length %d", checkpointKeyLength)
+
+// A validator set checkpoint is the full validator set of a subnet after the
+// block at the checkpoint's height was accepted. Rebuilding a validator set
+// at a height only needs to apply the diffs down from the closest checkpoint
+// above that height rather than from the last accepted height.
+//
+// The public keys of a subnet checkpoint are the keys the validators had
+// registered on the subnet. Like the current validator set, they must be
+// replaced with the keys of the primary network checkpoint at the same
+// height before the public key diffs are applied.
+
+type validatorCheckpoint struct {
+	Validators []validatorCheckpointEntry `serialize:"true"`
+}
+
+type validatorCheckpointEntry struct {
+	NodeID ids.NodeID `serialize:"true"`
+	// PublicKey is the compressed public key of the validator or empty if the
+	// validator didn't have a key.
+	PublicKey []byte `serialize:"true"`
+	Weight    uint64 `serialize:"true"`
+}
+
+func (e validatorCheckpointEntry) Compare(other validatorCheckpointEntry) int {
+	return e.NodeID.Compare(other.NodeID)
+}
+
+// marshalCheckpointKey returns the key of the checkpoint of [subnetID] at
+// [height]. Unlike the diff keys, heights are sorted in increasing order.
+func marshalCheckpointKey(subnetID ids.ID, height uint64) []byte {
+	key := make([]byte, checkpointKeyLength)
+	copy(key, subnetID[:])
+	binary.BigEndian.PutUint64(key[ids.IDLen:], height)
+	return key
+}
+
+func unmarshalCheckpointKey(key []byte) (ids.ID, uint64, error) {
+	if len(key) != checkpointKeyLength {
+		return ids.Empty, 0, errUnexpectedCheckpointKeyLength
+	}
+	var subnetID ids.ID
+	copy(subnetID[:], key)
+	return subnetID, binary.BigEndian.Uint64(key[ids.IDLen:]), nil
+}
+
+func marshalValidatorCheckpoint(vdrs map[ids.NodeID]*validators.GetValidatorOutput) ([]byte, error) {
+	checkpoint := validatorCheckpoint{
+		Validators: make([]validatorCheckpointEntry, 0, len(vdrs)),
+	}
+	for nodeID, vdr := range vdrs {
+		entry := validatorCheckpointEntry{
+			NodeID: nodeID,
+			Weight: vdr.Weight,
+		}
+		if vdr.PublicKey != nil {
+			entry.PublicKey = bls.PublicKeyToCompressedBytes(vdr.PublicKey)
+		}
+		checkpoint.Validators = append(checkpoint.Validators, entry)
+	}
+	utils.Sort(checkpoint.Validators)
+	return block.GenesisCodec.Marshal(block.CodecVersion, &checkpoint)
+}
+
+func unmarshalValidatorCheckpoint(b []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	var checkpoint validatorCheckpoint
+	if _, err := block.GenesisCodec.Unmarshal(b, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(checkpoint.Validators))
+	for _, entry := range checkpoint.Validators {
+		vdr := &validators.GetValidatorOutput{
+			NodeID: entry.NodeID,
+			Weight: entry.Weight,
+		}
+		if len(entry.PublicKey) != 0 {
+			pk, err := bls.PublicKeyFromCompressedBytes(entry.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			vdr.PublicKey = pk
+		}
+		vdrs[entry.NodeID] = vdr
+	}
+	return vdrs, nil
+}
+
+func (s *state) GetValidatorSetCheckpoint(
+	subnetID ids.ID,
+	minHeight uint64,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	it := s.validatorCheckpointsDB.NewIteratorWithStartAndPrefix(
+		marshalCheckpointKey(subnetID, minHeight),
+		subnetID[:],
+	)
+	defer it.Release()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return 0, nil, err
+		}
+		return 0, nil, database.ErrNotFound
+	}
+
+	_, height, err := unmarshalCheckpointKey(it.Key())
+	if err != nil {
+		return 0, nil, err
+	}
+	vdrs, err := unmarshalValidatorCheckpoint(it.Value())
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse validator checkpoint of %s at %d: %w", subnetID, height, err)
+	}
+	return height, vdrs, nil
+}
+
+// writeValidatorCheckpoints persists the validator sets of the primary network
+// and of the tracked subnets if [height] is a checkpoint height.
+//
+// Invariant: Must be called after writeCurrentStakers so that [s.validators]
+// contains the validator sets at [height].
+func (s *state) writeValidatorCheckpoints(updateValidators bool, height uint64) error {
+	// The validator sets are only up to date when the validator manager is
+	// being updated.
+	if !updateValidators || s.validatorCheckpointInterval == 0 || height%s.validatorCheckpointInterval != 0 {
+		return nil
+	}
+
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, s.cfg.TrackedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		checkpointBytes, err := marshalValidatorCheckpoint(s.validators.GetMap(subnetID))
+		if err != nil {
+			return fmt.Errorf("failed to marshal validator checkpoint of %s: %w", subnetID, err)
+		}
+		if err := s.validatorCheckpointsDB.Put(marshalCheckpointKey(subnetID, height), checkpointBytes); err != nil {
+			return fmt.Errorf("failed to write validator checkpoint of %s: %w", subnetID, err)
+		}
+	}
+	return nil
+}
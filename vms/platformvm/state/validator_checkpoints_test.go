@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestValidatorCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		pk       = bls.PublicFromSecretKey(sk)
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+		subnetID = ids.GenerateTestID()
+	)
+
+	s, db := newUninitializedState(require)
+	s.validatorCheckpointInterval = 4
+	s.cfg.TrackedSubnets = set.Of(subnetID)
+
+	require.NoError(s.validators.AddStaker(constants.PrimaryNetworkID, nodeID0, pk, ids.Empty, 1))
+	require.NoError(s.validators.AddStaker(constants.PrimaryNetworkID, nodeID1, nil, ids.Empty, 2))
+	require.NoError(s.validators.AddStaker(subnetID, nodeID0, nil, ids.Empty, 3))
+
+	// Only heights that are multiples of the interval are checkpointed, and
+	// only if the validator sets were updated.
+	require.NoError(s.writeValidatorCheckpoints(true, 3))
+	require.NoError(s.writeValidatorCheckpoints(false, 8))
+	_, _, err = s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.writeValidatorCheckpoints(true, 4))
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	height, primaryCheckpoint, err := s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 1)
+	require.NoError(err)
+	require.Equal(uint64(4), height)
+	require.Equal(
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID:    nodeID0,
+				PublicKey: pk,
+				Weight:    1,
+			},
+			nodeID1: {
+				NodeID: nodeID1,
+				Weight: 2,
+			},
+		},
+		primaryCheckpoint,
+	)
+
+	height, subnetCheckpoint, err := s.GetValidatorSetCheckpoint(subnetID, 4)
+	require.NoError(err)
+	require.Equal(uint64(4), height)
+	require.Equal(
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID: nodeID0,
+				Weight: 3,
+			},
+		},
+		subnetCheckpoint,
+	)
+
+	_, _, err = s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 5)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, _, err = s.GetValidatorSetCheckpoint(ids.GenerateTestID(), 0)
+	require.ErrorIs(err, database.ErrNotFound)
+}
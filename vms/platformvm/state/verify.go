@@ -0,0 +1,373 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errInvalidSubnetTx      = errors.New("invalid subnet tx")
+	errInvalidStakerTx      = errors.New("invalid staker tx")
+	errUnknownStakerSubnet  = errors.New("staker of unknown subnet")
+	errInsufficientSupply   = errors.New("supply is less than the outstanding potential rewards")
+	errSupplyAboveMaximum   = errors.New("supply exceeds the maximum supply")
+	errValidatorDiffsDiffer = errors.New("validator weight diffs don't replay to the recorded validator set")
+)
+
+// Verify walks the database and checks its referential integrity. Every
+// inconsistency that is found is returned, rather than only the first, so the
+// state of a database can be fully reported before or after an upgrade. If the
+// database can't be read, an error is returned instead.
+//
+// The following is checked:
+//   - Every subnet was created by a committed CreateSubnetTx.
+//   - Every current and pending staker was added by a committed staker tx,
+//     and validates a known subnet.
+//   - The supply of the primary network and of every transformed subnet
+//     covers the potential rewards of its current stakers, which are added to
+//     the supply when the stakers are. The supply of a transformed subnet
+//     doesn't exceed its maximum supply. Burned fees aren't removed from the
+//     supply, so it is only an upper bound of the circulating supply and
+//     can't be checked exactly.
+//   - The validator weight diffs of every subnet replay from the current
+//     validator set to every validator set checkpoint and, if no diffs have
+//     been pruned, to the empty validator set before genesis.
+func (s *state) Verify(ctx context.Context) ([]error, error) {
+	subnetIDs, inconsistencies, err := s.verifySubnets()
+	if err != nil {
+		return nil, err
+	}
+
+	stakerInconsistencies, err := s.verifyStakers(subnetIDs)
+	if err != nil {
+		return nil, err
+	}
+	inconsistencies = append(inconsistencies, stakerInconsistencies...)
+
+	supplyInconsistencies, err := s.verifySupplies(subnetIDs)
+	if err != nil {
+		return nil, err
+	}
+	inconsistencies = append(inconsistencies, supplyInconsistencies...)
+
+	lastAccepted, err := s.GetStatelessBlock(s.GetLastAccepted())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+	height := lastAccepted.Height()
+	for _, subnetID := range subnetIDs.List() {
+		diffInconsistencies, err := s.verifyValidatorDiffs(ctx, subnetID, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify validator diffs of %s: %w", subnetID, err)
+		}
+		inconsistencies = append(inconsistencies, diffInconsistencies...)
+	}
+	return inconsistencies, nil
+}
+
+// verifySubnets returns the IDs of the primary network and of the subnets that
+// were created, along with the subnets that weren't created by a committed
+// CreateSubnetTx.
+func (s *state) verifySubnets() (set.Set[ids.ID], []error, error) {
+	var (
+		subnetIDs       = set.Of(constants.PrimaryNetworkID)
+		inconsistencies []error
+	)
+
+	subnetIt := s.subnetDB.NewIterator()
+	defer subnetIt.Release()
+
+	for subnetIt.Next() {
+		subnetID, err := ids.ToID(subnetIt.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		subnetIDs.Add(subnetID)
+
+		tx, txStatus, err := s.GetTx(subnetID)
+		switch {
+		case err != nil:
+			inconsistencies = append(inconsistencies, fmt.Errorf("%w: failed to get tx of subnet %s: %w",
+				errInvalidSubnetTx,
+				subnetID,
+				err,
+			))
+		case txStatus != status.Committed:
+			inconsistencies = append(inconsistencies, fmt.Errorf("%w: tx of subnet %s has status %s",
+				errInvalidSubnetTx,
+				subnetID,
+				txStatus,
+			))
+		default:
+			if _, ok := tx.Unsigned.(*txs.CreateSubnetTx); !ok {
+				inconsistencies = append(inconsistencies, fmt.Errorf("%w: tx of subnet %s is a %T",
+					errInvalidSubnetTx,
+					subnetID,
+					tx.Unsigned,
+				))
+			}
+		}
+	}
+	return subnetIDs, inconsistencies, subnetIt.Error()
+}
+
+// verifyStakers returns the current and pending stakers that weren't added by
+// a committed staker tx or that validate a subnet not in [subnetIDs].
+func (s *state) verifyStakers(subnetIDs set.Set[ids.ID]) ([]error, error) {
+	var inconsistencies []error
+	for _, getIterator := range []func() (StakerIterator, error){
+		s.GetCurrentStakerIterator,
+		s.GetPendingStakerIterator,
+	} {
+		stakerIterator, err := getIterator()
+		if err != nil {
+			return nil, err
+		}
+		for stakerIterator.Next() {
+			staker := stakerIterator.Value()
+			if !subnetIDs.Contains(staker.SubnetID) {
+				inconsistencies = append(inconsistencies, fmt.Errorf("%w: staker %s validates %s",
+					errUnknownStakerSubnet,
+					staker.TxID,
+					staker.SubnetID,
+				))
+			}
+
+			tx, txStatus, err := s.GetTx(staker.TxID)
+			switch {
+			case err != nil:
+				inconsistencies = append(inconsistencies, fmt.Errorf("%w: failed to get tx of staker %s: %w",
+					errInvalidStakerTx,
+					staker.TxID,
+					err,
+				))
+			case txStatus != status.Committed:
+				inconsistencies = append(inconsistencies, fmt.Errorf("%w: tx of staker %s has status %s",
+					errInvalidStakerTx,
+					staker.TxID,
+					txStatus,
+				))
+			default:
+				if _, ok := tx.Unsigned.(txs.Staker); !ok {
+					inconsistencies = append(inconsistencies, fmt.Errorf("%w: tx of staker %s is a %T",
+						errInvalidStakerTx,
+						staker.TxID,
+						tx.Unsigned,
+					))
+				}
+			}
+		}
+		stakerIterator.Release()
+	}
+	return inconsistencies, nil
+}
+
+// verifySupplies returns the supplies of the primary network and of the
+// transformed subnets in [subnetIDs] that are outside of their bounds.
+func (s *state) verifySupplies(subnetIDs set.Set[ids.ID]) ([]error, error) {
+	potentialRewards := make(map[ids.ID]uint64)
+	stakerIterator, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer stakerIterator.Release()
+
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		potentialRewards[staker.SubnetID], err = safemath.Add64(potentialRewards[staker.SubnetID], staker.PotentialReward)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var inconsistencies []error
+	for _, subnetID := range subnetIDs.List() {
+		maxSupply := uint64(math.MaxUint64)
+		if subnetID != constants.PrimaryNetworkID {
+			transformSubnetTx, err := s.GetSubnetTransformation(subnetID)
+			if err == database.ErrNotFound {
+				// Only transformed subnets have a supply.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if transformSubnet, ok := transformSubnetTx.Unsigned.(*txs.TransformSubnetTx); ok {
+				maxSupply = transformSubnet.MaximumSupply
+			}
+		}
+
+		supply, err := s.GetCurrentSupply(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		if potentialReward := potentialRewards[subnetID]; supply < potentialReward {
+			inconsistencies = append(inconsistencies, fmt.Errorf("%w: supply of %s is %d, but the potential rewards are %d",
+				errInsufficientSupply,
+				subnetID,
+				supply,
+				potentialReward,
+			))
+		}
+		if supply > maxSupply {
+			inconsistencies = append(inconsistencies, fmt.Errorf("%w: supply of %s is %d, but the maximum supply is %d",
+				errSupplyAboveMaximum,
+				subnetID,
+				supply,
+				maxSupply,
+			))
+		}
+	}
+	return inconsistencies, nil
+}
+
+// verifyValidatorDiffs replays the validator weight diffs of [subnetID] from
+// the current validator set at [height] towards the genesis. The replayed
+// validator set is compared against every checkpoint and, if no diffs have
+// been pruned, against the empty validator set before genesis. Only the first
+// mismatch is returned, as the replayed validator set is meaningless after it.
+func (s *state) verifyValidatorDiffs(ctx context.Context, subnetID ids.ID, height uint64) ([]error, error) {
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput)
+	stakerIterator, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		if staker.SubnetID != subnetID {
+			continue
+		}
+		vdr, ok := vdrs[staker.NodeID]
+		if !ok {
+			vdr = &validators.GetValidatorOutput{
+				NodeID: staker.NodeID,
+			}
+			vdrs[staker.NodeID] = vdr
+		}
+		vdr.Weight, err = safemath.Add64(vdr.Weight, staker.Weight)
+		if err != nil {
+			stakerIterator.Release()
+			return nil, err
+		}
+	}
+	stakerIterator.Release()
+
+	checkpointHeights, err := s.getValidatorCheckpointHeights(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replay the diffs down to each checkpoint, from the highest to the
+	// lowest.
+	for _, checkpointHeight := range checkpointHeights {
+		if checkpointHeight > height || checkpointHeight < s.validatorDiffsPrunedHeight {
+			continue
+		}
+		if err := s.ApplyValidatorWeightDiffs(ctx, vdrs, height, checkpointHeight+1, subnetID); err != nil {
+			return []error{fmt.Errorf("%w: failed to replay diffs of %s down to height %d: %w",
+				errValidatorDiffsDiffer,
+				subnetID,
+				checkpointHeight,
+				err,
+			)}, nil
+		}
+		height = checkpointHeight
+
+		_, checkpoint, err := s.GetValidatorSetCheckpoint(subnetID, checkpointHeight)
+		if err != nil {
+			return nil, err
+		}
+		if inconsistency := compareWeights(subnetID, checkpointHeight, vdrs, checkpoint); inconsistency != nil {
+			return []error{inconsistency}, nil
+		}
+	}
+
+	if s.validatorDiffsPrunedHeight != 0 {
+		return nil, nil
+	}
+
+	// The diffs at height 0 add the genesis validators, so undoing them must
+	// leave no validators.
+	if err := s.ApplyValidatorWeightDiffs(ctx, vdrs, height, 0, subnetID); err != nil {
+		return []error{fmt.Errorf("%w: failed to replay diffs of %s down to genesis: %w",
+			errValidatorDiffsDiffer,
+			subnetID,
+			err,
+		)}, nil
+	}
+	if inconsistency := compareWeights(subnetID, 0, vdrs, nil); inconsistency != nil {
+		return []error{inconsistency}, nil
+	}
+	return nil, nil
+}
+
+// getValidatorCheckpointHeights returns the heights of the validator set
+// checkpoints of [subnetID] in decreasing order.
+func (s *state) getValidatorCheckpointHeights(subnetID ids.ID) ([]uint64, error) {
+	it := s.validatorCheckpointsDB.NewIteratorWithPrefix(subnetID[:])
+	defer it.Release()
+
+	var heights []uint64
+	for it.Next() {
+		_, height, err := unmarshalCheckpointKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		heights = append(heights, height)
+	}
+	slices.Reverse(heights)
+	return heights, it.Error()
+}
+
+// compareWeights returns an error if the weights of [replayed] differ from the
+// weights of [expected]. Public keys aren't compared.
+func compareWeights(
+	subnetID ids.ID,
+	height uint64,
+	replayed map[ids.NodeID]*validators.GetValidatorOutput,
+	expected map[ids.NodeID]*validators.GetValidatorOutput,
+) error {
+	nodeIDs := set.NewSet[ids.NodeID](len(replayed) + len(expected))
+	for nodeID := range replayed {
+		nodeIDs.Add(nodeID)
+	}
+	for nodeID := range expected {
+		nodeIDs.Add(nodeID)
+	}
+	for nodeID := range nodeIDs {
+		var replayedWeight, expectedWeight uint64
+		if vdr, ok := replayed[nodeID]; ok {
+			replayedWeight = vdr.Weight
+		}
+		if vdr, ok := expected[nodeID]; ok {
+			expectedWeight = vdr.Weight
+		}
+		if replayedWeight != expectedWeight {
+			return fmt.Errorf("%w: validator %s of %s has weight %d at height %d, expected %d",
+				errValidatorDiffsDiffer,
+				nodeID,
+				subnetID,
+				replayedWeight,
+				height,
+				expectedWeight,
+			)
+		}
+	}
+	return nil
+}
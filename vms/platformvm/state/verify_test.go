@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name                    string
+		corrupt                 func(*require.Assertions, *state)
+		expectedInconsistencies []error
+	}{
+		{
+			name:    "consistent",
+			corrupt: func(*require.Assertions, *state) {},
+		},
+		{
+			name: "staker tx not committed",
+			corrupt: func(require *require.Assertions, s *state) {
+				staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+				require.NoError(err)
+				tx, _, err := s.GetTx(staker.TxID)
+				require.NoError(err)
+				s.AddTx(tx, status.Aborted)
+			},
+			expectedInconsistencies: []error{
+				errInvalidStakerTx,
+			},
+		},
+		{
+			name: "staker of unknown subnet",
+			corrupt: func(require *require.Assertions, s *state) {
+				staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+				require.NoError(err)
+				subnetStaker := *staker
+				subnetStaker.TxID = ids.GenerateTestID()
+				subnetStaker.SubnetID = ids.GenerateTestID()
+				s.PutCurrentValidator(&subnetStaker)
+			},
+			expectedInconsistencies: []error{
+				errUnknownStakerSubnet,
+				errInvalidStakerTx,
+			},
+		},
+		{
+			name: "supply below potential rewards",
+			corrupt: func(_ *require.Assertions, s *state) {
+				s.SetCurrentSupply(constants.PrimaryNetworkID, 0)
+			},
+			expectedInconsistencies: []error{
+				errInsufficientSupply,
+			},
+		},
+		{
+			name: "validator diffs don't replay to genesis",
+			corrupt: func(require *require.Assertions, s *state) {
+				require.NoError(s.validatorWeightDiffsDB.Put(
+					marshalDiffKey(constants.PrimaryNetworkID, 0, ids.GenerateTestNodeID()),
+					marshalWeightDiff(&ValidatorWeightDiff{
+						Decrease: false,
+						Amount:   1,
+					}),
+				))
+			},
+			expectedInconsistencies: []error{
+				errValidatorDiffsDiffer,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			s := newInitializedState(require).(*state)
+			test.corrupt(require, s)
+			require.NoError(s.Commit())
+
+			inconsistencies, err := s.Verify(context.Background())
+			require.NoError(err)
+			require.Len(inconsistencies, len(test.expectedInconsistencies))
+			for i, expected := range test.expectedInconsistencies {
+				require.ErrorIs(inconsistencies[i], expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+var (
+	_ ValidatorTx = (*AddCappedPermissionlessValidatorTx)(nil)
+
+	errNoDelegationCap = errors.New("delegation cap must be non-zero")
+)
+
+// AddCappedPermissionlessValidatorTx is an unsigned
+// addCappedPermissionlessValidatorTx. It adds a permissionless validator that
+// accepts at most [MaxDelegatedWeight] of delegations at any point in time.
+type AddCappedPermissionlessValidatorTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// Maximum total weight that may be delegated to this validator at any
+	// point in time. The subnet's MaxValidatorWeightFactor and
+	// MaxValidatorStake still apply, so this can only lower the bound.
+	MaxDelegatedWeight uint64 `serialize:"true" json:"maxDelegatedWeight"`
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddCappedPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.MaxDelegatedWeight == 0:
+		return errNoDelegationCap
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddCappedPermissionlessValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddCappedPermissionlessValidatorTx(tx)
+}
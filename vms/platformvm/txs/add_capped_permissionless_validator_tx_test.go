@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestAddCappedPermissionlessValidatorTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		tx          *AddCappedPermissionlessValidatorTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			tx: &AddCappedPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no delegation cap",
+			tx: &AddCappedPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+					Validator: Validator{
+						NodeID: ids.GenerateTestNodeID(),
+					},
+				},
+			},
+			expectedErr: errNoDelegationCap,
+		},
+		{
+			name: "invalid validator tx",
+			tx: &AddCappedPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+				},
+				MaxDelegatedWeight: 1,
+			},
+			expectedErr: errEmptyNodeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+// MaxValidatorMetadataSize is the maximum number of bytes in the metadata of
+// an AddPermissionlessValidatorWithMetadataTx.
+const MaxValidatorMetadataSize = 1024
+
+var (
+	_ ValidatorTx = (*AddPermissionlessValidatorWithMetadataTx)(nil)
+
+	errNoMetadata       = errors.New("metadata must be non-empty")
+	errMetadataTooLarge = errors.New("metadata too large")
+)
+
+// AddPermissionlessValidatorWithMetadataTx is an unsigned
+// addPermissionlessValidatorWithMetadataTx. It adds a permissionless validator
+// along with metadata, such as a moniker or operator contact, that delegators
+// can use to identify the validator.
+type AddPermissionlessValidatorWithMetadataTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// Arbitrary bytes describing the validator, up to
+	// [MaxValidatorMetadataSize]. The chain doesn't interpret them.
+	Metadata types.JSONByteSlice `serialize:"true" json:"metadata"`
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddPermissionlessValidatorWithMetadataTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.Metadata) == 0:
+		return errNoMetadata
+	case len(tx.Metadata) > MaxValidatorMetadataSize:
+		return fmt.Errorf("%w: %d > %d", errMetadataTooLarge, len(tx.Metadata), MaxValidatorMetadataSize)
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddPermissionlessValidatorWithMetadataTx) Visit(visitor Visitor) error {
+	return visitor.AddPermissionlessValidatorWithMetadataTx(tx)
+}
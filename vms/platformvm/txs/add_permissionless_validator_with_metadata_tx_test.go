@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+func TestAddPermissionlessValidatorWithMetadataTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		tx          *AddPermissionlessValidatorWithMetadataTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no metadata",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+				},
+			},
+			expectedErr: errNoMetadata,
+		},
+		{
+			name: "metadata too large",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+				},
+				Metadata: make(types.JSONByteSlice, MaxValidatorMetadataSize+1),
+			},
+			expectedErr: errMetadataTooLarge,
+		},
+		{
+			name: "invalid validator tx",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+				},
+				Metadata: make(types.JSONByteSlice, MaxValidatorMetadataSize),
+			},
+			expectedErr: errEmptyNodeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+const (
+	// BasisPointsDenominator is the number of basis points that the reward
+	// splits of a validator add up to.
+	BasisPointsDenominator = 10_000
+
+	// MaxRewardSplits is the maximum number of owners that the validation
+	// reward of a validator can be split between.
+	MaxRewardSplits = 16
+)
+
+var (
+	_ ValidatorTx = (*AddSplitRewardsPermissionlessValidatorTx)(nil)
+
+	errNoRewardSplits          = errors.New("no reward splits")
+	errTooManyRewardSplits     = errors.New("too many reward splits")
+	errZeroRewardSplit         = errors.New("reward split must be non-zero")
+	errRewardSplitsDontAddUp   = errors.New("reward splits must add up to the basis points denominator")
+	errInvalidRewardSplitOwner = errors.New("invalid reward split owner")
+)
+
+// RewardSplit is the share of a validation reward that is minted to [Owner].
+type RewardSplit struct {
+	// Share of the reward, in basis points.
+	BasisPoints uint32 `serialize:"true" json:"basisPoints"`
+	// Who receives the share of the reward.
+	Owner fx.Owner `serialize:"true" json:"owner"`
+}
+
+// AddSplitRewardsPermissionlessValidatorTx is an unsigned
+// addSplitRewardsPermissionlessValidatorTx. It adds a permissionless validator
+// whose validation reward is split between multiple owners, such as the node
+// operator and the provider of the stake.
+//
+// The validation reward is minted to the owners of [ValidationRewardSplits]
+// rather than to [ValidatorRewardsOwner], which still authorizes changes to
+// the validator. Delegatee rewards are minted to [DelegatorRewardsOwner].
+type AddSplitRewardsPermissionlessValidatorTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// How the validation reward is split. The basis points of the splits must
+	// add up to [BasisPointsDenominator].
+	ValidationRewardSplits []RewardSplit `serialize:"true" json:"validationRewardSplits"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddSplitRewardsPermissionlessValidatorTx]. Also sets the [ctx] to the given
+// [vm.ctx] so that the addresses can be json marshalled into human readable
+// format
+func (tx *AddSplitRewardsPermissionlessValidatorTx) InitCtx(ctx *snow.Context) {
+	tx.AddPermissionlessValidatorTx.InitCtx(ctx)
+	for _, split := range tx.ValidationRewardSplits {
+		split.Owner.InitCtx(ctx)
+	}
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddSplitRewardsPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.ValidationRewardSplits) == 0:
+		return errNoRewardSplits
+	case len(tx.ValidationRewardSplits) > MaxRewardSplits:
+		return fmt.Errorf("%w: %d > %d", errTooManyRewardSplits, len(tx.ValidationRewardSplits), MaxRewardSplits)
+	}
+
+	var totalBasisPoints uint32
+	for _, split := range tx.ValidationRewardSplits {
+		switch {
+		case split.BasisPoints == 0:
+			return errZeroRewardSplit
+		case split.BasisPoints > BasisPointsDenominator:
+			return fmt.Errorf("%w: %d > %d", errRewardSplitsDontAddUp, split.BasisPoints, BasisPointsDenominator)
+		}
+		// Because there are at most [MaxRewardSplits] splits, each bounded by
+		// [BasisPointsDenominator], this can't overflow.
+		totalBasisPoints += split.BasisPoints
+		if split.Owner == nil {
+			return errInvalidRewardSplitOwner
+		}
+		if err := split.Owner.Verify(); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidRewardSplitOwner, err)
+		}
+	}
+	if totalBasisPoints != BasisPointsDenominator {
+		return fmt.Errorf("%w: %d != %d", errRewardSplitsDontAddUp, totalBasisPoints, BasisPointsDenominator)
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddSplitRewardsPermissionlessValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddSplitRewardsPermissionlessValidatorTx(tx)
+}
+
+// SplitReward returns the amount of [reward] that is minted to each of
+// [splits]. Amounts are rounded down and the remainder is added to the first
+// split, so the amounts always add up to [reward].
+func SplitReward(reward uint64, splits []RewardSplit) []uint64 {
+	var (
+		amounts = make([]uint64, len(splits))
+		// [reward] is split into a multiple of [BasisPointsDenominator] and a
+		// remainder so that multiplying by the basis points can't overflow.
+		quotient  = reward / BasisPointsDenominator
+		remainder = reward % BasisPointsDenominator
+		total     uint64
+	)
+	for i, split := range splits {
+		basisPoints := uint64(split.BasisPoints)
+		amounts[i] = quotient*basisPoints + remainder*basisPoints/BasisPointsDenominator
+		total += amounts[i]
+	}
+	if len(amounts) > 0 {
+		amounts[0] += reward - total
+	}
+	return amounts
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddSplitRewardsPermissionlessValidatorTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	newSplit := func(basisPoints uint32) RewardSplit {
+		return RewardSplit{
+			BasisPoints: basisPoints,
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		splits      []RewardSplit
+		expectedErr error
+	}{
+		{
+			name:        "no splits",
+			splits:      nil,
+			expectedErr: errNoRewardSplits,
+		},
+		{
+			name:        "too many splits",
+			splits:      make([]RewardSplit, MaxRewardSplits+1),
+			expectedErr: errTooManyRewardSplits,
+		},
+		{
+			name:        "zero split",
+			splits:      []RewardSplit{newSplit(BasisPointsDenominator), newSplit(0)},
+			expectedErr: errZeroRewardSplit,
+		},
+		{
+			name:        "split above denominator",
+			splits:      []RewardSplit{newSplit(math.MaxUint32), newSplit(BasisPointsDenominator + 1)},
+			expectedErr: errRewardSplitsDontAddUp,
+		},
+		{
+			name:        "splits below denominator",
+			splits:      []RewardSplit{newSplit(5_000), newSplit(4_999)},
+			expectedErr: errRewardSplitsDontAddUp,
+		},
+		{
+			name:        "missing owner",
+			splits:      []RewardSplit{{BasisPoints: BasisPointsDenominator}},
+			expectedErr: errInvalidRewardSplitOwner,
+		},
+		{
+			name: "invalid owner",
+			splits: []RewardSplit{{
+				BasisPoints: BasisPointsDenominator,
+				Owner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+				},
+			}},
+			expectedErr: errInvalidRewardSplitOwner,
+		},
+		{
+			name:        "invalid validator tx",
+			splits:      []RewardSplit{newSplit(3_000), newSplit(7_000)},
+			expectedErr: errEmptyNodeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := &AddSplitRewardsPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+				},
+				ValidationRewardSplits: test.splits,
+			}
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSplitReward(t *testing.T) {
+	tests := []struct {
+		name            string
+		reward          uint64
+		basisPoints     []uint32
+		expectedAmounts []uint64
+	}{
+		{
+			name:            "single owner",
+			reward:          1_234,
+			basisPoints:     []uint32{BasisPointsDenominator},
+			expectedAmounts: []uint64{1_234},
+		},
+		{
+			name:            "exact split",
+			reward:          10_000,
+			basisPoints:     []uint32{2_500, 7_500},
+			expectedAmounts: []uint64{2_500, 7_500},
+		},
+		{
+			name:            "remainder to first owner",
+			reward:          10,
+			basisPoints:     []uint32{3_333, 3_333, 3_334},
+			expectedAmounts: []uint64{4, 3, 3},
+		},
+		{
+			name:            "no overflow",
+			reward:          math.MaxUint64,
+			basisPoints:     []uint32{5_000, 5_000},
+			expectedAmounts: []uint64{math.MaxUint64/2 + 1, math.MaxUint64 / 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			splits := make([]RewardSplit, len(test.basisPoints))
+			for i, basisPoints := range test.basisPoints {
+				splits[i].BasisPoints = basisPoints
+			}
+			require.Equal(t, test.expectedAmounts, SplitReward(test.reward, splits))
+		})
+	}
+}
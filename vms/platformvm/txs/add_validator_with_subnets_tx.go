@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ ValidatorTx = (*AddValidatorWithSubnetsTx)(nil)
+
+	errNotPrimaryNetworkValidator    = errors.New("validator must be added to the primary network")
+	errNoSubnetValidators            = errors.New("no subnet validators")
+	errNotSubnetValidatorTx          = errors.New("not an AddSubnetValidatorTx")
+	errSubnetValidatorNodeIDMismatch = errors.New("subnet validator nodeID doesn't match the primary network validator")
+	errDuplicateSubnetValidator      = errors.New("duplicate subnet validator")
+	errSubnetValidatorHasFunds       = errors.New("subnet validator must not consume or produce UTXOs")
+)
+
+// AddValidatorWithSubnetsTx is an unsigned addValidatorWithSubnetsTx. It adds
+// a primary network validator and validators of one or more permissioned
+// subnets for the same node in a single tx, so the subnet validators don't
+// need to wait for the primary network validator to be accepted.
+//
+// Each subnet validator is a signed AddSubnetValidatorTx, authorized by the
+// subnet's owners, that is accepted along with this tx. The fees of the
+// primary network validator and of the subnet validators are all paid by the
+// inputs of this tx.
+type AddValidatorWithSubnetsTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// The subnet validators of the node. Each must be an AddSubnetValidatorTx
+	// for [Validator.NodeID] that doesn't consume or produce any UTXOs.
+	SubnetValidators []*Tx `serialize:"true" json:"subnetValidators"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddValidatorWithSubnetsTx] and of its subnet validators. Also sets the
+// [ctx] to the given [vm.ctx] so that the addresses can be json marshalled
+// into human readable format
+func (tx *AddValidatorWithSubnetsTx) InitCtx(ctx *snow.Context) {
+	tx.AddPermissionlessValidatorTx.InitCtx(ctx)
+	for _, subnetValidatorTx := range tx.SubnetValidators {
+		subnetValidatorTx.Unsigned.InitCtx(ctx)
+	}
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddValidatorWithSubnetsTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet != constants.PrimaryNetworkID:
+		return errNotPrimaryNetworkValidator
+	case len(tx.SubnetValidators) == 0:
+		return errNoSubnetValidators
+	}
+
+	subnetIDs := set.NewSet[ids.ID](len(tx.SubnetValidators))
+	for i, subnetValidatorTx := range tx.SubnetValidators {
+		if subnetValidatorTx == nil {
+			return fmt.Errorf("subnet validator %d: %w", i, ErrNilSignedTx)
+		}
+		subnetValidator, ok := subnetValidatorTx.Unsigned.(*AddSubnetValidatorTx)
+		if !ok {
+			return fmt.Errorf("subnet validator %d: %w: %T", i, errNotSubnetValidatorTx, subnetValidatorTx.Unsigned)
+		}
+
+		// The subnet validators are parsed as part of this tx, so their IDs
+		// and bytes must be populated before they can be verified.
+		if err := subnetValidatorTx.Initialize(Codec); err != nil {
+			return fmt.Errorf("subnet validator %d: %w", i, err)
+		}
+		if err := subnetValidatorTx.SyntacticVerify(ctx); err != nil {
+			return fmt.Errorf("subnet validator %d: %w", i, err)
+		}
+
+		switch {
+		case subnetValidator.Validator.NodeID != tx.Validator.NodeID:
+			return fmt.Errorf("subnet validator %d: %w", i, errSubnetValidatorNodeIDMismatch)
+		case subnetIDs.Contains(subnetValidator.Subnet):
+			return fmt.Errorf("%w: %s", errDuplicateSubnetValidator, subnetValidator.Subnet)
+		case len(subnetValidator.Ins) != 0 || len(subnetValidator.Outs) != 0:
+			return fmt.Errorf("subnet validator %d: %w", i, errSubnetValidatorHasFunds)
+		}
+		subnetIDs.Add(subnetValidator.Subnet)
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddValidatorWithSubnetsTx) Visit(visitor Visitor) error {
+	return visitor.AddValidatorWithSubnetsTx(tx)
+}
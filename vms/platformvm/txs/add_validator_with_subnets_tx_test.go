@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddValidatorWithSubnetsTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	// A subnet validator that passes syntactic verification.
+	newSubnetValidator := func(subnetID ids.ID) *AddSubnetValidatorTx {
+		return &AddSubnetValidatorTx{
+			BaseTx: validBaseTx,
+			SubnetValidator: SubnetValidator{
+				Validator: Validator{
+					NodeID: nodeID,
+					End:    1,
+					Wght:   1,
+				},
+				Subnet: subnetID,
+			},
+			SubnetAuth: &secp256k1fx.Input{},
+		}
+	}
+
+	var (
+		subnetID = ids.GenerateTestID()
+
+		wrongNodeID = newSubnetValidator(ids.GenerateTestID())
+		withFunds   = newSubnetValidator(ids.GenerateTestID())
+	)
+	wrongNodeID.Validator.NodeID = ids.GenerateTestNodeID()
+	withFunds.Outs = []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name             string
+		subnetID         ids.ID
+		subnetValidators []UnsignedTx
+		expectedErr      error
+	}{
+		{
+			name:             "not primary network",
+			subnetID:         ids.GenerateTestID(),
+			subnetValidators: []UnsignedTx{newSubnetValidator(subnetID)},
+			expectedErr:      errNotPrimaryNetworkValidator,
+		},
+		{
+			name:             "no subnet validators",
+			subnetID:         constants.PrimaryNetworkID,
+			subnetValidators: nil,
+			expectedErr:      errNoSubnetValidators,
+		},
+		{
+			name:             "not a subnet validator",
+			subnetID:         constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{&BaseTx{BaseTx: validBaseTx.BaseTx}},
+			expectedErr:      errNotSubnetValidatorTx,
+		},
+		{
+			name:     "invalid subnet validator",
+			subnetID: constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{
+				newSubnetValidator(constants.PrimaryNetworkID),
+			},
+			expectedErr: errAddPrimaryNetworkValidator,
+		},
+		{
+			name:             "nodeID mismatch",
+			subnetID:         constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{wrongNodeID},
+			expectedErr:      errSubnetValidatorNodeIDMismatch,
+		},
+		{
+			name:     "duplicate subnet",
+			subnetID: constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{
+				newSubnetValidator(subnetID),
+				newSubnetValidator(subnetID),
+			},
+			expectedErr: errDuplicateSubnetValidator,
+		},
+		{
+			name:             "subnet validator with funds",
+			subnetID:         constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{withFunds},
+			expectedErr:      errSubnetValidatorHasFunds,
+		},
+		{
+			name:             "invalid validator tx",
+			subnetID:         constants.PrimaryNetworkID,
+			subnetValidators: []UnsignedTx{newSubnetValidator(subnetID)},
+			expectedErr:      errNoStake,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subnetValidatorTxs := make([]*Tx, len(test.subnetValidators))
+			for i, subnetValidator := range test.subnetValidators {
+				subnetValidatorTxs[i] = &Tx{Unsigned: subnetValidator}
+			}
+			tx := &AddValidatorWithSubnetsTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+					Validator: Validator{
+						NodeID: nodeID,
+					},
+					Subnet: test.subnetID,
+				},
+				SubnetValidators: subnetValidatorTxs,
+			}
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	t.Run("nil subnet validator", func(t *testing.T) {
+		tx := &AddValidatorWithSubnetsTx{
+			AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+				BaseTx: validBaseTx,
+				Subnet: constants.PrimaryNetworkID,
+			},
+			SubnetValidators: []*Tx{nil},
+		}
+		err := tx.SyntacticVerify(ctx)
+		require.ErrorIs(t, err, ErrNilSignedTx)
+	})
+}
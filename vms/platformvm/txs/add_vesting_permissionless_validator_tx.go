@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ ValidatorTx = (*AddVestingPermissionlessValidatorTx)(nil)
+
+	errWrongNumberOfUnlockTimes  = errors.New("wrong number of stake unlock times")
+	errUnlockBeforeStakingEnds   = errors.New("stake unlocks before the validator stops staking")
+	errUnsupportedStakeOutput    = errors.New("unsupported stake output")
+	errUnsupportedLockedStakeOut = errors.New("unsupported stakeable locked output")
+)
+
+// AddVestingPermissionlessValidatorTx is an unsigned
+// addVestingPermissionlessValidatorTx. It adds a permissionless validator
+// whose stake vests according to a schedule once the validator stops staking,
+// such as for foundation or treasury staking programs.
+//
+// When the validator is removed, [StakeOuts][i] is returned as an output that
+// can't be spent before [StakeUnlockTimes][i]. A schedule that unlocks the
+// stake in several tranches is declared by splitting the stake into one stake
+// output per tranche.
+type AddVestingPermissionlessValidatorTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// Unix time, in seconds, that each of the stake outputs unlocks at after
+	// being returned. Must be at least the end time of the validator.
+	StakeUnlockTimes []uint64 `serialize:"true" json:"stakeUnlockTimes"`
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddVestingPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.StakeUnlockTimes) != len(tx.StakeOuts):
+		return fmt.Errorf("%w: %d != %d", errWrongNumberOfUnlockTimes, len(tx.StakeUnlockTimes), len(tx.StakeOuts))
+	}
+
+	for i, unlockTime := range tx.StakeUnlockTimes {
+		if unlockTime < tx.Validator.End {
+			return fmt.Errorf("%w: stake output %d unlocks at %d < %d", errUnlockBeforeStakingEnds, i, unlockTime, tx.Validator.End)
+		}
+		// Ensure that the stake can be locked when it is returned, so that
+		// removing the validator can't fail.
+		if _, err := LockStake(tx.StakeOuts[i].Out, unlockTime); err != nil {
+			return fmt.Errorf("stake output %d: %w", i, err)
+		}
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddVestingPermissionlessValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddVestingPermissionlessValidatorTx(tx)
+}
+
+// LockStake returns a copy of the stake output [out] that can't be spent
+// before [unlockTime]. If [out] is already locked past [unlockTime], its
+// locktime is kept.
+func LockStake(out avax.TransferableOut, unlockTime uint64) (avax.TransferableOut, error) {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		lockedOut := *out
+		lockedOut.Locktime = max(lockedOut.Locktime, unlockTime)
+		return &lockedOut, nil
+	case *stakeable.LockOut:
+		// Only a single level of stakeable locking is valid.
+		innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", errUnsupportedLockedStakeOut, out.TransferableOut)
+		}
+		lockedInnerOut, err := LockStake(innerOut, unlockTime)
+		if err != nil {
+			return nil, err
+		}
+		return &stakeable.LockOut{
+			Locktime:        out.Locktime,
+			TransferableOut: lockedInnerOut,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedStakeOutput, out)
+	}
+}
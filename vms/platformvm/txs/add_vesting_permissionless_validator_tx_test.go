@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddVestingPermissionlessValidatorTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		endTime   = uint64(1_000)
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	newStakeOut := func(out avax.TransferableOut) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: ids.GenerateTestID()},
+			Out:   out,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		stakeOuts   []*avax.TransferableOutput
+		unlockTimes []uint64
+		expectedErr error
+	}{
+		{
+			name:        "missing unlock time",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(&secp256k1fx.TransferOutput{})},
+			unlockTimes: nil,
+			expectedErr: errWrongNumberOfUnlockTimes,
+		},
+		{
+			name:        "extra unlock time",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(&secp256k1fx.TransferOutput{})},
+			unlockTimes: []uint64{endTime, endTime},
+			expectedErr: errWrongNumberOfUnlockTimes,
+		},
+		{
+			name:        "unlocks before end time",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(&secp256k1fx.TransferOutput{})},
+			unlockTimes: []uint64{endTime - 1},
+			expectedErr: errUnlockBeforeStakingEnds,
+		},
+		{
+			name:        "unsupported stake output",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(&avax.TestTransferable{})},
+			unlockTimes: []uint64{endTime},
+			expectedErr: errUnsupportedStakeOutput,
+		},
+		{
+			name: "unsupported stakeable locked output",
+			stakeOuts: []*avax.TransferableOutput{newStakeOut(&stakeable.LockOut{
+				TransferableOut: &avax.TestTransferable{},
+			})},
+			unlockTimes: []uint64{endTime},
+			expectedErr: errUnsupportedLockedStakeOut,
+		},
+		{
+			name:        "invalid validator tx",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(&secp256k1fx.TransferOutput{})},
+			unlockTimes: []uint64{endTime},
+			expectedErr: errEmptyNodeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := &AddVestingPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+					Validator: Validator{
+						End: endTime,
+					},
+					StakeOuts: test.stakeOuts,
+				},
+				StakeUnlockTimes: test.unlockTimes,
+			}
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestLockStake(t *testing.T) {
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	newTransferOutput := func(locktime uint64) *secp256k1fx.TransferOutput {
+		owners := owners
+		owners.Locktime = locktime
+		return &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: owners,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		outF        func() avax.TransferableOut
+		unlockTime  uint64
+		expectedOut avax.TransferableOut
+	}{
+		{
+			name: "unlocked output",
+			outF: func() avax.TransferableOut {
+				return newTransferOutput(0)
+			},
+			unlockTime:  10,
+			expectedOut: newTransferOutput(10),
+		},
+		{
+			name: "output locked past unlock time",
+			outF: func() avax.TransferableOut {
+				return newTransferOutput(20)
+			},
+			unlockTime:  10,
+			expectedOut: newTransferOutput(20),
+		},
+		{
+			name: "stakeable locked output",
+			outF: func() avax.TransferableOut {
+				return &stakeable.LockOut{
+					Locktime:        5,
+					TransferableOut: newTransferOutput(0),
+				}
+			},
+			unlockTime: 10,
+			expectedOut: &stakeable.LockOut{
+				Locktime:        5,
+				TransferableOut: newTransferOutput(10),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			out := test.outF()
+			lockedOut, err := LockStake(out, test.unlockTime)
+			require.NoError(err)
+			require.Equal(test.expectedOut, lockedOut)
+
+			// The stake output of the tx must not be modified.
+			require.Equal(test.outF(), out)
+		})
+	}
+}
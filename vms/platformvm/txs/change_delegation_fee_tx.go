@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+var _ UnsignedTx = (*ChangeDelegationFeeTx)(nil)
+
+// ChangeDelegationFeeTx is an unsigned changeDelegationFeeTx
+type ChangeDelegationFeeTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node whose delegation fee is being changed
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// ID of the subnet the node is validating
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Fee the validator charges delegators whose staking period starts after
+	// this tx is accepted, as a percentage times 10,000
+	DelegationShares uint32 `serialize:"true" json:"shares"`
+	// Proves that the issuer is the owner of the validator's validation
+	// rewards
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+func (tx *ChangeDelegationFeeTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	case tx.DelegationShares > reward.PercentDenominator:
+		return errTooManyShares
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.ValidatorAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ChangeDelegationFeeTx) Visit(visitor Visitor) error {
+	return visitor.ChangeDelegationFeeTx(tx)
+}
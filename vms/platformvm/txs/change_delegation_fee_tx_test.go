@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+var errInvalidValidatorAuth = errors.New("invalid validator auth")
+
+func TestChangeDelegationFeeTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *ChangeDelegationFeeTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *ChangeDelegationFeeTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *ChangeDelegationFeeTx {
+				return &ChangeDelegationFeeTx{BaseTx: verifiedBaseTx}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *ChangeDelegationFeeTx {
+				return &ChangeDelegationFeeTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "too many shares",
+			txFunc: func(*gomock.Controller) *ChangeDelegationFeeTx {
+				return &ChangeDelegationFeeTx{
+					BaseTx:           validBaseTx,
+					NodeID:           ids.GenerateTestNodeID(),
+					Subnet:           constants.PrimaryNetworkID,
+					DelegationShares: reward.PercentDenominator + 1,
+				}
+			},
+			expectedErr: errTooManyShares,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *ChangeDelegationFeeTx {
+				return &ChangeDelegationFeeTx{
+					BaseTx: invalidBaseTx,
+					NodeID: ids.GenerateTestNodeID(),
+					Subnet: constants.PrimaryNetworkID,
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid validatorAuth",
+			txFunc: func(ctrl *gomock.Controller) *ChangeDelegationFeeTx {
+				// This ValidatorAuth fails verification.
+				invalidValidatorAuth := verify.NewMockVerifiable(ctrl)
+				invalidValidatorAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &ChangeDelegationFeeTx{
+					BaseTx:        validBaseTx,
+					NodeID:        ids.GenerateTestNodeID(),
+					Subnet:        constants.PrimaryNetworkID,
+					ValidatorAuth: invalidValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *ChangeDelegationFeeTx {
+				// This ValidatorAuth passes verification.
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &ChangeDelegationFeeTx{
+					BaseTx:           validBaseTx,
+					NodeID:           ids.GenerateTestNodeID(),
+					Subnet:           constants.PrimaryNetworkID,
+					DelegationShares: reward.PercentDenominator,
+					ValidatorAuth:    validValidatorAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
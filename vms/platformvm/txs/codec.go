@@ -42,7 +42,10 @@ func init() {
 
 		c.SkipRegistrations(4)
 
-		errs.Add(RegisterDUnsignedTxsTypes(c))
+		errs.Add(
+			RegisterDUnsignedTxsTypes(c),
+			RegisterEUnsignedTxsTypes(c),
+		)
 	}
 
 	Codec = codec.NewDefaultManager()
@@ -109,3 +112,21 @@ func RegisterDUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
 		targetCodec.RegisterType(&BaseTx{}),
 	)
 }
+
+func RegisterEUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
+	return utils.Err(
+		targetCodec.RegisterType(&ChangeDelegationFeeTx{}),
+		targetCodec.RegisterType(&RotateValidatorNodeTx{}),
+		targetCodec.RegisterType(&AddCappedPermissionlessValidatorTx{}),
+		targetCodec.RegisterType(&SetValidatorBLSKeyTx{}),
+		targetCodec.RegisterType(&ReportMisbehaviorTx{}),
+		targetCodec.RegisterType(&ConflictingAttestations{}),
+		targetCodec.RegisterType(&SetSubnetStakingParamsTx{}),
+		targetCodec.RegisterType(&ReduceSubnetValidatorWeightTx{}),
+		targetCodec.RegisterType(&AddSplitRewardsPermissionlessValidatorTx{}),
+		targetCodec.RegisterType(&AddValidatorWithSubnetsTx{}),
+		targetCodec.RegisterType(&AddVestingPermissionlessValidatorTx{}),
+		targetCodec.RegisterType(&SetSubnetValidatorEpochTx{}),
+		targetCodec.RegisterType(&AddPermissionlessValidatorWithMetadataTx{}),
+	)
+}
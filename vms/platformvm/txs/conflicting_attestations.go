@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+var (
+	_ Evidence = (*ConflictingAttestations)(nil)
+
+	errInvalidAttestationSigLen    = errors.New("invalid attestation signature length")
+	errAttestationsDontConflict    = errors.New("attestations don't conflict")
+	errWrongAttestationNetworkID   = errors.New("attestation was signed for a different network")
+	errInvalidAttestationSignature = errors.New("invalid attestation signature")
+)
+
+// Attestation is an unsigned attestation and the signature of a single
+// validator over it.
+type Attestation struct {
+	UnsignedAttestation types.JSONByteSlice `serialize:"true" json:"unsignedAttestation"`
+	Signature           types.JSONByteSlice `serialize:"true" json:"signature"`
+}
+
+// ConflictingAttestations proves that [NodeID] attested to two different hashes
+// at the same height of the same chain.
+//
+// Attestations are domain separated from warp messages and honest validators
+// refuse to attest to a second hash at a height they already attested to. See
+// the attestation package.
+type ConflictingAttestations struct {
+	NodeID       ids.NodeID     `serialize:"true" json:"nodeID"`
+	Attestations [2]Attestation `serialize:"true" json:"attestations"`
+}
+
+func (e *ConflictingAttestations) Accused() ids.NodeID {
+	return e.NodeID
+}
+
+func (e *ConflictingAttestations) Verify() error {
+	switch {
+	case e == nil:
+		return errNilEvidence
+	case e.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	_, err := e.parse()
+	return err
+}
+
+func (e *ConflictingAttestations) VerifyMisbehavior(networkID uint32, pk *bls.PublicKey) error {
+	msgs, err := e.parse()
+	if err != nil {
+		return err
+	}
+
+	for i, msg := range msgs {
+		if msg.NetworkID != networkID {
+			return fmt.Errorf("%w: %d != %d",
+				errWrongAttestationNetworkID,
+				msg.NetworkID,
+				networkID,
+			)
+		}
+
+		sig, err := bls.SignatureFromBytes(e.Attestations[i].Signature)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidAttestationSignature, err)
+		}
+		if !bls.Verify(pk, sig, msg.Bytes()) {
+			return errInvalidAttestationSignature
+		}
+	}
+	return nil
+}
+
+// parse returns the unsigned attestations if they conflict.
+func (e *ConflictingAttestations) parse() ([2]*attestation.Unsigned, error) {
+	var msgs [2]*attestation.Unsigned
+	for i, a := range e.Attestations {
+		if len(a.Signature) != bls.SignatureLen {
+			return msgs, fmt.Errorf("%w: %d != %d",
+				errInvalidAttestationSigLen,
+				len(a.Signature),
+				bls.SignatureLen,
+			)
+		}
+
+		msg, err := attestation.Parse(a.UnsignedAttestation)
+		if err != nil {
+			return msgs, err
+		}
+		msgs[i] = msg
+	}
+
+	if !msgs[0].Conflicts(msgs[1]) {
+		return msgs, errAttestationsDontConflict
+	}
+	return msgs, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+func signAttestation(t *testing.T, sk *bls.SecretKey, msg attestation.Unsigned) Attestation {
+	t.Helper()
+
+	msgBytes := msg.Bytes()
+	return Attestation{
+		UnsignedAttestation: msgBytes,
+		Signature:           bls.SignatureToBytes(bls.Sign(sk, msgBytes)),
+	}
+}
+
+func TestConflictingAttestations(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		networkID = uint32(1337)
+		nodeID    = ids.GenerateTestNodeID()
+		pk        = bls.PublicFromSecretKey(sk)
+		original  = attestation.Unsigned{
+			NetworkID: networkID,
+			ChainID:   ids.GenerateTestID(),
+			Height:    10,
+			Hash:      ids.GenerateTestID(),
+		}
+		conflicting = original
+	)
+	conflicting.Hash = ids.GenerateTestID()
+
+	tests := []struct {
+		name              string
+		evidence          *ConflictingAttestations
+		expectedVerifyErr error
+		expectedErr       error
+	}{
+		{
+			name:              "nil evidence",
+			evidence:          nil,
+			expectedVerifyErr: errNilEvidence,
+		},
+		{
+			name: "empty nodeID",
+			evidence: &ConflictingAttestations{
+				Attestations: [2]Attestation{
+					signAttestation(t, sk, original),
+					signAttestation(t, sk, conflicting),
+				},
+			},
+			expectedVerifyErr: errEmptyNodeID,
+		},
+		{
+			name: "conflicting attestations",
+			evidence: &ConflictingAttestations{
+				NodeID: nodeID,
+				Attestations: [2]Attestation{
+					signAttestation(t, sk, original),
+					signAttestation(t, sk, conflicting),
+				},
+			},
+		},
+		{
+			name: "same hash",
+			evidence: &ConflictingAttestations{
+				NodeID: nodeID,
+				Attestations: [2]Attestation{
+					signAttestation(t, sk, original),
+					signAttestation(t, sk, original),
+				},
+			},
+			expectedVerifyErr: errAttestationsDontConflict,
+		},
+		{
+			name: "different heights",
+			evidence: func() *ConflictingAttestations {
+				other := conflicting
+				other.Height++
+				return &ConflictingAttestations{
+					NodeID: nodeID,
+					Attestations: [2]Attestation{
+						signAttestation(t, sk, original),
+						signAttestation(t, sk, other),
+					},
+				}
+			}(),
+			expectedVerifyErr: errAttestationsDontConflict,
+		},
+		{
+			name: "different chains",
+			evidence: func() *ConflictingAttestations {
+				other := conflicting
+				other.ChainID = ids.GenerateTestID()
+				return &ConflictingAttestations{
+					NodeID: nodeID,
+					Attestations: [2]Attestation{
+						signAttestation(t, sk, original),
+						signAttestation(t, sk, other),
+					},
+				}
+			}(),
+			expectedVerifyErr: errAttestationsDontConflict,
+		},
+		{
+			// Honest validators sign every warp message their chains produce,
+			// so two warp messages that happen to look like conflicting
+			// attestations must never be accepted as evidence.
+			name: "honestly signed warp messages",
+			evidence: func() *ConflictingAttestations {
+				var (
+					signer   = warp.NewSigner(sk, networkID, original.ChainID)
+					evidence = &ConflictingAttestations{
+						NodeID: nodeID,
+					}
+				)
+				for i, hash := range []ids.ID{original.Hash, conflicting.Hash} {
+					p := make([]byte, wrappers.LongLen+ids.IDLen)
+					binary.BigEndian.PutUint64(p, original.Height)
+					copy(p[wrappers.LongLen:], hash[:])
+
+					call, err := payload.NewAddressedCall([]byte{1, 2, 3}, p)
+					require.NoError(t, err)
+					msg, err := warp.NewUnsignedMessage(networkID, original.ChainID, call.Bytes())
+					require.NoError(t, err)
+					sig, err := signer.Sign(msg)
+					require.NoError(t, err)
+
+					evidence.Attestations[i] = Attestation{
+						UnsignedAttestation: msg.Bytes(),
+						Signature:           sig,
+					}
+				}
+				return evidence
+			}(),
+			expectedVerifyErr: attestation.ErrInvalidPrefix,
+		},
+		{
+			name: "invalid signature length",
+			evidence: func() *ConflictingAttestations {
+				a := signAttestation(t, sk, conflicting)
+				a.Signature = a.Signature[1:]
+				return &ConflictingAttestations{
+					NodeID: nodeID,
+					Attestations: [2]Attestation{
+						signAttestation(t, sk, original),
+						a,
+					},
+				}
+			}(),
+			expectedVerifyErr: errInvalidAttestationSigLen,
+		},
+		{
+			name: "signed by another key",
+			evidence: &ConflictingAttestations{
+				NodeID: nodeID,
+				Attestations: [2]Attestation{
+					signAttestation(t, sk, original),
+					signAttestation(t, otherSK, conflicting),
+				},
+			},
+			expectedErr: errInvalidAttestationSignature,
+		},
+		{
+			name: "wrong network",
+			evidence: func() *ConflictingAttestations {
+				original := original
+				original.NetworkID++
+				conflicting := conflicting
+				conflicting.NetworkID++
+				return &ConflictingAttestations{
+					NodeID: nodeID,
+					Attestations: [2]Attestation{
+						signAttestation(t, sk, original),
+						signAttestation(t, sk, conflicting),
+					},
+				}
+			}(),
+			expectedErr: errWrongAttestationNetworkID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.evidence.Verify()
+			require.ErrorIs(t, err, test.expectedVerifyErr)
+			if test.expectedVerifyErr != nil {
+				return
+			}
+
+			require.Equal(t, nodeID, test.evidence.Accused())
+			err = test.evidence.VerifyMisbehavior(networkID, pk)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
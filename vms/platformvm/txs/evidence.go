@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+// Evidence proves that a validator misbehaved.
+//
+// New kinds of misbehavior can be reported by registering additional Evidence
+// implementations with the codec.
+type Evidence interface {
+	// Verify returns nil iff the evidence is well-formed.
+	verify.Verifiable
+
+	// Accused returns the node that misbehaved.
+	Accused() ids.NodeID
+
+	// VerifyMisbehavior returns nil iff the evidence proves that the holder of
+	// [pk] misbehaved on the network with ID [networkID].
+	VerifyMisbehavior(networkID uint32, pk *bls.PublicKey) error
+}
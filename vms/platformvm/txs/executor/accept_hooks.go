@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// AcceptHooks holds the callbacks that are fired when a block containing a tx
+// of a given type is accepted.
+//
+// Hooks fire after the block's state changes are committed, in the order the
+// txs appear in the block and, for each tx, in the order the hooks were
+// registered. Proposal txs only fire hooks if they are committed. Hooks are
+// called while the chain's context lock is held, so they must not block.
+type AcceptHooks struct {
+	lock  sync.RWMutex
+	hooks map[reflect.Type][]func(*txs.Tx)
+}
+
+func NewAcceptHooks() *AcceptHooks {
+	return &AcceptHooks{
+		hooks: make(map[reflect.Type][]func(*txs.Tx)),
+	}
+}
+
+// RegisterAcceptHook registers [hook] to be called with every accepted tx
+// whose unsigned tx is of type [T].
+func RegisterAcceptHook[T txs.UnsignedTx](h *AcceptHooks, hook func(tx *txs.Tx, utx T)) {
+	txType := reflect.TypeOf((*T)(nil)).Elem()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.hooks[txType] = append(h.hooks[txType], func(tx *txs.Tx) {
+		hook(tx, tx.Unsigned.(T))
+	})
+}
+
+// OnAccept returns a function that fires the hooks registered for the type of
+// [tx]. If there are no such hooks, or [h] is nil, nil is returned.
+//
+// The hooks are captured when OnAccept is called, so hooks registered
+// afterwards are not fired for [tx].
+func (h *AcceptHooks) OnAccept(tx *txs.Tx) func() {
+	if h == nil {
+		return nil
+	}
+
+	h.lock.RLock()
+	hooks := h.hooks[reflect.TypeOf(tx.Unsigned)]
+	h.lock.RUnlock()
+
+	if len(hooks) == 0 {
+		return nil
+	}
+	return func() {
+		for _, hook := range hooks {
+			hook(tx)
+		}
+	}
+}
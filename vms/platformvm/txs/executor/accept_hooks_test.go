@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestAcceptHooks(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		hooks         = NewAcceptHooks()
+		createChainTx = &txs.Tx{Unsigned: &txs.CreateChainTx{ChainName: "chain"}}
+		baseTx        = &txs.Tx{Unsigned: &txs.BaseTx{}}
+		calls         []string
+	)
+
+	// Nil registries don't fire any hooks.
+	var nilHooks *AcceptHooks
+	require.Nil(nilHooks.OnAccept(createChainTx))
+
+	// Txs without registered hooks don't fire any hooks.
+	require.Nil(hooks.OnAccept(createChainTx))
+
+	RegisterAcceptHook(hooks, func(tx *txs.Tx, utx *txs.CreateChainTx) {
+		require.Equal(createChainTx, tx)
+		calls = append(calls, "first "+utx.ChainName)
+	})
+	RegisterAcceptHook(hooks, func(_ *txs.Tx, utx *txs.CreateChainTx) {
+		calls = append(calls, "second "+utx.ChainName)
+	})
+
+	require.Nil(hooks.OnAccept(baseTx))
+
+	onAccept := hooks.OnAccept(createChainTx)
+	require.NotNil(onAccept)
+
+	// Hooks registered after OnAccept is called aren't fired.
+	RegisterAcceptHook(hooks, func(*txs.Tx, *txs.CreateChainTx) {
+		calls = append(calls, "third")
+	})
+
+	onAccept()
+	require.Equal([]string{"first chain", "second chain"}, calls)
+}
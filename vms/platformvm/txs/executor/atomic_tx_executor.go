@@ -80,6 +80,54 @@ func (*AtomicTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*AtomicTxExecutor) ChangeDelegationFeeTx(*txs.ChangeDelegationFeeTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) RotateValidatorNodeTx(*txs.RotateValidatorNodeTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) SetValidatorBLSKeyTx(*txs.SetValidatorBLSKeyTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ReportMisbehaviorTx(*txs.ReportMisbehaviorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) SetSubnetStakingParamsTx(*txs.SetSubnetStakingParamsTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ReduceSubnetValidatorWeightTx(*txs.ReduceSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddSplitRewardsPermissionlessValidatorTx(*txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddValidatorWithSubnetsTx(*txs.AddValidatorWithSubnetsTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddVestingPermissionlessValidatorTx(*txs.AddVestingPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) SetSubnetValidatorEpochTx(*txs.SetSubnetValidatorEpochTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return ErrWrongTxType
+}
+
 func (e *AtomicTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	return e.atomicTx(tx)
 }
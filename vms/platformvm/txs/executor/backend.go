@@ -4,6 +4,8 @@
 package executor
 
 import (
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/utils"
@@ -11,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 )
 
@@ -23,4 +26,29 @@ type Backend struct {
 	Uptimes      uptime.Calculator
 	Rewards      reward.Calculator
 	Bootstrapped *utils.Atomic[bool]
+	// VerifiedTxs holds the IDs of txs that recently passed syntactic
+	// verification. If nil, txs are always syntactically verified.
+	VerifiedTxs cache.Cacher[ids.ID, struct{}]
+	// AcceptHooks are fired when blocks containing txs are accepted. If nil,
+	// no hooks are fired.
+	AcceptHooks *AcceptHooks
+}
+
+// SyntacticVerify verifies [tx] unless a tx with the same ID recently passed
+// syntactic verification. Because the ID commits to the bytes of the tx, such
+// a tx is identical to [tx].
+func (b *Backend) SyntacticVerify(tx *txs.Tx) error {
+	if b.VerifiedTxs == nil {
+		return tx.SyntacticVerify(b.Ctx)
+	}
+
+	txID := tx.ID()
+	if _, verified := b.VerifiedTxs.Get(txID); verified {
+		return nil
+	}
+	if err := tx.SyntacticVerify(b.Ctx); err != nil {
+		return err
+	}
+	b.VerifiedTxs.Put(txID, struct{}{})
+	return nil
 }
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestBackendSyntacticVerify(t *testing.T) {
+	require := require.New(t)
+
+	ctx := &snow.Context{
+		NetworkID: 1337,
+		ChainID:   ids.GenerateTestID(),
+	}
+	newTx := func() *txs.Tx {
+		tx := &txs.Tx{
+			Unsigned: &txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+				},
+			},
+		}
+		require.NoError(tx.Initialize(txs.Codec))
+		return tx
+	}
+
+	backend := &Backend{
+		Ctx:         ctx,
+		VerifiedTxs: &cache.LRU[ids.ID, struct{}]{Size: 1},
+	}
+	require.NoError(backend.SyntacticVerify(newTx()))
+
+	// Verifying the tx under a different network would fail, so if the
+	// verification of a re-parsed copy of the tx passes, it must have been
+	// skipped.
+	backend.Ctx = &snow.Context{
+		NetworkID: ctx.NetworkID + 1,
+		ChainID:   ctx.ChainID,
+	}
+	require.NoError(backend.SyntacticVerify(newTx()))
+
+	backend.VerifiedTxs.Flush()
+	err := backend.SyntacticVerify(newTx())
+	require.ErrorIs(err, avax.ErrWrongNetworkID)
+
+	// Txs that failed verification aren't cached.
+	err = backend.SyntacticVerify(newTx())
+	require.ErrorIs(err, avax.ErrWrongNetworkID)
+
+	// Without a cache, txs are always verified.
+	backend.VerifiedTxs = nil
+	err = backend.SyntacticVerify(newTx())
+	require.ErrorIs(err, avax.ErrWrongNetworkID)
+}
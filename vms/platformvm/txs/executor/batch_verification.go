@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var ErrConflictingTxs = errors.New("txs consume the same atomic inputs")
+
+// TxError is returned by VerifyStandardTxs when a tx fails execution.
+type TxError struct {
+	TxID ids.ID
+	Err  error
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("tx %s failed execution: %s", e.TxID, e.Err)
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// publicKeyRecoverer is implemented by fxs that cache recovered public keys,
+// such as *secp256k1fx.Fx.
+type publicKeyRecoverer interface {
+	RecoverPublicKeyFromHash(hash, sig []byte) (*secp256k1.PublicKey, error)
+}
+
+// VerifyStandardTxs executes [txs], in order, on top of [state] and returns
+// the union of the atomic inputs they consume, their merged atomic requests
+// and a function that performs their accept time side effects, which may be
+// nil.
+//
+// Before execution, the parts of verification that don't depend on the state
+// are performed for all the txs concurrently. Their results are cached so that
+// the sequential execution doesn't repeat them. Errors are only reported by the
+// sequential execution, so the returned error is the same as if the txs were
+// executed one by one.
+//
+// If a tx fails execution, a *TxError is returned. If the txs consume the same
+// atomic input, ErrConflictingTxs is returned.
+func VerifyStandardTxs(
+	backend *Backend,
+	state state.Diff,
+	txs []*txs.Tx,
+) (set.Set[ids.ID], map[ids.ID]*atomic.Requests, func(), error) {
+	prepareTxs(backend, txs)
+
+	var (
+		inputs         set.Set[ids.ID]
+		funcs          = make([]func(), 0, len(txs))
+		atomicRequests = make(map[ids.ID]*atomic.Requests)
+		removedKeys    = make(map[ids.ID]set.Set[string])
+	)
+	for _, tx := range txs {
+		txExecutor := StandardTxExecutor{
+			Backend: backend,
+			State:   state,
+			Tx:      tx,
+		}
+		if err := tx.Unsigned.Visit(&txExecutor); err != nil {
+			return nil, nil, nil, &TxError{
+				TxID: tx.ID(),
+				Err:  err,
+			}
+		}
+		// ensure it doesn't overlap with current input batch
+		if inputs.Overlaps(txExecutor.Inputs) {
+			return nil, nil, nil, ErrConflictingTxs
+		}
+		// Add UTXOs to batch
+		inputs.Union(txExecutor.Inputs)
+
+		state.AddTx(tx, status.Committed)
+		if txExecutor.OnAccept != nil {
+			funcs = append(funcs, txExecutor.OnAccept)
+		}
+		if onAccept := backend.AcceptHooks.OnAccept(tx); onAccept != nil {
+			funcs = append(funcs, onAccept)
+		}
+
+		// Add/merge in the atomic requests represented by [tx]
+		mergeAtomicRequests(atomicRequests, removedKeys, txExecutor.AtomicRequests)
+	}
+
+	switch len(funcs) {
+	case 0:
+		return inputs, atomicRequests, nil, nil
+	case 1:
+		return inputs, atomicRequests, funcs[0], nil
+	default:
+		return inputs, atomicRequests, func() {
+			for _, f := range funcs {
+				f()
+			}
+		}, nil
+	}
+}
+
+// mergeAtomicRequests merges [txRequests] into [blkRequests], so that shared
+// memory is updated with a single Requests object per chain. [removedKeys]
+// tracks the keys of the RemoveRequests that were already merged for each
+// chain, so that each key is only removed once.
+//
+// The Requests objects of [txRequests] are never modified.
+func mergeAtomicRequests(
+	blkRequests map[ids.ID]*atomic.Requests,
+	removedKeys map[ids.ID]set.Set[string],
+	txRequests map[ids.ID]*atomic.Requests,
+) {
+	for chainID, txChainRequests := range txRequests {
+		chainRequests, exists := blkRequests[chainID]
+		if !exists {
+			chainRequests = &atomic.Requests{}
+			blkRequests[chainID] = chainRequests
+		}
+
+		chainRequests.PutRequests = append(chainRequests.PutRequests, txChainRequests.PutRequests...)
+
+		chainRemovedKeys := removedKeys[chainID]
+		for _, key := range txChainRequests.RemoveRequests {
+			if chainRemovedKeys.Contains(string(key)) {
+				continue
+			}
+			chainRemovedKeys.Add(string(key))
+			chainRequests.RemoveRequests = append(chainRequests.RemoveRequests, key)
+		}
+		removedKeys[chainID] = chainRemovedKeys
+	}
+}
+
+// prepareTxs syntactically verifies [batch] and recovers the public keys of
+// their signatures using a worker per CPU. The results are only used to
+// populate the caches of [backend]; any failure is reported when the tx is
+// executed.
+//
+// The public keys are only recovered once bootstrapped, as signatures aren't
+// checked before then. Because the recovery cache of the fx is bounded, keys
+// recovered for very large batches may be evicted before they are used.
+func prepareTxs(backend *Backend, batch []*txs.Tx) {
+	numWorkers := min(runtime.NumCPU(), len(batch))
+	if numWorkers <= 1 {
+		return
+	}
+
+	recoverer, _ := backend.Fx.(publicKeyRecoverer)
+	if backend.Bootstrapped == nil || !backend.Bootstrapped.Get() {
+		recoverer = nil
+	}
+
+	var (
+		wg      sync.WaitGroup
+		pending = make(chan *txs.Tx, len(batch))
+	)
+	for _, tx := range batch {
+		pending <- tx
+	}
+	close(pending)
+
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for tx := range pending {
+				if err := backend.SyntacticVerify(tx); err != nil || recoverer == nil {
+					continue
+				}
+				recoverPublicKeys(recoverer, tx)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func recoverPublicKeys(recoverer publicKeyRecoverer, tx *txs.Tx) {
+	txHash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	for _, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			continue
+		}
+		for _, sig := range cred.Sigs {
+			_, _ = recoverer.RecoverPublicKeyFromHash(txHash, sig[:])
+		}
+	}
+}
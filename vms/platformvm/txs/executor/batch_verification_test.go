@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/txstest"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestVerifyStandardTxs(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	// Charge a fee so that the txs consume UTXOs. Each tx is funded by a
+	// different key so that they don't conflict.
+	env.config.CreateAssetTxFee = defaultTxFee
+	builder := txstest.NewBuilder(env.ctx, env.config, env.state)
+	batch := make([]*txs.Tx, 3)
+	for i := range batch {
+		tx, err := builder.NewCreateSubnetTx(
+			&secp256k1fx.OutputOwners{},
+			[]*secp256k1.PrivateKey{preFundedKeys[i]},
+		)
+		require.NoError(err)
+		batch[i] = tx
+	}
+
+	diff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	_, _, _, err = VerifyStandardTxs(&env.backend, diff, batch)
+	require.NoError(err)
+
+	for _, tx := range batch {
+		utx := tx.Unsigned.(*txs.CreateSubnetTx)
+		require.True(utx.SyntacticallyVerified)
+		for _, in := range utx.Ins {
+			_, err := diff.GetUTXO(in.InputID())
+			require.ErrorIs(err, database.ErrNotFound)
+		}
+
+		_, txStatus, err := diff.GetTx(tx.ID())
+		require.NoError(err)
+		require.Equal(status.Committed, txStatus)
+	}
+
+	// Re-executing a tx fails because its inputs were already consumed.
+	diff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	_, _, _, err = VerifyStandardTxs(&env.backend, diff, []*txs.Tx{batch[0], batch[1], batch[0]})
+	var txErr *TxError
+	require.ErrorAs(err, &txErr)
+	require.Equal(batch[0].ID(), txErr.TxID)
+}
+
+func TestMergeAtomicRequests(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID0 = ids.GenerateTestID()
+		chainID1 = ids.GenerateTestID()
+
+		put0 = &atomic.Element{Key: []byte{0}}
+		put1 = &atomic.Element{Key: []byte{1}}
+		put2 = &atomic.Element{Key: []byte{2}}
+
+		tx0Requests = map[ids.ID]*atomic.Requests{
+			chainID0: {
+				RemoveRequests: [][]byte{{0}, {1}},
+				PutRequests:    []*atomic.Element{put0},
+			},
+		}
+		tx1Requests = map[ids.ID]*atomic.Requests{
+			chainID0: {
+				RemoveRequests: [][]byte{{1}, {2}},
+				PutRequests:    []*atomic.Element{put1},
+			},
+			chainID1: {
+				RemoveRequests: [][]byte{{1}},
+				PutRequests:    []*atomic.Element{put2},
+			},
+		}
+
+		blkRequests = make(map[ids.ID]*atomic.Requests)
+		removedKeys = make(map[ids.ID]set.Set[string])
+	)
+	mergeAtomicRequests(blkRequests, removedKeys, tx0Requests)
+	mergeAtomicRequests(blkRequests, removedKeys, tx1Requests)
+
+	require.Equal(
+		map[ids.ID]*atomic.Requests{
+			chainID0: {
+				RemoveRequests: [][]byte{{0}, {1}, {2}},
+				PutRequests:    []*atomic.Element{put0, put1},
+			},
+			chainID1: {
+				RemoveRequests: [][]byte{{1}},
+				PutRequests:    []*atomic.Element{put2},
+			},
+		},
+		blkRequests,
+	)
+
+	// The requests of the txs aren't modified.
+	require.Equal([][]byte{{0}, {1}}, tx0Requests[chainID0].RemoveRequests)
+	require.Equal([]*atomic.Element{put0}, tx0Requests[chainID0].PutRequests)
+}
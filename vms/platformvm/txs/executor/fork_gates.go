@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import "errors"
+
+// Names of the network upgrades reported by ForkGate.
+const (
+	BanffFork    = "Banff"
+	DurangoFork  = "Durango"
+	EUpgradeFork = "E"
+)
+
+// ForkGate returns the name of the network upgrade whose activation status
+// caused a tx to fail verification with [err]. The tx was either built for a
+// later upgrade that isn't active yet, or uses a feature that the upgrade
+// removed. If [err] isn't caused by a fork gate, false is returned.
+func ForkGate(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrProposedAddStakerTxAfterBanff),
+		errors.Is(err, ErrAdvanceTimeTxIssuedAfterBanff):
+		return BanffFork, true
+	case errors.Is(err, ErrDurangoUpgradeNotActive),
+		errors.Is(err, ErrAddValidatorTxPostDurango),
+		errors.Is(err, ErrAddDelegatorTxPostDurango),
+		errors.Is(err, errMissingStartTimePreDurango):
+		return DurangoFork, true
+	case errors.Is(err, ErrEUpgradeNotActive):
+		return EUpgradeFork, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import "time"
+
+// forkRules are the network upgrades that are active at a chain time.
+//
+// Staker txs are verified against the forkRules of the chain time of the state
+// they are verified on, rather than by querying the upgrade config throughout
+// verification. This keeps the upgrades a verification depends on explicit,
+// and lets a tx be verified against the rules of a future chain time by
+// verifying it on a state whose chain time was moved forward.
+type forkRules struct {
+	isApricotPhase3Active bool
+	isDurangoActive       bool
+	isEActive             bool
+}
+
+func getForkRules(backend *Backend, timestamp time.Time) forkRules {
+	upgrades := backend.Config.UpgradeConfig
+	return forkRules{
+		isApricotPhase3Active: upgrades.IsApricotPhase3Activated(timestamp),
+		isDurangoActive:       upgrades.IsDurangoActivated(timestamp),
+		isEActive:             upgrades.IsEActivated(timestamp),
+	}
+}
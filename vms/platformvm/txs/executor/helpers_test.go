@@ -63,6 +63,7 @@ const (
 var (
 	defaultMinStakingDuration = 24 * time.Hour
 	defaultMaxStakingDuration = 365 * 24 * time.Hour
+	defaultMaxFutureStartTime = 2 * 7 * 24 * time.Hour
 	defaultGenesisTime        = time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC)
 	defaultValidateStartTime  = defaultGenesisTime
 	defaultValidateEndTime    = defaultValidateStartTime.Add(20 * defaultMinStakingDuration)
@@ -286,7 +287,9 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 		MaxValidatorStake:      500 * units.MilliAvax,
 		MinDelegatorStake:      1 * units.MilliAvax,
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig: reward.Config{
 			MaxConsumptionRate: .12 * reward.PercentDenominator,
 			MinConsumptionRate: .10 * reward.PercentDenominator,
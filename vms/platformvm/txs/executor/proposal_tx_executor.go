@@ -13,15 +13,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
 const (
-	// Maximum future start time for staking/delegating
-	MaxFutureStartTime = 24 * 7 * 2 * time.Hour
-
 	// SyncBound is the synchrony bound used for safe decision making
 	SyncBound = 10 * time.Second
 
@@ -98,6 +96,54 @@ func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) ChangeDelegationFeeTx(*txs.ChangeDelegationFeeTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) RotateValidatorNodeTx(*txs.RotateValidatorNodeTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetValidatorBLSKeyTx(*txs.SetValidatorBLSKeyTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ReportMisbehaviorTx(*txs.ReportMisbehaviorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetSubnetStakingParamsTx(*txs.SetSubnetStakingParamsTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ReduceSubnetValidatorWeightTx(*txs.ReduceSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddSplitRewardsPermissionlessValidatorTx(*txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddValidatorWithSubnetsTx(*txs.AddValidatorWithSubnetsTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddVestingPermissionlessValidatorTx(*txs.AddVestingPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetSubnetValidatorEpochTx(*txs.SetSubnetValidatorEpochTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return ErrWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -345,6 +391,16 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		// Handle staker lifecycle.
 		e.OnCommitState.DeleteCurrentValidator(stakerToReward)
 		e.OnAbortState.DeleteCurrentValidator(stakerToReward)
+
+		// All of the validator's delegators have been removed, so its
+		// delegation fee changes are no longer needed.
+		e.OnCommitState.SetDelegationFeeChanges(stakerToReward.TxID, nil)
+		e.OnAbortState.SetDelegationFeeChanges(stakerToReward.TxID, nil)
+
+		// The validator's rewards have been settled, so its misbehavior
+		// report is no longer needed.
+		e.OnCommitState.SetMisbehaviorReport(stakerToReward.TxID, ids.Empty)
+		e.OnAbortState.SetMisbehaviorReport(stakerToReward.TxID, ids.Empty)
 	case txs.DelegatorTx:
 		if err := e.rewardDelegatorTx(uStakerTx, stakerToReward); err != nil {
 			return err
@@ -386,25 +442,36 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 	// Refund the stake only when validator is about to leave
 	// the staking set
 	for i, out := range stake {
+		refund, err := stakeRefund(uValidatorTx, i)
+		if err != nil {
+			return err
+		}
 		utxo := &avax.UTXO{
 			UTXOID: avax.UTXOID{
 				TxID:        txID,
 				OutputIndex: uint32(len(outputs) + i),
 			},
 			Asset: out.Asset,
-			Out:   out.Output(),
+			Out:   refund,
 		}
 		e.OnCommitState.AddUTXO(utxo)
 		e.OnAbortState.AddUTXO(utxo)
 	}
 
+	// The rewards of a validator whose misbehavior was reported are withheld.
+	_, err := e.OnCommitState.GetMisbehaviorReport(txID)
+	switch {
+	case err == nil:
+		return e.withholdValidatorRewards(validator)
+	case err != database.ErrNotFound:
+		return fmt.Errorf("failed to fetch misbehavior report: %w", err)
+	}
+
 	utxosOffset := 0
 
 	// Provide the reward here
-	reward := validator.PotentialReward
-	if reward > 0 {
-		validationRewardsOwner := uValidatorTx.ValidationRewardsOwner()
-		outIntf, err := e.Fx.CreateOutput(reward, validationRewardsOwner)
+	for _, validationReward := range validationRewards(uValidatorTx, validator.PotentialReward) {
+		outIntf, err := e.Fx.CreateOutput(validationReward.amount, validationReward.owner)
 		if err != nil {
 			return fmt.Errorf("failed to create output: %w", err)
 		}
@@ -416,7 +483,7 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 		utxo := &avax.UTXO{
 			UTXOID: avax.UTXOID{
 				TxID:        txID,
-				OutputIndex: uint32(len(outputs) + len(stake)),
+				OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
 			},
 			Asset: stakeAsset,
 			Out:   out,
@@ -476,6 +543,95 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 	return nil
 }
 
+// stakeRefund returns the output that the [i]th stake output of
+// [uValidatorTx] is refunded as. If the validator declared a vesting schedule,
+// the refund is locked until the stake unlocks.
+func stakeRefund(uValidatorTx txs.ValidatorTx, i int) (avax.TransferableOut, error) {
+	out := uValidatorTx.Stake()[i].Output()
+	vestingTx, ok := uValidatorTx.(*txs.AddVestingPermissionlessValidatorTx)
+	if !ok {
+		return out, nil
+	}
+	return txs.LockStake(out, vestingTx.StakeUnlockTimes[i])
+}
+
+type validationReward struct {
+	amount uint64
+	owner  fx.Owner
+}
+
+// validationRewards returns the outputs that [reward] is minted to for the
+// validator added by [uValidatorTx]. If the validator split its reward, an
+// output is returned for every owner that receives a non-zero amount.
+func validationRewards(uValidatorTx txs.ValidatorTx, reward uint64) []validationReward {
+	if reward == 0 {
+		return nil
+	}
+
+	splitTx, ok := uValidatorTx.(*txs.AddSplitRewardsPermissionlessValidatorTx)
+	if !ok {
+		return []validationReward{{
+			amount: reward,
+			owner:  uValidatorTx.ValidationRewardsOwner(),
+		}}
+	}
+
+	var (
+		amounts = txs.SplitReward(reward, splitTx.ValidationRewardSplits)
+		rewards = make([]validationReward, 0, len(amounts))
+	)
+	for i, amount := range amounts {
+		if amount == 0 {
+			continue
+		}
+		rewards = append(rewards, validationReward{
+			amount: amount,
+			owner:  splitTx.ValidationRewardSplits[i].Owner,
+		})
+	}
+	return rewards
+}
+
+// withholdValidatorRewards burns the validation reward and the accrued delegatee
+// rewards of [validator] rather than issuing them.
+//
+// Note: The validation reward is removed from the supply of [e.OnAbortState]
+// by the caller.
+func (e *ProposalTxExecutor) withholdValidatorRewards(validator *state.Staker) error {
+	delegateeReward, err := e.OnCommitState.GetDelegateeReward(
+		validator.SubnetID,
+		validator.NodeID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to fetch accrued delegatee rewards: %w", err)
+	}
+
+	commitSupply, err := e.OnCommitState.GetCurrentSupply(validator.SubnetID)
+	if err != nil {
+		return err
+	}
+	withheld, err := math.Add64(validator.PotentialReward, delegateeReward)
+	if err != nil {
+		return err
+	}
+	commitSupply, err = math.Sub(commitSupply, withheld)
+	if err != nil {
+		return err
+	}
+	e.OnCommitState.SetCurrentSupply(validator.SubnetID, commitSupply)
+
+	abortSupply, err := e.OnAbortState.GetCurrentSupply(validator.SubnetID)
+	if err != nil {
+		return err
+	}
+	abortSupply, err = math.Sub(abortSupply, delegateeReward)
+	if err != nil {
+		return err
+	}
+	e.OnAbortState.SetCurrentSupply(validator.SubnetID, abortSupply)
+	return nil
+}
+
 func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, delegator *state.Staker) error {
 	var (
 		txID    = delegator.TxID
@@ -521,8 +677,16 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 		return ErrWrongTxType
 	}
 
+	// The delegator is charged the delegation fee that the validator charged
+	// when the delegator's staking period started.
+	feeChanges, err := e.OnCommitState.GetDelegationFeeChanges(validator.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get delegation fee changes of %s: %w", validator.TxID, err)
+	}
+	shares := state.DelegationShares(vdrTx.Shares(), feeChanges, delegator.StartTime)
+
 	// Calculate split of reward between delegator/delegatee
-	delegateeReward, delegatorReward := reward.Split(delegator.PotentialReward, vdrTx.Shares())
+	delegateeReward, delegatorReward := reward.Split(delegator.PotentialReward, shares)
 
 	utxosOffset := 0
 
@@ -227,6 +227,225 @@ func TestRewardValidatorTxExecuteOnAbort(t *testing.T) {
 	require.Equal(oldBalance+stakerToRemove.Weight, onAbortBalance)
 }
 
+func TestRewardValidatorTxWithholdsReportedRewards(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, apricotPhase5)
+
+	currentStakerIterator, err := env.state.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(currentStakerIterator.Next())
+
+	stakerToRemove := currentStakerIterator.Value()
+	currentStakerIterator.Release()
+	require.NotZero(stakerToRemove.PotentialReward)
+
+	stakerToRemoveTxIntf, _, err := env.state.GetTx(stakerToRemove.TxID)
+	require.NoError(err)
+	stakerToRemoveTx := stakerToRemoveTxIntf.Unsigned.(*txs.AddValidatorTx)
+	stakeOwners := stakerToRemoveTx.StakeOuts[0].Out.(*secp256k1fx.TransferOutput).AddressesSet()
+
+	env.state.SetTimestamp(stakerToRemove.EndTime)
+	env.state.SetMisbehaviorReport(stakerToRemove.TxID, ids.GenerateTestID())
+
+	oldBalance, err := avax.GetBalance(env.state, stakeOwners)
+	require.NoError(err)
+	oldSupply, err := env.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	tx, err := newRewardValidatorTx(t, stakerToRemove.TxID)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	// The reward is withheld whether or not the proposal is committed.
+	for _, onDecisionState := range []state.Diff{onCommitState, onAbortState} {
+		supply, err := onDecisionState.GetCurrentSupply(constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Equal(oldSupply-stakerToRemove.PotentialReward, supply)
+
+		_, err = onDecisionState.GetMisbehaviorReport(stakerToRemove.TxID)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	require.NoError(onCommitState.Apply(env.state))
+
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	onCommitBalance, err := avax.GetBalance(env.state, stakeOwners)
+	require.NoError(err)
+	require.Equal(oldBalance+stakerToRemove.Weight, onCommitBalance)
+}
+
+func TestRewardValidatorTxSplitsRewards(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, eUpgrade)
+
+	var (
+		operator = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		capitalProvider = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		uValidatorTx = &txs.AddSplitRewardsPermissionlessValidatorTx{
+			AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{
+				StakeOuts: []*avax.TransferableOutput{{
+					Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          1,
+						OutputOwners: *capitalProvider,
+					},
+				}},
+			},
+			ValidationRewardSplits: []txs.RewardSplit{
+				{
+					BasisPoints: 3_000,
+					Owner:       operator,
+				},
+				{
+					BasisPoints: 7_000,
+					Owner:       capitalProvider,
+				},
+			},
+		}
+		validator = &state.Staker{
+			TxID:            ids.GenerateTestID(),
+			NodeID:          ids.GenerateTestNodeID(),
+			SubnetID:        constants.PrimaryNetworkID,
+			PotentialReward: 1_001,
+		}
+	)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	require.NoError(onCommitState.SetDelegateeReward(constants.PrimaryNetworkID, validator.NodeID, 0))
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+	}
+	require.NoError(txExecutor.rewardValidatorTx(uValidatorTx, validator))
+
+	// The remainder of the split is given to the first owner.
+	expectedRewards := []struct {
+		owner  *secp256k1fx.OutputOwners
+		amount uint64
+	}{
+		{
+			owner:  operator,
+			amount: 301,
+		},
+		{
+			owner:  capitalProvider,
+			amount: 700,
+		},
+	}
+	for i, expectedReward := range expectedRewards {
+		utxoID := avax.UTXOID{
+			TxID:        validator.TxID,
+			OutputIndex: uint32(len(uValidatorTx.StakeOuts) + i),
+		}
+		utxo, err := onCommitState.GetUTXO(utxoID.InputID())
+		require.NoError(err)
+
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		require.Equal(expectedReward.amount, out.Amt)
+		require.Equal(expectedReward.owner.Addrs, out.Addrs)
+	}
+}
+
+func TestRewardValidatorTxLocksVestingStake(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, eUpgrade)
+
+	var (
+		owner = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		unlockTimes  = []uint64{100, 200}
+		uValidatorTx = &txs.AddVestingPermissionlessValidatorTx{
+			AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{
+				StakeOuts: []*avax.TransferableOutput{
+					{
+						Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+						Out: &secp256k1fx.TransferOutput{
+							Amt:          1,
+							OutputOwners: owner,
+						},
+					},
+					{
+						Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+						Out: &secp256k1fx.TransferOutput{
+							Amt:          2,
+							OutputOwners: owner,
+						},
+					},
+				},
+				ValidatorRewardsOwner: &owner,
+				DelegatorRewardsOwner: &owner,
+			},
+			StakeUnlockTimes: unlockTimes,
+		}
+		validator = &state.Staker{
+			TxID:     ids.GenerateTestID(),
+			NodeID:   ids.GenerateTestNodeID(),
+			SubnetID: constants.PrimaryNetworkID,
+		}
+	)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	require.NoError(onCommitState.SetDelegateeReward(constants.PrimaryNetworkID, validator.NodeID, 0))
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+	}
+	require.NoError(txExecutor.rewardValidatorTx(uValidatorTx, validator))
+
+	// The stake is refunded locked until it unlocks, whether or not the
+	// validator is rewarded.
+	for i, stakeOut := range uValidatorTx.StakeOuts {
+		utxoID := avax.UTXOID{
+			TxID:        validator.TxID,
+			OutputIndex: uint32(i),
+		}
+		for _, chainState := range []state.Diff{onCommitState, onAbortState} {
+			utxo, err := chainState.GetUTXO(utxoID.InputID())
+			require.NoError(err)
+
+			out := utxo.Out.(*secp256k1fx.TransferOutput)
+			require.Equal(stakeOut.Output().Amount(), out.Amt)
+			require.Equal(unlockTimes[i], out.Locktime)
+			require.Equal(owner.Addrs, out.Addrs)
+		}
+	}
+}
+
 func TestRewardDelegatorTxExecuteOnCommitPreDelegateeDeferral(t *testing.T) {
 	require := require.New(t)
 	env := newEnvironment(t, apricotPhase5)
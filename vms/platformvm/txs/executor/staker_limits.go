@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	StakerLimitWeightTooSmall         StakerLimitCode = "weightTooSmall"
+	StakerLimitWeightTooLarge         StakerLimitCode = "weightTooLarge"
+	StakerLimitDelegationFeeTooLow    StakerLimitCode = "delegationFeeTooLow"
+	StakerLimitStakeTooShort          StakerLimitCode = "stakeTooShort"
+	StakerLimitStakeTooLong           StakerLimitCode = "stakeTooLong"
+	StakerLimitValidatorOverDelegated StakerLimitCode = "validatorOverDelegated"
+)
+
+// StakerLimitCode identifies the staking limit that a staker tx violated.
+type StakerLimitCode string
+
+// StakerLimitError is returned when a staker tx violates a staking limit. It
+// reports the offending value and the limit it was checked against so that
+// callers don't need to parse the error message.
+//
+// Durations are reported in seconds.
+type StakerLimitError struct {
+	Code  StakerLimitCode
+	Got   uint64
+	Limit uint64
+
+	err    error
+	detail string
+}
+
+func (e *StakerLimitError) Error() string {
+	return fmt.Sprintf("%s: %s", e.err, e.detail)
+}
+
+// Unwrap returns the sentinel error of the violated limit, such as
+// [ErrWeightTooSmall].
+func (e *StakerLimitError) Unwrap() error {
+	return e.err
+}
+
+func amountLimitError(err error, code StakerLimitCode, got uint64, op string, limit uint64) error {
+	return &StakerLimitError{
+		Code:   code,
+		Got:    got,
+		Limit:  limit,
+		err:    err,
+		detail: fmt.Sprintf("%d %s %d", got, op, limit),
+	}
+}
+
+func durationLimitError(err error, code StakerLimitCode, got time.Duration, op string, limit time.Duration) error {
+	return &StakerLimitError{
+		Code:   code,
+		Got:    uint64(got / time.Second),
+		Limit:  uint64(limit / time.Second),
+		err:    err,
+		detail: fmt.Sprintf("%s %s %s", got, op, limit),
+	}
+}
+
+// stakerAttributes are the properties of a staker tx that are verified against
+// the staking limits.
+type stakerAttributes struct {
+	weight           uint64
+	delegationShares uint32
+	duration         time.Duration
+	stakedAssetID    ids.ID
+}
+
+// stakerLimit returns an error if [staker] doesn't satisfy the limit.
+type stakerLimit func(staker *stakerAttributes) error
+
+// verifyStakerLimits verifies [staker] against each of the [limits], in order,
+// returning the error of the first limit that isn't satisfied.
+func verifyStakerLimits(staker *stakerAttributes, limits ...stakerLimit) error {
+	for _, limit := range limits {
+		if err := limit(staker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minWeight ensures the staker is staking at least [minimum].
+func minWeight(minimum uint64) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.weight < minimum {
+			return amountLimitError(ErrWeightTooSmall, StakerLimitWeightTooSmall, staker.weight, "<", minimum)
+		}
+		return nil
+	}
+}
+
+// maxWeight ensures the staker isn't staking more than [maximum].
+func maxWeight(maximum uint64) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.weight > maximum {
+			return amountLimitError(ErrWeightTooLarge, StakerLimitWeightTooLarge, staker.weight, ">", maximum)
+		}
+		return nil
+	}
+}
+
+// minDelegationFee ensures the validator charges at least [minimum].
+func minDelegationFee(minimum uint32) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.delegationShares < minimum {
+			return amountLimitError(ErrInsufficientDelegationFee, StakerLimitDelegationFeeTooLow, uint64(staker.delegationShares), "<", uint64(minimum))
+		}
+		return nil
+	}
+}
+
+// minDuration ensures the staking period isn't shorter than [minimum].
+func minDuration(minimum time.Duration) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.duration < minimum {
+			return durationLimitError(ErrStakeTooShort, StakerLimitStakeTooShort, staker.duration, "<", minimum)
+		}
+		return nil
+	}
+}
+
+// maxDuration ensures the staking period isn't longer than [maximum].
+func maxDuration(maximum time.Duration) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.duration > maximum {
+			return durationLimitError(ErrStakeTooLong, StakerLimitStakeTooLong, staker.duration, ">", maximum)
+		}
+		return nil
+	}
+}
+
+// stakedAsset ensures the staker is staking [assetID].
+func stakedAsset(assetID ids.ID) stakerLimit {
+	return func(staker *stakerAttributes) error {
+		if staker.stakedAssetID != assetID {
+			return fmt.Errorf("%w: %s != %s", ErrWrongStakedAssetID, assetID, staker.stakedAssetID)
+		}
+		return nil
+	}
+}
+
+// limits returns the limits that a validator must satisfy.
+func (r *addValidatorRules) limits() []stakerLimit {
+	return []stakerLimit{
+		minWeight(r.minValidatorStake),
+		maxWeight(r.maxValidatorStake),
+		minDelegationFee(r.minDelegationFee),
+		minDuration(r.minStakeDuration),
+		maxDuration(r.maxStakeDuration),
+		stakedAsset(r.assetID),
+	}
+}
+
+// limits returns the limits that a delegator must satisfy.
+func (r *addDelegatorRules) limits() []stakerLimit {
+	return []stakerLimit{
+		minWeight(r.minDelegatorStake),
+		minDuration(r.minDelegationDuration),
+		maxDuration(r.maxStakeDuration),
+		stakedAsset(r.assetID),
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestVerifyStakerLimits(t *testing.T) {
+	var (
+		assetID = ids.GenerateTestID()
+		rules   = &addValidatorRules{
+			assetID:           assetID,
+			minValidatorStake: 10,
+			maxValidatorStake: 100,
+			minStakeDuration:  time.Hour,
+			maxStakeDuration:  24 * time.Hour,
+			minDelegationFee:  20_000,
+		}
+		valid = stakerAttributes{
+			weight:           50,
+			delegationShares: 20_000,
+			duration:         time.Hour,
+			stakedAssetID:    assetID,
+		}
+	)
+
+	tests := []struct {
+		name          string
+		modify        func(*stakerAttributes)
+		expectedErr   error
+		expectedLimit *StakerLimitError
+	}{
+		{
+			name:        "valid",
+			modify:      func(*stakerAttributes) {},
+			expectedErr: nil,
+		},
+		{
+			name: "weight too small",
+			modify: func(s *stakerAttributes) {
+				s.weight = 9
+			},
+			expectedErr: ErrWeightTooSmall,
+			expectedLimit: &StakerLimitError{
+				Code:  StakerLimitWeightTooSmall,
+				Got:   9,
+				Limit: 10,
+			},
+		},
+		{
+			name: "weight too large",
+			modify: func(s *stakerAttributes) {
+				s.weight = 101
+			},
+			expectedErr: ErrWeightTooLarge,
+			expectedLimit: &StakerLimitError{
+				Code:  StakerLimitWeightTooLarge,
+				Got:   101,
+				Limit: 100,
+			},
+		},
+		{
+			name: "insufficient delegation fee",
+			modify: func(s *stakerAttributes) {
+				s.delegationShares = 19_999
+			},
+			expectedErr: ErrInsufficientDelegationFee,
+			expectedLimit: &StakerLimitError{
+				Code:  StakerLimitDelegationFeeTooLow,
+				Got:   19_999,
+				Limit: 20_000,
+			},
+		},
+		{
+			name: "stake too short",
+			modify: func(s *stakerAttributes) {
+				s.duration = time.Hour - time.Second
+			},
+			expectedErr: ErrStakeTooShort,
+			expectedLimit: &StakerLimitError{
+				Code:  StakerLimitStakeTooShort,
+				Got:   3599,
+				Limit: 3600,
+			},
+		},
+		{
+			name: "stake too long",
+			modify: func(s *stakerAttributes) {
+				s.duration = 24*time.Hour + time.Second
+			},
+			expectedErr: ErrStakeTooLong,
+			expectedLimit: &StakerLimitError{
+				Code:  StakerLimitStakeTooLong,
+				Got:   24*3600 + 1,
+				Limit: 24 * 3600,
+			},
+		},
+		{
+			name: "wrong staked asset",
+			modify: func(s *stakerAttributes) {
+				s.stakedAssetID = ids.GenerateTestID()
+			},
+			expectedErr: ErrWrongStakedAssetID,
+		},
+		{
+			name: "first failing limit is reported",
+			modify: func(s *stakerAttributes) {
+				s.weight = 9
+				s.duration = 0
+			},
+			expectedErr: ErrWeightTooSmall,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			staker := valid
+			test.modify(&staker)
+
+			err := verifyStakerLimits(&staker, rules.limits()...)
+			require.ErrorIs(t, err, test.expectedErr)
+			if test.expectedLimit == nil {
+				return
+			}
+
+			var limitErr *StakerLimitError
+			require.ErrorAs(t, err, &limitErr)
+			require.Equal(t, test.expectedLimit.Code, limitErr.Code)
+			require.Equal(t, test.expectedLimit.Got, limitErr.Got)
+			require.Equal(t, test.expectedLimit.Limit, limitErr.Limit)
+		})
+	}
+}
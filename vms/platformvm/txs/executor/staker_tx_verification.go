@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
@@ -12,6 +13,9 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -33,6 +37,7 @@ var (
 	ErrOverDelegated                   = errors.New("validator would be over delegated")
 	ErrIsNotTransformSubnetTx          = errors.New("is not a transform subnet tx")
 	ErrTimestampNotBeforeStartTime     = errors.New("chain timestamp not before start time")
+	ErrFutureStakeTime                 = errors.New("staker is attempting to start staking too far in the future")
 	ErrAlreadyValidator                = errors.New("already a validator")
 	ErrDuplicateValidator              = errors.New("duplicate validator")
 	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
@@ -40,13 +45,31 @@ var (
 	ErrDurangoUpgradeNotActive         = errors.New("attempting to use a Durango-upgrade feature prior to activation")
 	ErrAddValidatorTxPostDurango       = errors.New("AddValidatorTx is not permitted post-Durango")
 	ErrAddDelegatorTxPostDurango       = errors.New("AddDelegatorTx is not permitted post-Durango")
+	ErrFeeConversionOverflow           = errors.New("converted fee overflows")
+	ErrEUpgradeNotActive               = errors.New("attempting to use an E-upgrade feature prior to activation")
+	ErrChangeFeeOfPermissionedVdr      = errors.New("attempting to change the delegation fee of a permissioned validator")
+	ErrDelegationFeeChangeTooSoon      = errors.New("delegation fee was changed too recently")
+	ErrUnauthorizedValidatorChange     = errors.New("unauthorized validator modification")
+	ErrRotateSubnetValidator           = errors.New("attempting to rotate the NodeID of a subnet validator")
+	ErrRotatePendingDelegators         = errors.New("attempting to rotate the NodeID of a validator with pending delegators")
+	ErrRotateSignerMismatch            = errors.New("rotated validator must have a BLS key if and only if the validator has one")
+	ErrBLSKeyUnchanged                 = errors.New("validator already has this BLS key")
+	ErrAccusedHasNoBLSKey              = errors.New("accused validator doesn't have a BLS key")
+	ErrMisbehaviorAlreadyReported      = errors.New("validator's misbehavior was already reported")
+	ErrInvalidEvidence                 = errors.New("evidence doesn't prove misbehavior")
+	ErrMinValidatorStakeAboveSupply    = errors.New("min validator stake must be less than or equal to the subnet's initial supply")
+	ErrMaxValidatorStakeAboveSupply    = errors.New("max validator stake must be less than or equal to the subnet's maximum supply")
+	ErrEndTimeNotEpochBoundary         = errors.New("validator end time isn't an epoch boundary of the subnet")
+	ErrRemovalAlreadyScheduled         = errors.New("validator is already scheduled to be removed by the next epoch boundary")
+	ErrReducePermissionlessValidator   = errors.New("attempting to reduce the weight of a permissionless validator")
+	ErrWeightNotReduced                = errors.New("weight must be less than the validator's current weight")
 )
 
 // verifySubnetValidatorPrimaryNetworkRequirements verifies the primary
 // network requirements for [subnetValidator]. An error is returned if they
 // are not fulfilled.
 func verifySubnetValidatorPrimaryNetworkRequirements(
-	isDurangoActive bool,
+	rules forkRules,
 	chainState state.Chain,
 	subnetValidator txs.Validator,
 ) error {
@@ -69,7 +92,7 @@ func verifySubnetValidatorPrimaryNetworkRequirements(
 	// Ensure that the period this validator validates the specified subnet
 	// is a subset of the time they validate the primary network.
 	startTime := chainState.GetTimestamp()
-	if !isDurangoActive {
+	if !rules.isDurangoActive {
 		startTime = subnetValidator.StartTime()
 	}
 	if !txs.BoundedBy(
@@ -96,42 +119,38 @@ func verifyAddValidatorTx(
 	[]*avax.TransferableOutput,
 	error,
 ) {
-	currentTimestamp := chainState.GetTimestamp()
-	if backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp) {
+	var (
+		currentTimestamp = chainState.GetTimestamp()
+		rules            = getForkRules(backend, currentTimestamp)
+	)
+	if rules.isDurangoActive {
 		return nil, ErrAddValidatorTxPostDurango
 	}
 
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return nil, err
 	}
 
-	if err := avax.VerifyMemoFieldLength(tx.Memo, false /*=isDurangoActive*/); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return nil, err
 	}
 
 	startTime := tx.StartTime()
-	duration := tx.EndTime().Sub(startTime)
-	switch {
-	case tx.Validator.Wght < backend.Config.MinValidatorStake:
-		// Ensure validator is staking at least the minimum amount
-		return nil, ErrWeightTooSmall
-
-	case tx.Validator.Wght > backend.Config.MaxValidatorStake:
-		// Ensure validator isn't staking too much
-		return nil, ErrWeightTooLarge
-
-	case tx.DelegationShares < backend.Config.MinDelegationFee:
-		// Ensure the validator fee is at least the minimum amount
-		return nil, ErrInsufficientDelegationFee
-
-	case duration < backend.Config.MinStakeDuration:
-		// Ensure staking length is not too short
-		return nil, ErrStakeTooShort
-
-	case duration > backend.Config.MaxStakeDuration:
-		// Ensure staking length is not too long
-		return nil, ErrStakeTooLong
+	err := verifyStakerLimits(
+		&stakerAttributes{
+			weight:           tx.Validator.Wght,
+			delegationShares: tx.DelegationShares,
+			duration:         tx.EndTime().Sub(startTime),
+		},
+		minWeight(backend.Config.MinValidatorStake),
+		maxWeight(backend.Config.MaxValidatorStake),
+		minDelegationFee(backend.Config.MinDelegationFee),
+		minDuration(backend.Config.MinStakeDuration),
+		maxDuration(backend.Config.MaxStakeDuration),
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -142,11 +161,11 @@ func verifyAddValidatorTx(
 		return outs, nil
 	}
 
-	if err := verifyStakerStartTime(false /*=isDurangoActive*/, currentTimestamp, startTime); err != nil {
+	if err := verifyStakerStartTime(backend, rules, currentTimestamp, startTime); err != nil {
 		return nil, err
 	}
 
-	_, err := GetValidator(chainState, constants.PrimaryNetworkID, tx.Validator.NodeID)
+	_, err = GetValidator(chainState, constants.PrimaryNetworkID, tx.Validator.NodeID)
 	if err == nil {
 		return nil, fmt.Errorf(
 			"%s is %w of the primary network",
@@ -186,45 +205,73 @@ func verifyAddSubnetValidatorTx(
 	chainState state.Chain,
 	sTx *txs.Tx,
 	tx *txs.AddSubnetValidatorTx,
+) error {
+	return verifyAddSubnetValidator(
+		backend,
+		chainState,
+		sTx,
+		tx,
+		backend.Config.AddSubnetValidatorFee,
+	)
+}
+
+// verifyAddSubnetValidator carries out the validation for an
+// AddSubnetValidatorTx that must burn [txFee].
+func verifyAddSubnetValidator(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddSubnetValidatorTx,
+	txFee uint64,
 ) error {
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return err
 	}
 
 	var (
 		currentTimestamp = chainState.GetTimestamp()
-		isDurangoActive  = backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp)
+		rules            = getForkRules(backend, currentTimestamp)
 	)
-	if err := avax.VerifyMemoFieldLength(tx.Memo, isDurangoActive); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return err
 	}
 
 	startTime := currentTimestamp
-	if !isDurangoActive {
+	if !rules.isDurangoActive {
 		startTime = tx.StartTime()
+	} else {
+		// If the validator set of the subnet is frozen, the validator is added
+		// at the next epoch boundary and must be removed at an epoch boundary.
+		epoch, err := getSubnetValidatorEpoch(chainState, tx.SubnetValidator.Subnet)
+		if err != nil {
+			return err
+		}
+		if endTime := tx.EndTime(); !isEpochBoundary(endTime, epoch) {
+			return fmt.Errorf("%w: %s", ErrEndTimeNotEpochBoundary, endTime)
+		}
+		startTime = nextEpochBoundary(currentTimestamp, epoch)
 	}
-	duration := tx.EndTime().Sub(startTime)
-
-	switch {
-	case duration < backend.Config.MinStakeDuration:
-		// Ensure staking length is not too short
-		return ErrStakeTooShort
-
-	case duration > backend.Config.MaxStakeDuration:
-		// Ensure staking length is not too long
-		return ErrStakeTooLong
+	err := verifyStakerLimits(
+		&stakerAttributes{
+			duration: tx.EndTime().Sub(startTime),
+		},
+		minDuration(backend.Config.MinStakeDuration),
+		maxDuration(backend.Config.MaxStakeDuration),
+	)
+	if err != nil {
+		return err
 	}
 
 	if !backend.Bootstrapped.Get() {
 		return nil
 	}
 
-	if err := verifyStakerStartTime(isDurangoActive, currentTimestamp, startTime); err != nil {
+	if err := verifyStakerStartTime(backend, rules, currentTimestamp, startTime); err != nil {
 		return err
 	}
 
-	_, err := GetValidator(chainState, tx.SubnetValidator.Subnet, tx.Validator.NodeID)
+	_, err = GetValidator(chainState, tx.SubnetValidator.Subnet, tx.Validator.NodeID)
 	if err == nil {
 		return fmt.Errorf(
 			"attempted to issue %w for %s on subnet %s",
@@ -241,7 +288,7 @@ func verifyAddSubnetValidatorTx(
 		)
 	}
 
-	if err := verifySubnetValidatorPrimaryNetworkRequirements(isDurangoActive, chainState, tx.Validator); err != nil {
+	if err := verifySubnetValidatorPrimaryNetworkRequirements(rules, chainState, tx.Validator); err != nil {
 		return err
 	}
 
@@ -258,7 +305,7 @@ func verifyAddSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddSubnetValidatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -282,15 +329,15 @@ func verifyRemoveSubnetValidatorTx(
 	tx *txs.RemoveSubnetValidatorTx,
 ) (*state.Staker, bool, error) {
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return nil, false, err
 	}
 
 	var (
 		currentTimestamp = chainState.GetTimestamp()
-		isDurangoActive  = backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp)
+		rules            = getForkRules(backend, currentTimestamp)
 	)
-	if err := avax.VerifyMemoFieldLength(tx.Memo, isDurangoActive); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return nil, false, err
 	}
 
@@ -315,6 +362,19 @@ func verifyRemoveSubnetValidatorTx(
 		return nil, false, ErrRemovePermissionlessValidator
 	}
 
+	// If the validator set of the subnet is frozen, current validators are
+	// removed at the next epoch boundary.
+	if isCurrentValidator {
+		epoch, err := getSubnetValidatorEpoch(chainState, tx.Subnet)
+		if err != nil {
+			return nil, false, err
+		}
+		removalTime := nextEpochBoundary(currentTimestamp, epoch)
+		if removalTime.After(currentTimestamp) && !vdr.EndTime.After(removalTime) {
+			return nil, false, ErrRemovalAlreadyScheduled
+		}
+	}
+
 	if !backend.Bootstrapped.Get() {
 		// Not bootstrapped yet -- don't need to do full verification.
 		return vdr, isCurrentValidator, nil
@@ -354,37 +414,38 @@ func verifyAddDelegatorTx(
 	[]*avax.TransferableOutput,
 	error,
 ) {
-	currentTimestamp := chainState.GetTimestamp()
-	if backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp) {
+	var (
+		currentTimestamp = chainState.GetTimestamp()
+		rules            = getForkRules(backend, currentTimestamp)
+	)
+	if rules.isDurangoActive {
 		return nil, ErrAddDelegatorTxPostDurango
 	}
 
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return nil, err
 	}
 
-	if err := avax.VerifyMemoFieldLength(tx.Memo, false /*=isDurangoActive*/); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return nil, err
 	}
 
 	var (
 		endTime   = tx.EndTime()
 		startTime = tx.StartTime()
-		duration  = endTime.Sub(startTime)
 	)
-	switch {
-	case duration < backend.Config.MinStakeDuration:
-		// Ensure staking length is not too short
-		return nil, ErrStakeTooShort
-
-	case duration > backend.Config.MaxStakeDuration:
-		// Ensure staking length is not too long
-		return nil, ErrStakeTooLong
-
-	case tx.Validator.Wght < backend.Config.MinDelegatorStake:
-		// Ensure validator is staking at least the minimum amount
-		return nil, ErrWeightTooSmall
+	err := verifyStakerLimits(
+		&stakerAttributes{
+			weight:   tx.Validator.Wght,
+			duration: endTime.Sub(startTime),
+		},
+		minDuration(backend.Config.MinDelegationDuration),
+		maxDuration(backend.Config.MaxStakeDuration),
+		minWeight(backend.Config.MinDelegatorStake),
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -395,7 +456,7 @@ func verifyAddDelegatorTx(
 		return outs, nil
 	}
 
-	if err := verifyStakerStartTime(false /*=isDurangoActive*/, currentTimestamp, startTime); err != nil {
+	if err := verifyStakerStartTime(backend, rules, currentTimestamp, startTime); err != nil {
 		return nil, err
 	}
 
@@ -413,7 +474,7 @@ func verifyAddDelegatorTx(
 		return nil, ErrStakeOverflow
 	}
 
-	if backend.Config.UpgradeConfig.IsApricotPhase3Activated(currentTimestamp) {
+	if rules.isApricotPhase3Active {
 		maximumWeight = min(maximumWeight, backend.Config.MaxValidatorStake)
 	}
 
@@ -425,20 +486,16 @@ func verifyAddDelegatorTx(
 	) {
 		return nil, ErrPeriodMismatch
 	}
-	overDelegated, err := overDelegated(
+	if err := verifyNotOverDelegated(
 		chainState,
 		primaryNetworkValidator,
 		maximumWeight,
 		tx.Validator.Wght,
 		startTime,
 		endTime,
-	)
-	if err != nil {
+	); err != nil {
 		return nil, err
 	}
-	if overDelegated {
-		return nil, ErrOverDelegated
-	}
 
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
@@ -464,17 +521,30 @@ func verifyAddPermissionlessValidatorTx(
 	chainState state.Chain,
 	sTx *txs.Tx,
 	tx *txs.AddPermissionlessValidatorTx,
+) error {
+	return verifyAddPermissionlessValidator(backend, chainState, sTx, tx, 0)
+}
+
+// verifyAddPermissionlessValidator carries out the validation for an
+// AddPermissionlessValidatorTx that must burn [additionalFee] on top of the
+// fee to add the validator.
+func verifyAddPermissionlessValidator(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddPermissionlessValidatorTx,
+	additionalFee uint64,
 ) error {
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return err
 	}
 
 	var (
 		currentTimestamp = chainState.GetTimestamp()
-		isDurangoActive  = backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp)
+		rules            = getForkRules(backend, currentTimestamp)
 	)
-	if err := avax.VerifyMemoFieldLength(tx.Memo, isDurangoActive); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return err
 	}
 
@@ -483,12 +553,12 @@ func verifyAddPermissionlessValidatorTx(
 	}
 
 	startTime := currentTimestamp
-	if !isDurangoActive {
+	if !rules.isDurangoActive {
 		startTime = tx.StartTime()
 	}
 	duration := tx.EndTime().Sub(startTime)
 
-	if err := verifyStakerStartTime(isDurangoActive, currentTimestamp, startTime); err != nil {
+	if err := verifyStakerStartTime(backend, rules, currentTimestamp, startTime); err != nil {
 		return err
 	}
 
@@ -497,36 +567,17 @@ func verifyAddPermissionlessValidatorTx(
 		return err
 	}
 
-	stakedAssetID := tx.StakeOuts[0].AssetID()
-	switch {
-	case tx.Validator.Wght < validatorRules.minValidatorStake:
-		// Ensure validator is staking at least the minimum amount
-		return ErrWeightTooSmall
-
-	case tx.Validator.Wght > validatorRules.maxValidatorStake:
-		// Ensure validator isn't staking too much
-		return ErrWeightTooLarge
-
-	case tx.DelegationShares < validatorRules.minDelegationFee:
-		// Ensure the validator fee is at least the minimum amount
-		return ErrInsufficientDelegationFee
-
-	case duration < validatorRules.minStakeDuration:
-		// Ensure staking length is not too short
-		return ErrStakeTooShort
-
-	case duration > validatorRules.maxStakeDuration:
-		// Ensure staking length is not too long
-		return ErrStakeTooLong
-
-	case stakedAssetID != validatorRules.assetID:
-		// Wrong assetID used
-		return fmt.Errorf(
-			"%w: %s != %s",
-			ErrWrongStakedAssetID,
-			validatorRules.assetID,
-			stakedAssetID,
-		)
+	err = verifyStakerLimits(
+		&stakerAttributes{
+			weight:           tx.Validator.Wght,
+			delegationShares: tx.DelegationShares,
+			duration:         duration,
+			stakedAssetID:    tx.StakeOuts[0].AssetID(),
+		},
+		validatorRules.limits()...,
+	)
+	if err != nil {
+		return err
 	}
 
 	_, err = GetValidator(chainState, tx.Subnet, tx.Validator.NodeID)
@@ -549,7 +600,7 @@ func verifyAddPermissionlessValidatorTx(
 
 	var txFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
-		if err := verifySubnetValidatorPrimaryNetworkRequirements(isDurangoActive, chainState, tx.Validator); err != nil {
+		if err := verifySubnetValidatorPrimaryNetworkRequirements(rules, chainState, tx.Validator); err != nil {
 			return err
 		}
 
@@ -557,6 +608,15 @@ func verifyAddPermissionlessValidatorTx(
 	} else {
 		txFee = backend.Config.AddPrimaryNetworkValidatorFee
 	}
+	txFee, err = safemath.Add64(txFee, additionalFee)
+	if err != nil {
+		return err
+	}
+
+	fees, err := stakerTxFees(backend, validatorRules.assetID, validatorRules.feeConversionRate, tx.Ins, txFee)
+	if err != nil {
+		return err
+	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
 	copy(outs, tx.Outs)
@@ -569,9 +629,7 @@ func verifyAddPermissionlessValidatorTx(
 		tx.Ins,
 		outs,
 		sTx.Creds,
-		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: txFee,
-		},
+		fees,
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
 	}
@@ -579,6 +637,156 @@ func verifyAddPermissionlessValidatorTx(
 	return nil
 }
 
+// verifyAddCappedPermissionlessValidatorTx carries out the validation for an
+// AddCappedPermissionlessValidatorTx.
+func verifyAddCappedPermissionlessValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddCappedPermissionlessValidatorTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	return verifyAddPermissionlessValidatorTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.AddPermissionlessValidatorTx,
+	)
+}
+
+// verifyAddSplitRewardsPermissionlessValidatorTx carries out the validation for
+// an AddSplitRewardsPermissionlessValidatorTx.
+func verifyAddSplitRewardsPermissionlessValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddSplitRewardsPermissionlessValidatorTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	return verifyAddPermissionlessValidatorTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.AddPermissionlessValidatorTx,
+	)
+}
+
+// verifyAddValidatorWithSubnetsTx carries out the validation for an
+// AddValidatorWithSubnetsTx. It returns the subnet validators to add along
+// with the primary network validator.
+//
+// The subnet validators are verified as if the primary network validator had
+// already been added, so each must validate its subnet for a subset of the
+// time that the node validates the primary network. The fees of all the
+// validators are paid by [tx].
+func verifyAddValidatorWithSubnetsTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddValidatorWithSubnetsTx,
+) ([]*txs.Tx, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return nil, ErrEUpgradeNotActive
+	}
+
+	subnetValidatorsFee, err := safemath.Mul64(
+		uint64(len(tx.SubnetValidators)),
+		backend.Config.AddSubnetValidatorFee,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyAddPermissionlessValidator(
+		backend,
+		chainState,
+		sTx,
+		&tx.AddPermissionlessValidatorTx,
+		subnetValidatorsFee,
+	); err != nil {
+		return nil, err
+	}
+
+	primaryNetworkValidator, err := state.NewCurrentStaker(
+		sTx.ID(),
+		&tx.AddPermissionlessValidatorTx,
+		currentTimestamp,
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	onPrimaryNetworkValidator, err := state.NewDiffOn(chainState)
+	if err != nil {
+		return nil, err
+	}
+	onPrimaryNetworkValidator.PutCurrentValidator(primaryNetworkValidator)
+
+	for _, subnetValidatorTx := range tx.SubnetValidators {
+		subnetValidator := subnetValidatorTx.Unsigned.(*txs.AddSubnetValidatorTx)
+		if err := verifyAddSubnetValidator(
+			backend,
+			onPrimaryNetworkValidator,
+			subnetValidatorTx,
+			subnetValidator,
+			0, // The fee is paid by [tx]
+		); err != nil {
+			return nil, fmt.Errorf("invalid validator of %s: %w", subnetValidator.Subnet, err)
+		}
+	}
+	return tx.SubnetValidators, nil
+}
+
+// verifyAddVestingPermissionlessValidatorTx carries out the validation for an
+// AddVestingPermissionlessValidatorTx.
+func verifyAddVestingPermissionlessValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddVestingPermissionlessValidatorTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	return verifyAddPermissionlessValidatorTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.AddPermissionlessValidatorTx,
+	)
+}
+
+// verifyAddPermissionlessValidatorWithMetadataTx carries out the validation for
+// an AddPermissionlessValidatorWithMetadataTx.
+func verifyAddPermissionlessValidatorWithMetadataTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddPermissionlessValidatorWithMetadataTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	return verifyAddPermissionlessValidatorTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.AddPermissionlessValidatorTx,
+	)
+}
+
 // verifyAddPermissionlessDelegatorTx carries out the validation for an
 // AddPermissionlessDelegatorTx.
 func verifyAddPermissionlessDelegatorTx(
@@ -588,15 +796,15 @@ func verifyAddPermissionlessDelegatorTx(
 	tx *txs.AddPermissionlessDelegatorTx,
 ) error {
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return err
 	}
 
 	var (
 		currentTimestamp = chainState.GetTimestamp()
-		isDurangoActive  = backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp)
+		rules            = getForkRules(backend, currentTimestamp)
 	)
-	if err := avax.VerifyMemoFieldLength(tx.Memo, isDurangoActive); err != nil {
+	if err := avax.VerifyMemoFieldLength(tx.Memo, rules.isDurangoActive); err != nil {
 		return err
 	}
 
@@ -608,12 +816,12 @@ func verifyAddPermissionlessDelegatorTx(
 		endTime   = tx.EndTime()
 		startTime = currentTimestamp
 	)
-	if !isDurangoActive {
+	if !rules.isDurangoActive {
 		startTime = tx.StartTime()
 	}
 	duration := endTime.Sub(startTime)
 
-	if err := verifyStakerStartTime(isDurangoActive, currentTimestamp, startTime); err != nil {
+	if err := verifyStakerStartTime(backend, rules, currentTimestamp, startTime); err != nil {
 		return err
 	}
 
@@ -622,28 +830,16 @@ func verifyAddPermissionlessDelegatorTx(
 		return err
 	}
 
-	stakedAssetID := tx.StakeOuts[0].AssetID()
-	switch {
-	case tx.Validator.Wght < delegatorRules.minDelegatorStake:
-		// Ensure delegator is staking at least the minimum amount
-		return ErrWeightTooSmall
-
-	case duration < delegatorRules.minStakeDuration:
-		// Ensure staking length is not too short
-		return ErrStakeTooShort
-
-	case duration > delegatorRules.maxStakeDuration:
-		// Ensure staking length is not too long
-		return ErrStakeTooLong
-
-	case stakedAssetID != delegatorRules.assetID:
-		// Wrong assetID used
-		return fmt.Errorf(
-			"%w: %s != %s",
-			ErrWrongStakedAssetID,
-			delegatorRules.assetID,
-			stakedAssetID,
-		)
+	err = verifyStakerLimits(
+		&stakerAttributes{
+			weight:        tx.Validator.Wght,
+			duration:      duration,
+			stakedAssetID: tx.StakeOuts[0].AssetID(),
+		},
+		delegatorRules.limits()...,
+	)
+	if err != nil {
+		return err
 	}
 
 	validator, err := GetValidator(chainState, tx.Subnet, tx.Validator.NodeID)
@@ -664,6 +860,11 @@ func verifyAddPermissionlessDelegatorTx(
 		maximumWeight = math.MaxUint64
 	}
 	maximumWeight = min(maximumWeight, delegatorRules.maxValidatorStake)
+	maximumWeight = applyMinSelfStakeRatio(validator.Weight, delegatorRules.minSelfStakeRatio, maximumWeight)
+	maximumWeight, err = applyDelegationCap(chainState, validator, maximumWeight)
+	if err != nil {
+		return err
+	}
 
 	if !txs.BoundedBy(
 		startTime,
@@ -673,20 +874,16 @@ func verifyAddPermissionlessDelegatorTx(
 	) {
 		return ErrPeriodMismatch
 	}
-	overDelegated, err := overDelegated(
+	if err := verifyNotOverDelegated(
 		chainState,
 		validator,
 		maximumWeight,
 		tx.Validator.Wght,
 		startTime,
 		endTime,
-	)
-	if err != nil {
+	); err != nil {
 		return err
 	}
-	if overDelegated {
-		return ErrOverDelegated
-	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
 	copy(outs, tx.Outs)
@@ -709,6 +906,11 @@ func verifyAddPermissionlessDelegatorTx(
 		txFee = backend.Config.AddPrimaryNetworkDelegatorFee
 	}
 
+	fees, err := stakerTxFees(backend, delegatorRules.assetID, delegatorRules.feeConversionRate, tx.Ins, txFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -716,9 +918,7 @@ func verifyAddPermissionlessDelegatorTx(
 		tx.Ins,
 		outs,
 		sTx.Creds,
-		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: txFee,
-		},
+		fees,
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
 	}
@@ -737,12 +937,12 @@ func verifyTransferSubnetOwnershipTx(
 	sTx *txs.Tx,
 	tx *txs.TransferSubnetOwnershipTx,
 ) error {
-	if !backend.Config.UpgradeConfig.IsDurangoActivated(chainState.GetTimestamp()) {
+	if !getForkRules(backend, chainState.GetTimestamp()).isDurangoActive {
 		return ErrDurangoUpgradeNotActive
 	}
 
 	// Verify the tx is well-formed
-	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+	if err := backend.SyntacticVerify(sTx); err != nil {
 		return err
 	}
 
@@ -777,11 +977,137 @@ func verifyTransferSubnetOwnershipTx(
 	return nil
 }
 
-// Ensure the proposed validator starts after the current time
-func verifyStakerStartTime(isDurangoActive bool, chainTime, stakerTime time.Time) error {
-	// Pre Durango activation, start time must be after current chain time.
+// verifyChangeDelegationFeeTx carries out the validation for a
+// ChangeDelegationFeeTx. It returns the validator's current delegation fee
+// changes.
+//
+// The transaction is valid if:
+// * [tx.NodeID] is a current permissionless validator of [tx.Subnet].
+// * [tx.DelegationShares] is at least the minimum delegation fee of
+// [tx.Subnet].
+// * The delegation fee wasn't changed within the cooldown period.
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it as the owner of the validation rewards.
+// * The flow checker passes.
+func verifyChangeDelegationFeeTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ChangeDelegationFeeTx,
+) (*state.Staker, []*state.DelegationFeeChange, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return nil, nil, ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to fetch the current validator %s of %s: %w",
+			tx.NodeID,
+			tx.Subnet,
+			err,
+		)
+	}
+	if vdr.Priority.IsPermissionedValidator() {
+		return nil, nil, ErrChangeFeeOfPermissionedVdr
+	}
+
+	changes, err := chainState.GetDelegationFeeChanges(vdr.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, changes, nil
+	}
+
+	validatorRules, err := getValidatorRules(backend, chainState, tx.Subnet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = verifyStakerLimits(
+		&stakerAttributes{
+			delegationShares: tx.DelegationShares,
+		},
+		minDelegationFee(validatorRules.minDelegationFee),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(changes) > 0 {
+		lastChangeTime := time.Unix(int64(changes[len(changes)-1].Time), 0)
+		nextChangeTime := lastChangeTime.Add(backend.Config.DelegationFeeChangeCooldown)
+		if currentTimestamp.Before(nextChangeTime) {
+			return nil, nil, fmt.Errorf(
+				"%w: next change allowed at %s",
+				ErrDelegationFeeChangeTooSoon,
+				nextChangeTime,
+			)
+		}
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to fetch the validator tx %s: %w",
+			vdr.TxID,
+			err,
+		)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, nil, ErrWrongTxType
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the validator
+		// authorization
+		return nil, nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	vdrCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.ValidatorAuth, vdrCred, vdrTx.ValidationRewardsOwner()); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrUnauthorizedValidatorChange, err)
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, changes, nil
+}
+
+// Ensure the proposed validator starts after the current time, but not too far
+// after it
+func verifyStakerStartTime(backend *Backend, rules forkRules, chainTime, stakerTime time.Time) error {
+	// Pre Durango activation, start time must be after current chain time and
+	// at most [MaxFutureStartTime] after it.
 	// Post Durango activation, start time is not validated
-	if isDurangoActive {
+	if rules.isDurangoActive {
 		return nil
 	}
 
@@ -793,5 +1119,575 @@ func verifyStakerStartTime(isDurangoActive bool, chainTime, stakerTime time.Time
 			stakerTime,
 		)
 	}
+
+	maxFutureStartTime := backend.Config.MaxFutureStartTime
+	if maxStartTime := chainTime.Add(maxFutureStartTime); stakerTime.After(maxStartTime) {
+		return fmt.Errorf(
+			"%w: %s is more than %s after %s",
+			ErrFutureStakeTime,
+			stakerTime,
+			maxFutureStartTime,
+			chainTime,
+		)
+	}
+	return nil
+}
+
+// stakerTxFees returns the fees that must be burned by a tx that adds a staker
+// and consumes [ins].
+//
+// If the subnet owner set a fee conversion [rate], the tx may pay its fee in
+// the subnet's staking asset, [stakingAssetID], rather than in AVAX. The
+// staking asset is only charged if the tx doesn't consume any AVAX.
+func stakerTxFees(
+	backend *Backend,
+	stakingAssetID ids.ID,
+	rate uint64,
+	ins []*avax.TransferableInput,
+	txFee uint64,
+) (map[ids.ID]uint64, error) {
+	avaxAssetID := backend.Ctx.AVAXAssetID
+	if rate == 0 || stakingAssetID == avaxAssetID {
+		return map[ids.ID]uint64{
+			avaxAssetID: txFee,
+		}, nil
+	}
+	for _, in := range ins {
+		if in.AssetID() == avaxAssetID {
+			return map[ids.ID]uint64{
+				avaxAssetID: txFee,
+			}, nil
+		}
+	}
+
+	// Round up so that a non-zero fee is never converted to a zero fee.
+	scaledFee, err := safemath.Mul64(txFee, rate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d nAVAX at a rate of %d per AVAX",
+			ErrFeeConversionOverflow,
+			txFee,
+			rate,
+		)
+	}
+	fee := scaledFee / units.Avax
+	if scaledFee%units.Avax != 0 {
+		fee++
+	}
+	return map[ids.ID]uint64{
+		stakingAssetID: fee,
+	}, nil
+}
+
+// verifyRotateValidatorNodeTx carries out the validation for a
+// RotateValidatorNodeTx. It returns the validator that is being rotated.
+func verifyRotateValidatorNodeTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.RotateValidatorNodeTx,
+) (*state.Staker, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return nil, ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the current validator %s: %w",
+			tx.NodeID,
+			err,
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	_, err = GetValidator(chainState, constants.PrimaryNetworkID, tx.NewNodeID)
+	if err == nil {
+		return nil, fmt.Errorf(
+			"%w: %s",
+			ErrDuplicateValidator,
+			tx.NewNodeID,
+		)
+	}
+	if err != database.ErrNotFound {
+		return nil, fmt.Errorf(
+			"failed to find whether %s is a primary network validator: %w",
+			tx.NewNodeID,
+			err,
+		)
+	}
+
+	if (vdr.PublicKey != nil) != (tx.Signer.Key() != nil) {
+		return nil, ErrRotateSignerMismatch
+	}
+
+	if err := verifyNoPendingDelegators(chainState, tx.NodeID); err != nil {
+		return nil, err
+	}
+
+	// Subnet validators are required to validate the primary network, so the
+	// node can't be rotated while it is validating a subnet.
+	if err := verifyNotSubnetStaker(chainState, tx.NodeID); err != nil {
+		return nil, err
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the validator tx %s: %w",
+			vdr.TxID,
+			err,
+		)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the validator
+		// authorization
+		return nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	vdrCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.ValidatorAuth, vdrCred, vdrTx.ValidationRewardsOwner()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthorizedValidatorChange, err)
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
+// verifySetValidatorBLSKeyTx carries out the validation for a
+// SetValidatorBLSKeyTx. It returns the validator whose BLS key is being set.
+func verifySetValidatorBLSKeyTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetValidatorBLSKeyTx,
+) (*state.Staker, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return nil, ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the current validator %s: %w",
+			tx.NodeID,
+			err,
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	if vdr.PublicKey != nil && bytes.Equal(
+		bls.PublicKeyToCompressedBytes(vdr.PublicKey),
+		bls.PublicKeyToCompressedBytes(tx.Signer.Key()),
+	) {
+		return nil, ErrBLSKeyUnchanged
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the validator tx %s: %w",
+			vdr.TxID,
+			err,
+		)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the validator
+		// authorization
+		return nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	vdrCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.ValidatorAuth, vdrCred, vdrTx.ValidationRewardsOwner()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthorizedValidatorChange, err)
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
+func verifyNoPendingDelegators(chainState state.Chain, nodeID ids.NodeID) error {
+	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return err
+	}
+	defer pendingDelegatorIterator.Release()
+
+	if pendingDelegatorIterator.Next() {
+		return ErrRotatePendingDelegators
+	}
+	return nil
+}
+
+func verifyNotSubnetStaker(chainState state.Chain, nodeID ids.NodeID) error {
+	currentSubnetIDs, err := chainState.GetCurrentValidatorSubnetIDs(nodeID)
+	if err != nil {
+		return err
+	}
+	pendingSubnetIDs, err := chainState.GetPendingValidatorSubnetIDs(nodeID)
+	if err != nil {
+		return err
+	}
+
+	// Subnet delegators can only exist while the subnet validator exists, so
+	// only the validators need to be checked.
+	for _, subnetIDs := range []set.Set[ids.ID]{currentSubnetIDs, pendingSubnetIDs} {
+		for subnetID := range subnetIDs {
+			if subnetID == constants.PrimaryNetworkID {
+				continue
+			}
+			return fmt.Errorf(
+				"%w: %s validates %s",
+				ErrRotateSubnetValidator,
+				nodeID,
+				subnetID,
+			)
+		}
+	}
+	return nil
+}
+
+// verifyReportMisbehaviorTx carries out the validation for a
+// ReportMisbehaviorTx. It returns the accused validator.
+func verifyReportMisbehaviorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ReportMisbehaviorTx,
+) (*state.Staker, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !getForkRules(backend, currentTimestamp).isEActive {
+		return nil, ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	nodeID := tx.Evidence.Accused()
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the current validator %s: %w",
+			nodeID,
+			err,
+		)
+	}
+
+	// A validator's rewards are only withheld once per staking period.
+	_, err = chainState.GetMisbehaviorReport(vdr.TxID)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w: %s", ErrMisbehaviorAlreadyReported, nodeID)
+	case err != database.ErrNotFound:
+		return nil, fmt.Errorf(
+			"failed to fetch the misbehavior report of %s: %w",
+			nodeID,
+			err,
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	if vdr.PublicKey == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccusedHasNoBLSKey, nodeID)
+	}
+	if err := tx.Evidence.VerifyMisbehavior(backend.Ctx.NetworkID, vdr.PublicKey); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvidence, err)
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
+// verifySetSubnetStakingParamsTx carries out the validation for a
+// SetSubnetStakingParamsTx.
+//
+// The transaction is valid if:
+// * [tx.Subnet] is an elastic subnet.
+// * [tx.MinValidatorStake] is at most the initial supply of [tx.Subnet].
+// * [tx.MaxValidatorStake] is at most the maximum supply of [tx.Subnet].
+// * [tx.MaxStakeDuration] is at most the global max stake duration.
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify [tx.Subnet].
+// * The flow checker passes.
+func verifySetSubnetStakingParamsTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetStakingParamsTx,
+) error {
+	if !getForkRules(backend, chainState.GetTimestamp()).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return err
+	}
+
+	transformSubnet, err := GetTransformSubnetTx(chainState, tx.Subnet)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case tx.MinValidatorStake > transformSubnet.InitialSupply:
+		return ErrMinValidatorStakeAboveSupply
+	case tx.MaxValidatorStake > transformSubnet.MaximumSupply:
+		return ErrMaxValidatorStakeAboveSupply
+	// Note: math.MaxInt32 * time.Second < math.MaxInt64 - so this can never
+	// overflow.
+	case time.Duration(tx.MaxStakeDuration)*time.Second > backend.Config.MaxStakeDuration:
+		return errMaxStakeDurationTooLarge
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// verifyReduceSubnetValidatorWeightTx carries out the validation for a
+// ReduceSubnetValidatorWeightTx. It returns the validator whose weight is being
+// reduced.
+//
+// The transaction is valid if:
+// * [tx.NodeID] is a current permissioned validator of [tx.Subnet].
+// * [tx.Weight] is less than the validator's current weight.
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify [tx.Subnet].
+// * The flow checker passes.
+func verifyReduceSubnetValidatorWeightTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ReduceSubnetValidatorWeightTx,
+) (*state.Staker, error) {
+	if !getForkRules(backend, chainState.GetTimestamp()).isEActive {
+		return nil, ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the current validator %s of %s: %w",
+			tx.NodeID,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if !vdr.Priority.IsPermissionedValidator() {
+		return nil, ErrReducePermissionlessValidator
+	}
+
+	if tx.Weight >= vdr.Weight {
+		return nil, fmt.Errorf(
+			"%w: %d >= %d",
+			ErrWeightNotReduced,
+			tx.Weight,
+			vdr.Weight,
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
+// verifySetSubnetValidatorEpochTx carries out the validation for a
+// SetSubnetValidatorEpochTx.
+//
+// The transaction is valid if:
+// * [tx.Subnet] is a permissioned subnet.
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify [tx.Subnet].
+// * The flow checker passes.
+func verifySetSubnetValidatorEpochTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetValidatorEpochTx,
+) error {
+	if !getForkRules(backend, chainState.GetTimestamp()).isEActive {
+		return ErrEUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := backend.SyntacticVerify(sTx); err != nil {
+		return err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	baseTxCreds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
 	return nil
 }
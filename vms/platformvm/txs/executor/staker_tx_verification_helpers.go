@@ -4,12 +4,15 @@
 package executor
 
 import (
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -21,6 +24,9 @@ type addValidatorRules struct {
 	minStakeDuration  time.Duration
 	maxStakeDuration  time.Duration
 	minDelegationFee  uint32
+	// feeConversionRate is the number of units of [assetID] charged in place
+	// of 1 AVAX of fees. If 0, fees must be paid in AVAX.
+	feeConversionRate uint64
 }
 
 func getValidatorRules(
@@ -44,23 +50,43 @@ func getValidatorRules(
 		return nil, err
 	}
 
-	return &addValidatorRules{
+	rules := &addValidatorRules{
 		assetID:           transformSubnet.AssetID,
 		minValidatorStake: transformSubnet.MinValidatorStake,
 		maxValidatorStake: transformSubnet.MaxValidatorStake,
 		minStakeDuration:  time.Duration(transformSubnet.MinStakeDuration) * time.Second,
 		maxStakeDuration:  time.Duration(transformSubnet.MaxStakeDuration) * time.Second,
 		minDelegationFee:  transformSubnet.MinDelegationFee,
-	}, nil
+	}
+
+	params, err := getSubnetStakingParams(chainState, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if params != nil {
+		rules.minValidatorStake = params.MinValidatorStake
+		rules.maxValidatorStake = params.MaxValidatorStake
+		rules.minStakeDuration = time.Duration(params.MinStakeDuration) * time.Second
+		rules.maxStakeDuration = time.Duration(params.MaxStakeDuration) * time.Second
+		rules.feeConversionRate = params.FeeConversionRate
+	}
+	return rules, nil
 }
 
 type addDelegatorRules struct {
 	assetID                  ids.ID
 	minDelegatorStake        uint64
 	maxValidatorStake        uint64
-	minStakeDuration         time.Duration
+	minDelegationDuration    time.Duration
 	maxStakeDuration         time.Duration
 	maxValidatorWeightFactor byte
+	// minSelfStakeRatio is the minimum portion, out of
+	// [reward.PercentDenominator], of a validator's total weight that must be
+	// its own stake. If 0, no minimum is enforced.
+	minSelfStakeRatio uint32
+	// feeConversionRate is the number of units of [assetID] charged in place
+	// of 1 AVAX of fees. If 0, fees must be paid in AVAX.
+	feeConversionRate uint64
 }
 
 func getDelegatorRules(
@@ -73,7 +99,7 @@ func getDelegatorRules(
 			assetID:                  backend.Ctx.AVAXAssetID,
 			minDelegatorStake:        backend.Config.MinDelegatorStake,
 			maxValidatorStake:        backend.Config.MaxValidatorStake,
-			minStakeDuration:         backend.Config.MinStakeDuration,
+			minDelegationDuration:    backend.Config.MinDelegationDuration,
 			maxStakeDuration:         backend.Config.MaxStakeDuration,
 			maxValidatorWeightFactor: MaxValidatorWeightFactor,
 		}, nil
@@ -84,14 +110,48 @@ func getDelegatorRules(
 		return nil, err
 	}
 
-	return &addDelegatorRules{
+	// Unless the subnet owner set a minimum delegation duration, elastic
+	// subnets require delegators to satisfy the same minimum duration as
+	// validators.
+	rules := &addDelegatorRules{
 		assetID:                  transformSubnet.AssetID,
 		minDelegatorStake:        transformSubnet.MinDelegatorStake,
 		maxValidatorStake:        transformSubnet.MaxValidatorStake,
-		minStakeDuration:         time.Duration(transformSubnet.MinStakeDuration) * time.Second,
+		minDelegationDuration:    time.Duration(transformSubnet.MinStakeDuration) * time.Second,
 		maxStakeDuration:         time.Duration(transformSubnet.MaxStakeDuration) * time.Second,
 		maxValidatorWeightFactor: transformSubnet.MaxValidatorWeightFactor,
-	}, nil
+	}
+
+	params, err := getSubnetStakingParams(chainState, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if params != nil {
+		rules.minDelegatorStake = params.MinDelegatorStake
+		rules.maxValidatorStake = params.MaxValidatorStake
+		rules.minDelegationDuration = time.Duration(params.MinDelegationDuration) * time.Second
+		rules.maxStakeDuration = time.Duration(params.MaxStakeDuration) * time.Second
+		rules.minSelfStakeRatio = params.MinSelfStakeRatio
+		rules.feeConversionRate = params.FeeConversionRate
+	}
+	return rules, nil
+}
+
+// getSubnetStakingParams returns the staking parameters that replaced the ones
+// of the transformation of [subnetID], or nil if they were never updated.
+func getSubnetStakingParams(chainState state.Chain, subnetID ids.ID) (*state.SubnetStakingParams, error) {
+	params, err := chainState.GetSubnetStakingParams(subnetID)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch the staking params of subnet %s: %w",
+			subnetID,
+			err,
+		)
+	}
+	return params, nil
 }
 
 // GetNextStakerChangeTime returns the next time a staker will be either added
@@ -143,27 +203,84 @@ func GetValidator(state state.Chain, subnetID ids.ID, nodeID ids.NodeID) (*state
 	return state.GetPendingValidator(subnetID, nodeID)
 }
 
-// overDelegated returns true if [validator] will be overdelegated when adding [delegator].
+// applyDelegationCap returns [weightLimit] lowered to the delegation cap that
+// [validator] set when it was added, if any. The returned limit includes the
+// validator's own weight, like [weightLimit].
+func applyDelegationCap(
+	chainState state.Chain,
+	validator *state.Staker,
+	weightLimit uint64,
+) (uint64, error) {
+	validatorTx, _, err := chainState.GetTx(validator.TxID)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to fetch the validator tx %s: %w",
+			validator.TxID,
+			err,
+		)
+	}
+
+	cappedTx, ok := validatorTx.Unsigned.(*txs.AddCappedPermissionlessValidatorTx)
+	if !ok {
+		return weightLimit, nil
+	}
+
+	capWeight, err := math.Add64(validator.Weight, cappedTx.MaxDelegatedWeight)
+	if err != nil {
+		return weightLimit, nil
+	}
+	return min(weightLimit, capWeight), nil
+}
+
+// applyMinSelfStakeRatio returns [weightLimit] lowered so that the own weight
+// of a validator, [validatorWeight], remains at least [minSelfStakeRatio] of
+// its total weight. The returned limit includes the validator's own weight,
+// like [weightLimit].
+func applyMinSelfStakeRatio(
+	validatorWeight uint64,
+	minSelfStakeRatio uint32,
+	weightLimit uint64,
+) uint64 {
+	if minSelfStakeRatio == 0 {
+		return weightLimit
+	}
+
+	// The total weight must satisfy:
+	// validatorWeight / totalWeight >= minSelfStakeRatio / PercentDenominator
+	maxWeight := new(big.Int).SetUint64(validatorWeight)
+	maxWeight.Mul(maxWeight, new(big.Int).SetUint64(reward.PercentDenominator))
+	maxWeight.Div(maxWeight, new(big.Int).SetUint64(uint64(minSelfStakeRatio)))
+	if !maxWeight.IsUint64() {
+		return weightLimit
+	}
+	return min(weightLimit, maxWeight.Uint64())
+}
+
+// verifyNotOverDelegated returns an error if [validator] will be overdelegated
+// when adding [delegator].
 //
 // A [validator] would become overdelegated if:
 // - the maximum total weight on [validator] exceeds [weightLimit]
-func overDelegated(
+func verifyNotOverDelegated(
 	state state.Chain,
 	validator *state.Staker,
 	weightLimit uint64,
 	delegatorWeight uint64,
 	delegatorStartTime time.Time,
 	delegatorEndTime time.Time,
-) (bool, error) {
+) error {
 	maxWeight, err := GetMaxWeight(state, validator, delegatorStartTime, delegatorEndTime)
 	if err != nil {
-		return true, err
+		return err
 	}
 	newMaxWeight, err := math.Add64(maxWeight, delegatorWeight)
 	if err != nil {
-		return true, err
+		return err
+	}
+	if newMaxWeight > weightLimit {
+		return amountLimitError(ErrOverDelegated, StakerLimitValidatorOverDelegated, newMaxWeight, ">", weightLimit)
 	}
-	return newMaxWeight > weightLimit, nil
+	return nil
 }
 
 // GetMaxWeight returns the maximum total weight of the [validator], including
@@ -178,69 +295,46 @@ func GetMaxWeight(
 	startTime time.Time,
 	endTime time.Time,
 ) (uint64, error) {
-	currentDelegatorIterator, err := chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	schedule, err := state.GetDelegatorWeightSchedule(chainState, validator.SubnetID, validator.NodeID)
 	if err != nil {
 		return 0, err
 	}
 
-	// TODO: We can optimize this by moving the current total weight to be
-	//       stored in the validator state.
-	//
 	// Calculate the current total weight on this validator, including the
 	// weight of the actual validator and the sum of the weights of all of the
 	// currently active delegators.
-	currentWeight := validator.Weight
-	for currentDelegatorIterator.Next() {
-		currentDelegator := currentDelegatorIterator.Value()
-
-		currentWeight, err = math.Add64(currentWeight, currentDelegator.Weight)
-		if err != nil {
-			currentDelegatorIterator.Release()
-			return 0, err
-		}
-	}
-	currentDelegatorIterator.Release()
-
-	currentDelegatorIterator, err = chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
-	if err != nil {
-		return 0, err
-	}
-	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(validator.SubnetID, validator.NodeID)
+	currentWeight, err := math.Add64(validator.Weight, schedule.Current)
 	if err != nil {
-		currentDelegatorIterator.Release()
 		return 0, err
 	}
-	delegatorChangesIterator := state.NewStakerDiffIterator(currentDelegatorIterator, pendingDelegatorIterator)
-	defer delegatorChangesIterator.Release()
 
 	// Iterate over the future stake weight changes and calculate the maximum
 	// total weight on the validator, only including the points in the time
 	// range [startTime, endTime].
 	var currentMax uint64
-	for delegatorChangesIterator.Next() {
-		delegator, isAdded := delegatorChangesIterator.Value()
-		// [delegator.NextTime] > [endTime]
-		if delegator.NextTime.After(endTime) {
+	for _, change := range schedule.Changes {
+		// [change.Time] > [endTime]
+		if change.Time.After(endTime) {
 			// This delegation change (and all following changes) occurs after
 			// [endTime]. Since we're calculating the max amount staked in
 			// [startTime, endTime], we can stop.
 			break
 		}
 
-		// [delegator.NextTime] >= [startTime]
-		if !delegator.NextTime.Before(startTime) {
+		// [change.Time] >= [startTime]
+		if !change.Time.Before(startTime) {
 			// We have advanced time to be at the inside of the delegation
 			// window. Make sure that the max weight is updated accordingly.
 			currentMax = max(currentMax, currentWeight)
 		}
 
 		var op func(uint64, uint64) (uint64, error)
-		if isAdded {
+		if change.Added {
 			op = math.Add64
 		} else {
 			op = math.Sub[uint64]
 		}
-		currentWeight, err = op(currentWeight, delegator.Weight)
+		currentWeight, err = op(currentWeight, change.Weight)
 		if err != nil {
 			return 0, err
 		}
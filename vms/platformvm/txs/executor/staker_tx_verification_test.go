@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -17,10 +18,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -185,6 +189,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				state := state.NewMockChain(ctrl)
 				state.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			sTxF: func() *txs.Tx {
@@ -212,6 +217,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				state := state.NewMockChain(ctrl)
 				state.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			sTxF: func() *txs.Tx {
@@ -239,6 +245,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				state := state.NewMockChain(ctrl)
 				state.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			sTxF: func() *txs.Tx {
@@ -267,6 +274,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				state := state.NewMockChain(ctrl)
 				state.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			sTxF: func() *txs.Tx {
@@ -298,6 +306,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				state := state.NewMockChain(ctrl)
 				state.EXPECT().GetTimestamp().Return(time.Unix(1, 0)) // chain time is after fork activation since time.Unix(1, 0).After(activeForkTime)
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			sTxF: func() *txs.Tx {
@@ -329,6 +338,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				mockState := state.NewMockChain(ctrl)
 				mockState.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				mockState.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				mockState.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return mockState
 			},
 			sTxF: func() *txs.Tx {
@@ -362,6 +372,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				mockState := state.NewMockChain(ctrl)
 				mockState.EXPECT().GetTimestamp().Return(now) // chain time is after latest fork activation since now.After(activeForkTime)
 				mockState.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				mockState.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				// State says validator exists
 				mockState.EXPECT().GetCurrentValidator(subnetID, verifiedTx.NodeID()).Return(nil, nil)
 				return mockState
@@ -389,6 +400,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				mockState := state.NewMockChain(ctrl)
 				mockState.EXPECT().GetTimestamp().Return(now).Times(2) // chain time is after latest fork activation since now.After(activeForkTime)
 				mockState.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				mockState.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetCurrentValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetPendingValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				// Validator time isn't subset of primary network validator time
@@ -436,6 +448,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				mockState := state.NewMockChain(ctrl)
 				mockState.EXPECT().GetTimestamp().Return(now).Times(2) // chain time is after latest fork activation since now.After(activeForkTime)
 				mockState.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				mockState.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetCurrentValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetPendingValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				primaryNetworkVdr := &state.Staker{
@@ -482,6 +495,7 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 				mockState := state.NewMockChain(ctrl)
 				mockState.EXPECT().GetTimestamp().Return(now).Times(2) // chain time is after Durango fork activation since now.After(activeForkTime)
 				mockState.EXPECT().GetSubnetTransformation(subnetID).Return(&transformTx, nil)
+				mockState.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetCurrentValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				mockState.EXPECT().GetPendingValidator(subnetID, verifiedTx.NodeID()).Return(nil, database.ErrNotFound)
 				primaryNetworkVdr := &state.Staker{
@@ -606,6 +620,7 @@ func TestGetValidatorRules(t *testing.T) {
 					},
 				}
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(tx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			expectedRules: &addValidatorRules{
@@ -618,6 +633,60 @@ func TestGetValidatorRules(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:     "can't get subnet staking params",
+			subnetID: subnetID,
+			backend:  nil,
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				tx := &txs.Tx{
+					Unsigned: &txs.TransformSubnetTx{},
+				}
+				state.EXPECT().GetSubnetTransformation(subnetID).Return(tx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, errTest)
+				return state
+			},
+			expectedRules: &addValidatorRules{},
+			expectedErr:   errTest,
+		},
+		{
+			name:     "subnet with updated staking params",
+			subnetID: subnetID,
+			backend:  nil,
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				chainState := state.NewMockChain(ctrl)
+				tx := &txs.Tx{
+					Unsigned: &txs.TransformSubnetTx{
+						AssetID:           customAssetID,
+						MinValidatorStake: config.MinValidatorStake,
+						MaxValidatorStake: config.MaxValidatorStake,
+						MinStakeDuration:  1337,
+						MaxStakeDuration:  42,
+						MinDelegationFee:  config.MinDelegationFee,
+					},
+				}
+				chainState.EXPECT().GetSubnetTransformation(subnetID).Return(tx, nil)
+				chainState.EXPECT().GetSubnetStakingParams(subnetID).Return(&state.SubnetStakingParams{
+					MinValidatorStake:     10,
+					MaxValidatorStake:     20,
+					MinStakeDuration:      30,
+					MaxStakeDuration:      40,
+					MinDelegatorStake:     50,
+					MinDelegationDuration: 60,
+					FeeConversionRate:     80,
+				}, nil)
+				return chainState
+			},
+			expectedRules: &addValidatorRules{
+				assetID:           customAssetID,
+				minValidatorStake: 10,
+				maxValidatorStake: 20,
+				minStakeDuration:  30 * time.Second,
+				maxStakeDuration:  40 * time.Second,
+				minDelegationFee:  config.MinDelegationFee,
+				feeConversionRate: 80,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -648,10 +717,11 @@ func TestGetDelegatorRules(t *testing.T) {
 	}
 	var (
 		config = &config.Config{
-			MinDelegatorStake: 1,
-			MaxValidatorStake: 2,
-			MinStakeDuration:  time.Second,
-			MaxStakeDuration:  2 * time.Second,
+			MinDelegatorStake:     1,
+			MaxValidatorStake:     2,
+			MinStakeDuration:      time.Second,
+			MinDelegationDuration: 3 * time.Second,
+			MaxStakeDuration:      2 * time.Second,
 		}
 		avaxAssetID   = ids.GenerateTestID()
 		customAssetID = ids.GenerateTestID()
@@ -674,7 +744,7 @@ func TestGetDelegatorRules(t *testing.T) {
 				assetID:                  avaxAssetID,
 				minDelegatorStake:        config.MinDelegatorStake,
 				maxValidatorStake:        config.MaxValidatorStake,
-				minStakeDuration:         config.MinStakeDuration,
+				minDelegationDuration:    config.MinDelegationDuration,
 				maxStakeDuration:         config.MaxStakeDuration,
 				maxValidatorWeightFactor: MaxValidatorWeightFactor,
 			},
@@ -725,18 +795,59 @@ func TestGetDelegatorRules(t *testing.T) {
 					},
 				}
 				state.EXPECT().GetSubnetTransformation(subnetID).Return(tx, nil)
+				state.EXPECT().GetSubnetStakingParams(subnetID).Return(nil, database.ErrNotFound)
 				return state
 			},
 			expectedRules: &addDelegatorRules{
 				assetID:                  customAssetID,
 				minDelegatorStake:        config.MinDelegatorStake,
 				maxValidatorStake:        config.MaxValidatorStake,
-				minStakeDuration:         1337 * time.Second,
+				minDelegationDuration:    1337 * time.Second,
 				maxStakeDuration:         42 * time.Second,
 				maxValidatorWeightFactor: 21,
 			},
 			expectedErr: nil,
 		},
+		{
+			name:     "subnet with updated staking params",
+			subnetID: subnetID,
+			backend:  nil,
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				chainState := state.NewMockChain(ctrl)
+				tx := &txs.Tx{
+					Unsigned: &txs.TransformSubnetTx{
+						AssetID:                  customAssetID,
+						MinDelegatorStake:        config.MinDelegatorStake,
+						MaxValidatorStake:        config.MaxValidatorStake,
+						MinStakeDuration:         1337,
+						MaxStakeDuration:         42,
+						MaxValidatorWeightFactor: 21,
+					},
+				}
+				chainState.EXPECT().GetSubnetTransformation(subnetID).Return(tx, nil)
+				chainState.EXPECT().GetSubnetStakingParams(subnetID).Return(&state.SubnetStakingParams{
+					MinValidatorStake:     10,
+					MaxValidatorStake:     20,
+					MinStakeDuration:      30,
+					MaxStakeDuration:      40,
+					MinDelegatorStake:     50,
+					MinDelegationDuration: 60,
+					MinSelfStakeRatio:     70,
+					FeeConversionRate:     80,
+				}, nil)
+				return chainState
+			},
+			expectedRules: &addDelegatorRules{
+				assetID:                  customAssetID,
+				minDelegatorStake:        50,
+				maxValidatorStake:        20,
+				minDelegationDuration:    60 * time.Second,
+				maxStakeDuration:         40 * time.Second,
+				maxValidatorWeightFactor: 21,
+				minSelfStakeRatio:        70,
+				feeConversionRate:        80,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -754,3 +865,231 @@ func TestGetDelegatorRules(t *testing.T) {
 		})
 	}
 }
+
+func TestStakerTxFees(t *testing.T) {
+	var (
+		avaxAssetID   = ids.GenerateTestID()
+		customAssetID = ids.GenerateTestID()
+		backend       = &Backend{
+			Ctx: &snow.Context{
+				AVAXAssetID: avaxAssetID,
+			},
+		}
+		customIn = &avax.TransferableInput{
+			Asset: avax.Asset{ID: customAssetID},
+		}
+		avaxIn = &avax.TransferableInput{
+			Asset: avax.Asset{ID: avaxAssetID},
+		}
+	)
+
+	tests := []struct {
+		name           string
+		stakingAssetID ids.ID
+		rate           uint64
+		ins            []*avax.TransferableInput
+		txFee          uint64
+		expectedFees   map[ids.ID]uint64
+		expectedErr    error
+	}{
+		{
+			name:           "primary network",
+			stakingAssetID: avaxAssetID,
+			rate:           3 * units.Avax,
+			ins:            []*avax.TransferableInput{avaxIn},
+			txFee:          10,
+			expectedFees: map[ids.ID]uint64{
+				avaxAssetID: 10,
+			},
+		},
+		{
+			name:           "subnet without conversion rate",
+			stakingAssetID: customAssetID,
+			rate:           0,
+			ins:            []*avax.TransferableInput{customIn},
+			txFee:          10,
+			expectedFees: map[ids.ID]uint64{
+				avaxAssetID: 10,
+			},
+		},
+		{
+			name:           "consumes AVAX",
+			stakingAssetID: customAssetID,
+			rate:           3 * units.Avax,
+			ins:            []*avax.TransferableInput{customIn, avaxIn},
+			txFee:          10,
+			expectedFees: map[ids.ID]uint64{
+				avaxAssetID: 10,
+			},
+		},
+		{
+			name:           "pays in staking asset",
+			stakingAssetID: customAssetID,
+			rate:           3 * units.Avax,
+			ins:            []*avax.TransferableInput{customIn},
+			txFee:          10,
+			expectedFees: map[ids.ID]uint64{
+				customAssetID: 30,
+			},
+		},
+		{
+			name:           "rounds up",
+			stakingAssetID: customAssetID,
+			rate:           1,
+			ins:            []*avax.TransferableInput{customIn},
+			txFee:          units.Avax + 1,
+			expectedFees: map[ids.ID]uint64{
+				customAssetID: 2,
+			},
+		},
+		{
+			name:           "overflow",
+			stakingAssetID: customAssetID,
+			rate:           3 * units.Avax,
+			ins:            []*avax.TransferableInput{customIn},
+			txFee:          math.MaxUint64,
+			expectedErr:    ErrFeeConversionOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fees, err := stakerTxFees(backend, test.stakingAssetID, test.rate, test.ins, test.txFee)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedFees, fees)
+		})
+	}
+}
+
+func TestApplyMinSelfStakeRatio(t *testing.T) {
+	tests := []struct {
+		name              string
+		validatorWeight   uint64
+		minSelfStakeRatio uint32
+		weightLimit       uint64
+		expectedLimit     uint64
+	}{
+		{
+			name:              "no minimum",
+			validatorWeight:   10,
+			minSelfStakeRatio: 0,
+			weightLimit:       50,
+			expectedLimit:     50,
+		},
+		{
+			name:              "minimum below limit",
+			validatorWeight:   10,
+			minSelfStakeRatio: reward.PercentDenominator / 4,
+			weightLimit:       50,
+			expectedLimit:     40,
+		},
+		{
+			name:              "minimum above limit",
+			validatorWeight:   10,
+			minSelfStakeRatio: reward.PercentDenominator / 10,
+			weightLimit:       50,
+			expectedLimit:     50,
+		},
+		{
+			name:              "minimum rounds down",
+			validatorWeight:   10,
+			minSelfStakeRatio: reward.PercentDenominator / 3,
+			weightLimit:       50,
+			expectedLimit:     30,
+		},
+		{
+			name:              "no delegation",
+			validatorWeight:   10,
+			minSelfStakeRatio: reward.PercentDenominator,
+			weightLimit:       50,
+			expectedLimit:     10,
+		},
+		{
+			name:              "minimum overflows",
+			validatorWeight:   math.MaxUint64,
+			minSelfStakeRatio: 1,
+			weightLimit:       math.MaxUint64,
+			expectedLimit:     math.MaxUint64,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit := applyMinSelfStakeRatio(test.validatorWeight, test.minSelfStakeRatio, test.weightLimit)
+			require.Equal(t, test.expectedLimit, limit)
+		})
+	}
+}
+
+func TestApplyDelegationCap(t *testing.T) {
+	var (
+		vdrTxID = ids.GenerateTestID()
+		vdr     = &state.Staker{
+			TxID:   vdrTxID,
+			Weight: 10,
+		}
+	)
+
+	tests := []struct {
+		name          string
+		vdrTx         txs.UnsignedTx
+		getTxErr      error
+		weightLimit   uint64
+		expectedLimit uint64
+		expectedErr   error
+	}{
+		{
+			name:          "uncapped validator",
+			vdrTx:         &txs.AddPermissionlessValidatorTx{},
+			weightLimit:   50,
+			expectedLimit: 50,
+		},
+		{
+			name: "cap below limit",
+			vdrTx: &txs.AddCappedPermissionlessValidatorTx{
+				MaxDelegatedWeight: 5,
+			},
+			weightLimit:   50,
+			expectedLimit: 15,
+		},
+		{
+			name: "cap above limit",
+			vdrTx: &txs.AddCappedPermissionlessValidatorTx{
+				MaxDelegatedWeight: 100,
+			},
+			weightLimit:   50,
+			expectedLimit: 50,
+		},
+		{
+			name: "cap overflows",
+			vdrTx: &txs.AddCappedPermissionlessValidatorTx{
+				MaxDelegatedWeight: math.MaxUint64,
+			},
+			weightLimit:   50,
+			expectedLimit: 50,
+		},
+		{
+			name:        "can't get validator tx",
+			getTxErr:    database.ErrNotFound,
+			weightLimit: 50,
+			expectedErr: database.ErrNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			chainState := state.NewMockChain(ctrl)
+			chainState.EXPECT().GetTx(vdrTxID).Return(
+				&txs.Tx{Unsigned: test.vdrTx},
+				status.Committed,
+				test.getTxErr,
+			)
+
+			limit, err := applyDelegationCap(chainState, vdr, test.weightLimit)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedLimit, limit)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
@@ -50,7 +51,7 @@ func (*StandardTxExecutor) RewardValidatorTx(*txs.RewardValidatorTx) error {
 }
 
 func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -101,7 +102,7 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 
 func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	// Make sure this transaction is well formed.
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -141,7 +142,7 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 }
 
 func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -227,7 +228,7 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 }
 
 func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -397,7 +398,22 @@ func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidat
 	}
 
 	if isCurrentValidator {
-		e.State.DeleteCurrentValidator(staker)
+		epoch, err := getSubnetValidatorEpoch(e.State, tx.Subnet)
+		if err != nil {
+			return err
+		}
+
+		chainTime := e.State.GetTimestamp()
+		if removalTime := nextEpochBoundary(chainTime, epoch); removalTime.After(chainTime) {
+			// The validator set of the subnet is frozen, so the validator is
+			// removed when the chain time reaches the next epoch boundary.
+			scheduledStaker := *staker
+			scheduledStaker.EndTime = removalTime
+			scheduledStaker.NextTime = removalTime
+			e.State.UpdateCurrentValidator(&scheduledStaker)
+		} else {
+			e.State.DeleteCurrentValidator(staker)
+		}
 	} else {
 		e.State.DeletePendingValidator(staker)
 	}
@@ -412,7 +428,7 @@ func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidat
 }
 
 func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -497,6 +513,203 @@ func (e *StandardTxExecutor) AddPermissionlessValidatorTx(tx *txs.AddPermissionl
 	return nil
 }
 
+func (e *StandardTxExecutor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	if err := verifyAddCappedPermissionlessValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	if err := e.putStaker(tx); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork &&
+		tx.Subnet == constants.PrimaryNetworkID &&
+		tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addCappedPermissionlessValidator"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddSplitRewardsPermissionlessValidatorTx(tx *txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	if err := verifyAddSplitRewardsPermissionlessValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	if err := e.putStaker(tx); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork &&
+		tx.Subnet == constants.PrimaryNetworkID &&
+		tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addSplitRewardsPermissionlessValidator"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddValidatorWithSubnetsTx(tx *txs.AddValidatorWithSubnetsTx) error {
+	subnetValidatorTxs, err := verifyAddValidatorWithSubnetsTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := e.putStaker(tx); err != nil {
+		return err
+	}
+
+	// Each subnet validator is stored as its own tx so that the subnet
+	// validator can be looked up, and removed, like any other.
+	for _, subnetValidatorTx := range subnetValidatorTxs {
+		e.State.AddTx(subnetValidatorTx, status.Committed)
+
+		subnetValidatorExecutor := StandardTxExecutor{
+			Backend: e.Backend,
+			State:   e.State,
+			Tx:      subnetValidatorTx,
+		}
+		subnetValidator := subnetValidatorTx.Unsigned.(*txs.AddSubnetValidatorTx)
+		if err := subnetValidatorExecutor.putStaker(subnetValidator); err != nil {
+			return err
+		}
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork &&
+		tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addValidatorWithSubnets"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddVestingPermissionlessValidatorTx(tx *txs.AddVestingPermissionlessValidatorTx) error {
+	if err := verifyAddVestingPermissionlessValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	if err := e.putStaker(tx); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork &&
+		tx.Subnet == constants.PrimaryNetworkID &&
+		tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addVestingPermissionlessValidator"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	if err := verifyAddPermissionlessValidatorWithMetadataTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	if err := e.putStaker(tx); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork &&
+		tx.Subnet == constants.PrimaryNetworkID &&
+		tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addPermissionlessValidatorWithMetadata"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+// Verifies a [*txs.SetSubnetValidatorEpochTx] and, if it passes, executes it
+// on [e.State]. For verification rules, see [verifySetSubnetValidatorEpochTx].
+// Validators that are already queued keep the times they were queued for.
+func (e *StandardTxExecutor) SetSubnetValidatorEpochTx(tx *txs.SetSubnetValidatorEpochTx) error {
+	if err := verifySetSubnetValidatorEpochTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	e.State.SetSubnetValidatorEpoch(tx.Subnet, time.Duration(tx.EpochHours)*time.Hour)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
 func (e *StandardTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	if err := verifyAddPermissionlessDelegatorTx(
 		e.Backend,
@@ -540,13 +753,186 @@ func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwn
 	return nil
 }
 
+// Verifies a [*txs.ChangeDelegationFeeTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyChangeDelegationFeeTx].
+// This transaction will result in delegators whose staking period starts at or
+// after the current chain time being charged [tx.DelegationShares].
+func (e *StandardTxExecutor) ChangeDelegationFeeTx(tx *txs.ChangeDelegationFeeTx) error {
+	vdr, changes, err := verifyChangeDelegationFeeTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Copy the changes to avoid modifying the slice held by the parent state.
+	newChanges := make([]*state.DelegationFeeChange, len(changes), len(changes)+1)
+	copy(newChanges, changes)
+	newChanges = append(newChanges, &state.DelegationFeeChange{
+		Time:   uint64(e.State.GetTimestamp().Unix()),
+		Shares: tx.DelegationShares,
+	})
+	e.State.SetDelegationFeeChanges(vdr.TxID, newChanges)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.SetValidatorBLSKeyTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifySetValidatorBLSKeyTx].
+func (e *StandardTxExecutor) SetValidatorBLSKeyTx(tx *txs.SetValidatorBLSKeyTx) error {
+	vdr, err := verifySetValidatorBLSKeyTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	newVdr := *vdr
+	newVdr.PublicKey = tx.Signer.Key()
+	e.State.UpdateCurrentValidator(&newVdr)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.ReportMisbehaviorTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyReportMisbehaviorTx].
+// This transaction will result in the rewards of the accused validator's
+// current staking period being withheld.
+func (e *StandardTxExecutor) ReportMisbehaviorTx(tx *txs.ReportMisbehaviorTx) error {
+	vdr, err := verifyReportMisbehaviorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	e.State.SetMisbehaviorReport(vdr.TxID, txID)
+
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.SetSubnetStakingParamsTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifySetSubnetStakingParamsTx].
+// The new parameters apply to stakers added after the tx is accepted.
+func (e *StandardTxExecutor) SetSubnetStakingParamsTx(tx *txs.SetSubnetStakingParamsTx) error {
+	if err := verifySetSubnetStakingParamsTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	e.State.SetSubnetStakingParams(tx.Subnet, &state.SubnetStakingParams{
+		MinValidatorStake:     tx.MinValidatorStake,
+		MaxValidatorStake:     tx.MaxValidatorStake,
+		MinStakeDuration:      tx.MinStakeDuration,
+		MaxStakeDuration:      tx.MaxStakeDuration,
+		MinDelegatorStake:     tx.MinDelegatorStake,
+		MinDelegationDuration: tx.MinDelegationDuration,
+		MinSelfStakeRatio:     tx.MinSelfStakeRatio,
+		FeeConversionRate:     tx.FeeConversionRate,
+	})
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.ReduceSubnetValidatorWeightTx] and, if it passes, executes
+// it on [e.State]. For verification rules, see
+// [verifyReduceSubnetValidatorWeightTx]. The validator keeps validating with
+// the reduced weight until the end of its validation period.
+func (e *StandardTxExecutor) ReduceSubnetValidatorWeightTx(tx *txs.ReduceSubnetValidatorWeightTx) error {
+	vdr, err := verifyReduceSubnetValidatorWeightTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	newVdr := *vdr
+	newVdr.Weight = tx.Weight
+	e.State.UpdateCurrentValidator(&newVdr)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+func (e *StandardTxExecutor) RotateValidatorNodeTx(tx *txs.RotateValidatorNodeTx) error {
+	vdr, err := verifyRotateValidatorNodeTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	delegatorIterator, err := e.State.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return err
+	}
+	var delegators []*state.Staker
+	for delegatorIterator.Next() {
+		delegators = append(delegators, delegatorIterator.Value())
+	}
+	delegatorIterator.Release()
+
+	// The validator and its delegators keep their txIDs, so their rewards and
+	// uptime are carried over to [NewNodeID].
+	e.State.DeleteCurrentValidator(vdr)
+	newVdr := *vdr
+	newVdr.NodeID = tx.NewNodeID
+	newVdr.PublicKey = tx.Signer.Key()
+	e.State.PutCurrentValidator(&newVdr)
+
+	for _, delegator := range delegators {
+		e.State.DeleteCurrentDelegator(delegator)
+		newDelegator := *delegator
+		newDelegator.NodeID = tx.NewNodeID
+		e.State.PutCurrentDelegator(&newDelegator)
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
 func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	if !e.Backend.Config.UpgradeConfig.IsDurangoActivated(e.State.GetTimestamp()) {
 		return ErrDurangoUpgradeNotActive
 	}
 
 	// Verify the tx is well-formed
-	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+	if err := e.SyntacticVerify(e.Tx); err != nil {
 		return err
 	}
 
@@ -629,6 +1015,20 @@ func (e *StandardTxExecutor) putStaker(stakerTx txs.Staker) error {
 		return err
 	}
 
+	// If the validator set of the subnet is frozen, permissioned validators
+	// are queued in the pending staker set until the next epoch boundary.
+	if staker.Priority.IsCurrentValidator() && staker.Priority.IsPermissionedValidator() {
+		epoch, err := getSubnetValidatorEpoch(e.State, staker.SubnetID)
+		if err != nil {
+			return err
+		}
+		if startTime := nextEpochBoundary(chainTime, epoch); startTime.After(chainTime) {
+			staker.StartTime = startTime
+			staker.NextTime = startTime
+			staker.Priority = txs.SubnetPermissionedValidatorPendingPriority
+		}
+	}
+
 	switch priority := staker.Priority; {
 	case priority.IsCurrentValidator():
 		e.State.PutCurrentValidator(staker)
@@ -20,10 +20,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -606,6 +608,35 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 		require.ErrorIs(err, ErrTimestampNotBeforeStartTime)
 	}
 
+	// Case: Proposed validator starts too far after the current timestamp
+	{
+		startTime := newTimestamp.Add(env.config.MaxFutureStartTime + time.Second)
+		tx, err := env.txBuilder.NewAddSubnetValidatorTx(
+			&txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					Start:  uint64(startTime.Unix()),
+					End:    uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+					Wght:   defaultWeight,
+				},
+				Subnet: testSubnet1.ID(),
+			},
+			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		)
+		require.NoError(err)
+
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+
+		executor := StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		}
+		err = tx.Unsigned.Visit(&executor)
+		require.ErrorIs(err, ErrFutureStakeTime)
+	}
+
 	// reset the timestamp
 	env.state.SetTimestamp(defaultGenesisTime)
 
@@ -1588,6 +1619,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				// Set dependency expectations.
 				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime).AnyTimes()
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil).Times(1)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(time.Duration(0), database.ErrNotFound).AnyTimes()
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
@@ -1613,6 +1645,71 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "frozen validator set schedules removal",
+			newExecutor: func(ctrl *gomock.Controller) (*txs.RemoveSubnetValidatorTx, *StandardTxExecutor) {
+				env := newValidRemoveSubnetValidatorTxVerifyEnv(t, ctrl)
+				env.staker.EndTime = env.latestForkTime.Add(72 * time.Hour)
+				removalTime := nextEpochBoundary(env.latestForkTime, 24*time.Hour)
+
+				// Set dependency expectations.
+				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime).AnyTimes()
+				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil).Times(1)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(24*time.Hour, nil).AnyTimes()
+				subnetOwner := fx.NewMockOwner(ctrl)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
+				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
+				env.flowChecker.EXPECT().VerifySpend(
+					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
+				).Return(nil).Times(1)
+				env.state.EXPECT().UpdateCurrentValidator(gomock.Any()).DoAndReturn(func(staker *state.Staker) {
+					require.Equal(t, removalTime, staker.EndTime)
+					require.Equal(t, removalTime, staker.NextTime)
+				})
+				env.state.EXPECT().DeleteUTXO(gomock.Any()).Times(len(env.unsignedTx.Ins))
+				env.state.EXPECT().AddUTXO(gomock.Any()).Times(len(env.unsignedTx.Outs))
+				e := &StandardTxExecutor{
+					Backend: &Backend{
+						Config:       defaultTestConfig(t, durango, env.latestForkTime),
+						Bootstrapped: &utils.Atomic[bool]{},
+						Fx:           env.fx,
+						FlowChecker:  env.flowChecker,
+						Ctx:          &snow.Context{},
+					},
+					Tx:    env.tx,
+					State: env.state,
+				}
+				e.Bootstrapped.Set(true)
+				return env.unsignedTx, e
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "removal already scheduled",
+			newExecutor: func(ctrl *gomock.Controller) (*txs.RemoveSubnetValidatorTx, *StandardTxExecutor) {
+				env := newValidRemoveSubnetValidatorTxVerifyEnv(t, ctrl)
+				env.staker.EndTime = nextEpochBoundary(env.latestForkTime, 24*time.Hour)
+
+				// Set dependency expectations.
+				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime).AnyTimes()
+				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil).Times(1)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(24*time.Hour, nil).AnyTimes()
+				e := &StandardTxExecutor{
+					Backend: &Backend{
+						Config:       defaultTestConfig(t, durango, env.latestForkTime),
+						Bootstrapped: &utils.Atomic[bool]{},
+						Fx:           env.fx,
+						FlowChecker:  env.flowChecker,
+						Ctx:          &snow.Context{},
+					},
+					Tx:    env.tx,
+					State: env.state,
+				}
+				e.Bootstrapped.Set(true)
+				return env.unsignedTx, e
+			},
+			expectedErr: ErrRemovalAlreadyScheduled,
+		},
 		{
 			name: "tx fails syntactic verification",
 			newExecutor: func(ctrl *gomock.Controller) (*txs.RemoveSubnetValidatorTx, *StandardTxExecutor) {
@@ -1696,6 +1793,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(time.Duration(0), database.ErrNotFound).AnyTimes()
 				e := &StandardTxExecutor{
 					Backend: &Backend{
 						Config:       defaultTestConfig(t, durango, env.latestForkTime),
@@ -1719,6 +1817,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(time.Duration(0), database.ErrNotFound).AnyTimes()
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound)
 				e := &StandardTxExecutor{
 					Backend: &Backend{
@@ -1743,6 +1842,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(time.Duration(0), database.ErrNotFound).AnyTimes()
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(errTest)
@@ -1769,6 +1869,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
+				env.state.EXPECT().GetSubnetValidatorEpoch(env.unsignedTx.Subnet).Return(time.Duration(0), database.ErrNotFound).AnyTimes()
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
@@ -2079,6 +2180,1364 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 	}
 }
 
+// Returns a ChangeDelegationFeeTx that passes syntactic verification.
+func newChangeDelegationFeeTx(t *testing.T, shares uint32) (*txs.ChangeDelegationFeeTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.ChangeDelegationFeeTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		NodeID:           ids.GenerateTestNodeID(),
+		Subnet:           constants.PrimaryNetworkID,
+		DelegationShares: shares,
+		ValidatorAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorChangeDelegationFeeTx(t *testing.T) {
+	var (
+		now        = time.Now().Truncate(time.Second)
+		cooldown   = time.Hour
+		vdrTxID    = ids.GenerateTestID()
+		vdrStaker  = &state.Staker{TxID: vdrTxID, Priority: txs.PrimaryNetworkValidatorCurrentPriority}
+		lastChange = &state.DelegationFeeChange{
+			Time:   uint64(now.Add(-cooldown).Unix()),
+			Shares: 30_000,
+		}
+	)
+
+	tests := []struct {
+		name        string
+		fork        fork
+		shares      uint32
+		staker      *state.Staker
+		changes     []*state.DelegationFeeChange
+		authErr     error
+		expectedErr error
+	}{
+		{
+			name:        "valid tx",
+			fork:        eUpgrade,
+			shares:      40_000,
+			staker:      vdrStaker,
+			changes:     []*state.DelegationFeeChange{lastChange},
+			expectedErr: nil,
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			shares:      40_000,
+			staker:      vdrStaker,
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:   "permissioned validator",
+			fork:   eUpgrade,
+			shares: 40_000,
+			staker: &state.Staker{
+				TxID:     vdrTxID,
+				Priority: txs.SubnetPermissionedValidatorCurrentPriority,
+			},
+			expectedErr: ErrChangeFeeOfPermissionedVdr,
+		},
+		{
+			name:        "fee below minimum",
+			fork:        eUpgrade,
+			shares:      19_999,
+			staker:      vdrStaker,
+			expectedErr: ErrInsufficientDelegationFee,
+		},
+		{
+			name:   "changed too recently",
+			fork:   eUpgrade,
+			shares: 40_000,
+			staker: vdrStaker,
+			changes: []*state.DelegationFeeChange{{
+				Time:   lastChange.Time + 1,
+				Shares: 30_000,
+			}},
+			expectedErr: ErrDelegationFeeChangeTooSoon,
+		},
+		{
+			name:        "unauthorized",
+			fork:        eUpgrade,
+			shares:      40_000,
+			staker:      vdrStaker,
+			authErr:     errTest,
+			expectedErr: ErrUnauthorizedValidatorChange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newChangeDelegationFeeTx(t, test.shares)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				rewardsOwner   = fx.NewMockOwner(ctrl)
+				vdrTx          = &txs.Tx{
+					Unsigned: &txs.AddPermissionlessValidatorTx{
+						ValidatorRewardsOwner: rewardsOwner,
+					},
+				}
+			)
+
+			cfg := defaultTestConfig(t, test.fork, now)
+			cfg.MinDelegationFee = 20_000
+			cfg.DelegationFeeChangeCooldown = cooldown
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(unsignedTx.Subnet, unsignedTx.NodeID).Return(test.staker, nil).AnyTimes()
+			chainState.EXPECT().GetDelegationFeeChanges(vdrTxID).Return(test.changes, nil).AnyTimes()
+			chainState.EXPECT().GetTx(vdrTxID).Return(vdrTx, status.Committed, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.ValidatorAuth, tx.Creds[1], rewardsOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(nil).AnyTimes()
+			if test.expectedErr == nil {
+				expectedChanges := make([]*state.DelegationFeeChange, 0, len(test.changes)+1)
+				expectedChanges = append(expectedChanges, test.changes...)
+				expectedChanges = append(expectedChanges, &state.DelegationFeeChange{
+					Time:   uint64(now.Unix()),
+					Shares: test.shares,
+				})
+				chainState.EXPECT().SetDelegationFeeChanges(vdrTxID, expectedChanges)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func newRotateValidatorNodeTx(t *testing.T, sig signer.Signer) (*txs.RotateValidatorNodeTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.RotateValidatorNodeTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		NodeID:    ids.GenerateTestNodeID(),
+		NewNodeID: ids.GenerateTestNodeID(),
+		Signer:    sig,
+		ValidatorAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+// newMockStakerIterator returns an iterator over [stakers].
+func newMockStakerIterator(ctrl *gomock.Controller, stakers ...*state.Staker) state.StakerIterator {
+	index := -1
+	it := state.NewMockStakerIterator(ctrl)
+	it.EXPECT().Next().DoAndReturn(func() bool {
+		index++
+		return index < len(stakers)
+	}).AnyTimes()
+	it.EXPECT().Value().DoAndReturn(func() *state.Staker {
+		return stakers[index]
+	}).AnyTimes()
+	it.EXPECT().Release().AnyTimes()
+	return it
+}
+
+func TestStandardExecutorRotateValidatorNodeTx(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		now     = time.Now().Truncate(time.Second)
+		vdrTxID = ids.GenerateTestID()
+		pop     = signer.NewProofOfPossession(sk)
+	)
+
+	tests := []struct {
+		name              string
+		fork              fork
+		signer            signer.Signer
+		vdrPublicKey      *bls.PublicKey
+		newNodeValidates  bool
+		pendingDelegators bool
+		subnetValidator   bool
+		authErr           error
+		expectedErr       error
+	}{
+		{
+			name:         "valid tx",
+			fork:         eUpgrade,
+			signer:       pop,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			expectedErr:  nil,
+		},
+		{
+			name:        "valid tx without BLS key",
+			fork:        eUpgrade,
+			signer:      &signer.Empty{},
+			expectedErr: nil,
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			signer:      &signer.Empty{},
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:             "new node is already a validator",
+			fork:             eUpgrade,
+			signer:           &signer.Empty{},
+			newNodeValidates: true,
+			expectedErr:      ErrDuplicateValidator,
+		},
+		{
+			name:         "missing BLS key",
+			fork:         eUpgrade,
+			signer:       &signer.Empty{},
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			expectedErr:  ErrRotateSignerMismatch,
+		},
+		{
+			name:              "pending delegators",
+			fork:              eUpgrade,
+			signer:            &signer.Empty{},
+			pendingDelegators: true,
+			expectedErr:       ErrRotatePendingDelegators,
+		},
+		{
+			name:            "subnet validator",
+			fork:            eUpgrade,
+			signer:          &signer.Empty{},
+			subnetValidator: true,
+			expectedErr:     ErrRotateSubnetValidator,
+		},
+		{
+			name:        "unauthorized",
+			fork:        eUpgrade,
+			signer:      &signer.Empty{},
+			authErr:     errTest,
+			expectedErr: ErrUnauthorizedValidatorChange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newRotateValidatorNodeTx(t, test.signer)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				rewardsOwner   = fx.NewMockOwner(ctrl)
+				vdrTx          = &txs.Tx{
+					Unsigned: &txs.AddPermissionlessValidatorTx{
+						ValidatorRewardsOwner: rewardsOwner,
+					},
+				}
+				vdr = &state.Staker{
+					TxID:      vdrTxID,
+					NodeID:    unsignedTx.NodeID,
+					PublicKey: test.vdrPublicKey,
+					SubnetID:  constants.PrimaryNetworkID,
+					Weight:    1,
+					Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+				}
+				delegator = &state.Staker{
+					TxID:     ids.GenerateTestID(),
+					NodeID:   unsignedTx.NodeID,
+					SubnetID: constants.PrimaryNetworkID,
+					Weight:   1,
+					Priority: txs.PrimaryNetworkDelegatorCurrentPriority,
+				}
+				currentSubnetIDs = set.Of(constants.PrimaryNetworkID)
+
+				pendingDelegators []*state.Staker
+				newNodeErr        = database.ErrNotFound
+			)
+			if test.newNodeValidates {
+				newNodeErr = nil
+			}
+			if test.pendingDelegators {
+				pendingDelegators = append(pendingDelegators, &state.Staker{
+					TxID:     ids.GenerateTestID(),
+					NodeID:   unsignedTx.NodeID,
+					SubnetID: constants.PrimaryNetworkID,
+					Priority: txs.PrimaryNetworkDelegatorBanffPendingPriority,
+				})
+			}
+			if test.subnetValidator {
+				currentSubnetIDs.Add(ids.GenerateTestID())
+			}
+
+			cfg := defaultTestConfig(t, test.fork, now)
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, unsignedTx.NodeID).Return(vdr, nil).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, unsignedTx.NewNodeID).Return(&state.Staker{}, newNodeErr).AnyTimes()
+			chainState.EXPECT().GetPendingValidator(constants.PrimaryNetworkID, unsignedTx.NewNodeID).Return(nil, database.ErrNotFound).AnyTimes()
+			chainState.EXPECT().GetPendingDelegatorIterator(constants.PrimaryNetworkID, unsignedTx.NodeID).DoAndReturn(
+				func(ids.ID, ids.NodeID) (state.StakerIterator, error) {
+					return newMockStakerIterator(ctrl, pendingDelegators...), nil
+				},
+			).AnyTimes()
+			chainState.EXPECT().GetCurrentValidatorSubnetIDs(unsignedTx.NodeID).Return(currentSubnetIDs, nil).AnyTimes()
+			chainState.EXPECT().GetPendingValidatorSubnetIDs(unsignedTx.NodeID).Return(nil, nil).AnyTimes()
+			chainState.EXPECT().GetTx(vdrTxID).Return(vdrTx, status.Committed, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.ValidatorAuth, tx.Creds[1], rewardsOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(nil).AnyTimes()
+			if test.expectedErr == nil {
+				newVdr := *vdr
+				newVdr.NodeID = unsignedTx.NewNodeID
+				newVdr.PublicKey = test.signer.Key()
+				newDelegator := *delegator
+				newDelegator.NodeID = unsignedTx.NewNodeID
+
+				chainState.EXPECT().GetCurrentDelegatorIterator(constants.PrimaryNetworkID, unsignedTx.NodeID).Return(
+					newMockStakerIterator(ctrl, delegator), nil,
+				)
+				chainState.EXPECT().DeleteCurrentValidator(vdr)
+				chainState.EXPECT().PutCurrentValidator(&newVdr)
+				chainState.EXPECT().DeleteCurrentDelegator(delegator)
+				chainState.EXPECT().PutCurrentDelegator(&newDelegator)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func newSetValidatorBLSKeyTx(t *testing.T, sig signer.Signer) (*txs.SetValidatorBLSKeyTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.SetValidatorBLSKeyTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		NodeID: ids.GenerateTestNodeID(),
+		Signer: sig,
+		ValidatorAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorSetValidatorBLSKeyTx(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	oldSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		now     = time.Now().Truncate(time.Second)
+		vdrTxID = ids.GenerateTestID()
+		pop     = signer.NewProofOfPossession(sk)
+	)
+
+	tests := []struct {
+		name         string
+		fork         fork
+		vdrPublicKey *bls.PublicKey
+		authErr      error
+		expectedErr  error
+	}{
+		{
+			name:        "register BLS key",
+			fork:        eUpgrade,
+			expectedErr: nil,
+		},
+		{
+			name:         "rotate BLS key",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(oldSK),
+			expectedErr:  nil,
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:         "unchanged BLS key",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			expectedErr:  ErrBLSKeyUnchanged,
+		},
+		{
+			name:        "unauthorized",
+			fork:        eUpgrade,
+			authErr:     errTest,
+			expectedErr: ErrUnauthorizedValidatorChange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newSetValidatorBLSKeyTx(t, pop)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				rewardsOwner   = fx.NewMockOwner(ctrl)
+				vdrTx          = &txs.Tx{
+					Unsigned: &txs.AddValidatorTx{
+						RewardsOwner: rewardsOwner,
+					},
+				}
+				vdr = &state.Staker{
+					TxID:      vdrTxID,
+					NodeID:    unsignedTx.NodeID,
+					PublicKey: test.vdrPublicKey,
+					SubnetID:  constants.PrimaryNetworkID,
+					Weight:    1,
+					Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+				}
+			)
+
+			cfg := defaultTestConfig(t, test.fork, now)
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, unsignedTx.NodeID).Return(vdr, nil).AnyTimes()
+			chainState.EXPECT().GetTx(vdrTxID).Return(vdrTx, status.Committed, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.ValidatorAuth, tx.Creds[1], rewardsOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(nil).AnyTimes()
+			if test.expectedErr == nil {
+				newVdr := *vdr
+				newVdr.PublicKey = bls.PublicFromSecretKey(sk)
+
+				chainState.EXPECT().UpdateCurrentValidator(&newVdr)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+// newConflictingAttestations returns evidence that [nodeID], holding [sk],
+// attested to two different hashes at the same height.
+func newConflictingAttestations(t *testing.T, sk *bls.SecretKey, networkID uint32, nodeID ids.NodeID) *txs.ConflictingAttestations {
+	t.Helper()
+
+	var (
+		chainID  = ids.GenerateTestID()
+		evidence = &txs.ConflictingAttestations{
+			NodeID: nodeID,
+		}
+	)
+	for i := range evidence.Attestations {
+		msg := &attestation.Unsigned{
+			NetworkID: networkID,
+			ChainID:   chainID,
+			Height:    1,
+			Hash:      ids.GenerateTestID(),
+		}
+		msgBytes := msg.Bytes()
+		evidence.Attestations[i] = txs.Attestation{
+			UnsignedAttestation: msgBytes,
+			Signature:           bls.SignatureToBytes(bls.Sign(sk, msgBytes)),
+		}
+	}
+	return evidence
+}
+
+func newReportMisbehaviorTx(t *testing.T, evidence txs.Evidence) (*txs.ReportMisbehaviorTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.ReportMisbehaviorTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID: constants.UnitTestID,
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		Evidence: evidence,
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorReportMisbehaviorTx(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		now     = time.Now().Truncate(time.Second)
+		nodeID  = ids.GenerateTestNodeID()
+		vdrTxID = ids.GenerateTestID()
+	)
+
+	tests := []struct {
+		name         string
+		fork         fork
+		vdrPublicKey *bls.PublicKey
+		reportErr    error
+		flowCheckErr error
+		expectedErr  error
+	}{
+		{
+			name:         "report misbehavior",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			reportErr:    database.ErrNotFound,
+			expectedErr:  nil,
+		},
+		{
+			name:         "E upgrade not active",
+			fork:         durango,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			reportErr:    database.ErrNotFound,
+			expectedErr:  ErrEUpgradeNotActive,
+		},
+		{
+			name:         "already reported",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			reportErr:    nil,
+			expectedErr:  ErrMisbehaviorAlreadyReported,
+		},
+		{
+			name:        "accused has no BLS key",
+			fork:        eUpgrade,
+			reportErr:   database.ErrNotFound,
+			expectedErr: ErrAccusedHasNoBLSKey,
+		},
+		{
+			name:         "evidence signed by another key",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(otherSK),
+			reportErr:    database.ErrNotFound,
+			expectedErr:  ErrInvalidEvidence,
+		},
+		{
+			name:         "flow check failed",
+			fork:         eUpgrade,
+			vdrPublicKey: bls.PublicFromSecretKey(sk),
+			reportErr:    database.ErrNotFound,
+			flowCheckErr: errTest,
+			expectedErr:  ErrFlowCheckFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				ctx            = &snow.Context{NetworkID: constants.UnitTestID}
+				evidence       = newConflictingAttestations(t, sk, ctx.NetworkID, nodeID)
+				unsignedTx, tx = newReportMisbehaviorTx(t, evidence)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				vdr            = &state.Staker{
+					TxID:      vdrTxID,
+					NodeID:    nodeID,
+					PublicKey: test.vdrPublicKey,
+					SubnetID:  constants.PrimaryNetworkID,
+					Weight:    1,
+					Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+				}
+			)
+
+			cfg := defaultTestConfig(t, test.fork, now)
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(vdr, nil).AnyTimes()
+			chainState.EXPECT().GetMisbehaviorReport(vdrTxID).Return(ids.GenerateTestID(), test.reportErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds, gomock.Any(),
+			).Return(test.flowCheckErr).AnyTimes()
+			if test.expectedErr == nil {
+				chainState.EXPECT().SetMisbehaviorReport(vdrTxID, tx.ID())
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					FlowChecker:  flowChecker,
+					Ctx:          ctx,
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func newSetSubnetStakingParamsTx(
+	t *testing.T,
+	modify func(*txs.SetSubnetStakingParamsTx),
+) (*txs.SetSubnetStakingParamsTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.SetSubnetStakingParamsTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		Subnet:                ids.GenerateTestID(),
+		MinValidatorStake:     10,
+		MaxValidatorStake:     100,
+		MinStakeDuration:      10,
+		MaxStakeDuration:      100,
+		MinDelegatorStake:     1,
+		MinDelegationDuration: 20,
+		MinSelfStakeRatio:     30,
+		FeeConversionRate:     40,
+		SubnetAuth:            &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	modify(unsignedTx)
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorSetSubnetStakingParamsTx(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		fork           fork
+		modify         func(*txs.SetSubnetStakingParamsTx)
+		transformErr   error
+		authErr        error
+		flowCheckErr   error
+		expectedErr    error
+		expectedParams *state.SubnetStakingParams
+	}{
+		{
+			name:   "valid tx",
+			fork:   eUpgrade,
+			modify: func(*txs.SetSubnetStakingParamsTx) {},
+			expectedParams: &state.SubnetStakingParams{
+				MinValidatorStake:     10,
+				MaxValidatorStake:     100,
+				MinStakeDuration:      10,
+				MaxStakeDuration:      100,
+				MinDelegatorStake:     1,
+				MinDelegationDuration: 20,
+				MinSelfStakeRatio:     30,
+				FeeConversionRate:     40,
+			},
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			modify:      func(*txs.SetSubnetStakingParamsTx) {},
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:         "not an elastic subnet",
+			fork:         eUpgrade,
+			modify:       func(*txs.SetSubnetStakingParamsTx) {},
+			transformErr: database.ErrNotFound,
+			expectedErr:  database.ErrNotFound,
+		},
+		{
+			name: "min validator stake above initial supply",
+			fork: eUpgrade,
+			modify: func(tx *txs.SetSubnetStakingParamsTx) {
+				tx.MinValidatorStake = 101
+				tx.MaxValidatorStake = 101
+			},
+			expectedErr: ErrMinValidatorStakeAboveSupply,
+		},
+		{
+			name: "max validator stake above maximum supply",
+			fork: eUpgrade,
+			modify: func(tx *txs.SetSubnetStakingParamsTx) {
+				tx.MaxValidatorStake = 1_001
+			},
+			expectedErr: ErrMaxValidatorStakeAboveSupply,
+		},
+		{
+			name: "max stake duration above global maximum",
+			fork: eUpgrade,
+			modify: func(tx *txs.SetSubnetStakingParamsTx) {
+				tx.MaxStakeDuration = 1_001
+			},
+			expectedErr: errMaxStakeDurationTooLarge,
+		},
+		{
+			name:        "unauthorized",
+			fork:        eUpgrade,
+			modify:      func(*txs.SetSubnetStakingParamsTx) {},
+			authErr:     errTest,
+			expectedErr: errUnauthorizedSubnetModification,
+		},
+		{
+			name:         "flow check failed",
+			fork:         eUpgrade,
+			modify:       func(*txs.SetSubnetStakingParamsTx) {},
+			flowCheckErr: errTest,
+			expectedErr:  ErrFlowCheckFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newSetSubnetStakingParamsTx(t, test.modify)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				subnetOwner    = fx.NewMockOwner(ctrl)
+				transformTx    = &txs.Tx{
+					Unsigned: &txs.TransformSubnetTx{
+						InitialSupply: 100,
+						MaximumSupply: 1_000,
+					},
+				}
+			)
+
+			cfg := defaultTestConfig(t, test.fork, now)
+			cfg.MaxStakeDuration = 1_000 * time.Second
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetSubnetTransformation(unsignedTx.Subnet).Return(transformTx, test.transformErr).AnyTimes()
+			chainState.EXPECT().GetSubnetOwner(unsignedTx.Subnet).Return(subnetOwner, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.SubnetAuth, tx.Creds[1], subnetOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(test.flowCheckErr).AnyTimes()
+			if test.expectedErr == nil {
+				chainState.EXPECT().SetSubnetStakingParams(unsignedTx.Subnet, test.expectedParams)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func newReduceSubnetValidatorWeightTx(t *testing.T, weight uint64) (*txs.ReduceSubnetValidatorWeightTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.ReduceSubnetValidatorWeightTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		NodeID:     ids.GenerateTestNodeID(),
+		Subnet:     ids.GenerateTestID(),
+		Weight:     weight,
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorReduceSubnetValidatorWeightTx(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		fork        fork
+		weight      uint64
+		priority    txs.Priority
+		vdrErr      error
+		authErr     error
+		expectedErr error
+	}{
+		{
+			name:     "valid tx",
+			fork:     eUpgrade,
+			weight:   5,
+			priority: txs.SubnetPermissionedValidatorCurrentPriority,
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			weight:      5,
+			priority:    txs.SubnetPermissionedValidatorCurrentPriority,
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:        "not a current validator",
+			fork:        eUpgrade,
+			weight:      5,
+			priority:    txs.SubnetPermissionedValidatorCurrentPriority,
+			vdrErr:      database.ErrNotFound,
+			expectedErr: database.ErrNotFound,
+		},
+		{
+			name:        "permissionless validator",
+			fork:        eUpgrade,
+			weight:      5,
+			priority:    txs.SubnetPermissionlessValidatorCurrentPriority,
+			expectedErr: ErrReducePermissionlessValidator,
+		},
+		{
+			name:        "weight not reduced",
+			fork:        eUpgrade,
+			weight:      10,
+			priority:    txs.SubnetPermissionedValidatorCurrentPriority,
+			expectedErr: ErrWeightNotReduced,
+		},
+		{
+			name:        "unauthorized",
+			fork:        eUpgrade,
+			weight:      5,
+			priority:    txs.SubnetPermissionedValidatorCurrentPriority,
+			authErr:     errTest,
+			expectedErr: errUnauthorizedSubnetModification,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newReduceSubnetValidatorWeightTx(t, test.weight)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				subnetOwner    = fx.NewMockOwner(ctrl)
+				vdr            = &state.Staker{
+					TxID:     ids.GenerateTestID(),
+					NodeID:   unsignedTx.NodeID,
+					SubnetID: unsignedTx.Subnet,
+					Weight:   10,
+					Priority: test.priority,
+				}
+			)
+
+			cfg := defaultTestConfig(t, test.fork, now)
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetCurrentValidator(unsignedTx.Subnet, unsignedTx.NodeID).Return(vdr, test.vdrErr).AnyTimes()
+			chainState.EXPECT().GetSubnetOwner(unsignedTx.Subnet).Return(subnetOwner, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.SubnetAuth, tx.Creds[1], subnetOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(nil).AnyTimes()
+			if test.expectedErr == nil {
+				newVdr := *vdr
+				newVdr.Weight = test.weight
+
+				chainState.EXPECT().UpdateCurrentValidator(&newVdr)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       cfg,
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestStandardExecutorAddValidatorWithSubnetsTx(t *testing.T) {
+	tests := []struct {
+		name                  string
+		fork                  fork
+		subnetValidatorEndGap time.Duration
+		expectedErr           error
+	}{
+		{
+			name: "valid tx",
+			fork: eUpgrade,
+		},
+		{
+			name:        "E upgrade not active",
+			fork:        durango,
+			expectedErr: ErrEUpgradeNotActive,
+		},
+		{
+			name:                  "subnet validator outlives primary network validator",
+			fork:                  eUpgrade,
+			subnetValidatorEndGap: time.Second,
+			expectedErr:           ErrPeriodMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment(t, test.fork)
+			env.ctx.Lock.Lock()
+			defer env.ctx.Lock.Unlock()
+
+			var (
+				nodeID    = ids.GenerateTestNodeID()
+				startTime = env.state.GetTimestamp()
+				endTime   = startTime.Add(defaultMinStakingDuration)
+				owner     = &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				}
+			)
+
+			sk, err := bls.NewSecretKey()
+			require.NoError(err)
+
+			primaryNetworkValidatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+				&txs.SubnetValidator{
+					Validator: txs.Validator{
+						NodeID: nodeID,
+						End:    uint64(endTime.Unix()),
+						Wght:   env.config.MinValidatorStake,
+					},
+					Subnet: constants.PrimaryNetworkID,
+				},
+				signer.NewProofOfPossession(sk),
+				env.ctx.AVAXAssetID,
+				owner,
+				owner,
+				reward.PercentDenominator,
+				[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			)
+			require.NoError(err)
+
+			subnetOwner, err := env.state.GetSubnetOwner(testSubnet1.ID())
+			require.NoError(err)
+			subnetAuthIndices, subnetAuthKeys, ok := secp256k1fx.NewKeychain(testSubnet1ControlKeys...).Match(
+				subnetOwner.(*secp256k1fx.OutputOwners),
+				0,
+			)
+			require.True(ok)
+
+			subnetValidatorTx := &txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    env.ctx.NetworkID,
+					BlockchainID: env.ctx.ChainID,
+				}},
+				SubnetValidator: txs.SubnetValidator{
+					Validator: txs.Validator{
+						NodeID: nodeID,
+						End:    uint64(endTime.Add(test.subnetValidatorEndGap).Unix()),
+						Wght:   1,
+					},
+					Subnet: testSubnet1.ID(),
+				},
+				SubnetAuth: &secp256k1fx.Input{SigIndices: subnetAuthIndices},
+			}}
+			require.NoError(subnetValidatorTx.Sign(txs.Codec, [][]*secp256k1.PrivateKey{subnetAuthKeys}))
+
+			primaryNetworkValidator := primaryNetworkValidatorTx.Unsigned.(*txs.AddPermissionlessValidatorTx)
+			tx := &txs.Tx{Unsigned: &txs.AddValidatorWithSubnetsTx{
+				AddPermissionlessValidatorTx: *primaryNetworkValidator,
+				SubnetValidators:             []*txs.Tx{subnetValidatorTx},
+			}}
+			inputSigners := make([][]*secp256k1.PrivateKey, len(primaryNetworkValidator.Ins))
+			for i := range inputSigners {
+				inputSigners[i] = []*secp256k1.PrivateKey{preFundedKeys[0]}
+			}
+			require.NoError(tx.Sign(txs.Codec, inputSigners))
+
+			onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   onAcceptState,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			vdr, err := onAcceptState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+			require.NoError(err)
+			require.Equal(tx.ID(), vdr.TxID)
+
+			subnetVdr, err := onAcceptState.GetCurrentValidator(testSubnet1.ID(), nodeID)
+			require.NoError(err)
+			require.Equal(subnetValidatorTx.ID(), subnetVdr.TxID)
+
+			_, txStatus, err := onAcceptState.GetTx(subnetValidatorTx.ID())
+			require.NoError(err)
+			require.Equal(status.Committed, txStatus)
+		})
+	}
+}
+
+func newSetSubnetValidatorEpochTx(t *testing.T, epochHours uint32) (*txs.SetSubnetValidatorEpochTx, *txs.Tx) {
+	t.Helper()
+
+	unsignedTx := &txs.SetSubnetValidatorEpochTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		Subnet:     ids.GenerateTestID(),
+		EpochHours: epochHours,
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorSetSubnetValidatorEpochTx(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		fork          fork
+		epochHours    uint32
+		transformErr  error
+		authErr       error
+		flowCheckErr  error
+		expectedErr   error
+		expectedEpoch time.Duration
+	}{
+		{
+			name:          "valid tx",
+			fork:          eUpgrade,
+			epochHours:    24,
+			transformErr:  database.ErrNotFound,
+			expectedEpoch: 24 * time.Hour,
+		},
+		{
+			name:          "unfreeze validator set",
+			fork:          eUpgrade,
+			epochHours:    0,
+			transformErr:  database.ErrNotFound,
+			expectedEpoch: 0,
+		},
+		{
+			name:         "E upgrade not active",
+			fork:         durango,
+			epochHours:   24,
+			transformErr: database.ErrNotFound,
+			expectedErr:  ErrEUpgradeNotActive,
+		},
+		{
+			name:        "elastic subnet",
+			fork:        eUpgrade,
+			epochHours:  24,
+			expectedErr: errIsImmutable,
+		},
+		{
+			name:         "unauthorized",
+			fork:         eUpgrade,
+			epochHours:   24,
+			transformErr: database.ErrNotFound,
+			authErr:      errTest,
+			expectedErr:  errUnauthorizedSubnetModification,
+		},
+		{
+			name:         "flow check failed",
+			fork:         eUpgrade,
+			epochHours:   24,
+			transformErr: database.ErrNotFound,
+			flowCheckErr: errTest,
+			expectedErr:  ErrFlowCheckFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				unsignedTx, tx = newSetSubnetValidatorEpochTx(t, test.epochHours)
+				mockFx         = fx.NewMockFx(ctrl)
+				flowChecker    = utxo.NewMockVerifier(ctrl)
+				chainState     = state.NewMockDiff(ctrl)
+				subnetOwner    = fx.NewMockOwner(ctrl)
+			)
+
+			chainState.EXPECT().GetTimestamp().Return(now).AnyTimes()
+			chainState.EXPECT().GetSubnetTransformation(unsignedTx.Subnet).Return(&txs.Tx{}, test.transformErr).AnyTimes()
+			chainState.EXPECT().GetSubnetOwner(unsignedTx.Subnet).Return(subnetOwner, nil).AnyTimes()
+			mockFx.EXPECT().VerifyPermission(unsignedTx, unsignedTx.SubnetAuth, tx.Creds[1], subnetOwner).Return(test.authErr).AnyTimes()
+			flowChecker.EXPECT().VerifySpend(
+				unsignedTx, chainState, unsignedTx.Ins, unsignedTx.Outs, tx.Creds[:1], gomock.Any(),
+			).Return(test.flowCheckErr).AnyTimes()
+			if test.expectedErr == nil {
+				chainState.EXPECT().SetSubnetValidatorEpoch(unsignedTx.Subnet, test.expectedEpoch)
+				chainState.EXPECT().DeleteUTXO(gomock.Any()).Times(len(unsignedTx.Ins))
+			}
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config:       defaultTestConfig(t, test.fork, now),
+					Bootstrapped: &utils.Atomic[bool]{},
+					Fx:           mockFx,
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: chainState,
+			}
+			e.Bootstrapped.Set(true)
+
+			err := unsignedTx.Visit(e)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
 func defaultTestConfig(t *testing.T, f fork, tm time.Time) *config.Config {
 	c := &config.Config{
 		UpgradeConfig: upgrade.Config{
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+// getSubnetValidatorEpoch returns the duration of the epochs that the
+// validator set of [subnetID] is frozen for. If the validator set isn't
+// frozen, 0 is returned.
+func getSubnetValidatorEpoch(chainState state.Chain, subnetID ids.ID) (time.Duration, error) {
+	epoch, err := chainState.GetSubnetValidatorEpoch(subnetID)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return epoch, err
+}
+
+// nextEpochBoundary returns the first epoch boundary at or after [t]. Epoch
+// boundaries are the multiples of [epoch] since the Unix epoch. If [epoch] is
+// 0, every time is a boundary.
+func nextEpochBoundary(t time.Time, epoch time.Duration) time.Time {
+	epochSeconds := int64(epoch / time.Second)
+	if epochSeconds == 0 {
+		return t
+	}
+	unix := t.Unix()
+	if remainder := unix % epochSeconds; remainder != 0 {
+		unix += epochSeconds - remainder
+	}
+	return time.Unix(unix, 0)
+}
+
+// isEpochBoundary returns true if [t] is an epoch boundary.
+func isEpochBoundary(t time.Time, epoch time.Duration) bool {
+	return nextEpochBoundary(t, epoch).Equal(t)
+}
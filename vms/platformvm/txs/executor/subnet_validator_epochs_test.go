@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextEpochBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		epoch    time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "not frozen",
+			time:     time.Unix(1_000, 0),
+			epoch:    0,
+			expected: time.Unix(1_000, 0),
+		},
+		{
+			name:     "on boundary",
+			time:     time.Unix(7_200, 0),
+			epoch:    time.Hour,
+			expected: time.Unix(7_200, 0),
+		},
+		{
+			name:     "between boundaries",
+			time:     time.Unix(3_601, 0),
+			epoch:    time.Hour,
+			expected: time.Unix(7_200, 0),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			boundary := nextEpochBoundary(test.time, test.epoch)
+			require.Equal(test.expected, boundary)
+			require.True(isEpochBoundary(boundary, test.epoch))
+		})
+	}
+}
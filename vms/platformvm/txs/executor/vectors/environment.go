@@ -0,0 +1,292 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vectors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/api"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/txstest"
+	"github.com/ava-labs/avalanchego/vms/platformvm/upgrade"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	TxFee             = 100
+	MinValidatorStake = 5 * units.MilliAvax
+	MaxValidatorStake = 500 * units.MilliAvax
+	MinDelegatorStake = 1 * units.MilliAvax
+	MinDelegationFee  = 20_000
+	MinStakeDuration  = 24 * time.Hour
+	MaxStakeDuration  = 365 * 24 * time.Hour
+
+	MaxFutureStartTime = 2 * 7 * 24 * time.Hour
+
+	genesisBalance = 100 * MinValidatorStake
+	genesisWeight  = MinValidatorStake
+)
+
+var (
+	// AVAXAssetID is the ID of the asset used to pay fees and to stake.
+	AVAXAssetID = ids.ID(hashing.ComputeHash256Array([]byte("AVAX")))
+
+	// GenesisTime is the timestamp of the chain when the vectors are
+	// executed.
+	GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// GenesisValidatorEndTime is the end time of every genesis validator.
+	GenesisValidatorEndTime = GenesisTime.Add(20 * MinStakeDuration)
+
+	// Keys fund the genesis UTXOs. Each key is allocated [genesisBalance]
+	// nAVAX. The first key owns the validation rewards of every genesis
+	// validator.
+	Keys = secp256k1.TestKeys()
+
+	// GenesisNodeIDs are the node IDs of the genesis validators. None of them
+	// has a BLS key.
+	GenesisNodeIDs = []ids.NodeID{
+		ids.BuildTestNodeID([]byte{0x01}),
+		ids.BuildTestNodeID([]byte{0x02}),
+		ids.BuildTestNodeID([]byte{0x03}),
+	}
+)
+
+// environment is the chain state and executor backend that the vectors of a
+// fork are built and verified against.
+type environment struct {
+	fork      Fork
+	ctx       *snow.Context
+	config    *config.Config
+	state     state.State
+	txBuilder *txstest.Builder
+	backend   *executor.Backend
+}
+
+func newEnvironment(fork Fork) (*environment, error) {
+	cfg, err := newConfig(fork)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &snow.Context{
+		NetworkID:   constants.UnitTestID,
+		SubnetID:    constants.PrimaryNetworkID,
+		ChainID:     constants.PlatformChainID,
+		AVAXAssetID: AVAXAssetID,
+		Log:         logging.NoLog{},
+	}
+
+	genesisBytes, err := buildGenesis()
+	if err != nil {
+		return nil, err
+	}
+
+	execCfg, err := config.GetExecutionConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := reward.NewCalculator(cfg.RewardConfig)
+	baseState, err := state.New(
+		memdb.New(),
+		genesisBytes,
+		prometheus.NewRegistry(),
+		cfg,
+		execCfg,
+		ctx,
+		metrics.Noop,
+		rewards,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize state: %w", err)
+	}
+
+	clk := &mockable.Clock{}
+	clk.Set(GenesisTime)
+
+	fx := &secp256k1fx.Fx{}
+	if err := fx.Initialize(&fxVM{
+		registry: linearcodec.NewDefault(),
+		clk:      clk,
+		log:      ctx.Log,
+	}); err != nil {
+		return nil, err
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		return nil, err
+	}
+
+	var isBootstrapped utils.Atomic[bool]
+	isBootstrapped.Set(true)
+
+	return &environment{
+		fork:      fork,
+		ctx:       ctx,
+		config:    cfg,
+		state:     baseState,
+		txBuilder: txstest.NewBuilder(ctx, cfg, baseState),
+		backend: &executor.Backend{
+			Config:       cfg,
+			Ctx:          ctx,
+			Clk:          clk,
+			Fx:           fx,
+			FlowChecker:  utxo.NewVerifier(ctx, clk, fx),
+			Rewards:      rewards,
+			Bootstrapped: &isBootstrapped,
+		},
+	}, nil
+}
+
+func newConfig(fork Fork) (*config.Config, error) {
+	upgrades := upgrade.Config{
+		ApricotPhase3Time: GenesisTime,
+		ApricotPhase5Time: GenesisTime,
+		BanffTime:         GenesisTime,
+		CortinaTime:       GenesisTime,
+		DurangoTime:       GenesisTime,
+		EUpgradeTime:      mockable.MaxTime,
+	}
+	switch fork {
+	case Durango:
+	case EUpgrade:
+		upgrades.EUpgradeTime = GenesisTime
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownFork, fork)
+	}
+
+	return &config.Config{
+		Validators:                    validators.NewManager(),
+		TxFee:                         TxFee,
+		CreateAssetTxFee:              TxFee,
+		CreateSubnetTxFee:             100 * TxFee,
+		TransformSubnetTxFee:          100 * TxFee,
+		CreateBlockchainTxFee:         100 * TxFee,
+		AddPrimaryNetworkValidatorFee: TxFee,
+		AddPrimaryNetworkDelegatorFee: TxFee,
+		AddSubnetValidatorFee:         TxFee,
+		AddSubnetDelegatorFee:         TxFee,
+		MinValidatorStake:             MinValidatorStake,
+		MaxValidatorStake:             MaxValidatorStake,
+		MinDelegatorStake:             MinDelegatorStake,
+		MinDelegationFee:              MinDelegationFee,
+		MinStakeDuration:              MinStakeDuration,
+		MinDelegationDuration:         MinStakeDuration,
+		MaxStakeDuration:              MaxStakeDuration,
+		MaxFutureStartTime:            MaxFutureStartTime,
+		RewardConfig: reward.Config{
+			MaxConsumptionRate: .12 * reward.PercentDenominator,
+			MinConsumptionRate: .10 * reward.PercentDenominator,
+			MintingPeriod:      365 * 24 * time.Hour,
+			SupplyCap:          720 * units.MegaAvax,
+		},
+		UpgradeConfig: upgrades,
+	}, nil
+}
+
+func buildGenesis() ([]byte, error) {
+	rewardsAddr, err := address.FormatBech32(constants.UnitTestHRP, Keys[0].Address().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	genesisUTXOs := make([]api.UTXO, len(Keys))
+	for i, key := range Keys {
+		addr, err := address.FormatBech32(constants.UnitTestHRP, key.Address().Bytes())
+		if err != nil {
+			return nil, err
+		}
+		genesisUTXOs[i] = api.UTXO{
+			Amount:  json.Uint64(genesisBalance),
+			Address: addr,
+		}
+	}
+
+	genesisValidators := make([]api.GenesisPermissionlessValidator, len(GenesisNodeIDs))
+	for i, nodeID := range GenesisNodeIDs {
+		genesisValidators[i] = api.GenesisPermissionlessValidator{
+			GenesisValidator: api.GenesisValidator{
+				StartTime: json.Uint64(GenesisTime.Unix()),
+				EndTime:   json.Uint64(GenesisValidatorEndTime.Unix()),
+				NodeID:    nodeID,
+			},
+			RewardOwner: &api.Owner{
+				Threshold: 1,
+				Addresses: []string{rewardsAddr},
+			},
+			Staked: []api.UTXO{{
+				Amount:  json.Uint64(genesisWeight),
+				Address: rewardsAddr,
+			}},
+			DelegationFee: reward.PercentDenominator,
+		}
+	}
+
+	args := api.BuildGenesisArgs{
+		NetworkID:     json.Uint32(constants.UnitTestID),
+		AvaxAssetID:   AVAXAssetID,
+		UTXOs:         genesisUTXOs,
+		Validators:    genesisValidators,
+		Time:          json.Uint64(GenesisTime.Unix()),
+		InitialSupply: json.Uint64(360 * units.MegaAvax),
+		Encoding:      formatting.Hex,
+	}
+
+	reply := api.BuildGenesisReply{}
+	ss := api.StaticService{}
+	if err := ss.BuildGenesis(nil, &args, &reply); err != nil {
+		return nil, fmt.Errorf("failed to build genesis: %w", err)
+	}
+	return formatting.Decode(reply.Encoding, reply.Bytes)
+}
+
+// newBLSKey deterministically derives a BLS key from [seed].
+func newBLSKey(seed byte) (*bls.SecretKey, error) {
+	skBytes := make([]byte, bls.SecretKeyLen)
+	skBytes[len(skBytes)-1] = seed
+	return bls.SecretKeyFromBytes(skBytes)
+}
+
+type fxVM struct {
+	registry codec.Registry
+	clk      *mockable.Clock
+	log      logging.Logger
+}
+
+func (vm *fxVM) CodecRegistry() codec.Registry {
+	return vm.registry
+}
+
+func (vm *fxVM) Clock() *mockable.Clock {
+	return vm.clk
+}
+
+func (vm *fxVM) Logger() logging.Logger {
+	return vm.log
+}
@@ -0,0 +1,333 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package vectors generates serialized P-chain transactions along with whether
+// the node considers them valid under each supported fork configuration.
+//
+// The vectors are fully deterministic so that SDKs can verify that their
+// builders produce byte-identical txs and that they reject the same txs as the
+// node. Every vector is verified against the state described by [GenesisTime],
+// [GenesisNodeIDs] and [Keys], independently of the other vectors.
+package vectors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+const (
+	// Durango is the fork configuration where every upgrade prior to the E
+	// upgrade is active.
+	Durango Fork = "durango"
+	// EUpgrade is the fork configuration where the E upgrade is active.
+	EUpgrade Fork = "eUpgrade"
+)
+
+var (
+	// Forks are the fork configurations that vectors are generated for.
+	Forks = []Fork{
+		Durango,
+		EUpgrade,
+	}
+
+	errUnknownFork = errors.New("unknown fork")
+)
+
+type Fork string
+
+// Vector is a tx along with the result of executing it.
+type Vector struct {
+	// Name uniquely identifies the vector within its fork.
+	Name string `json:"name"`
+	// Fork is the fork configuration the tx was executed under.
+	Fork Fork `json:"fork"`
+	// TxID is the ID of the signed tx.
+	TxID ids.ID `json:"txID"`
+	// Bytes is the hex encoding of the signed tx.
+	Bytes string `json:"bytes"`
+	// Valid is true if the tx was accepted by the executor.
+	Valid bool `json:"valid"`
+	// Error is the reason the tx was rejected, if it was.
+	Error string `json:"error,omitempty"`
+}
+
+type testCase struct {
+	name    string
+	buildTx func(*environment) (*txs.Tx, error)
+}
+
+// Generate returns the vectors of every tx case under every fork in [Forks].
+func Generate() ([]*Vector, error) {
+	var vectors []*Vector
+	for _, fork := range Forks {
+		forkVectors, err := GenerateFork(fork)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, forkVectors...)
+	}
+	return vectors, nil
+}
+
+// GenerateFork returns the vectors of every tx case under [fork].
+func GenerateFork(fork Fork) ([]*Vector, error) {
+	env, err := newEnvironment(fork)
+	if err != nil {
+		return nil, err
+	}
+	defer env.state.Close()
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		tx, err := tc.buildTx(env)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s under %s: %w", tc.name, fork, err)
+		}
+
+		txBytes, err := formatting.Encode(formatting.HexNC, tx.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		vector := &Vector{
+			Name:  tc.name,
+			Fork:  fork,
+			TxID:  tx.ID(),
+			Bytes: txBytes,
+			Valid: true,
+		}
+		if err := env.execute(tx); err != nil {
+			vector.Valid = false
+			vector.Error = err.Error()
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+// execute verifies [tx] on top of the genesis state, so that the result
+// doesn't depend on the other vectors.
+func (e *environment) execute(tx *txs.Tx) error {
+	diff, err := state.NewDiffOn(e.state)
+	if err != nil {
+		return err
+	}
+	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
+		Backend: e.backend,
+		State:   diff,
+		Tx:      tx,
+	})
+}
+
+var testCases = []testCase{
+	{
+		name: "base tx",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return e.txBuilder.NewBaseTx(
+				[]*avax.TransferableOutput{{
+					Asset: avax.Asset{ID: AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          1,
+						OutputOwners: owner(Keys[1]),
+					},
+				}},
+				Keys[:1],
+			)
+		},
+	},
+	{
+		name: "base tx with memo",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return e.txBuilder.NewBaseTx(
+				nil,
+				Keys[:1],
+				common.WithMemo([]byte("memo")),
+			)
+		},
+	},
+	{
+		name: "create subnet",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			o := owner(Keys[0])
+			return e.txBuilder.NewCreateSubnetTx(&o, Keys[:1])
+		},
+	},
+	{
+		name: "add permissionless validator",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newAddPermissionlessValidatorTx(e, 0x10, MinValidatorStake, MinStakeDuration)
+		},
+	},
+	{
+		name: "add permissionless validator with insufficient weight",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newAddPermissionlessValidatorTx(e, 0x11, MinValidatorStake-1, MinStakeDuration)
+		},
+	},
+	{
+		name: "add permissionless validator with insufficient duration",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newAddPermissionlessValidatorTx(e, 0x12, MinValidatorStake, MinStakeDuration-time.Second)
+		},
+	},
+	{
+		name: "add permissionless delegator",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newAddPermissionlessDelegatorTx(e, GenesisNodeIDs[0])
+		},
+	},
+	{
+		name: "add permissionless delegator to unknown validator",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newAddPermissionlessDelegatorTx(e, ids.BuildTestNodeID([]byte{0x20}))
+		},
+	},
+	{
+		name: "change delegation fee",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newChangeDelegationFeeTx(e, MinDelegationFee)
+		},
+	},
+	{
+		name: "change delegation fee below minimum",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newChangeDelegationFeeTx(e, MinDelegationFee-1)
+		},
+	},
+	{
+		name: "set validator BLS key",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			sk, err := newBLSKey(0x30)
+			if err != nil {
+				return nil, err
+			}
+			return newValidatorAuthTx(e, func(baseTx txs.BaseTx) txs.UnsignedTx {
+				return &txs.SetValidatorBLSKeyTx{
+					BaseTx:        baseTx,
+					NodeID:        GenesisNodeIDs[1],
+					Signer:        signer.NewProofOfPossession(sk),
+					ValidatorAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+				}
+			})
+		},
+	},
+	{
+		name: "rotate validator node",
+		buildTx: func(e *environment) (*txs.Tx, error) {
+			return newValidatorAuthTx(e, func(baseTx txs.BaseTx) txs.UnsignedTx {
+				return &txs.RotateValidatorNodeTx{
+					BaseTx:        baseTx,
+					NodeID:        GenesisNodeIDs[2],
+					NewNodeID:     ids.BuildTestNodeID([]byte{0x40}),
+					Signer:        &signer.Empty{},
+					ValidatorAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+				}
+			})
+		},
+	},
+}
+
+func owner(key *secp256k1.PrivateKey) secp256k1fx.OutputOwners {
+	return secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{key.Address()},
+	}
+}
+
+func newAddPermissionlessValidatorTx(
+	e *environment,
+	seed byte,
+	weight uint64,
+	duration time.Duration,
+) (*txs.Tx, error) {
+	sk, err := newBLSKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	rewardsOwner := owner(Keys[0])
+	return e.txBuilder.NewAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.BuildTestNodeID([]byte{seed}),
+				Start:  uint64(GenesisTime.Unix()),
+				End:    uint64(GenesisTime.Add(duration).Unix()),
+				Wght:   weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		signer.NewProofOfPossession(sk),
+		AVAXAssetID,
+		&rewardsOwner,
+		&rewardsOwner,
+		MinDelegationFee,
+		Keys[:1],
+	)
+}
+
+func newAddPermissionlessDelegatorTx(e *environment, nodeID ids.NodeID) (*txs.Tx, error) {
+	rewardsOwner := owner(Keys[0])
+	return e.txBuilder.NewAddPermissionlessDelegatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(GenesisTime.Unix()),
+				End:    uint64(GenesisTime.Add(MinStakeDuration).Unix()),
+				Wght:   MinDelegatorStake,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		AVAXAssetID,
+		&rewardsOwner,
+		Keys[:1],
+	)
+}
+
+func newChangeDelegationFeeTx(e *environment, shares uint32) (*txs.Tx, error) {
+	return newValidatorAuthTx(e, func(baseTx txs.BaseTx) txs.UnsignedTx {
+		return &txs.ChangeDelegationFeeTx{
+			BaseTx:           baseTx,
+			NodeID:           GenesisNodeIDs[0],
+			Subnet:           constants.PrimaryNetworkID,
+			DelegationShares: shares,
+			ValidatorAuth:    &secp256k1fx.Input{SigIndices: []uint32{0}},
+		}
+	})
+}
+
+// newValidatorAuthTx signs a tx that must be authorized by the owner of the
+// validation rewards of a genesis validator. The wallet doesn't support these
+// txs, so the fee is paid with the inputs of a base tx built by the wallet.
+func newValidatorAuthTx(
+	e *environment,
+	newUnsignedTx func(txs.BaseTx) txs.UnsignedTx,
+) (*txs.Tx, error) {
+	baseTx, err := e.txBuilder.NewBaseTx(nil, Keys[:1])
+	if err != nil {
+		return nil, err
+	}
+	utx, ok := baseTx.Unsigned.(*txs.BaseTx)
+	if !ok {
+		return nil, fmt.Errorf("unexpected base tx type %T", baseTx.Unsigned)
+	}
+
+	// The inputs are all owned by the first key, which also owns the
+	// validation rewards of the genesis validators.
+	signers := make([][]*secp256k1.PrivateKey, len(utx.Ins)+1)
+	for i := range signers {
+		signers[i] = Keys[:1]
+	}
+	return txs.NewSigned(newUnsignedTx(*utx), txs.Codec, signers)
+}
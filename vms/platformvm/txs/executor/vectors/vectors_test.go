@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	vectors, err := Generate()
+	require.NoError(err)
+	require.Len(vectors, len(Forks)*len(testCases))
+
+	// The vectors must be reproducible to be useful to anyone else.
+	otherVectors, err := Generate()
+	require.NoError(err)
+	require.Equal(vectors, otherVectors)
+
+	valid := make(map[Fork]map[string]bool)
+	for _, vector := range vectors {
+		if valid[vector.Fork] == nil {
+			valid[vector.Fork] = make(map[string]bool)
+		}
+		require.NotContains(valid[vector.Fork], vector.Name)
+		valid[vector.Fork][vector.Name] = vector.Valid
+		require.Equal(vector.Valid, vector.Error == "", vector.Error)
+	}
+
+	expected := map[Fork]map[string]bool{
+		Durango: {
+			"base tx":                      true,
+			"base tx with memo":            false,
+			"create subnet":                true,
+			"add permissionless validator": true,
+			"add permissionless validator with insufficient weight":   false,
+			"add permissionless validator with insufficient duration": false,
+			"add permissionless delegator":                            true,
+			"add permissionless delegator to unknown validator":       false,
+			"change delegation fee":                                   false,
+			"change delegation fee below minimum":                     false,
+			"set validator BLS key":                                   false,
+			"rotate validator node":                                   false,
+		},
+		EUpgrade: {
+			"base tx":                      true,
+			"base tx with memo":            false,
+			"create subnet":                true,
+			"add permissionless validator": true,
+			"add permissionless validator with insufficient weight":   false,
+			"add permissionless validator with insufficient duration": false,
+			"add permissionless delegator":                            true,
+			"add permissionless delegator to unknown validator":       false,
+			"change delegation fee":                                   true,
+			"change delegation fee below minimum":                     false,
+			"set validator BLS key":                                   true,
+			"rotate validator node":                                   true,
+		},
+	}
+	require.Equal(expected, valid)
+}
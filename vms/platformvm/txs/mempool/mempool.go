@@ -5,10 +5,17 @@ package mempool
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
 	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
@@ -19,6 +26,10 @@ var (
 
 	ErrCantIssueAdvanceTimeTx     = errors.New("can not issue an advance time tx")
 	ErrCantIssueRewardValidatorTx = errors.New("can not issue a reward validator tx")
+	ErrSubnetStakerQuotaExceeded  = errors.New("subnet staker tx quota exceeded")
+
+	errUnknownTxType   = errors.New("unknown tx type")
+	errNegativeAVAXFee = errors.New("tx produces more AVAX than it consumes")
 )
 
 type Mempool interface {
@@ -30,29 +41,95 @@ type Mempool interface {
 	// a notification will only be sent if there is at least one transaction in
 	// the mempool.
 	RequestBuildBlock(emptyBlockPermitted bool)
+
+	// ValidSnapshot returns the txs in the mempool, in priority order, that
+	// [verify] accepts, stopping once the next tx would exceed [maxBytes].
+	//
+	// [verify] is called on each tx in turn. It is expected to apply accepted
+	// txs to the state they are verified against, so that later txs are
+	// verified on top of the earlier ones. If [verify] returns a non-nil
+	// [dropReason], the tx is removed from the mempool and marked as dropped.
+	// If [verify] returns a non-nil [err], the snapshot is aborted and [err]
+	// is returned.
+	//
+	// The returned txs are left in the mempool.
+	ValidSnapshot(
+		maxBytes int,
+		verify func(tx *txs.Tx) (dropReason error, err error),
+	) ([]*txs.Tx, error)
+}
+
+// SubnetQuotaError is returned when a staker tx is not added to the mempool
+// because the mempool already holds the maximum number of staker txs of its
+// subnet.
+type SubnetQuotaError struct {
+	SubnetID ids.ID
+	Limit    int
+}
+
+func (e *SubnetQuotaError) Error() string {
+	return fmt.Sprintf("%s: subnet %s already has %d pending staker txs",
+		ErrSubnetStakerQuotaExceeded,
+		e.SubnetID,
+		e.Limit,
+	)
+}
+
+// Unwrap returns [ErrSubnetStakerQuotaExceeded].
+func (*SubnetQuotaError) Unwrap() error {
+	return ErrSubnetStakerQuotaExceeded
 }
 
 type mempool struct {
 	txmempool.Mempool[*txs.Tx]
 
 	toEngine chan<- common.Message
+
+	// maxSubnetStakerTxs is the maximum number of staker txs of a single
+	// subnet, other than the primary network, that may be in the mempool. If
+	// 0, the number of staker txs isn't limited.
+	maxSubnetStakerTxs int
+
+	// subnetStakersLock serializes the additions of subnet staker txs, so that
+	// the quota can't be exceeded by concurrent additions.
+	subnetStakersLock sync.Mutex
+	// subnetStakerTxs maps a subnetID to the IDs of its staker txs that were
+	// added to the mempool. Txs removed from the mempool are pruned lazily,
+	// as txs may also be removed when they are replaced or evicted.
+	subnetStakerTxs map[ids.ID]set.Set[ids.ID]
 }
 
+// New returns a mempool where a tx can replace the txs it conflicts with if
+// it burns at least [minFeeBumpPercent] percent more AVAX than them, and that
+// holds at most [maxSubnetStakerTxs] staker txs of each subnet other than the
+// primary network. If [maxSubnetStakerTxs] is 0, the number of staker txs
+// isn't limited.
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	avaxAssetID ids.ID,
+	minFeeBumpPercent uint64,
+	maxSubnetStakerTxs int,
 ) (Mempool, error) {
 	metrics, err := txmempool.NewMetrics(namespace, registerer)
 	if err != nil {
 		return nil, err
 	}
-	pool := txmempool.New[*txs.Tx](
+	pool := txmempool.NewWithReplacement[*txs.Tx](
 		metrics,
+		&txmempool.ReplacementConfig[*txs.Tx]{
+			Fee: func(tx *txs.Tx) (uint64, error) {
+				return BurnedAVAX(avaxAssetID, tx)
+			},
+			MinFeeBumpPercent: minFeeBumpPercent,
+		},
 	)
 	return &mempool{
-		Mempool:  pool,
-		toEngine: toEngine,
+		Mempool:            pool,
+		toEngine:           toEngine,
+		maxSubnetStakerTxs: maxSubnetStakerTxs,
+		subnetStakerTxs:    make(map[ids.ID]set.Set[ids.ID]),
 	}, nil
 }
 
@@ -65,7 +142,66 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	default:
 	}
 
-	return m.Mempool.Add(tx)
+	subnetID, ok := subnetStakerID(tx)
+	if !ok || m.maxSubnetStakerTxs == 0 {
+		return m.Mempool.Add(tx)
+	}
+
+	m.subnetStakersLock.Lock()
+	defer m.subnetStakersLock.Unlock()
+
+	txIDs := m.subnetStakerTxs[subnetID]
+	for txID := range txIDs {
+		if _, ok := m.Get(txID); !ok {
+			txIDs.Remove(txID)
+		}
+	}
+	if txIDs.Len() >= m.maxSubnetStakerTxs {
+		return &SubnetQuotaError{
+			SubnetID: subnetID,
+			Limit:    m.maxSubnetStakerTxs,
+		}
+	}
+
+	if err := m.Mempool.Add(tx); err != nil {
+		return err
+	}
+	txIDs.Add(tx.ID())
+	m.subnetStakerTxs[subnetID] = txIDs
+	return nil
+}
+
+func (m *mempool) Remove(removed ...*txs.Tx) {
+	m.Mempool.Remove(removed...)
+	if m.maxSubnetStakerTxs == 0 {
+		return
+	}
+
+	m.subnetStakersLock.Lock()
+	defer m.subnetStakersLock.Unlock()
+
+	for _, tx := range removed {
+		subnetID, ok := subnetStakerID(tx)
+		if !ok {
+			continue
+		}
+		txIDs := m.subnetStakerTxs[subnetID]
+		txIDs.Remove(tx.ID())
+		if txIDs.Len() == 0 {
+			delete(m.subnetStakerTxs, subnetID)
+		}
+	}
+}
+
+// subnetStakerID returns the subnet that [tx] adds a staker to, if [tx] is a
+// staker tx of a subnet other than the primary network.
+func subnetStakerID(tx *txs.Tx) (ids.ID, bool) {
+	staker, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return ids.Empty, false
+	}
+	subnetID := staker.SubnetID()
+	return subnetID, subnetID != constants.PrimaryNetworkID
 }
 
 func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
@@ -78,3 +214,158 @@ func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
 	default:
 	}
 }
+
+func (m *mempool) ValidSnapshot(
+	maxBytes int,
+	verify func(tx *txs.Tx) (error, error),
+) ([]*txs.Tx, error) {
+	// The txs are collected before being verified because txs can't be
+	// dropped while iterating over the mempool.
+	var candidates []*txs.Tx
+	m.Iterate(func(tx *txs.Tx) bool {
+		candidates = append(candidates, tx)
+		return true
+	})
+
+	var snapshot []*txs.Tx
+	for _, tx := range candidates {
+		txSize := tx.Size()
+		if txSize > maxBytes {
+			break
+		}
+
+		dropReason, err := verify(tx)
+		if err != nil {
+			return nil, err
+		}
+		if dropReason != nil {
+			m.Remove(tx)
+			m.MarkDropped(tx.ID(), dropReason)
+			continue
+		}
+
+		maxBytes -= txSize
+		snapshot = append(snapshot, tx)
+	}
+	return snapshot, nil
+}
+
+// BurnedAVAX returns the amount of AVAX consumed by [tx] that isn't produced by
+// it.
+func BurnedAVAX(avaxAssetID ids.ID, tx *txs.Tx) (uint64, error) {
+	var (
+		ins  [][]*avax.TransferableInput
+		outs [][]*avax.TransferableOutput
+	)
+	switch utx := tx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddSubnetValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.AddDelegatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.CreateChainTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.CreateSubnetTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.ImportTx:
+		ins = [][]*avax.TransferableInput{utx.Ins, utx.ImportedInputs}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.ExportTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.ExportedOutputs}
+	case *txs.RemoveSubnetValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.TransformSubnetTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.AddPermissionlessValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddPermissionlessDelegatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddCappedPermissionlessValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddSplitRewardsPermissionlessValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddValidatorWithSubnetsTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddVestingPermissionlessValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.AddPermissionlessValidatorWithMetadataTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.StakeOuts}
+	case *txs.TransferSubnetOwnershipTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.BaseTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.ChangeDelegationFeeTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.RotateValidatorNodeTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.SetValidatorBLSKeyTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.ReportMisbehaviorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.SetSubnetStakingParamsTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.SetSubnetValidatorEpochTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *txs.ReduceSubnetValidatorWeightTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	default:
+		return 0, fmt.Errorf("%w: %T", errUnknownTxType, utx)
+	}
+
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, inputs := range ins {
+		for _, in := range inputs {
+			if in.AssetID() != avaxAssetID {
+				continue
+			}
+			consumed, err = math.Add64(consumed, in.Input().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, outputs := range outs {
+		for _, out := range outputs {
+			if out.AssetID() != avaxAssetID {
+				continue
+			}
+			produced, err = math.Add64(produced, out.Output().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	if produced > consumed {
+		return 0, fmt.Errorf("%w: %d > %d", errNegativeAVAXFee, produced, consumed)
+	}
+	return consumed - produced, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
+)
+
+var avaxAssetID = ids.GenerateTestID()
+
+func newTestBaseTx(t *testing.T, utxoID avax.UTXOID, consumed, produced uint64) *txs.Tx {
+	utx := &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxoID,
+				Asset:  avax.Asset{ID: avaxAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: consumed,
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: produced,
+				},
+			}},
+		},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(t, err)
+	return tx
+}
+
+func newTestSubnetValidatorTx(t *testing.T, subnetID ids.ID) *txs.Tx {
+	utx := &txs.AddSubnetValidatorTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  avax.Asset{ID: avaxAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+					},
+				}},
+			},
+		},
+		SubnetValidator: txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestBurnedAVAX(t *testing.T) {
+	require := require.New(t)
+
+	utxoID := avax.UTXOID{TxID: ids.GenerateTestID()}
+	fee, err := BurnedAVAX(avaxAssetID, newTestBaseTx(t, utxoID, 100, 60))
+	require.NoError(err)
+	require.Equal(uint64(40), fee)
+
+	_, err = BurnedAVAX(avaxAssetID, newTestBaseTx(t, utxoID, 60, 100))
+	require.ErrorIs(err, errNegativeAVAXFee)
+
+	_, err = BurnedAVAX(avaxAssetID, &txs.Tx{Unsigned: &txs.AdvanceTimeTx{}})
+	require.ErrorIs(err, errUnknownTxType)
+}
+
+func TestReplaceByFee(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("", prometheus.NewRegistry(), nil, avaxAssetID, 10, 0)
+	require.NoError(err)
+
+	utxoID := avax.UTXOID{TxID: ids.GenerateTestID()}
+	original := newTestBaseTx(t, utxoID, 1000, 900)
+	require.NoError(mempool.Add(original))
+
+	// Burning 105 AVAX isn't a 10% bump over 100 AVAX.
+	insufficient := newTestBaseTx(t, utxoID, 1000, 895)
+	err = mempool.Add(insufficient)
+	require.ErrorIs(err, txmempool.ErrInsufficientFeeBump)
+	_, ok := mempool.Get(original.ID())
+	require.True(ok)
+
+	replacement := newTestBaseTx(t, utxoID, 1000, 890)
+	require.NoError(mempool.Add(replacement))
+	_, ok = mempool.Get(original.ID())
+	require.False(ok)
+	_, ok = mempool.Get(replacement.ID())
+	require.True(ok)
+	require.ErrorIs(mempool.GetDropReason(original.ID()), txmempool.ErrReplacedByFee)
+}
+
+func TestValidSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("", prometheus.NewRegistry(), nil, avaxAssetID, 10, 0)
+	require.NoError(err)
+
+	var (
+		invalid   = newTestBaseTx(t, avax.UTXOID{TxID: ids.GenerateTestID()}, 100, 90)
+		valid     = newTestBaseTx(t, avax.UTXOID{TxID: ids.GenerateTestID()}, 100, 80)
+		doesntFit = newTestBaseTx(t, avax.UTXOID{TxID: ids.GenerateTestID()}, 100, 70)
+	)
+	require.NoError(mempool.Add(invalid))
+	require.NoError(mempool.Add(valid))
+	require.NoError(mempool.Add(doesntFit))
+
+	errInvalid := errors.New("invalid")
+	var verified []ids.ID
+	snapshot, err := mempool.ValidSnapshot(
+		// Dropped txs don't count towards the size of the snapshot.
+		2*valid.Size()-1,
+		func(tx *txs.Tx) (error, error) {
+			verified = append(verified, tx.ID())
+			if tx == invalid {
+				return errInvalid, nil
+			}
+			return nil, nil
+		},
+	)
+	require.NoError(err)
+	require.Equal([]*txs.Tx{valid}, snapshot)
+
+	// [doesntFit] doesn't fit into the remaining bytes, so it isn't verified.
+	require.Equal([]ids.ID{invalid.ID(), valid.ID()}, verified)
+
+	// Only the invalid tx is removed from the mempool.
+	_, ok := mempool.Get(valid.ID())
+	require.True(ok)
+	_, ok = mempool.Get(invalid.ID())
+	require.False(ok)
+	require.ErrorIs(mempool.GetDropReason(invalid.ID()), errInvalid)
+	_, ok = mempool.Get(doesntFit.ID())
+	require.True(ok)
+	require.NoError(mempool.GetDropReason(doesntFit.ID()))
+}
+
+func TestValidSnapshotAborts(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("", prometheus.NewRegistry(), nil, avaxAssetID, 10, 0)
+	require.NoError(err)
+
+	tx := newTestBaseTx(t, avax.UTXOID{TxID: ids.GenerateTestID()}, 100, 90)
+	require.NoError(mempool.Add(tx))
+
+	errFatal := errors.New("fatal")
+	_, err = mempool.ValidSnapshot(tx.Size(), func(*txs.Tx) (error, error) {
+		return nil, errFatal
+	})
+	require.ErrorIs(err, errFatal)
+
+	// The tx isn't dropped when the snapshot is aborted.
+	_, ok := mempool.Get(tx.ID())
+	require.True(ok)
+	require.NoError(mempool.GetDropReason(tx.ID()))
+}
+
+func TestSubnetStakerQuota(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("", prometheus.NewRegistry(), nil, avaxAssetID, 10, 2)
+	require.NoError(err)
+
+	var (
+		subnetID      = ids.GenerateTestID()
+		otherSubnetID = ids.GenerateTestID()
+		first         = newTestSubnetValidatorTx(t, subnetID)
+		second        = newTestSubnetValidatorTx(t, subnetID)
+		third         = newTestSubnetValidatorTx(t, subnetID)
+	)
+	require.NoError(mempool.Add(first))
+	require.NoError(mempool.Add(second))
+
+	err = mempool.Add(third)
+	require.ErrorIs(err, ErrSubnetStakerQuotaExceeded)
+	var quotaErr *SubnetQuotaError
+	require.ErrorAs(err, &quotaErr)
+	require.Equal(subnetID, quotaErr.SubnetID)
+	require.Equal(2, quotaErr.Limit)
+
+	// The quota of a subnet doesn't apply to other subnets or to txs that
+	// aren't subnet staker txs.
+	require.NoError(mempool.Add(newTestSubnetValidatorTx(t, otherSubnetID)))
+	require.NoError(mempool.Add(newTestBaseTx(t, avax.UTXOID{TxID: ids.GenerateTestID()}, 100, 90)))
+
+	// Removing a tx frees its slot.
+	mempool.Remove(first)
+	require.NoError(mempool.Add(third))
+}
@@ -54,6 +54,18 @@ func (mr *MockMempoolMockRecorder) Add(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// AddAdmissionFilter mocks base method.
+func (m *MockMempool) AddAdmissionFilter(arg0 func(*txs.Tx) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddAdmissionFilter", arg0)
+}
+
+// AddAdmissionFilter indicates an expected call of AddAdmissionFilter.
+func (mr *MockMempoolMockRecorder) AddAdmissionFilter(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAdmissionFilter", reflect.TypeOf((*MockMempool)(nil).AddAdmissionFilter), arg0)
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) (*txs.Tx, bool) {
 	m.ctrl.T.Helper()
@@ -163,3 +175,32 @@ func (mr *MockMempoolMockRecorder) RequestBuildBlock(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBuildBlock", reflect.TypeOf((*MockMempool)(nil).RequestBuildBlock), arg0)
 }
+
+// SetMaxSize mocks base method.
+func (m *MockMempool) SetMaxSize(arg0 int) []*txs.Tx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxSize", arg0)
+	ret0, _ := ret[0].([]*txs.Tx)
+	return ret0
+}
+
+// SetMaxSize indicates an expected call of SetMaxSize.
+func (mr *MockMempoolMockRecorder) SetMaxSize(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxSize", reflect.TypeOf((*MockMempool)(nil).SetMaxSize), arg0)
+}
+
+// ValidSnapshot mocks base method.
+func (m *MockMempool) ValidSnapshot(arg0 int, arg1 func(*txs.Tx) (error, error)) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidSnapshot", arg0, arg1)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidSnapshot indicates an expected call of ValidSnapshot.
+func (mr *MockMempoolMockRecorder) ValidSnapshot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidSnapshot", reflect.TypeOf((*MockMempool)(nil).ValidSnapshot), arg0, arg1)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import "runtime"
+
+// Size returns the number of bytes the mempool may hold when the process is
+// using [memoryUsage] bytes of memory.
+//
+// While [memoryUsage] is at most [memoryLimit], the mempool may hold [maxSize]
+// bytes. Past [memoryLimit], the mempool is shrunk by the excess memory usage,
+// down to [minSize] bytes.
+func Size(minSize, maxSize int, memoryLimit, memoryUsage uint64) int {
+	if memoryUsage <= memoryLimit {
+		return maxSize
+	}
+	minSize = min(minSize, maxSize)
+	excess := memoryUsage - memoryLimit
+	if excess >= uint64(maxSize-minSize) {
+		return minSize
+	}
+	return maxSize - int(excess)
+}
+
+// MemoryUsage returns the number of bytes of memory obtained from the OS by the
+// Go runtime that haven't been returned to it.
+func MemoryUsage() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys - stats.HeapReleased
+}
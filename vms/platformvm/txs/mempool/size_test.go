@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		minSize      int
+		maxSize      int
+		memoryLimit  uint64
+		memoryUsage  uint64
+		expectedSize int
+	}{
+		{
+			name:         "below limit",
+			minSize:      10,
+			maxSize:      100,
+			memoryLimit:  1_000,
+			memoryUsage:  500,
+			expectedSize: 100,
+		},
+		{
+			name:         "at limit",
+			minSize:      10,
+			maxSize:      100,
+			memoryLimit:  1_000,
+			memoryUsage:  1_000,
+			expectedSize: 100,
+		},
+		{
+			name:         "shrunk by excess usage",
+			minSize:      10,
+			maxSize:      100,
+			memoryLimit:  1_000,
+			memoryUsage:  1_030,
+			expectedSize: 70,
+		},
+		{
+			name:         "shrunk to floor",
+			minSize:      10,
+			maxSize:      100,
+			memoryLimit:  1_000,
+			memoryUsage:  2_000,
+			expectedSize: 10,
+		},
+		{
+			name:         "floor above ceiling",
+			minSize:      100,
+			maxSize:      10,
+			memoryLimit:  1_000,
+			memoryUsage:  2_000,
+			expectedSize: 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			size := Size(test.minSize, test.maxSize, test.memoryLimit, test.memoryUsage)
+			require.Equal(t, test.expectedSize, size)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*ReduceSubnetValidatorWeightTx)(nil)
+
+	ErrReducePrimaryNetworkValidatorWeight = errors.New("can't reduce the weight of a primary network validator")
+)
+
+// ReduceSubnetValidatorWeightTx reduces the weight of a current permissioned
+// subnet validator, such as a validator that the subnet's owners consider to
+// be chronically offline. Uptime is measured locally by each node, so it can't
+// be verified by consensus; the failure is instead attested by the subnet's
+// owners authorizing the tx.
+type ReduceSubnetValidatorWeightTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node whose weight is being reduced.
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet the node is validating.
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// The weight of the validator from now on. Must be less than its current
+	// weight.
+	Weight uint64 `serialize:"true" json:"weight"`
+	// Proves that the issuer has the right to modify the subnet's validators.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *ReduceSubnetValidatorWeightTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrReducePrimaryNetworkValidatorWeight
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	case tx.Weight == 0:
+		return ErrWeightTooSmall
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ReduceSubnetValidatorWeightTx) Visit(visitor Visitor) error {
+	return visitor.ReduceSubnetValidatorWeightTx(tx)
+}
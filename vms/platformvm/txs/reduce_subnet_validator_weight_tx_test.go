@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestReduceSubnetValidatorWeightTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		ctx       = &snow.Context{
+			ChainID:   chainID,
+			NetworkID: networkID,
+		}
+	)
+
+	// A tx that passes syntactic verification.
+	newValidTx := func() *ReduceSubnetValidatorWeightTx {
+		return &ReduceSubnetValidatorWeightTx{
+			BaseTx: BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: chainID,
+				},
+			},
+			NodeID:     ids.GenerateTestNodeID(),
+			Subnet:     ids.GenerateTestID(),
+			Weight:     1,
+			SubnetAuth: &secp256k1fx.Input{},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		txF         func() *ReduceSubnetValidatorWeightTx
+		expectedErr error
+	}{
+		{
+			name: "nil tx",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				return &ReduceSubnetValidatorWeightTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				tx := newValidTx()
+				tx.Subnet = constants.PrimaryNetworkID
+				return tx
+			},
+			expectedErr: ErrReducePrimaryNetworkValidatorWeight,
+		},
+		{
+			name: "empty nodeID",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				tx := newValidTx()
+				tx.NodeID = ids.EmptyNodeID
+				return tx
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "zero weight",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				tx := newValidTx()
+				tx.Weight = 0
+				return tx
+			},
+			expectedErr: ErrWeightTooSmall,
+		},
+		{
+			name: "invalid base tx",
+			txF: func() *ReduceSubnetValidatorWeightTx {
+				tx := newValidTx()
+				tx.NetworkID++
+				return tx
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name:        "valid tx",
+			txF:         newValidTx,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.txF().SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+var (
+	_ UnsignedTx = (*ReportMisbehaviorTx)(nil)
+
+	errNilEvidence = errors.New("nil evidence")
+)
+
+// ReportMisbehaviorTx is an unsigned reportMisbehaviorTx. Once accepted, the
+// rewards of the reported validator's current staking period are withheld.
+type ReportMisbehaviorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Proof that a current primary network validator misbehaved
+	Evidence Evidence `serialize:"true" json:"evidence"`
+}
+
+func (tx *ReportMisbehaviorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Evidence == nil:
+		return errNilEvidence
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := tx.Evidence.Verify(); err != nil {
+		return fmt.Errorf("failed to verify evidence: %w", err)
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ReportMisbehaviorTx) Visit(visitor Visitor) error {
+	return visitor.ReportMisbehaviorTx(tx)
+}
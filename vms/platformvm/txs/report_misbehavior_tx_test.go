@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
+)
+
+func TestReportMisbehaviorTxSyntacticVerify(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		original  = attestation.Unsigned{
+			NetworkID: networkID,
+			ChainID:   ids.GenerateTestID(),
+			Height:    1,
+			Hash:      ids.GenerateTestID(),
+		}
+		conflicting = original
+	)
+	conflicting.Hash = ids.GenerateTestID()
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	validEvidence := &ConflictingAttestations{
+		NodeID: ids.GenerateTestNodeID(),
+		Attestations: [2]Attestation{
+			signAttestation(t, sk, original),
+			signAttestation(t, sk, conflicting),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		tx          *ReportMisbehaviorTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			tx: &ReportMisbehaviorTx{
+				BaseTx: BaseTx{
+					SyntacticallyVerified: true,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "nil evidence",
+			tx: &ReportMisbehaviorTx{
+				BaseTx: validBaseTx,
+			},
+			expectedErr: errNilEvidence,
+		},
+		{
+			name: "invalid evidence",
+			tx: &ReportMisbehaviorTx{
+				BaseTx: validBaseTx,
+				Evidence: &ConflictingAttestations{
+					NodeID: validEvidence.NodeID,
+					Attestations: [2]Attestation{
+						signAttestation(t, sk, original),
+						signAttestation(t, sk, original),
+					},
+				},
+			},
+			expectedErr: errAttestationsDontConflict,
+		},
+		{
+			name: "valid tx",
+			tx: &ReportMisbehaviorTx{
+				BaseTx:   validBaseTx,
+				Evidence: validEvidence,
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestReportMisbehaviorTxSerialization(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		original = attestation.Unsigned{
+			NetworkID: 1337,
+			ChainID:   ids.GenerateTestID(),
+			Height:    1,
+			Hash:      ids.GenerateTestID(),
+		}
+		conflicting = original
+	)
+	conflicting.Hash = ids.GenerateTestID()
+
+	unsignedTx := &ReportMisbehaviorTx{
+		BaseTx: BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    original.NetworkID,
+				BlockchainID: ids.GenerateTestID(),
+				Outs:         []*avax.TransferableOutput{},
+				Ins:          []*avax.TransferableInput{},
+				Memo:         []byte{},
+			},
+		},
+		Evidence: &ConflictingAttestations{
+			NodeID: ids.GenerateTestNodeID(),
+			Attestations: [2]Attestation{
+				signAttestation(t, sk, original),
+				signAttestation(t, sk, conflicting),
+			},
+		},
+	}
+	tx, err := NewSigned(unsignedTx, Codec, nil)
+	require.NoError(err)
+
+	parsedTx, err := Parse(Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), parsedTx.ID())
+	require.Equal(unsignedTx, parsedTx.Unsigned)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+var (
+	_ UnsignedTx = (*RotateValidatorNodeTx)(nil)
+
+	errSameNodeID = errors.New("new nodeID must differ from the current nodeID")
+)
+
+// RotateValidatorNodeTx is an unsigned rotateValidatorNodeTx
+type RotateValidatorNodeTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node that is currently validating the primary network
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The node that will replace [NodeID] as the validator
+	NewNodeID ids.NodeID `serialize:"true" json:"newNodeID"`
+	// The BLS key of [NewNodeID]. Must be provided if the validator currently
+	// has a BLS key.
+	Signer signer.Signer `serialize:"true" json:"signer"`
+	// Proves that the issuer is the owner of the validator's validation
+	// rewards
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+func (tx *RotateValidatorNodeTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID, tx.NewNodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	case tx.NodeID == tx.NewNodeID:
+		return errSameNodeID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(tx.Signer, tx.ValidatorAuth); err != nil {
+		return fmt.Errorf("failed to verify signer or validator authorization: %w", err)
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RotateValidatorNodeTx) Visit(visitor Visitor) error {
+	return visitor.RotateValidatorNodeTx(tx)
+}
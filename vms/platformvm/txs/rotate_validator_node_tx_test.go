@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+func TestRotateValidatorNodeTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *RotateValidatorNodeTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		newNodeID = ids.GenerateTestNodeID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return &RotateValidatorNodeTx{BaseTx: verifiedBaseTx}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return &RotateValidatorNodeTx{
+					BaseTx:    validBaseTx,
+					NewNodeID: newNodeID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "empty new nodeID",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return &RotateValidatorNodeTx{
+					BaseTx: validBaseTx,
+					NodeID: nodeID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "same nodeID",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return &RotateValidatorNodeTx{
+					BaseTx:    validBaseTx,
+					NodeID:    nodeID,
+					NewNodeID: nodeID,
+				}
+			},
+			expectedErr: errSameNodeID,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *RotateValidatorNodeTx {
+				return &RotateValidatorNodeTx{
+					BaseTx:    invalidBaseTx,
+					NodeID:    nodeID,
+					NewNodeID: newNodeID,
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid validatorAuth",
+			txFunc: func(ctrl *gomock.Controller) *RotateValidatorNodeTx {
+				// This ValidatorAuth fails verification.
+				invalidValidatorAuth := verify.NewMockVerifiable(ctrl)
+				invalidValidatorAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &RotateValidatorNodeTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					Signer:        &signer.Empty{},
+					ValidatorAuth: invalidValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *RotateValidatorNodeTx {
+				// This ValidatorAuth passes verification.
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &RotateValidatorNodeTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					Signer:        &signer.Empty{},
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const (
+	// MaxScheduledTxs is the maximum number of txs that can be pending
+	// issuance at once.
+	MaxScheduledTxs = 1024
+	// MaxScheduledTxsPerCaller is the maximum number of txs that a single
+	// caller can have pending issuance at once.
+	MaxScheduledTxsPerCaller = 16
+)
+
+var (
+	_ Scheduler                    = (*scheduler)(nil)
+	_ utils.Sortable[*ScheduledTx] = (*ScheduledTx)(nil)
+
+	ErrNotStakerTx      = errors.New("only staking txs can be scheduled")
+	ErrDuplicateTx      = errors.New("tx is already scheduled")
+	ErrTooManyScheduled = errors.New("too many txs are scheduled")
+	ErrCallerTooLong    = errors.New("caller is too long")
+	ErrNotScheduled     = errors.New("tx is not scheduled")
+
+	errInvalidEntry = errors.New("invalid scheduled tx entry")
+)
+
+// ScheduledTx is a fully signed tx that should be issued once the chain time
+// reaches [IssueTime].
+type ScheduledTx struct {
+	Tx        *txs.Tx
+	IssueTime time.Time
+	// Caller identifies who scheduled the tx
+	Caller string
+	// LastIssueErr is the error returned by the last failed attempt to issue
+	// the tx, if any. It is not persisted.
+	LastIssueErr error
+}
+
+// Scheduler persists fully signed staking txs until they should be issued.
+type Scheduler interface {
+	// Add schedules [tx], on behalf of [caller], to be issued once the chain
+	// time is at or after [issueTime].
+	Add(tx *txs.Tx, issueTime time.Time, caller string) error
+	// Remove cancels the issuance of the tx with ID [txID]. It must also be
+	// called once the tx was issued.
+	Remove(txID ids.ID) error
+	// Get returns the scheduled tx with ID [txID], if it exists.
+	Get(txID ids.ID) (ScheduledTx, bool)
+	// List returns all the scheduled txs, ordered by their issue time.
+	List() []ScheduledTx
+	// Ready returns all the txs that should be issued at [chainTime], ordered
+	// by their issue time. The txs remain scheduled until they are removed.
+	Ready(chainTime time.Time) []*txs.Tx
+	// SetIssueErr records that issuing the tx with ID [txID] failed with
+	// [err]. Returns the error of the previous failed attempt, if any.
+	SetIssueErr(txID ids.ID, err error) error
+}
+
+type scheduler struct {
+	lock sync.Mutex
+	db   database.Database
+	txs  map[ids.ID]*ScheduledTx
+	// numTxsPerCaller is the number of scheduled txs of each caller
+	numTxsPerCaller map[string]int
+}
+
+// New returns a Scheduler that persists the scheduled txs in [db]. Any txs
+// that were previously scheduled in [db] are loaded.
+func New(db database.Database) (Scheduler, error) {
+	s := &scheduler{
+		db:              db,
+		txs:             make(map[ids.ID]*ScheduledTx),
+		numTxsPerCaller: make(map[string]int),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		scheduledTx, err := parseEntry(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		s.txs[scheduledTx.Tx.ID()] = scheduledTx
+		s.numTxsPerCaller[scheduledTx.Caller]++
+	}
+	return s, it.Error()
+}
+
+func (s *scheduler) Add(tx *txs.Tx, issueTime time.Time, caller string) error {
+	if _, ok := tx.Unsigned.(txs.Staker); !ok {
+		return fmt.Errorf("%w: %T", ErrNotStakerTx, tx.Unsigned)
+	}
+	if len(caller) > math.MaxUint16 {
+		return fmt.Errorf("%w: %d bytes", ErrCallerTooLong, len(caller))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	txID := tx.ID()
+	if _, ok := s.txs[txID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTx, txID)
+	}
+	if len(s.txs) >= MaxScheduledTxs {
+		return fmt.Errorf("%w: %d", ErrTooManyScheduled, MaxScheduledTxs)
+	}
+	if s.numTxsPerCaller[caller] >= MaxScheduledTxsPerCaller {
+		return fmt.Errorf("%w: %d by %q", ErrTooManyScheduled, MaxScheduledTxsPerCaller, caller)
+	}
+
+	scheduledTx := &ScheduledTx{
+		Tx:        tx,
+		IssueTime: issueTime.Truncate(time.Second),
+		Caller:    caller,
+	}
+	if err := s.db.Put(txID[:], marshalEntry(scheduledTx)); err != nil {
+		return err
+	}
+	s.txs[txID] = scheduledTx
+	s.numTxsPerCaller[caller]++
+	return nil
+}
+
+func (s *scheduler) Remove(txID ids.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scheduledTx, ok := s.txs[txID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotScheduled, txID)
+	}
+	if err := s.db.Delete(txID[:]); err != nil {
+		return err
+	}
+	delete(s.txs, txID)
+
+	s.numTxsPerCaller[scheduledTx.Caller]--
+	if s.numTxsPerCaller[scheduledTx.Caller] == 0 {
+		delete(s.numTxsPerCaller, scheduledTx.Caller)
+	}
+	return nil
+}
+
+func (s *scheduler) Get(txID ids.ID) (ScheduledTx, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scheduledTx, ok := s.txs[txID]
+	if !ok {
+		return ScheduledTx{}, false
+	}
+	return *scheduledTx, true
+}
+
+func (s *scheduler) List() []ScheduledTx {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sortedTxs := make([]*ScheduledTx, 0, len(s.txs))
+	for _, scheduledTx := range s.txs {
+		sortedTxs = append(sortedTxs, scheduledTx)
+	}
+	utils.Sort(sortedTxs)
+
+	scheduledTxs := make([]ScheduledTx, len(sortedTxs))
+	for i, scheduledTx := range sortedTxs {
+		scheduledTxs[i] = *scheduledTx
+	}
+	return scheduledTxs
+}
+
+func (s *scheduler) Ready(chainTime time.Time) []*txs.Tx {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var ready []*ScheduledTx
+	for _, scheduledTx := range s.txs {
+		if !scheduledTx.IssueTime.After(chainTime) {
+			ready = append(ready, scheduledTx)
+		}
+	}
+	utils.Sort(ready)
+
+	readyTxs := make([]*txs.Tx, len(ready))
+	for i, scheduledTx := range ready {
+		readyTxs[i] = scheduledTx.Tx
+	}
+	return readyTxs
+}
+
+func (s *scheduler) SetIssueErr(txID ids.ID, err error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scheduledTx, ok := s.txs[txID]
+	if !ok {
+		return nil
+	}
+	prevErr := scheduledTx.LastIssueErr
+	scheduledTx.LastIssueErr = err
+	return prevErr
+}
+
+// Compare orders scheduled txs by their issue time, breaking ties by txID.
+func (s *ScheduledTx) Compare(other *ScheduledTx) int {
+	if cmp := s.IssueTime.Compare(other.IssueTime); cmp != 0 {
+		return cmp
+	}
+	return s.Tx.ID().Compare(other.Tx.ID())
+}
+
+// marshalEntry encodes [scheduledTx] as:
+// - 8 bytes: issue time as unix seconds
+// - 2 bytes: length of the caller
+// - the caller
+// - the remaining bytes: the signed tx
+func marshalEntry(scheduledTx *ScheduledTx) []byte {
+	var (
+		txBytes = scheduledTx.Tx.Bytes()
+		caller  = scheduledTx.Caller
+		entry   = make([]byte, wrappers.LongLen+wrappers.ShortLen+len(caller)+len(txBytes))
+	)
+	binary.BigEndian.PutUint64(entry, uint64(scheduledTx.IssueTime.Unix()))
+	binary.BigEndian.PutUint16(entry[wrappers.LongLen:], uint16(len(caller)))
+	copy(entry[wrappers.LongLen+wrappers.ShortLen:], caller)
+	copy(entry[wrappers.LongLen+wrappers.ShortLen+len(caller):], txBytes)
+	return entry
+}
+
+func parseEntry(entry []byte) (*ScheduledTx, error) {
+	const headerLen = wrappers.LongLen + wrappers.ShortLen
+	if len(entry) < headerLen {
+		return nil, errInvalidEntry
+	}
+	callerLen := int(binary.BigEndian.Uint16(entry[wrappers.LongLen:]))
+	if len(entry) < headerLen+callerLen {
+		return nil, errInvalidEntry
+	}
+	tx, err := txs.Parse(txs.Codec, entry[headerLen+callerLen:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidEntry, err)
+	}
+	issueTime := int64(binary.BigEndian.Uint64(entry))
+	return &ScheduledTx{
+		Tx:        tx,
+		IssueTime: time.Unix(issueTime, 0),
+		Caller:    string(entry[headerLen : headerLen+callerLen]),
+	}, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const testCaller = "127.0.0.1"
+
+func newStakerTx(t *testing.T) *txs.Tx {
+	memo := ids.GenerateTestID()
+	tx := &txs.Tx{
+		Unsigned: &txs.AddValidatorTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					Memo: memo[:],
+				},
+			},
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+			},
+			RewardsOwner: &secp256k1fx.OutputOwners{},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func TestSchedulerAdd(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(memdb.New())
+	require.NoError(err)
+
+	nonStakerTx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{},
+		},
+	}
+	require.NoError(nonStakerTx.Initialize(txs.Codec))
+	err = s.Add(nonStakerTx, time.Unix(1, 0), testCaller)
+	require.ErrorIs(err, ErrNotStakerTx)
+
+	tx := newStakerTx(t)
+	require.NoError(s.Add(tx, time.Unix(1, 0), testCaller))
+
+	err = s.Add(tx, time.Unix(2, 0), testCaller)
+	require.ErrorIs(err, ErrDuplicateTx)
+
+	scheduledTx, ok := s.Get(tx.ID())
+	require.True(ok)
+	require.Equal(time.Unix(1, 0), scheduledTx.IssueTime)
+	require.Equal(testCaller, scheduledTx.Caller)
+}
+
+func TestSchedulerRemove(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(memdb.New())
+	require.NoError(err)
+
+	tx := newStakerTx(t)
+	err = s.Remove(tx.ID())
+	require.ErrorIs(err, ErrNotScheduled)
+
+	require.NoError(s.Add(tx, time.Unix(1, 0), testCaller))
+	require.NoError(s.Remove(tx.ID()))
+
+	_, ok := s.Get(tx.ID())
+	require.False(ok)
+	require.Empty(s.List())
+}
+
+func TestSchedulerReady(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s, err := New(db)
+	require.NoError(err)
+
+	var (
+		tx0 = newStakerTx(t)
+		tx1 = newStakerTx(t)
+		tx2 = newStakerTx(t)
+	)
+	require.NoError(s.Add(tx2, time.Unix(30, 0), testCaller))
+	require.NoError(s.Add(tx1, time.Unix(20, 0), testCaller))
+	require.NoError(s.Add(tx0, time.Unix(10, 0), testCaller))
+
+	scheduledTxs := s.List()
+	require.Len(scheduledTxs, 3)
+	require.Equal(tx0.ID(), scheduledTxs[0].Tx.ID())
+	require.Equal(tx1.ID(), scheduledTxs[1].Tx.ID())
+	require.Equal(tx2.ID(), scheduledTxs[2].Tx.ID())
+
+	require.Empty(s.Ready(time.Unix(5, 0)))
+	require.Equal([]*txs.Tx{tx0, tx1}, s.Ready(time.Unix(20, 0)))
+
+	// Ready txs remain scheduled until they are issued.
+	require.Equal([]*txs.Tx{tx0, tx1}, s.Ready(time.Unix(20, 0)))
+
+	// A failed issuance is recorded so that it can be reported.
+	errIssue := errors.New("issue failed")
+	require.NoError(s.SetIssueErr(tx1.ID(), errIssue))
+	require.Equal(errIssue, s.SetIssueErr(tx1.ID(), errIssue))
+	scheduledTx, ok := s.Get(tx1.ID())
+	require.True(ok)
+	require.Equal(errIssue, scheduledTx.LastIssueErr)
+
+	// Issued txs are removed and should no longer be persisted.
+	require.NoError(s.Remove(tx0.ID()))
+
+	s, err = New(db)
+	require.NoError(err)
+
+	scheduledTxs = s.List()
+	require.Len(scheduledTxs, 2)
+	require.Equal(tx1.ID(), scheduledTxs[0].Tx.ID())
+	require.NoError(scheduledTxs[0].LastIssueErr)
+	require.Equal(tx2.ID(), scheduledTxs[1].Tx.ID())
+	require.Equal(time.Unix(30, 0), scheduledTxs[1].IssueTime)
+	require.Equal(testCaller, scheduledTxs[1].Caller)
+	require.Equal(tx2.Bytes(), scheduledTxs[1].Tx.Bytes())
+}
+
+func TestSchedulerMaxScheduledTxs(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(memdb.New())
+	require.NoError(err)
+
+	for i := 0; i < MaxScheduledTxs; i++ {
+		caller := strconv.Itoa(i / MaxScheduledTxsPerCaller)
+		require.NoError(s.Add(newStakerTx(t), time.Unix(1, 0), caller))
+	}
+
+	err = s.Add(newStakerTx(t), time.Unix(1, 0), "")
+	require.ErrorIs(err, ErrTooManyScheduled)
+}
+
+func TestSchedulerMaxScheduledTxsPerCaller(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s, err := New(db)
+	require.NoError(err)
+
+	scheduledTxs := make([]*txs.Tx, MaxScheduledTxsPerCaller)
+	for i := range scheduledTxs {
+		scheduledTxs[i] = newStakerTx(t)
+		require.NoError(s.Add(scheduledTxs[i], time.Unix(1, 0), testCaller))
+	}
+
+	err = s.Add(newStakerTx(t), time.Unix(1, 0), testCaller)
+	require.ErrorIs(err, ErrTooManyScheduled)
+
+	// Other callers are not affected.
+	require.NoError(s.Add(newStakerTx(t), time.Unix(1, 0), "127.0.0.2"))
+
+	// The limit is enforced after a restart.
+	s, err = New(db)
+	require.NoError(err)
+
+	err = s.Add(newStakerTx(t), time.Unix(1, 0), testCaller)
+	require.ErrorIs(err, ErrTooManyScheduled)
+
+	// Removing a tx frees up space for its caller.
+	require.NoError(s.Remove(scheduledTxs[0].ID()))
+	require.NoError(s.Add(newStakerTx(t), time.Unix(1, 0), testCaller))
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+var (
+	_ UnsignedTx = (*SetSubnetStakingParamsTx)(nil)
+
+	errCantSetPrimaryNetworkStakingParams = errors.New("cannot set the staking parameters of the primary network")
+	errMinDelegationDurationZero          = errors.New("min delegation duration must be non-0")
+	errMinDelegationDurationTooLarge      = errors.New("min delegation duration must be less than or equal to max stake duration")
+	errMinSelfStakeRatioTooLarge          = errors.New("min self-stake ratio must be less than or equal to 100%")
+)
+
+// SetSubnetStakingParamsTx updates the staking parameters of an elastic subnet.
+// The parameters only apply to stakers added after the tx is accepted.
+type SetSubnetStakingParamsTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the elastic subnet whose parameters are being updated
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// MinValidatorStake is the minimum amount of funds required to become a
+	// validator.
+	MinValidatorStake uint64 `serialize:"true" json:"minValidatorStake"`
+	// MaxValidatorStake is the maximum amount of funds a single validator can
+	// be allocated, including delegated funds.
+	MaxValidatorStake uint64 `serialize:"true" json:"maxValidatorStake"`
+	// MinStakeDuration is the minimum number of seconds a validator can
+	// validate for in a single period.
+	MinStakeDuration uint32 `serialize:"true" json:"minStakeDuration"`
+	// MaxStakeDuration is the maximum number of seconds a staker can stake for
+	// in a single period.
+	MaxStakeDuration uint32 `serialize:"true" json:"maxStakeDuration"`
+	// MinDelegatorStake is the minimum amount of funds required to become a
+	// delegator.
+	MinDelegatorStake uint64 `serialize:"true" json:"minDelegatorStake"`
+	// MinDelegationDuration is the minimum number of seconds a delegator can
+	// delegate for in a single period.
+	MinDelegationDuration uint32 `serialize:"true" json:"minDelegationDuration"`
+	// MinSelfStakeRatio is the minimum portion, out of
+	// [reward.PercentDenominator], of a validator's total weight that must be
+	// the validator's own stake for it to accept a delegation. If 0, the total
+	// weight is only bounded by the max validator weight factor.
+	MinSelfStakeRatio uint32 `serialize:"true" json:"minSelfStakeRatio"`
+	// FeeConversionRate is the number of units of the subnet's staking asset
+	// that are charged in place of 1 AVAX for the fees of txs that add
+	// stakers to the subnet without consuming AVAX. If 0, fees must be paid in
+	// AVAX.
+	FeeConversionRate uint64 `serialize:"true" json:"feeConversionRate"`
+	// Authorizes this update
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *SetSubnetStakingParamsTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errCantSetPrimaryNetworkStakingParams
+	case tx.MinValidatorStake == 0:
+		return errMinValidatorStakeZero
+	case tx.MinValidatorStake > tx.MaxValidatorStake:
+		return errMinValidatorStakeAboveMax
+	case tx.MinStakeDuration == 0:
+		return errMinStakeDurationZero
+	case tx.MinStakeDuration > tx.MaxStakeDuration:
+		return errMinStakeDurationTooLarge
+	case tx.MinDelegatorStake == 0:
+		return errMinDelegatorStakeZero
+	case tx.MinDelegationDuration == 0:
+		return errMinDelegationDurationZero
+	case tx.MinDelegationDuration > tx.MaxStakeDuration:
+		return errMinDelegationDurationTooLarge
+	case tx.MinSelfStakeRatio > reward.PercentDenominator:
+		return errMinSelfStakeRatioTooLarge
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetStakingParamsTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetStakingParamsTx(tx)
+}
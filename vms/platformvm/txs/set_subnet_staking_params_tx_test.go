@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSetSubnetStakingParamsTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		ctx       = &snow.Context{
+			ChainID:   chainID,
+			NetworkID: networkID,
+		}
+	)
+
+	// A tx that passes syntactic verification.
+	newValidTx := func() *SetSubnetStakingParamsTx {
+		return &SetSubnetStakingParamsTx{
+			BaseTx: BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: chainID,
+				},
+			},
+			Subnet:                ids.GenerateTestID(),
+			MinValidatorStake:     1,
+			MaxValidatorStake:     2,
+			MinStakeDuration:      3,
+			MaxStakeDuration:      4,
+			MinDelegatorStake:     5,
+			MinDelegationDuration: 4,
+			MinSelfStakeRatio:     reward.PercentDenominator,
+			SubnetAuth:            &secp256k1fx.Input{},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		txF         func() *SetSubnetStakingParamsTx
+		expectedErr error
+	}{
+		{
+			name: "nil tx",
+			txF: func() *SetSubnetStakingParamsTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txF: func() *SetSubnetStakingParamsTx {
+				return &SetSubnetStakingParamsTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.Subnet = constants.PrimaryNetworkID
+				return tx
+			},
+			expectedErr: errCantSetPrimaryNetworkStakingParams,
+		},
+		{
+			name: "zero min validator stake",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinValidatorStake = 0
+				return tx
+			},
+			expectedErr: errMinValidatorStakeZero,
+		},
+		{
+			name: "min validator stake above max",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinValidatorStake = 3
+				return tx
+			},
+			expectedErr: errMinValidatorStakeAboveMax,
+		},
+		{
+			name: "zero min stake duration",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinStakeDuration = 0
+				return tx
+			},
+			expectedErr: errMinStakeDurationZero,
+		},
+		{
+			name: "min stake duration above max",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinStakeDuration = 5
+				return tx
+			},
+			expectedErr: errMinStakeDurationTooLarge,
+		},
+		{
+			name: "zero min delegator stake",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinDelegatorStake = 0
+				return tx
+			},
+			expectedErr: errMinDelegatorStakeZero,
+		},
+		{
+			name: "zero min delegation duration",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinDelegationDuration = 0
+				return tx
+			},
+			expectedErr: errMinDelegationDurationZero,
+		},
+		{
+			name: "min delegation duration above max stake duration",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinDelegationDuration = 5
+				return tx
+			},
+			expectedErr: errMinDelegationDurationTooLarge,
+		},
+		{
+			name: "min self-stake ratio above 100%",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.MinSelfStakeRatio = reward.PercentDenominator + 1
+				return tx
+			},
+			expectedErr: errMinSelfStakeRatioTooLarge,
+		},
+		{
+			name: "invalid base tx",
+			txF: func() *SetSubnetStakingParamsTx {
+				tx := newValidTx()
+				tx.NetworkID++
+				return tx
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name:        "valid tx",
+			txF:         newValidTx,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.txF().SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+// MaxSubnetValidatorEpochHours is the maximum duration, in hours, of the epochs
+// of a subnet's validator set.
+const MaxSubnetValidatorEpochHours = 365 * 24
+
+var (
+	_ UnsignedTx = (*SetSubnetValidatorEpochTx)(nil)
+
+	errCantSetPrimaryNetworkEpoch = errors.New("cannot set the validator epoch of the primary network")
+	errEpochTooLong               = errors.New("validator epoch is too long")
+)
+
+// SetSubnetValidatorEpochTx freezes the validator set of a permissioned subnet
+// between epoch boundaries. Once set, the validators that are added to or
+// removed from the subnet only take effect at the next epoch boundary.
+type SetSubnetValidatorEpochTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet whose validator set is being frozen
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// EpochHours is the duration, in hours, of an epoch. Epoch boundaries are
+	// the multiples of the epoch duration since the Unix epoch. If 0, changes
+	// to the validator set take effect immediately.
+	EpochHours uint32 `serialize:"true" json:"epochHours"`
+	// Authorizes this update
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *SetSubnetValidatorEpochTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errCantSetPrimaryNetworkEpoch
+	case tx.EpochHours > MaxSubnetValidatorEpochHours:
+		return errEpochTooLong
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetValidatorEpochTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetValidatorEpochTx(tx)
+}
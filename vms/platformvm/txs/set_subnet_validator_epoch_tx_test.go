@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSetSubnetValidatorEpochTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		ctx       = &snow.Context{
+			ChainID:   chainID,
+			NetworkID: networkID,
+		}
+	)
+
+	// A tx that passes syntactic verification.
+	newValidTx := func() *SetSubnetValidatorEpochTx {
+		return &SetSubnetValidatorEpochTx{
+			BaseTx: BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: chainID,
+				},
+			},
+			Subnet:     ids.GenerateTestID(),
+			EpochHours: 24,
+			SubnetAuth: &secp256k1fx.Input{},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		txF         func() *SetSubnetValidatorEpochTx
+		expectedErr error
+	}{
+		{
+			name: "nil tx",
+			txF: func() *SetSubnetValidatorEpochTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txF: func() *SetSubnetValidatorEpochTx {
+				return &SetSubnetValidatorEpochTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			txF: func() *SetSubnetValidatorEpochTx {
+				tx := newValidTx()
+				tx.Subnet = constants.PrimaryNetworkID
+				return tx
+			},
+			expectedErr: errCantSetPrimaryNetworkEpoch,
+		},
+		{
+			name: "epoch too long",
+			txF: func() *SetSubnetValidatorEpochTx {
+				tx := newValidTx()
+				tx.EpochHours = MaxSubnetValidatorEpochHours + 1
+				return tx
+			},
+			expectedErr: errEpochTooLong,
+		},
+		{
+			name: "invalid base tx",
+			txF: func() *SetSubnetValidatorEpochTx {
+				tx := newValidTx()
+				tx.NetworkID++
+				return tx
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "disable epochs",
+			txF: func() *SetSubnetValidatorEpochTx {
+				tx := newValidTx()
+				tx.EpochHours = 0
+				return tx
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "valid tx",
+			txF:         newValidTx,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.txF().SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+var (
+	_ UnsignedTx = (*SetValidatorBLSKeyTx)(nil)
+
+	errMissingBLSKey = errors.New("missing BLS key")
+)
+
+// SetValidatorBLSKeyTx is an unsigned setValidatorBLSKeyTx
+type SetValidatorBLSKeyTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node that is currently validating the primary network
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The BLS key that [NodeID] will use from now on. This registers a BLS key
+	// if the validator doesn't have one, and replaces it otherwise.
+	Signer signer.Signer `serialize:"true" json:"signer"`
+	// Proves that the issuer is the owner of the validator's validation
+	// rewards
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+func (tx *SetValidatorBLSKeyTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(tx.Signer, tx.ValidatorAuth); err != nil {
+		return fmt.Errorf("failed to verify signer or validator authorization: %w", err)
+	}
+	if tx.Signer.Key() == nil {
+		return errMissingBLSKey
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetValidatorBLSKeyTx) Visit(visitor Visitor) error {
+	return visitor.SetValidatorBLSKeyTx(tx)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSetValidatorBLSKeyTxSyntacticVerify(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		pop       = signer.NewProofOfPossession(sk)
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		tx          *SetValidatorBLSKeyTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			tx: &SetValidatorBLSKeyTx{
+				BaseTx: BaseTx{
+					SyntacticallyVerified: true,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "empty nodeID",
+			tx: &SetValidatorBLSKeyTx{
+				BaseTx:        validBaseTx,
+				Signer:        pop,
+				ValidatorAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "missing BLS key",
+			tx: &SetValidatorBLSKeyTx{
+				BaseTx:        validBaseTx,
+				NodeID:        nodeID,
+				Signer:        &signer.Empty{},
+				ValidatorAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errMissingBLSKey,
+		},
+		{
+			name: "valid tx",
+			tx: &SetValidatorBLSKeyTx{
+				BaseTx:        validBaseTx,
+				NodeID:        nodeID,
+				Signer:        pop,
+				ValidatorAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
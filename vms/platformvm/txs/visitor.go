@@ -20,4 +20,16 @@ type Visitor interface {
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
 	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
 	BaseTx(*BaseTx) error
+	ChangeDelegationFeeTx(*ChangeDelegationFeeTx) error
+	RotateValidatorNodeTx(*RotateValidatorNodeTx) error
+	AddCappedPermissionlessValidatorTx(*AddCappedPermissionlessValidatorTx) error
+	SetValidatorBLSKeyTx(*SetValidatorBLSKeyTx) error
+	ReportMisbehaviorTx(*ReportMisbehaviorTx) error
+	SetSubnetStakingParamsTx(*SetSubnetStakingParamsTx) error
+	ReduceSubnetValidatorWeightTx(*ReduceSubnetValidatorWeightTx) error
+	AddSplitRewardsPermissionlessValidatorTx(*AddSplitRewardsPermissionlessValidatorTx) error
+	AddValidatorWithSubnetsTx(*AddValidatorWithSubnetsTx) error
+	AddVestingPermissionlessValidatorTx(*AddVestingPermissionlessValidatorTx) error
+	SetSubnetValidatorEpochTx(*SetSubnetValidatorEpochTx) error
+	AddPermissionlessValidatorWithMetadataTx(*AddPermissionlessValidatorWithMetadataTx) error
 }
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// FlowCheckError is returned when the inputs of a tx can't be spent to produce
+// its outputs. It reports why the flow check failed so that callers don't need
+// to parse the error message.
+type FlowCheckError struct {
+	// FailedInput is the index of the input whose credential failed
+	// verification, or -1 if no credential failed verification.
+	FailedInput int
+	// MissingUnlocked is the amount of each asset by which the unlocked
+	// outputs exceed the unlocked inputs.
+	MissingUnlocked map[ids.ID]uint64
+	// MissingLocked is the amount of each asset by which the locked outputs
+	// exceed the locked inputs that can be used to produce them, after the
+	// unlocked inputs are used to cover the difference.
+	MissingLocked map[ids.ID]uint64
+
+	err error
+}
+
+func (e *FlowCheckError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the cause of the failure, such as
+// [ErrInsufficientUnlockedFunds].
+func (e *FlowCheckError) Unwrap() error {
+	return e.err
+}
+
+func credentialError(index int, err error) error {
+	return &FlowCheckError{
+		FailedInput: index,
+		err:         fmt.Errorf("failed to verify credential of input %d: %w", index, err),
+	}
+}
+
+// shortfallError returns the error reporting [missingLocked] and
+// [missingUnlocked], or nil if no funds are missing.
+func shortfallError(missingLocked, missingUnlocked map[ids.ID]uint64) error {
+	var err error
+	switch {
+	case len(missingLocked) > 0:
+		err = ErrInsufficientLockedFunds
+	case len(missingUnlocked) > 0:
+		err = ErrInsufficientUnlockedFunds
+	default:
+		return nil
+	}
+
+	var shortfalls []string
+	for _, missing := range []struct {
+		kind    string
+		amounts map[ids.ID]uint64
+	}{
+		{kind: "locked", amounts: missingLocked},
+		{kind: "unlocked", amounts: missingUnlocked},
+	} {
+		assetIDs := make([]ids.ID, 0, len(missing.amounts))
+		for assetID := range missing.amounts {
+			assetIDs = append(assetIDs, assetID)
+		}
+		utils.Sort(assetIDs)
+		for _, assetID := range assetIDs {
+			shortfalls = append(shortfalls, fmt.Sprintf("%d more %s %s",
+				missing.amounts[assetID],
+				missing.kind,
+				assetID,
+			))
+		}
+	}
+	return &FlowCheckError{
+		FailedInput:     -1,
+		MissingUnlocked: missingUnlocked,
+		MissingLocked:   missingLocked,
+		err:             fmt.Errorf("%w: needs %s", err, strings.Join(shortfalls, ", ")),
+	}
+}
@@ -139,9 +139,9 @@ func (h *verifier) VerifySpendUTXOs(
 			len(utxos),
 		)
 	}
-	for _, cred := range creds { // Verify credentials are well-formed.
+	for index, cred := range creds { // Verify credentials are well-formed.
 		if err := cred.Verify(); err != nil {
-			return err
+			return credentialError(index, err)
 		}
 	}
 
@@ -200,7 +200,7 @@ func (h *verifier) VerifySpendUTXOs(
 
 		// Verify that this tx's credentials allow [in] to be spent
 		if err := h.fx.VerifyTransfer(tx, in, creds[index], out); err != nil {
-			return fmt.Errorf("failed to verify transfer: %w", err)
+			return credentialError(index, err)
 		}
 
 		amount := in.Amount()
@@ -290,6 +290,9 @@ func (h *verifier) VerifySpendUTXOs(
 		owners[ownerID] = newAmount
 	}
 
+	// assetID -> amount
+	var missingLocked, missingUnlocked map[ids.ID]uint64
+
 	// Make sure that for each assetID and locktime, tokens produced <= tokens consumed
 	for assetID, producedAssetAmounts := range lockedProduced {
 		lockedConsumedAsset := lockedConsumed[assetID]
@@ -302,14 +305,15 @@ func (h *verifier) VerifySpendUTXOs(
 					increase := producedAmount - consumedAmount
 					unlockedConsumedAsset := unlockedConsumed[assetID]
 					if increase > unlockedConsumedAsset {
-						return fmt.Errorf(
-							"%w: %s needs %d more %s for locktime %d",
-							ErrInsufficientLockedFunds,
-							ownerID,
-							increase-unlockedConsumedAsset,
-							assetID,
-							locktime,
-						)
+						if missingLocked == nil {
+							missingLocked = make(map[ids.ID]uint64)
+						}
+						missing, err := math.Add64(missingLocked[assetID], increase-unlockedConsumedAsset)
+						if err != nil {
+							return err
+						}
+						missingLocked[assetID] = missing
+						increase = unlockedConsumedAsset
 					}
 					unlockedConsumed[assetID] = unlockedConsumedAsset - increase
 				}
@@ -321,13 +325,11 @@ func (h *verifier) VerifySpendUTXOs(
 		unlockedConsumedAsset := unlockedConsumed[assetID]
 		// More unlocked tokens produced than consumed. Invalid.
 		if unlockedProducedAsset > unlockedConsumedAsset {
-			return fmt.Errorf(
-				"%w: needs %d more %s",
-				ErrInsufficientUnlockedFunds,
-				unlockedProducedAsset-unlockedConsumedAsset,
-				assetID,
-			)
+			if missingUnlocked == nil {
+				missingUnlocked = make(map[ids.ID]uint64)
+			}
+			missingUnlocked[assetID] = unlockedProducedAsset - unlockedConsumedAsset
 		}
 	}
-	return nil
+	return shortfallError(missingLocked, missingUnlocked)
 }
@@ -1103,3 +1103,158 @@ func TestVerifySpendUTXOs(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySpendUTXOsFlowCheckError(t *testing.T) {
+	fx := &secp256k1fx.Fx{}
+
+	require.NoError(t, fx.InitializeVM(&secp256k1fx.TestVM{}))
+	require.NoError(t, fx.Bootstrapped())
+
+	ctx := snowtest.Context(t, snowtest.PChainID)
+
+	h := &verifier{
+		ctx: ctx,
+		clk: &mockable.Clock{},
+		fx:  fx,
+	}
+
+	unsignedTx := dummyUnsignedTx{
+		BaseTx: txs.BaseTx{},
+	}
+	unsignedTx.SetBytes([]byte{0})
+
+	customAssetID := ids.GenerateTestID()
+
+	tests := []struct {
+		description     string
+		utxos           []*avax.UTXO
+		ins             []*avax.TransferableInput
+		outs            []*avax.TransferableOutput
+		creds           []verify.Verifiable
+		producedAmounts map[ids.ID]uint64
+		expectedErr     error
+		expectedFlow    *FlowCheckError
+	}{
+		{
+			description: "missing unlocked funds of multiple assets",
+			utxos:       []*avax.UTXO{},
+			ins:         []*avax.TransferableInput{},
+			outs:        []*avax.TransferableOutput{},
+			creds:       []verify.Verifiable{},
+			producedAmounts: map[ids.ID]uint64{
+				h.ctx.AVAXAssetID: 1,
+				customAssetID:     2,
+			},
+			expectedErr: ErrInsufficientUnlockedFunds,
+			expectedFlow: &FlowCheckError{
+				FailedInput: -1,
+				MissingUnlocked: map[ids.ID]uint64{
+					h.ctx.AVAXAssetID: 1,
+					customAssetID:     2,
+				},
+			},
+		},
+		{
+			description: "missing locked and unlocked funds",
+			utxos: []*avax.UTXO{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+				},
+			}},
+			ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: 1,
+				},
+			}},
+			outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &stakeable.LockOut{
+					Locktime: 1,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt: 3,
+					},
+				},
+			}},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+			producedAmounts: map[ids.ID]uint64{
+				h.ctx.AVAXAssetID: 1,
+			},
+			expectedErr: ErrInsufficientLockedFunds,
+			expectedFlow: &FlowCheckError{
+				FailedInput: -1,
+				MissingUnlocked: map[ids.ID]uint64{
+					h.ctx.AVAXAssetID: 1,
+				},
+				MissingLocked: map[ids.ID]uint64{
+					h.ctx.AVAXAssetID: 2,
+				},
+			},
+		},
+		{
+			description: "invalid credential",
+			utxos: []*avax.UTXO{
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: 1,
+					},
+				},
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: 1,
+					},
+				},
+			},
+			ins: []*avax.TransferableInput{
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+					},
+				},
+				{
+					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+					},
+				},
+			},
+			outs: []*avax.TransferableOutput{},
+			creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+				(*secp256k1fx.Credential)(nil),
+			},
+			producedAmounts: map[ids.ID]uint64{},
+			expectedErr:     secp256k1fx.ErrNilCredential,
+			expectedFlow: &FlowCheckError{
+				FailedInput: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			require := require.New(t)
+
+			err := h.VerifySpendUTXOs(
+				&unsignedTx,
+				test.utxos,
+				test.ins,
+				test.outs,
+				test.creds,
+				test.producedAmounts,
+			)
+			require.ErrorIs(err, test.expectedErr)
+
+			var flowErr *FlowCheckError
+			require.ErrorAs(err, &flowErr)
+			require.Equal(test.expectedFlow.FailedInput, flowErr.FailedInput)
+			require.Equal(test.expectedFlow.MissingUnlocked, flowErr.MissingUnlocked)
+			require.Equal(test.expectedFlow.MissingLocked, flowErr.MissingLocked)
+		})
+	}
+}
@@ -660,7 +660,9 @@ func buildVM(t *testing.T) (*VM, ids.ID, error) {
 		MaxValidatorStake:      defaultMaxValidatorStake,
 		MinDelegatorStake:      defaultMinDelegatorStake,
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			ApricotPhase3Time: forkTime,
@@ -4,16 +4,23 @@
 package validators
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/window"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
@@ -21,6 +28,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const (
@@ -33,6 +42,8 @@ const (
 var (
 	_ validators.State = (*manager)(nil)
 
+	ErrInconsistentValidatorSet = errors.New("cached validator set is inconsistent with the state")
+
 	errUnfinalizedHeight = errors.New("failed to fetch validator set at unfinalized height")
 )
 
@@ -44,6 +55,47 @@ type Manager interface {
 	// OnAcceptedBlockID registers the ID of the latest accepted block.
 	// It is used to update the [recentlyAccepted] sliding window.
 	OnAcceptedBlockID(blkID ids.ID)
+
+	// CheckConsistency recomputes a randomly selected cached validator set
+	// from the validator diffs. If the recomputed validator set doesn't match
+	// the cached validator set, ErrInconsistentValidatorSet is returned.
+	CheckConsistency(ctx context.Context) error
+
+	// GetValidatorPublicKey returns the BLS public key that [nodeID] had
+	// registered as a primary network validator at [targetHeight]. If
+	// [nodeID] didn't have a public key at [targetHeight], nil is returned.
+	//
+	// Unlike GetValidatorSet, only the public key diffs of [nodeID] are
+	// applied, so the full validator set isn't generated.
+	GetValidatorPublicKey(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		targetHeight uint64,
+	) (*bls.PublicKey, error)
+
+	// GetTotalWeight returns the total weight of the validators of
+	// [subnetID] at [targetHeight].
+	//
+	// If the validator set at [targetHeight] is cached or [targetHeight] is
+	// the current height, the validator set isn't generated.
+	GetTotalWeight(
+		ctx context.Context,
+		subnetID ids.ID,
+		targetHeight uint64,
+	) (uint64, error)
+
+	// GetSubsetWeight returns the cumulative weight that [nodeIDs] had as
+	// validators of [subnetID] at [targetHeight]. NodeIDs that weren't
+	// validators at [targetHeight] don't contribute any weight.
+	//
+	// Unlike GetValidatorSet, only the nodes whose weight changed after
+	// [targetHeight] are tracked, so the full validator set isn't generated.
+	GetSubsetWeight(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeIDs set.Set[ids.NodeID],
+		targetHeight uint64,
+	) (uint64, error)
 }
 
 type State interface {
@@ -86,6 +138,15 @@ type State interface {
 		startHeight uint64,
 		endHeight uint64,
 	) error
+
+	// GetValidatorSetCheckpoint returns the lowest height at or above
+	// [minHeight] at which the validator set of [subnetID] was checkpointed,
+	// along with the validator set at that height. If there is no such
+	// checkpoint, database.ErrNotFound is returned.
+	GetValidatorSetCheckpoint(
+		subnetID ids.ID,
+		minHeight uint64,
+	) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
 }
 
 func NewManager(
@@ -94,14 +155,17 @@ func NewManager(
 	state State,
 	metrics metrics.Metrics,
 	clk *mockable.Clock,
+	cacheConfig CacheConfig,
 ) Manager {
 	return &manager{
-		log:     log,
-		cfg:     cfg,
-		state:   state,
-		metrics: metrics,
-		clk:     clk,
-		caches:  make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]),
+		log:            log,
+		cfg:            cfg,
+		state:          state,
+		metrics:        metrics,
+		clk:            clk,
+		cacheConfig:    cacheConfig,
+		caches:         make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]),
+		recentlyCached: make([]validatorSetKey, 0, validatorSetsCacheSize),
 		recentlyAccepted: window.New[ids.ID](
 			window.Config{
 				Clock:   clk,
@@ -122,11 +186,18 @@ type manager struct {
 	metrics metrics.Metrics
 	clk     *mockable.Clock
 
+	cacheConfig CacheConfig
+
 	// Maps caches for each subnet that is currently tracked.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
 	caches map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]
 
+	// ring buffer of the most recently cached validator sets, used to select
+	// the validator sets to check for consistency
+	recentlyCached     []validatorSetKey
+	recentlyCachedNext int
+
 	// sliding window of blocks that were recently accepted
 	recentlyAccepted window.Window[ids.ID]
 }
@@ -200,22 +271,19 @@ func (m *manager) GetValidatorSet(
 	// get the start time to track metrics
 	startTime := m.clk.Time()
 
-	var (
-		validatorSet  map[ids.NodeID]*validators.GetValidatorOutput
-		currentHeight uint64
-		err           error
-	)
-	if subnetID == constants.PrimaryNetworkID {
-		validatorSet, currentHeight, err = m.makePrimaryNetworkValidatorSet(ctx, targetHeight)
-	} else {
-		validatorSet, currentHeight, err = m.makeSubnetValidatorSet(ctx, targetHeight, subnetID)
-	}
+	validatorSet, currentHeight, err := m.makeValidatorSet(ctx, targetHeight, subnetID)
 	if err != nil {
 		return nil, err
 	}
 
 	// cache the validator set
 	validatorSetsCache.Put(targetHeight, validatorSet)
+	if m.isCached(subnetID) {
+		m.markCached(validatorSetKey{
+			subnetID: subnetID,
+			height:   targetHeight,
+		})
+	}
 
 	duration := m.clk.Time().Sub(startTime)
 	m.metrics.IncValidatorSetsCreated()
@@ -224,9 +292,14 @@ func (m *manager) GetValidatorSet(
 	return validatorSet, nil
 }
 
+// isCached returns true if the validator sets of [subnetID] are cached. Only
+// the validator sets of tracked subnets are cached.
+func (m *manager) isCached(subnetID ids.ID) bool {
+	return subnetID == constants.PrimaryNetworkID || m.cfg.TrackedSubnets.Contains(subnetID)
+}
+
 func (m *manager) getValidatorSetCache(subnetID ids.ID) cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput] {
-	// Only cache tracked subnets
-	if subnetID != constants.PrimaryNetworkID && !m.cfg.TrackedSubnets.Contains(subnetID) {
+	if !m.isCached(subnetID) {
 		return &cache.Empty[uint64, map[ids.NodeID]*validators.GetValidatorOutput]{}
 	}
 
@@ -235,13 +308,28 @@ func (m *manager) getValidatorSetCache(subnetID ids.ID) cache.Cacher[uint64, map
 		return validatorSetsCache
 	}
 
-	validatorSetsCache = &cache.LRU[uint64, map[ids.NodeID]*validators.GetValidatorOutput]{
-		Size: validatorSetsCacheSize,
+	if m.cacheConfig.MaxValidators > 0 {
+		validatorSetsCache = newSpillCache(m.log, subnetID, m.cacheConfig)
+	} else {
+		validatorSetsCache = &cache.LRU[uint64, map[ids.NodeID]*validators.GetValidatorOutput]{
+			Size: validatorSetsCacheSize,
+		}
 	}
 	m.caches[subnetID] = validatorSetsCache
 	return validatorSetsCache
 }
 
+func (m *manager) makeValidatorSet(
+	ctx context.Context,
+	targetHeight uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, uint64, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		return m.makePrimaryNetworkValidatorSet(ctx, targetHeight)
+	}
+	return m.makeSubnetValidatorSet(ctx, targetHeight, subnetID)
+}
+
 func (m *manager) makePrimaryNetworkValidatorSet(
 	ctx context.Context,
 	targetHeight uint64,
@@ -259,17 +347,29 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 		)
 	}
 
+	// If there is a checkpoint between [targetHeight] and [currentHeight],
+	// the diffs only need to be applied from the checkpoint.
+	startHeight := currentHeight
+	checkpointHeight, checkpoint, ok, err := m.getCheckpoint(constants.PrimaryNetworkID, targetHeight, currentHeight)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ok {
+		startHeight = checkpointHeight
+		validatorSet = checkpoint
+	}
+
 	// Rebuild primary network validators at [targetHeight]
 	//
 	// Note: Since we are attempting to generate the validator set at
 	// [targetHeight], we want to apply the diffs from
-	// (targetHeight, currentHeight]. Because the state interface is implemented
-	// to be inclusive, we apply diffs in [targetHeight + 1, currentHeight].
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
 	lastDiffHeight := targetHeight + 1
 	err = m.state.ApplyValidatorWeightDiffs(
 		ctx,
 		validatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 		constants.PlatformChainID,
 	)
@@ -280,12 +380,34 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 	err = m.state.ApplyValidatorPublicKeyDiffs(
 		ctx,
 		validatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 	)
 	return validatorSet, currentHeight, err
 }
 
+// getCheckpoint returns the validator set checkpoint of [subnetID] with the
+// lowest height in [targetHeight, currentHeight). If there is no such
+// checkpoint, false is returned.
+func (m *manager) getCheckpoint(
+	subnetID ids.ID,
+	targetHeight uint64,
+	currentHeight uint64,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, bool, error) {
+	checkpointHeight, checkpoint, err := m.state.GetValidatorSetCheckpoint(subnetID, targetHeight)
+	if err == database.ErrNotFound {
+		return 0, nil, false, nil
+	}
+	if err != nil {
+		return 0, nil, false, err
+	}
+	if checkpointHeight >= currentHeight {
+		// The current validator set is at least as close to [targetHeight].
+		return 0, nil, false, nil
+	}
+	return checkpointHeight, checkpoint, true, nil
+}
+
 func (m *manager) getCurrentPrimaryValidatorSet(
 	ctx context.Context,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, uint64, error) {
@@ -312,17 +434,38 @@ func (m *manager) makeSubnetValidatorSet(
 		)
 	}
 
+	// If there are checkpoints of both the subnet and the primary network
+	// between [targetHeight] and [currentHeight], the diffs only need to be
+	// applied from the checkpoint. The primary network checkpoint provides the
+	// public keys at the checkpoint height.
+	startHeight := currentHeight
+	checkpointHeight, checkpoint, ok, err := m.getCheckpoint(subnetID, targetHeight, currentHeight)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ok {
+		primaryCheckpointHeight, primaryCheckpoint, err := m.state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, checkpointHeight)
+		switch {
+		case err == nil && primaryCheckpointHeight == checkpointHeight:
+			startHeight = checkpointHeight
+			subnetValidatorSet = checkpoint
+			primaryValidatorSet = primaryCheckpoint
+		case err != nil && err != database.ErrNotFound:
+			return nil, 0, err
+		}
+	}
+
 	// Rebuild subnet validators at [targetHeight]
 	//
 	// Note: Since we are attempting to generate the validator set at
 	// [targetHeight], we want to apply the diffs from
-	// (targetHeight, currentHeight]. Because the state interface is implemented
-	// to be inclusive, we apply diffs in [targetHeight + 1, currentHeight].
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
 	lastDiffHeight := targetHeight + 1
 	err = m.state.ApplyValidatorWeightDiffs(
 		ctx,
 		subnetValidatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 		subnetID,
 	)
@@ -331,10 +474,10 @@ func (m *manager) makeSubnetValidatorSet(
 	}
 
 	// Update the subnet validator set to include the public keys at
-	// [currentHeight]. When we apply the public key diffs, we will convert
+	// [startHeight]. When we apply the public key diffs, we will convert
 	// these keys to represent the public keys at [targetHeight]. If the subnet
-	// validator is not currently a primary network validator, it doesn't have a
-	// key at [currentHeight].
+	// validator is not a primary network validator at [startHeight], it
+	// doesn't have a key at [startHeight].
 	for nodeID, vdr := range subnetValidatorSet {
 		if primaryVdr, ok := primaryValidatorSet[nodeID]; ok {
 			vdr.PublicKey = primaryVdr.PublicKey
@@ -346,7 +489,7 @@ func (m *manager) makeSubnetValidatorSet(
 	err = m.state.ApplyValidatorPublicKeyDiffs(
 		ctx,
 		subnetValidatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 	)
 	return subnetValidatorSet, currentHeight, err
@@ -362,6 +505,158 @@ func (m *manager) getCurrentValidatorSets(
 	return subnetMap, primaryMap, currentHeight, err
 }
 
+func (m *manager) GetValidatorPublicKey(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	targetHeight uint64,
+) (*bls.PublicKey, error) {
+	validatorSetsCache := m.getValidatorSetCache(constants.PrimaryNetworkID)
+	if validatorSet, ok := validatorSetsCache.Get(targetHeight); ok {
+		if vdr, ok := validatorSet[nodeID]; ok {
+			return vdr.PublicKey, nil
+		}
+		return nil, nil
+	}
+
+	currentHeight, err := m.getCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentHeight < targetHeight {
+		return nil, fmt.Errorf("%w with SubnetID = %s: current P-chain height (%d) < requested P-Chain height (%d)",
+			errUnfinalizedHeight,
+			constants.PrimaryNetworkID,
+			currentHeight,
+			targetHeight,
+		)
+	}
+
+	vdr := &validators.GetValidatorOutput{
+		NodeID: nodeID,
+	}
+	startHeight := currentHeight
+	checkpointHeight, checkpoint, ok, err := m.getCheckpoint(constants.PrimaryNetworkID, targetHeight, currentHeight)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		startHeight = checkpointHeight
+		if checkpointVdr, ok := checkpoint[nodeID]; ok {
+			vdr.PublicKey = checkpointVdr.PublicKey
+		}
+	} else if currentVdr, ok := m.cfg.Validators.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		vdr.PublicKey = currentVdr.PublicKey
+	}
+
+	// Rebuild the public key at [targetHeight]
+	//
+	// Note: Since we are attempting to generate the public key at
+	// [targetHeight], we want to apply the diffs from
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
+	err = m.state.ApplyValidatorPublicKeyDiffs(
+		ctx,
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID: vdr,
+		},
+		startHeight,
+		targetHeight+1,
+	)
+	return vdr.PublicKey, err
+}
+
+func (m *manager) GetTotalWeight(
+	ctx context.Context,
+	subnetID ids.ID,
+	targetHeight uint64,
+) (uint64, error) {
+	validatorSetsCache := m.getValidatorSetCache(subnetID)
+	if validatorSet, ok := validatorSetsCache.Get(targetHeight); ok {
+		return totalWeight(validatorSet)
+	}
+
+	currentHeight, err := m.getCurrentHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if currentHeight == targetHeight {
+		return m.cfg.Validators.TotalWeight(subnetID)
+	}
+
+	validatorSet, err := m.GetValidatorSet(ctx, targetHeight, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	return totalWeight(validatorSet)
+}
+
+func (m *manager) GetSubsetWeight(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeIDs set.Set[ids.NodeID],
+	targetHeight uint64,
+) (uint64, error) {
+	validatorSetsCache := m.getValidatorSetCache(subnetID)
+	if validatorSet, ok := validatorSetsCache.Get(targetHeight); ok {
+		return subsetWeight(validatorSet, nodeIDs)
+	}
+
+	currentHeight, err := m.getCurrentHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if currentHeight < targetHeight {
+		return 0, fmt.Errorf("%w with SubnetID = %s: current P-chain height (%d) < requested P-Chain height (%d)",
+			errUnfinalizedHeight,
+			subnetID,
+			currentHeight,
+			targetHeight,
+		)
+	}
+	if currentHeight == targetHeight {
+		return m.cfg.Validators.SubsetWeight(subnetID, nodeIDs)
+	}
+
+	startHeight := currentHeight
+	checkpointHeight, vdrs, ok, err := m.getCheckpoint(subnetID, targetHeight, currentHeight)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		startHeight = checkpointHeight
+	} else {
+		vdrs = make(map[ids.NodeID]*validators.GetValidatorOutput, nodeIDs.Len())
+		for nodeID := range nodeIDs {
+			if weight := m.cfg.Validators.GetWeight(subnetID, nodeID); weight != 0 {
+				vdrs[nodeID] = &validators.GetValidatorOutput{
+					NodeID: nodeID,
+					Weight: weight,
+				}
+			}
+		}
+	}
+
+	// Rebuild the weights at [targetHeight]. Nodes outside of [nodeIDs] that
+	// are in the checkpoint or have diffs are added to [vdrs], but don't
+	// contribute to the result.
+	//
+	// Note: Since we are attempting to generate the weights at
+	// [targetHeight], we want to apply the diffs from
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
+	err = m.state.ApplyValidatorWeightDiffs(
+		ctx,
+		vdrs,
+		startHeight,
+		targetHeight+1,
+		subnetID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return subsetWeight(vdrs, nodeIDs)
+}
+
 func (m *manager) GetSubnetID(_ context.Context, chainID ids.ID) (ids.ID, error) {
 	if chainID == constants.PlatformChainID {
 		return constants.PrimaryNetworkID, nil
@@ -385,3 +680,125 @@ func (m *manager) GetSubnetID(_ context.Context, chainID ids.ID) (ids.ID, error)
 func (m *manager) OnAcceptedBlockID(blkID ids.ID) {
 	m.recentlyAccepted.Add(blkID)
 }
+
+func (m *manager) CheckConsistency(ctx context.Context) error {
+	if len(m.recentlyCached) == 0 {
+		return nil
+	}
+
+	key := m.recentlyCached[rand.Intn(len(m.recentlyCached))] // #nosec G404
+	cachedSet, ok := m.getValidatorSetCache(key.subnetID).Get(key.height)
+	if !ok {
+		// The validator set was evicted from the cache since it was recorded.
+		return nil
+	}
+
+	validatorSet, _, err := m.makeValidatorSet(ctx, key.height, key.subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to recompute validator set of subnet %s at height %d: %w",
+			key.subnetID,
+			key.height,
+			err,
+		)
+	}
+
+	nodeID, ok := firstMismatch(cachedSet, validatorSet)
+	if !ok {
+		return nil
+	}
+
+	m.metrics.IncValidatorSetsInconsistent()
+	m.log.Error("cached validator set diverged from the state",
+		zap.Stringer("subnetID", key.subnetID),
+		zap.Uint64("height", key.height),
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("numCachedValidators", len(cachedSet)),
+		zap.Int("numValidators", len(validatorSet)),
+	)
+	return fmt.Errorf("%w: validator %s of subnet %s at height %d",
+		ErrInconsistentValidatorSet,
+		nodeID,
+		key.subnetID,
+		key.height,
+	)
+}
+
+// markCached records that the validator set of [key] was cached.
+func (m *manager) markCached(key validatorSetKey) {
+	if len(m.recentlyCached) < cap(m.recentlyCached) {
+		m.recentlyCached = append(m.recentlyCached, key)
+		return
+	}
+	m.recentlyCached[m.recentlyCachedNext] = key
+	m.recentlyCachedNext = (m.recentlyCachedNext + 1) % len(m.recentlyCached)
+}
+
+func totalWeight(vdrs map[ids.NodeID]*validators.GetValidatorOutput) (uint64, error) {
+	var (
+		weight uint64
+		err    error
+	)
+	for _, vdr := range vdrs {
+		weight, err = safemath.Add64(weight, vdr.Weight)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return weight, nil
+}
+
+func subsetWeight(
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	nodeIDs set.Set[ids.NodeID],
+) (uint64, error) {
+	var (
+		weight uint64
+		err    error
+	)
+	for nodeID := range nodeIDs {
+		vdr, ok := vdrs[nodeID]
+		if !ok {
+			continue
+		}
+		weight, err = safemath.Add64(weight, vdr.Weight)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return weight, nil
+}
+
+type validatorSetKey struct {
+	subnetID ids.ID
+	height   uint64
+}
+
+// firstMismatch returns the ID of a node whose weight or public key differs
+// between [a] and [b]. If the validator sets are equal, false is returned.
+func firstMismatch(a, b map[ids.NodeID]*validators.GetValidatorOutput) (ids.NodeID, bool) {
+	for nodeID, vdrA := range a {
+		vdrB, ok := b[nodeID]
+		if !ok || !equalValidators(vdrA, vdrB) {
+			return nodeID, true
+		}
+	}
+	for nodeID := range b {
+		if _, ok := a[nodeID]; !ok {
+			return nodeID, true
+		}
+	}
+	return ids.EmptyNodeID, false
+}
+
+func equalValidators(a, b *validators.GetValidatorOutput) bool {
+	if a.Weight != b.Weight {
+		return false
+	}
+	if a.PublicKey == nil || b.PublicKey == nil {
+		return a.PublicKey == b.PublicKey
+	}
+	return bytes.Equal(
+		bls.PublicKeyToUncompressedBytes(a.PublicKey),
+		bls.PublicKeyToUncompressedBytes(b.PublicKey),
+	)
+}
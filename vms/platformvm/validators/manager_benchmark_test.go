@@ -138,6 +138,7 @@ func BenchmarkGetValidatorSet(b *testing.B) {
 		s,
 		metrics,
 		new(mockable.Clock),
+		CacheConfig{},
 	)
 
 	var (
@@ -0,0 +1,595 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ State = (*testState)(nil)
+
+// testState applies weight and public key diffs to a single validator.
+// Applying the weight diff at a height subtracts the weight that the validator
+// gained at that height. Applying the public key diff at a height sets the
+// public key that the validator had prior to that height.
+type testState struct {
+	lastAccepted   block.Block
+	nodeID         ids.NodeID
+	weightDiffs    map[uint64]uint64
+	publicKeyDiffs map[uint64]*bls.PublicKey
+	// subnetID -> height -> validator set
+	checkpoints map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (*testState) GetTx(ids.ID) (*txs.Tx, status.Status, error) {
+	return nil, status.Unknown, nil
+}
+
+func (s *testState) GetLastAccepted() ids.ID {
+	return s.lastAccepted.ID()
+}
+
+func (s *testState) GetStatelessBlock(ids.ID) (block.Block, error) {
+	return s.lastAccepted, nil
+}
+
+func (s *testState) ApplyValidatorWeightDiffs(
+	_ context.Context,
+	validators map[ids.NodeID]*validators.GetValidatorOutput,
+	startHeight uint64,
+	endHeight uint64,
+	_ ids.ID,
+) error {
+	vdr, ok := validators[s.nodeID]
+	if !ok {
+		return nil
+	}
+	for height := startHeight; height >= endHeight; height-- {
+		vdr.Weight -= s.weightDiffs[height]
+	}
+	return nil
+}
+
+func (s *testState) ApplyValidatorPublicKeyDiffs(
+	_ context.Context,
+	validators map[ids.NodeID]*validators.GetValidatorOutput,
+	startHeight uint64,
+	endHeight uint64,
+) error {
+	vdr, ok := validators[s.nodeID]
+	if !ok {
+		return nil
+	}
+	for height := startHeight; height >= endHeight; height-- {
+		if pk, ok := s.publicKeyDiffs[height]; ok {
+			vdr.PublicKey = pk
+		}
+	}
+	return nil
+}
+
+func (s *testState) GetValidatorSetCheckpoint(
+	subnetID ids.ID,
+	minHeight uint64,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	var (
+		found  bool
+		height uint64
+	)
+	for checkpointHeight := range s.checkpoints[subnetID] {
+		if checkpointHeight >= minHeight && (!found || checkpointHeight < height) {
+			found = true
+			height = checkpointHeight
+		}
+	}
+	if !found {
+		return 0, nil, database.ErrNotFound
+	}
+
+	// Return a copy so that applying diffs doesn't modify the checkpoint.
+	checkpoint := make(map[ids.NodeID]*validators.GetValidatorOutput)
+	for nodeID, vdr := range s.checkpoints[subnetID][height] {
+		vdrCopy := *vdr
+		checkpoint[nodeID] = &vdrCopy
+	}
+	return height, checkpoint, nil
+}
+
+func TestCheckConsistency(t *testing.T) {
+	require := require.New(t)
+
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 2, nil)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 10))
+
+	state := &testState{
+		lastAccepted: lastAccepted,
+		nodeID:       nodeID,
+		weightDiffs: map[uint64]uint64{
+			2: 5,
+		},
+	}
+	m := NewManager(
+		logging.NoLog{},
+		config.Config{
+			Validators: vdrs,
+		},
+		state,
+		metrics.Noop,
+		&mockable.Clock{},
+		CacheConfig{},
+	)
+
+	// Nothing has been cached yet.
+	require.NoError(m.CheckConsistency(context.Background()))
+
+	validatorSet, err := m.GetValidatorSet(context.Background(), 1, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(uint64(5), validatorSet[nodeID].Weight)
+
+	require.NoError(m.CheckConsistency(context.Background()))
+
+	// Recomputing the validator set with a different diff should be reported.
+	state.weightDiffs[2] = 4
+	err = m.CheckConsistency(context.Background())
+	require.ErrorIs(err, ErrInconsistentValidatorSet)
+
+	// The cached validator set isn't modified by the check.
+	validatorSet, err = m.GetValidatorSet(context.Background(), 1, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(uint64(5), validatorSet[nodeID].Weight)
+}
+
+func TestCheckConsistencyIgnoresUntrackedSubnets(t *testing.T) {
+	require := require.New(t)
+
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 2, nil)
+	require.NoError(err)
+
+	var (
+		nodeID   = ids.GenerateTestNodeID()
+		subnetID = ids.GenerateTestID()
+		vdrs     = validators.NewManager()
+	)
+	require.NoError(vdrs.AddStaker(subnetID, nodeID, nil, ids.Empty, 10))
+
+	state := &testState{
+		lastAccepted: lastAccepted,
+		nodeID:       nodeID,
+		weightDiffs:  map[uint64]uint64{},
+	}
+	m := NewManager(
+		logging.NoLog{},
+		config.Config{
+			Validators: vdrs,
+		},
+		state,
+		metrics.Noop,
+		&mockable.Clock{},
+		CacheConfig{},
+	)
+
+	_, err = m.GetValidatorSet(context.Background(), 1, subnetID)
+	require.NoError(err)
+
+	// Validator sets of untracked subnets aren't cached, so they can't be
+	// inconsistent.
+	state.weightDiffs[2] = 4
+	require.NoError(m.CheckConsistency(context.Background()))
+}
+
+func TestGetValidatorPublicKey(t *testing.T) {
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 3, nil)
+	require.NoError(t, err)
+
+	sk0, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	sk1, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	var (
+		nodeID = ids.GenerateTestNodeID()
+		pk0    = bls.PublicFromSecretKey(sk0)
+		pk1    = bls.PublicFromSecretKey(sk1)
+		vdrs   = validators.NewManager()
+	)
+	require.NoError(t, vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, pk1, ids.Empty, 10))
+
+	// The validator was added with [pk0] at height 2 and changed to [pk1] at
+	// height 3.
+	state := &testState{
+		lastAccepted: lastAccepted,
+		nodeID:       nodeID,
+		publicKeyDiffs: map[uint64]*bls.PublicKey{
+			2: nil,
+			3: pk0,
+		},
+	}
+	m := NewManager(
+		logging.NoLog{},
+		config.Config{
+			Validators: vdrs,
+		},
+		state,
+		metrics.Noop,
+		&mockable.Clock{},
+		CacheConfig{},
+	)
+
+	tests := []struct {
+		name        string
+		nodeID      ids.NodeID
+		height      uint64
+		expectedPK  *bls.PublicKey
+		expectedErr error
+	}{
+		{
+			name:       "current height",
+			nodeID:     nodeID,
+			height:     3,
+			expectedPK: pk1,
+		},
+		{
+			name:       "before key change",
+			nodeID:     nodeID,
+			height:     2,
+			expectedPK: pk0,
+		},
+		{
+			name:       "before validator was added",
+			nodeID:     nodeID,
+			height:     1,
+			expectedPK: nil,
+		},
+		{
+			name:       "unknown validator",
+			nodeID:     ids.GenerateTestNodeID(),
+			height:     3,
+			expectedPK: nil,
+		},
+		{
+			name:        "unfinalized height",
+			nodeID:      nodeID,
+			height:      4,
+			expectedErr: errUnfinalizedHeight,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pk, err := m.GetValidatorPublicKey(context.Background(), test.nodeID, test.height)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedPK, pk)
+		})
+	}
+}
+
+func TestGetWeights(t *testing.T) {
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 3, nil)
+	require.NoError(t, err)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		vdrs        = validators.NewManager()
+	)
+	require.NoError(t, vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 10))
+	require.NoError(t, vdrs.AddStaker(constants.PrimaryNetworkID, otherNodeID, nil, ids.Empty, 5))
+
+	// [nodeID] gained 4 weight at height 2 and 2 weight at height 3.
+	state := &testState{
+		lastAccepted: lastAccepted,
+		nodeID:       nodeID,
+		weightDiffs: map[uint64]uint64{
+			2: 4,
+			3: 2,
+		},
+	}
+	m := NewManager(
+		logging.NoLog{},
+		config.Config{
+			Validators: vdrs,
+		},
+		state,
+		metrics.Noop,
+		&mockable.Clock{},
+		CacheConfig{},
+	)
+
+	totalTests := []struct {
+		name           string
+		height         uint64
+		expectedWeight uint64
+	}{
+		{
+			name:           "current height",
+			height:         3,
+			expectedWeight: 15,
+		},
+		{
+			name:           "historical height",
+			height:         2,
+			expectedWeight: 13,
+		},
+	}
+	for _, test := range totalTests {
+		t.Run("total weight "+test.name, func(t *testing.T) {
+			weight, err := m.GetTotalWeight(context.Background(), constants.PrimaryNetworkID, test.height)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedWeight, weight)
+		})
+	}
+
+	subsetTests := []struct {
+		name           string
+		nodeIDs        set.Set[ids.NodeID]
+		height         uint64
+		expectedWeight uint64
+		expectedErr    error
+	}{
+		{
+			name:           "current height",
+			nodeIDs:        set.Of(nodeID, otherNodeID),
+			height:         3,
+			expectedWeight: 15,
+		},
+		{
+			name:           "historical height",
+			nodeIDs:        set.Of(nodeID),
+			height:         1,
+			expectedWeight: 4,
+		},
+		{
+			name:           "historical height with unchanged weight",
+			nodeIDs:        set.Of(nodeID, otherNodeID),
+			height:         2,
+			expectedWeight: 13,
+		},
+		{
+			name:           "subset without changed weights",
+			nodeIDs:        set.Of(otherNodeID),
+			height:         1,
+			expectedWeight: 5,
+		},
+		{
+			name:           "unknown validator",
+			nodeIDs:        set.Of(ids.GenerateTestNodeID()),
+			height:         2,
+			expectedWeight: 0,
+		},
+		{
+			name:        "unfinalized height",
+			nodeIDs:     set.Of(nodeID),
+			height:      4,
+			expectedErr: errUnfinalizedHeight,
+		},
+	}
+	for _, test := range subsetTests {
+		t.Run("subset weight "+test.name, func(t *testing.T) {
+			weight, err := m.GetSubsetWeight(context.Background(), constants.PrimaryNetworkID, test.nodeIDs, test.height)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedWeight, weight)
+		})
+	}
+}
+
+func TestGetValidatorSetFromCheckpoint(t *testing.T) {
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 10, nil)
+	require.NoError(t, err)
+
+	var (
+		nodeID   = ids.GenerateTestNodeID()
+		subnetID = ids.GenerateTestID()
+		vdrs     = validators.NewManager()
+	)
+	require.NoError(t, vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 100))
+	require.NoError(t, vdrs.AddStaker(subnetID, nodeID, nil, ids.Empty, 100))
+
+	// The validator gained 1 weight at every height. The checkpoints don't
+	// match the diffs so that the tests can tell which one was used.
+	weightDiffs := make(map[uint64]uint64)
+	for height := uint64(1); height <= 10; height++ {
+		weightDiffs[height] = 1
+	}
+	checkpoint := func(weight uint64) map[ids.NodeID]*validators.GetValidatorOutput {
+		return map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID: {
+				NodeID: nodeID,
+				Weight: weight,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		subnetID       ids.ID
+		checkpoints    map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput
+		targetHeight   uint64
+		expectedWeight uint64
+	}{
+		{
+			name:           "no checkpoint",
+			subnetID:       constants.PrimaryNetworkID,
+			targetHeight:   3,
+			expectedWeight: 93,
+		},
+		{
+			name:     "checkpoint above target height",
+			subnetID: constants.PrimaryNetworkID,
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				constants.PrimaryNetworkID: {
+					5: checkpoint(50),
+					8: checkpoint(80),
+				},
+			},
+			targetHeight:   3,
+			expectedWeight: 48,
+		},
+		{
+			name:     "checkpoint at target height",
+			subnetID: constants.PrimaryNetworkID,
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				constants.PrimaryNetworkID: {
+					5: checkpoint(50),
+				},
+			},
+			targetHeight:   5,
+			expectedWeight: 50,
+		},
+		{
+			name:     "checkpoint below target height",
+			subnetID: constants.PrimaryNetworkID,
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				constants.PrimaryNetworkID: {
+					5: checkpoint(50),
+				},
+			},
+			targetHeight:   6,
+			expectedWeight: 96,
+		},
+		{
+			name:     "subnet checkpoint",
+			subnetID: subnetID,
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				constants.PrimaryNetworkID: {
+					5: checkpoint(50),
+				},
+				subnetID: {
+					5: checkpoint(40),
+				},
+			},
+			targetHeight:   3,
+			expectedWeight: 38,
+		},
+		{
+			name:     "subnet checkpoint without primary network checkpoint",
+			subnetID: subnetID,
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				subnetID: {
+					5: checkpoint(40),
+				},
+			},
+			targetHeight:   3,
+			expectedWeight: 93,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManager(
+				logging.NoLog{},
+				config.Config{
+					Validators: vdrs,
+				},
+				&testState{
+					lastAccepted: lastAccepted,
+					nodeID:       nodeID,
+					weightDiffs:  weightDiffs,
+					checkpoints:  test.checkpoints,
+				},
+				metrics.Noop,
+				&mockable.Clock{},
+				CacheConfig{},
+			)
+
+			validatorSet, err := m.GetValidatorSet(context.Background(), test.targetHeight, test.subnetID)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedWeight, validatorSet[nodeID].Weight)
+		})
+	}
+}
+
+func TestGetFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	lastAccepted, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), 10, nil)
+	require.NoError(err)
+
+	sk0, err := bls.NewSecretKey()
+	require.NoError(err)
+	sk1, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		nodeID = ids.GenerateTestNodeID()
+		pk0    = bls.PublicFromSecretKey(sk0)
+		pk1    = bls.PublicFromSecretKey(sk1)
+		vdrs   = validators.NewManager()
+	)
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, pk1, ids.Empty, 100))
+
+	// The validator gained 1 weight at every height and changed its key at
+	// height 8. The checkpoint doesn't match the diffs so that the test can
+	// tell that it was used.
+	weightDiffs := make(map[uint64]uint64)
+	for height := uint64(1); height <= 10; height++ {
+		weightDiffs[height] = 1
+	}
+	m := NewManager(
+		logging.NoLog{},
+		config.Config{
+			Validators: vdrs,
+		},
+		&testState{
+			lastAccepted: lastAccepted,
+			nodeID:       nodeID,
+			weightDiffs:  weightDiffs,
+			publicKeyDiffs: map[uint64]*bls.PublicKey{
+				8: pk0,
+			},
+			checkpoints: map[ids.ID]map[uint64]map[ids.NodeID]*validators.GetValidatorOutput{
+				constants.PrimaryNetworkID: {
+					5: {
+						nodeID: {
+							NodeID:    nodeID,
+							PublicKey: pk1,
+							Weight:    50,
+						},
+					},
+				},
+			},
+		},
+		metrics.Noop,
+		&mockable.Clock{},
+		CacheConfig{},
+	)
+
+	// The key diff above the checkpoint isn't applied.
+	pk, err := m.GetValidatorPublicKey(context.Background(), nodeID, 3)
+	require.NoError(err)
+	require.Equal(pk1, pk)
+
+	// Without a checkpoint below the current height, the diffs are applied
+	// from the current height.
+	pk, err = m.GetValidatorPublicKey(context.Background(), nodeID, 7)
+	require.NoError(err)
+	require.Equal(pk0, pk)
+
+	weight, err := m.GetSubsetWeight(context.Background(), constants.PrimaryNetworkID, set.Of(nodeID), 3)
+	require.NoError(err)
+	require.Equal(uint64(48), weight)
+
+	weight, err = m.GetSubsetWeight(context.Background(), constants.PrimaryNetworkID, set.Of(nodeID), 7)
+	require.NoError(err)
+	require.Equal(uint64(97), weight)
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/linked"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// spilledSetKey = [subnetID] + [height]
+	spilledSetKeyLength = ids.IDLen + database.Uint64Size
+
+	// spilledValidator = [nodeID] + [weight] + [hasPublicKey] + [publicKey]
+	spilledValidatorLength        = ids.NodeIDLen + database.Uint64Size + 1
+	spilledValidatorWithKeyLength = spilledValidatorLength + bls.PublicKeyLen
+)
+
+var (
+	_ cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput] = (*spillCache)(nil)
+
+	errTruncatedSpilledSet = errors.New("truncated spilled validator set")
+)
+
+// CacheConfig bounds the memory used by the cached validator sets of each
+// tracked subnet.
+type CacheConfig struct {
+	// MaxValidators is the number of validators, summed across the cached
+	// validator sets of a subnet, that may be held in memory. Once exceeded,
+	// the least recently used validator sets are spilled to [SpillDB]. If 0,
+	// a fixed number of validator sets of each subnet are held in memory and
+	// nothing is spilled.
+	MaxValidators int
+	// MaxSpilledSets is the number of validator sets of each subnet that may
+	// be spilled to [SpillDB]. Once exceeded, the least recently spilled
+	// validator sets are deleted.
+	MaxSpilledSets int
+	// SpillDB holds the spilled validator sets. Its contents are only valid
+	// for the lifetime of the manager, so it should be cleared on startup.
+	SpillDB database.Database
+}
+
+// spillCache is a validator set cache of a single subnet that keeps its memory
+// usage bounded by the number of validators it holds. Rather than discarding
+// the least recently used validator sets, they are serialized to disk. The
+// heights of the spilled validator sets are indexed in memory, so that a miss
+// never reads from disk. A spilled validator set is moved back into memory
+// when it's requested.
+type spillCache struct {
+	log      logging.Logger
+	subnetID ids.ID
+	db       database.Database
+
+	lock sync.Mutex
+	// height -> validator set, ordered from least to most recently used
+	inMemory        *linked.Hashmap[uint64, map[ids.NodeID]*validators.GetValidatorOutput]
+	inMemorySize    int
+	maxInMemorySize int
+	// heights of the validator sets on disk, ordered from least to most
+	// recently spilled
+	spilled    *linked.Hashmap[uint64, struct{}]
+	maxSpilled int
+}
+
+func newSpillCache(log logging.Logger, subnetID ids.ID, config CacheConfig) *spillCache {
+	return &spillCache{
+		log:             log,
+		subnetID:        subnetID,
+		db:              config.SpillDB,
+		inMemory:        linked.NewHashmap[uint64, map[ids.NodeID]*validators.GetValidatorOutput](),
+		maxInMemorySize: config.MaxValidators,
+		spilled:         linked.NewHashmap[uint64, struct{}](),
+		maxSpilled:      config.MaxSpilledSets,
+	}
+}
+
+func (c *spillCache) Put(height uint64, vdrs map[ids.NodeID]*validators.GetValidatorOutput) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(height)
+	c.put(height, vdrs)
+}
+
+func (c *spillCache) Get(height uint64) (map[ids.NodeID]*validators.GetValidatorOutput, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if vdrs, ok := c.inMemory.Get(height); ok {
+		c.inMemory.Put(height, vdrs) // Mark [height] as MRU.
+		return vdrs, true
+	}
+	if _, ok := c.spilled.Get(height); !ok {
+		return nil, false
+	}
+
+	key := marshalSpilledSetKey(c.subnetID, height)
+	vdrs, err := c.read(key)
+	c.spilled.Delete(height)
+	c.delete(key)
+	if err != nil {
+		c.log.Warn("failed to read spilled validator set",
+			zap.Stringer("subnetID", c.subnetID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+
+	c.put(height, vdrs)
+	return vdrs, true
+}
+
+func (c *spillCache) Evict(height uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(height)
+}
+
+func (c *spillCache) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	it := c.spilled.NewIterator()
+	for it.Next() {
+		c.delete(marshalSpilledSetKey(c.subnetID, it.Key()))
+	}
+	c.spilled.Clear()
+	c.inMemory.Clear()
+	c.inMemorySize = 0
+}
+
+func (c *spillCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.inMemory.Len() + c.spilled.Len()
+}
+
+func (c *spillCache) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return float64(c.inMemorySize) / float64(c.maxInMemorySize)
+}
+
+// put inserts [vdrs] into memory and spills the least recently used validator
+// sets until the memory bound is honored. A validator set that is larger than
+// the memory bound is spilled immediately.
+func (c *spillCache) put(height uint64, vdrs map[ids.NodeID]*validators.GetValidatorOutput) {
+	c.inMemory.Put(height, vdrs)
+	c.inMemorySize += spillCacheSize(vdrs)
+	for c.inMemorySize > c.maxInMemorySize {
+		oldestHeight, oldestVdrs, _ := c.inMemory.Oldest()
+		c.inMemory.Delete(oldestHeight)
+		c.inMemorySize -= spillCacheSize(oldestVdrs)
+		c.spill(oldestHeight, oldestVdrs)
+	}
+}
+
+// spill writes [vdrs] to disk and deletes the least recently spilled validator
+// sets until at most [maxSpilled] sets are on disk. Because this is a cache,
+// failing to write a validator set only drops it.
+func (c *spillCache) spill(height uint64, vdrs map[ids.NodeID]*validators.GetValidatorOutput) {
+	if c.maxSpilled <= 0 {
+		return
+	}
+
+	key := marshalSpilledSetKey(c.subnetID, height)
+	if err := c.db.Put(key, marshalSpilledSet(vdrs)); err != nil {
+		c.log.Warn("failed to spill validator set",
+			zap.Stringer("subnetID", c.subnetID),
+			zap.Uint64("height", height),
+			zap.Int("numValidators", len(vdrs)),
+			zap.Error(err),
+		)
+		return
+	}
+	c.spilled.Put(height, struct{}{})
+
+	for c.spilled.Len() > c.maxSpilled {
+		oldestHeight, _, _ := c.spilled.Oldest()
+		c.spilled.Delete(oldestHeight)
+		c.delete(marshalSpilledSetKey(c.subnetID, oldestHeight))
+	}
+}
+
+func (c *spillCache) evict(height uint64) {
+	if vdrs, ok := c.inMemory.Get(height); ok {
+		c.inMemory.Delete(height)
+		c.inMemorySize -= spillCacheSize(vdrs)
+	}
+	if c.spilled.Delete(height) {
+		c.delete(marshalSpilledSetKey(c.subnetID, height))
+	}
+}
+
+func (c *spillCache) read(key []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	vdrsBytes, err := c.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalSpilledSet(vdrsBytes)
+}
+
+// delete removes [key] from disk. A validator set that fails to be deleted is
+// no longer indexed, so it's never read and is cleared on the next startup.
+func (c *spillCache) delete(key []byte) {
+	if err := c.db.Delete(key); err != nil {
+		c.log.Warn("failed to delete spilled validator set",
+			zap.Stringer("subnetID", c.subnetID),
+			zap.Error(err),
+		)
+	}
+}
+
+// spillCacheSize returns the number of validators in [vdrs]. Empty validator
+// sets are counted as a single validator so that the number of sets in memory
+// is bounded.
+func spillCacheSize(vdrs map[ids.NodeID]*validators.GetValidatorOutput) int {
+	return max(len(vdrs), 1)
+}
+
+func marshalSpilledSetKey(subnetID ids.ID, height uint64) []byte {
+	key := make([]byte, spilledSetKeyLength)
+	copy(key, subnetID[:])
+	binary.BigEndian.PutUint64(key[ids.IDLen:], height)
+	return key
+}
+
+func marshalSpilledSet(vdrs map[ids.NodeID]*validators.GetValidatorOutput) []byte {
+	nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+	for nodeID := range vdrs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+
+	vdrsBytes := make([]byte, 0, len(vdrs)*spilledValidatorWithKeyLength)
+	for _, nodeID := range nodeIDs {
+		vdr := vdrs[nodeID]
+		vdrsBytes = append(vdrsBytes, nodeID.Bytes()...)
+		vdrsBytes = binary.BigEndian.AppendUint64(vdrsBytes, vdr.Weight)
+		if vdr.PublicKey == nil {
+			vdrsBytes = append(vdrsBytes, 0)
+			continue
+		}
+		vdrsBytes = append(vdrsBytes, 1)
+		vdrsBytes = append(vdrsBytes, bls.PublicKeyToCompressedBytes(vdr.PublicKey)...)
+	}
+	return vdrsBytes
+}
+
+func unmarshalSpilledSet(vdrsBytes []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput)
+	for len(vdrsBytes) > 0 {
+		if len(vdrsBytes) < spilledValidatorLength {
+			return nil, errTruncatedSpilledSet
+		}
+		nodeID, err := ids.ToNodeID(vdrsBytes[:ids.NodeIDLen])
+		if err != nil {
+			return nil, err
+		}
+		vdr := &validators.GetValidatorOutput{
+			NodeID: nodeID,
+			Weight: binary.BigEndian.Uint64(vdrsBytes[ids.NodeIDLen:]),
+		}
+		hasPublicKey := vdrsBytes[spilledValidatorLength-1] == 1
+		vdrsBytes = vdrsBytes[spilledValidatorLength:]
+
+		if hasPublicKey {
+			if len(vdrsBytes) < bls.PublicKeyLen {
+				return nil, errTruncatedSpilledSet
+			}
+			vdr.PublicKey, err = bls.PublicKeyFromCompressedBytes(vdrsBytes[:bls.PublicKeyLen])
+			if err != nil {
+				return nil, err
+			}
+			vdrsBytes = vdrsBytes[bls.PublicKeyLen:]
+		}
+		vdrs[nodeID] = vdr
+	}
+	return vdrs, nil
+}
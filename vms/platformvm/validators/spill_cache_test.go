@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestValidatorSet(t *testing.T, numValidators int) map[ids.NodeID]*validators.GetValidatorOutput {
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, numValidators)
+	for i := 0; i < numValidators; i++ {
+		vdr := &validators.GetValidatorOutput{
+			NodeID: ids.GenerateTestNodeID(),
+			Weight: uint64(i + 1),
+		}
+		// Only every other validator has a public key.
+		if i%2 == 0 {
+			sk, err := bls.NewSecretKey()
+			require.NoError(t, err)
+			vdr.PublicKey = bls.PublicFromSecretKey(sk)
+		}
+		vdrs[vdr.NodeID] = vdr
+	}
+	return vdrs
+}
+
+func TestSpillCache(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db       = memdb.New()
+		subnetID = ids.GenerateTestID()
+		c        = newSpillCache(logging.NoLog{}, subnetID, CacheConfig{
+			MaxValidators:  4,
+			MaxSpilledSets: 2,
+			SpillDB:        db,
+		})
+		vdrs1 = newTestValidatorSet(t, 2)
+		vdrs2 = newTestValidatorSet(t, 2)
+		vdrs3 = newTestValidatorSet(t, 2)
+		vdrs4 = newTestValidatorSet(t, 2)
+	)
+
+	c.Put(1, vdrs1)
+	c.Put(2, vdrs2)
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Zero(count)
+
+	// Putting a third set exceeds the memory bound, so the least recently
+	// used set is spilled.
+	c.Put(3, vdrs3)
+	require.Equal(3, c.Len())
+	has, err := db.Has(marshalSpilledSetKey(subnetID, 1))
+	require.NoError(err)
+	require.True(has)
+
+	// Getting the spilled set moves it back into memory, which spills the
+	// least recently used set.
+	vdrs, ok := c.Get(1)
+	require.True(ok)
+	require.Equal(vdrs1, vdrs)
+	has, err = db.Has(marshalSpilledSetKey(subnetID, 1))
+	require.NoError(err)
+	require.False(has)
+	has, err = db.Has(marshalSpilledSetKey(subnetID, 2))
+	require.NoError(err)
+	require.True(has)
+
+	// Only the most recently spilled sets are kept on disk.
+	c.Put(4, vdrs4)
+	require.Equal(4, c.Len())
+	c.Put(5, newTestValidatorSet(t, 2))
+	require.Equal(4, c.Len())
+	_, ok = c.Get(2)
+	require.False(ok)
+	count, err = database.Count(db)
+	require.NoError(err)
+	require.Equal(2, count)
+
+	vdrs, ok = c.Get(3)
+	require.True(ok)
+	require.Equal(vdrs3, vdrs)
+
+	// Evicting a spilled set removes it from disk.
+	c.Evict(1)
+	_, ok = c.Get(1)
+	require.False(ok)
+	has, err = db.Has(marshalSpilledSetKey(subnetID, 1))
+	require.NoError(err)
+	require.False(has)
+
+	c.Flush()
+	require.Zero(c.Len())
+	count, err = database.Count(db)
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestSpillCacheWithoutSpilling(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	c := newSpillCache(logging.NoLog{}, ids.GenerateTestID(), CacheConfig{
+		MaxValidators: 2,
+		SpillDB:       db,
+	})
+
+	// A set that is larger than the memory bound isn't cached.
+	c.Put(1, newTestValidatorSet(t, 3))
+	_, ok := c.Get(1)
+	require.False(ok)
+
+	// Empty sets are still bounded.
+	c.Put(2, newTestValidatorSet(t, 0))
+	c.Put(3, newTestValidatorSet(t, 0))
+	c.Put(4, newTestValidatorSet(t, 0))
+	require.Equal(2, c.Len())
+	_, ok = c.Get(2)
+	require.False(ok)
+
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestSpilledSetSerialization(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := newTestValidatorSet(t, 5)
+	vdrsBytes := marshalSpilledSet(vdrs)
+	parsedVdrs, err := unmarshalSpilledSet(vdrsBytes)
+	require.NoError(err)
+	require.Equal(vdrs, parsedVdrs)
+
+	parsedVdrs, err = unmarshalSpilledSet(nil)
+	require.NoError(err)
+	require.Empty(parsedVdrs)
+
+	_, err = unmarshalSpilledSet(vdrsBytes[:len(vdrsBytes)-1])
+	require.ErrorIs(err, errTruncatedSpilledSet)
+}
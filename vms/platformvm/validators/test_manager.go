@@ -8,6 +8,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var TestManager Manager = testManager{}
@@ -31,3 +33,19 @@ func (testManager) GetValidatorSet(context.Context, uint64, ids.ID) (map[ids.Nod
 }
 
 func (testManager) OnAcceptedBlockID(ids.ID) {}
+
+func (testManager) CheckConsistency(context.Context) error {
+	return nil
+}
+
+func (testManager) GetValidatorPublicKey(context.Context, ids.NodeID, uint64) (*bls.PublicKey, error) {
+	return nil, nil
+}
+
+func (testManager) GetTotalWeight(context.Context, ids.ID, uint64) (uint64, error) {
+	return 0, nil
+}
+
+func (testManager) GetSubsetWeight(context.Context, ids.ID, set.Set[ids.NodeID], uint64) (uint64, error) {
+	return 0, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -40,6 +41,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/scheduler"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/txs/mempool"
@@ -52,7 +54,21 @@ import (
 	pvalidators "github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
 
+const (
+	// scheduledTxsIssueFrequency is how often the scheduled txs are checked to
+	// see if any of them should be issued.
+	scheduledTxsIssueFrequency = time.Second
+	// mempoolResizeFrequency is how often the mempool is resized based on the
+	// memory usage of the process, if enabled.
+	mempoolResizeFrequency = 10 * time.Second
+)
+
 var (
+	scheduledTxsPrefix      = []byte("scheduledTxs")
+	validatorSetCachePrefix = []byte("validatorSetCache")
+
+	errInconsistentDatabase = errors.New("inconsistent database")
+
 	_ snowmanblock.ChainVM       = (*VM)(nil)
 	_ secp256k1fx.VM             = (*VM)(nil)
 	_ validators.State           = (*VM)(nil)
@@ -86,6 +102,16 @@ type VM struct {
 
 	manager blockexecutor.Manager
 
+	// txExecutorBackend is shared by the block and tx executors. The API uses
+	// it to apply the same reward calculation as consensus.
+	txExecutorBackend *txexecutor.Backend
+
+	validatorManager pvalidators.Manager
+
+	// scheduler holds the signed txs that are waiting for the chain time to
+	// reach their issue time
+	scheduler scheduler.Scheduler
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -134,6 +160,12 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if execConfig.CheckpointFile != "" {
+		if err := vm.importCheckpoint(execConfig.CheckpointFile); err != nil {
+			return fmt.Errorf("failed to import checkpoint: %w", err)
+		}
+	}
+
 	rewards := reward.NewCalculator(vm.RewardConfig)
 
 	vm.state, err = state.New(
@@ -150,13 +182,43 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
+	if execConfig.VerifyDatabase {
+		if err := vm.verifyDatabase(ctx); err != nil {
+			return err
+		}
+	}
+
+	vm.scheduler, err = scheduler.New(prefixdb.New(scheduledTxsPrefix, vm.db))
+	if err != nil {
+		return fmt.Errorf("failed to initialize tx scheduler: %w", err)
+	}
+
+	// The spilled validator sets are only indexed in memory, so the sets
+	// spilled before a restart can never be read.
+	validatorSetCacheDB := prefixdb.New(validatorSetCachePrefix, vm.db)
+	if err := database.AtomicClear(validatorSetCacheDB, validatorSetCacheDB); err != nil {
+		return fmt.Errorf("failed to clear spilled validator sets: %w", err)
+	}
+
+	validatorManager := pvalidators.NewManager(
+		chainCtx.Log,
+		vm.Config,
+		vm.state,
+		vm.metrics,
+		&vm.clock,
+		pvalidators.CacheConfig{
+			MaxValidators:  execConfig.ValidatorSetCacheMaxValidators,
+			MaxSpilledSets: execConfig.ValidatorSetCacheMaxSpilledSets,
+			SpillDB:        validatorSetCacheDB,
+		},
+	)
 	vm.State = validatorManager
+	vm.validatorManager = validatorManager
 	utxoVerifier := utxo.NewVerifier(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state, &vm.clock)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
 
-	txExecutorBackend := &txexecutor.Backend{
+	vm.txExecutorBackend = &txexecutor.Backend{
 		Config:       &vm.Config,
 		Ctx:          vm.ctx,
 		Clk:          &vm.clock,
@@ -165,22 +227,32 @@ func (vm *VM) Initialize(
 		Uptimes:      vm.uptimeManager,
 		Rewards:      rewards,
 		Bootstrapped: &vm.bootstrapped,
+		VerifiedTxs:  &cache.LRU[ids.ID, struct{}]{Size: execConfig.VerifiedTxCacheSize},
+		AcceptHooks:  txexecutor.NewAcceptHooks(),
 	}
 
-	mempool, err := pmempool.New("mempool", registerer, toEngine)
+	mempool, err := pmempool.New(
+		"mempool",
+		registerer,
+		toEngine,
+		vm.ctx.AVAXAssetID,
+		execConfig.MempoolMinFeeBumpPercent,
+		execConfig.MempoolMaxSubnetStakerTxs,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	mempool.SetMaxSize(execConfig.MempoolMaxSize)
 
 	vm.manager = blockexecutor.NewManager(
 		mempool,
 		vm.metrics,
 		vm.state,
-		txExecutorBackend,
+		vm.txExecutorBackend,
 		validatorManager,
 	)
 
-	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
+	txVerifier := network.NewLockedTxVerifier(&vm.txExecutorBackend.Ctx.Lock, vm.manager)
 	vm.Network, err = network.New(
 		chainCtx.Log,
 		chainCtx.NodeID,
@@ -191,7 +263,7 @@ func (vm *VM) Initialize(
 		),
 		txVerifier,
 		mempool,
-		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
+		vm.txExecutorBackend.Config.PartialSyncPrimaryNetwork,
 		appSender,
 		registerer,
 		execConfig.Network,
@@ -208,7 +280,7 @@ func (vm *VM) Initialize(
 
 	vm.Builder = blockbuilder.New(
 		mempool,
-		txExecutorBackend,
+		vm.txExecutorBackend,
 		vm.manager,
 	)
 
@@ -231,6 +303,13 @@ func (vm *VM) Initialize(
 	// Incrementing [awaitShutdown] would cause a deadlock since
 	// [periodicallyPruneMempool] grabs the context lock.
 	go vm.periodicallyPruneMempool(execConfig.MempoolPruneFrequency)
+	go vm.periodicallyIssueScheduledTxs(scheduledTxsIssueFrequency)
+	if execConfig.MempoolMemoryLimit > 0 {
+		go vm.periodicallyResizeMempool(mempool, execConfig)
+	}
+	if frequency := execConfig.ValidatorSetConsistencyCheckFrequency; frequency > 0 {
+		go vm.periodicallyCheckValidatorSetConsistency(validatorManager, frequency)
+	}
 
 	go func() {
 		err := vm.state.ReindexBlocks(&vm.ctx.Lock, vm.ctx.Log)
@@ -244,6 +323,35 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// verifyDatabase checks the consistency of the state and logs every
+// inconsistency that is found. An error is returned if the database is
+// inconsistent, so that a corrupted database is never built on.
+func (vm *VM) verifyDatabase(ctx context.Context) error {
+	startTime := time.Now()
+	vm.ctx.Log.Info("verifying database")
+
+	inconsistencies, err := vm.state.Verify(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify database: %w", err)
+	}
+	for _, inconsistency := range inconsistencies {
+		vm.ctx.Log.Error("found database inconsistency",
+			zap.Error(inconsistency),
+		)
+	}
+	if len(inconsistencies) != 0 {
+		return fmt.Errorf("%w: found %d inconsistencies",
+			errInconsistentDatabase,
+			len(inconsistencies),
+		)
+	}
+
+	vm.ctx.Log.Info("verified database",
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
 func (vm *VM) periodicallyPruneMempool(frequency time.Duration) {
 	ticker := time.NewTicker(frequency)
 	defer ticker.Stop()
@@ -269,21 +377,122 @@ func (vm *VM) pruneMempool() error {
 	// Packing all of the transactions in order performs additional checks that
 	// the MempoolTxVerifier doesn't include. So, evicting transactions from
 	// here is expected to happen occasionally.
-	blockTxs, err := vm.Builder.PackBlockTxs(math.MaxInt)
-	if err != nil {
-		return err
-	}
+	_, err := vm.Builder.PackBlockTxs(math.MaxInt)
+	return err
+}
 
-	for _, tx := range blockTxs {
-		if err := vm.Builder.Add(tx); err != nil {
-			vm.ctx.Log.Debug(
-				"failed to reissue tx",
-				zap.Stringer("txID", tx.ID()),
-				zap.Error(err),
+// periodicallyResizeMempool shrinks the mempool while the memory usage of the
+// process exceeds [execConfig.MempoolMemoryLimit] and grows it back once the usage
+// drops.
+func (vm *VM) periodicallyResizeMempool(
+	mempool pmempool.Mempool,
+	execConfig *config.ExecutionConfig,
+) {
+	ticker := time.NewTicker(mempoolResizeFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-vm.onShutdownCtx.Done():
+			return
+		case <-ticker.C:
+			memoryUsage := pmempool.MemoryUsage()
+			size := pmempool.Size(
+				execConfig.MempoolMinSize,
+				execConfig.MempoolMaxSize,
+				execConfig.MempoolMemoryLimit,
+				memoryUsage,
 			)
+			if evicted := mempool.SetMaxSize(size); len(evicted) > 0 {
+				vm.ctx.Log.Info("evicted txs from the mempool due to memory pressure",
+					zap.Uint64("memoryUsage", memoryUsage),
+					zap.Int("mempoolSize", size),
+					zap.Int("numEvicted", len(evicted)),
+				)
+			}
 		}
 	}
+}
 
+func (vm *VM) periodicallyCheckValidatorSetConsistency(
+	validatorManager pvalidators.Manager,
+	frequency time.Duration,
+) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-vm.onShutdownCtx.Done():
+			return
+		case <-ticker.C:
+			vm.ctx.Lock.Lock()
+			err := validatorManager.CheckConsistency(vm.onShutdownCtx)
+			vm.ctx.Lock.Unlock()
+
+			// Inconsistencies are reported by the validator manager.
+			if err != nil && !errors.Is(err, pvalidators.ErrInconsistentValidatorSet) {
+				vm.ctx.Log.Warn("checking validator set consistency failed",
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func (vm *VM) periodicallyIssueScheduledTxs(frequency time.Duration) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-vm.onShutdownCtx.Done():
+			return
+		case <-ticker.C:
+			if err := vm.issueScheduledTxs(); err != nil {
+				vm.ctx.Log.Warn("issuing scheduled txs failed",
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// issueScheduledTxs issues all the scheduled txs whose issue time is at or
+// before the last accepted chain time. Txs that fail to be issued remain
+// scheduled, and are retried, until they are issued or cancelled.
+func (vm *VM) issueScheduledTxs() error {
+	vm.ctx.Lock.Lock()
+	if !vm.bootstrapped.Get() {
+		vm.ctx.Lock.Unlock()
+		return nil
+	}
+	chainTime := vm.state.GetTimestamp()
+	vm.ctx.Lock.Unlock()
+
+	// The context lock must not be held here because the mempool grabs it
+	// while verifying the txs.
+	for _, tx := range vm.scheduler.Ready(chainTime) {
+		txID := tx.ID()
+		if err := vm.issueTxFromRPC(tx); err != nil {
+			// Only the first failure, or a change in the failure, is logged
+			// to avoid logging the same error every time the tx is retried.
+			prevErr := vm.scheduler.SetIssueErr(txID, err)
+			if prevErr == nil || prevErr.Error() != err.Error() {
+				vm.ctx.Log.Warn("failed to issue scheduled tx",
+					zap.Stringer("txID", txID),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		if err := vm.scheduler.Remove(txID); err != nil {
+			return err
+		}
+		vm.ctx.Log.Info("issued scheduled tx",
+			zap.Stringer("txID", txID),
+		)
+	}
 	return nil
 }
 
@@ -468,7 +677,8 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	}
 	err := server.RegisterService(service, "platform")
 	return map[string]http.Handler{
-		"": server,
+		"":         server,
+		"/stakers": &stakerExporter{service: service},
 	}, err
 }
 
@@ -505,6 +715,12 @@ func (vm *VM) Logger() logging.Logger {
 	return vm.ctx.Log
 }
 
+// AcceptHooks returns the registry of callbacks that are fired when blocks
+// containing txs are accepted. It is only available after Initialize.
+func (vm *VM) AcceptHooks() *txexecutor.AcceptHooks {
+	return vm.txExecutorBackend.AcceptHooks
+}
+
 func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
 	return vm.state.GetBlockIDAtHeight(height)
 }
@@ -441,7 +441,9 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 		Validators:             validators.NewManager(),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			BanffTime:    latestForkTime,
@@ -89,6 +89,7 @@ const (
 var (
 	defaultMinStakingDuration = 24 * time.Hour
 	defaultMaxStakingDuration = 365 * 24 * time.Hour
+	defaultMaxFutureStartTime = 2 * 7 * 24 * time.Hour
 
 	defaultRewardConfig = reward.Config{
 		MaxConsumptionRate: .12 * reward.PercentDenominator,
@@ -253,7 +254,9 @@ func defaultVM(t *testing.T, f fork) (*VM, *txstest.Builder, database.Database,
 		MaxValidatorStake:      defaultMaxValidatorStake,
 		MinDelegatorStake:      defaultMinDelegatorStake,
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			ApricotPhase3Time: apricotPhase3Time,
@@ -1179,7 +1182,9 @@ func TestRestartFullyAccepted(t *testing.T) {
 		Validators:             validators.NewManager(),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			BanffTime:    latestForkTime,
@@ -1269,7 +1274,9 @@ func TestRestartFullyAccepted(t *testing.T) {
 		Validators:             validators.NewManager(),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			BanffTime:    latestForkTime,
@@ -1320,7 +1327,9 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		Validators:             validators.NewManager(),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			BanffTime:    latestForkTime,
@@ -1669,7 +1678,9 @@ func TestUnverifiedParent(t *testing.T) {
 		Validators:             validators.NewManager(),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		MinStakeDuration:       defaultMinStakingDuration,
+		MinDelegationDuration:  defaultMinStakingDuration,
 		MaxStakeDuration:       defaultMaxStakingDuration,
+		MaxFutureStartTime:     defaultMaxFutureStartTime,
 		RewardConfig:           defaultRewardConfig,
 		UpgradeConfig: upgrade.Config{
 			BanffTime:    latestForkTime,
@@ -2486,3 +2497,38 @@ func TestPruneMempool(t *testing.T) {
 	_, ok = vm.Builder.Get(baseTxID)
 	require.True(ok)
 }
+
+func TestAcceptHooks(t *testing.T) {
+	require := require.New(t)
+	vm, txBuilder, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var accepted []ids.ID
+	txexecutor.RegisterAcceptHook(vm.AcceptHooks(), func(tx *txs.Tx, _ *txs.CreateSubnetTx) {
+		accepted = append(accepted, tx.ID())
+	})
+
+	createSubnetTx, err := txBuilder.NewCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+		},
+		[]*secp256k1.PrivateKey{keys[0]},
+	)
+	require.NoError(err)
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTxFromRPC(createSubnetTx))
+	vm.ctx.Lock.Lock()
+
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	// Hooks are only fired once the block is accepted.
+	require.NoError(blk.Verify(context.Background()))
+	require.Empty(accepted)
+
+	require.NoError(blk.Accept(context.Background()))
+	require.Equal([]ids.ID{createSubnetTx.ID()}, accepted)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/linked"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/setmap"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -24,7 +25,8 @@ const (
 	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
 	droppedTxIDsCacheSize = 64
 
-	// maxMempoolSize is the maximum number of bytes allowed in the mempool
+	// maxMempoolSize is the default maximum number of bytes allowed in the
+	// mempool
 	maxMempoolSize = 64 * units.MiB
 )
 
@@ -33,6 +35,9 @@ var (
 	ErrTxTooLarge           = errors.New("tx too large")
 	ErrMempoolFull          = errors.New("mempool is full")
 	ErrConflictsWithOtherTx = errors.New("tx conflicts with other tx")
+	ErrInsufficientFeeBump  = errors.New("insufficient fee bump to replace conflicting txs")
+	ErrReplacedByFee        = errors.New("replaced by a conflicting tx paying a higher fee")
+	ErrRejectedByFilter     = errors.New("tx rejected by admission filter")
 )
 
 type Tx interface {
@@ -43,9 +48,34 @@ type Tx interface {
 
 type Metrics interface {
 	Update(numTxs, bytesAvailable int)
+	// Replaced records that [numTxs] txs were replaced by a conflicting tx
+	// paying a higher fee.
+	Replaced(numTxs int)
+}
+
+// ReplacementConfig allows a tx to replace the txs in the mempool that it
+// conflicts with, if it pays a sufficiently higher fee.
+type ReplacementConfig[T Tx] struct {
+	// Fee returns the fee paid by [tx].
+	Fee func(tx T) (uint64, error)
+	// MinFeeBumpPercent is the minimum percentage by which the fee of a tx
+	// must exceed the total fee of the txs it conflicts with to replace them.
+	MinFeeBumpPercent uint64
+	// OnReplace, if non-nil, is called with every tx that is replaced and the
+	// tx that replaced it. It is called while holding the mempool lock.
+	OnReplace func(replaced, replacement T)
 }
 
 type Mempool[T Tx] interface {
+	// AddAdmissionFilter registers [filter] to be evaluated by Add. A tx is
+	// only added if [filter] returns nil for it, along with every other
+	// registered filter. Txs that are already in the mempool are not
+	// re-evaluated.
+	//
+	// Filters are called while holding the mempool lock, so they must not
+	// call into the mempool.
+	AddAdmissionFilter(filter func(tx T) error)
+
 	Add(tx T) error
 	Get(txID ids.ID) (T, bool)
 	// Remove [txs] and any conflicts of [txs] from the mempool.
@@ -65,26 +95,52 @@ type Mempool[T Tx] interface {
 
 	// Len returns the number of txs in the mempool.
 	Len() int
+
+	// SetMaxSize sets the maximum number of bytes allowed in the mempool. If
+	// the txs in the mempool exceed [maxSize] bytes, the most recently added
+	// txs are evicted until they don't. The evicted txs are returned and are
+	// not marked as dropped, so they may be reissued.
+	SetMaxSize(maxSize int) []T
 }
 
 type mempool[T Tx] struct {
 	lock           sync.RWMutex
 	unissuedTxs    *linked.Hashmap[ids.ID, T]
 	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
+	maxSize        int
 	bytesAvailable int
 	droppedTxIDs   *cache.LRU[ids.ID, error] // TxID -> Verification error
 
+	// replacement is nil if conflicting txs are never replaced.
+	replacement *ReplacementConfig[T]
+
+	filters []func(tx T) error
+
 	metrics Metrics
 }
 
+// New returns a mempool that rejects txs that conflict with txs already in the
+// mempool.
 func New[T Tx](
 	metrics Metrics,
+) *mempool[T] {
+	return NewWithReplacement[T](metrics, nil)
+}
+
+// NewWithReplacement returns a mempool where a tx can replace the txs it
+// conflicts with according to [replacement]. If [replacement] is nil,
+// conflicting txs are rejected.
+func NewWithReplacement[T Tx](
+	metrics Metrics,
+	replacement *ReplacementConfig[T],
 ) *mempool[T] {
 	m := &mempool[T]{
 		unissuedTxs:    linked.NewHashmap[ids.ID, T](),
 		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
+		maxSize:        maxMempoolSize,
 		bytesAvailable: maxMempoolSize,
 		droppedTxIDs:   &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		replacement:    replacement,
 		metrics:        metrics,
 	}
 	m.updateMetrics()
@@ -96,6 +152,13 @@ func (m *mempool[T]) updateMetrics() {
 	m.metrics.Update(m.unissuedTxs.Len(), m.bytesAvailable)
 }
 
+func (m *mempool[T]) AddAdmissionFilter(filter func(tx T) error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.filters = append(m.filters, filter)
+}
+
 func (m *mempool[T]) Add(tx T) error {
 	txID := tx.ID()
 
@@ -115,17 +178,42 @@ func (m *mempool[T]) Add(tx T) error {
 			MaxTxSize,
 		)
 	}
-	if txSize > m.bytesAvailable {
+
+	for _, filter := range m.filters {
+		if err := filter(tx); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrRejectedByFilter, txID, err)
+		}
+	}
+
+	var (
+		inputs    = tx.InputIDs()
+		conflicts []T
+		// replacedSize is the number of bytes that would be freed by replacing
+		// the conflicting txs.
+		replacedSize int
+	)
+	if m.replacement != nil {
+		conflicts = m.getConflicts(inputs)
+		for _, conflict := range conflicts {
+			replacedSize += conflict.Size()
+		}
+	}
+
+	if bytesAvailable := m.bytesAvailable + replacedSize; txSize > bytesAvailable {
 		return fmt.Errorf("%w: %s size (%d) > available space (%d)",
 			ErrMempoolFull,
 			txID,
 			txSize,
-			m.bytesAvailable,
+			bytesAvailable,
 		)
 	}
 
-	inputs := tx.InputIDs()
-	if m.consumedUTXOs.HasOverlap(inputs) {
+	if len(conflicts) > 0 {
+		if err := m.verifyReplacement(tx, conflicts); err != nil {
+			return err
+		}
+		m.replace(tx, conflicts)
+	} else if m.consumedUTXOs.HasOverlap(inputs) {
 		return fmt.Errorf("%w: %s", ErrConflictsWithOtherTx, txID)
 	}
 
@@ -141,6 +229,88 @@ func (m *mempool[T]) Add(tx T) error {
 	return nil
 }
 
+// getConflicts returns the txs in the mempool that consume any of [inputs].
+//
+// Assumes the lock is held.
+func (m *mempool[T]) getConflicts(inputs set.Set[ids.ID]) []T {
+	var (
+		conflictIDs set.Set[ids.ID]
+		conflicts   []T
+	)
+	for input := range inputs {
+		conflictID, ok := m.consumedUTXOs.GetKey(input)
+		if !ok || conflictIDs.Contains(conflictID) {
+			continue
+		}
+		conflictIDs.Add(conflictID)
+
+		conflict, _ := m.unissuedTxs.Get(conflictID)
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// verifyReplacement verifies that [tx] pays enough of a fee to replace
+// [conflicts].
+//
+// Assumes the lock is held.
+func (m *mempool[T]) verifyReplacement(tx T, conflicts []T) error {
+	txID := tx.ID()
+	fee, err := m.replacement.Fee(tx)
+	if err != nil {
+		return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+	}
+
+	var conflictsFee uint64
+	for _, conflict := range conflicts {
+		conflictFee, err := m.replacement.Fee(conflict)
+		if err != nil {
+			return fmt.Errorf("failed to calculate fee of %s: %w", conflict.ID(), err)
+		}
+		conflictsFee, err = math.Add64(conflictsFee, conflictFee)
+		if err != nil {
+			return err
+		}
+	}
+
+	// minFee = conflictsFee * (100 + MinFeeBumpPercent) / 100
+	bumpedFee, err := math.Mul64(conflictsFee, 100+m.replacement.MinFeeBumpPercent)
+	if err != nil {
+		return err
+	}
+	minFee := bumpedFee / 100
+	if fee <= conflictsFee || fee < minFee {
+		return fmt.Errorf("%w: %s pays %d but replacing %d conflicting tx(s) requires at least %d",
+			ErrInsufficientFeeBump,
+			txID,
+			fee,
+			len(conflicts),
+			max(minFee, conflictsFee+1),
+		)
+	}
+	return nil
+}
+
+// replace removes [conflicts] from the mempool and marks them as dropped
+// because they were replaced by [tx].
+//
+// Assumes the lock is held.
+func (m *mempool[T]) replace(tx T, conflicts []T) {
+	reason := fmt.Errorf("%w: %s", ErrReplacedByFee, tx.ID())
+	for _, conflict := range conflicts {
+		conflictID := conflict.ID()
+		m.consumedUTXOs.DeleteKey(conflictID)
+		m.unissuedTxs.Delete(conflictID)
+		m.bytesAvailable += conflict.Size()
+		m.droppedTxIDs.Put(conflictID, reason)
+
+		if m.replacement.OnReplace != nil {
+			m.replacement.OnReplace(conflict, tx)
+		}
+	}
+	m.metrics.Replaced(len(conflicts))
+}
+
 func (m *mempool[T]) Get(txID ids.ID) (T, bool) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -218,3 +388,25 @@ func (m *mempool[_]) Len() int {
 
 	return m.unissuedTxs.Len()
 }
+
+func (m *mempool[T]) SetMaxSize(maxSize int) []T {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.bytesAvailable += maxSize - m.maxSize
+	m.maxSize = maxSize
+
+	var evicted []T
+	for m.bytesAvailable < 0 {
+		txID, tx, ok := m.unissuedTxs.Newest()
+		if !ok {
+			break
+		}
+		m.consumedUTXOs.DeleteKey(txID)
+		m.unissuedTxs.Delete(txID)
+		m.bytesAvailable += tx.Size()
+		evicted = append(evicted, tx)
+	}
+	m.updateMetrics()
+	return evicted
+}
@@ -5,6 +5,7 @@ package mempool
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,7 @@ type dummyTx struct {
 	size     int
 	id       ids.ID
 	inputIDs []ids.ID
+	fee      uint64
 }
 
 func (tx *dummyTx) Size() int {
@@ -37,6 +39,8 @@ type noMetrics struct{}
 
 func (*noMetrics) Update(int, int) {}
 
+func (*noMetrics) Replaced(int) {}
+
 func newMempool() *mempool[*dummyTx] {
 	return New[*dummyTx](&noMetrics{})
 }
@@ -191,6 +195,154 @@ func TestRemoveConflict(t *testing.T) {
 	require.False(exists)
 }
 
+func TestReplaceByFee(t *testing.T) {
+	var (
+		tx0 = newTx(0, 32)
+		tx1 = newTx(1, 32)
+	)
+	tx0.fee = 100
+	tx1.fee = 100
+
+	tests := []struct {
+		name        string
+		tx          *dummyTx
+		err         error
+		expectedTxs []*dummyTx
+	}{
+		{
+			name: "insufficient fee bump",
+			tx: &dummyTx{
+				size:     32,
+				id:       ids.GenerateTestID(),
+				inputIDs: tx0.inputIDs,
+				fee:      109,
+			},
+			err:         ErrInsufficientFeeBump,
+			expectedTxs: []*dummyTx{tx0, tx1},
+		},
+		{
+			name: "replace single tx",
+			tx: &dummyTx{
+				size:     32,
+				id:       ids.GenerateTestID(),
+				inputIDs: tx0.inputIDs,
+				fee:      110,
+			},
+			expectedTxs: []*dummyTx{tx1},
+		},
+		{
+			name: "insufficient fee bump to replace multiple txs",
+			tx: &dummyTx{
+				size:     32,
+				id:       ids.GenerateTestID(),
+				inputIDs: append(tx0.inputIDs, tx1.inputIDs...),
+				fee:      219,
+			},
+			err:         ErrInsufficientFeeBump,
+			expectedTxs: []*dummyTx{tx0, tx1},
+		},
+		{
+			name: "replace multiple txs",
+			tx: &dummyTx{
+				size:     32,
+				id:       ids.GenerateTestID(),
+				inputIDs: append(tx0.inputIDs, tx1.inputIDs...),
+				fee:      220,
+			},
+			expectedTxs: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var replaced []*dummyTx
+			mempool := NewWithReplacement[*dummyTx](
+				&noMetrics{},
+				&ReplacementConfig[*dummyTx]{
+					Fee: func(tx *dummyTx) (uint64, error) {
+						return tx.fee, nil
+					},
+					MinFeeBumpPercent: 10,
+					OnReplace: func(tx, replacement *dummyTx) {
+						require.Equal(test.tx, replacement)
+						replaced = append(replaced, tx)
+					},
+				},
+			)
+			require.NoError(mempool.Add(tx0))
+			require.NoError(mempool.Add(tx1))
+
+			err := mempool.Add(test.tx)
+			require.ErrorIs(err, test.err)
+
+			_, ok := mempool.Get(test.tx.ID())
+			require.Equal(test.err == nil, ok)
+
+			for _, tx := range []*dummyTx{tx0, tx1} {
+				_, ok := mempool.Get(tx.ID())
+				require.Equal(slices.Contains(test.expectedTxs, tx), ok)
+
+				isReplaced := slices.Contains(replaced, tx)
+				require.Equal(!ok, isReplaced)
+				if isReplaced {
+					require.ErrorIs(mempool.GetDropReason(tx.ID()), ErrReplacedByFee)
+				}
+			}
+
+			expectedBytesAvailable := maxMempoolSize - 32*len(test.expectedTxs)
+			if test.err == nil {
+				expectedBytesAvailable -= test.tx.Size()
+			}
+			require.Equal(expectedBytesAvailable, mempool.bytesAvailable)
+		})
+	}
+}
+
+func TestAdmissionFilters(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		errTooSmall = errors.New("tx too small")
+		errBlocked  = errors.New("tx blocked")
+
+		blockedInput = ids.GenerateTestID()
+		smallTx      = newTx(0, 8)
+		blockedTx    = &dummyTx{
+			size:     32,
+			id:       ids.GenerateTestID(),
+			inputIDs: []ids.ID{blockedInput},
+		}
+		validTx = newTx(1, 32)
+	)
+
+	mempool := newMempool()
+	mempool.AddAdmissionFilter(func(tx *dummyTx) error {
+		if tx.Size() < 16 {
+			return errTooSmall
+		}
+		return nil
+	})
+	mempool.AddAdmissionFilter(func(tx *dummyTx) error {
+		if inputIDs := tx.InputIDs(); inputIDs.Contains(blockedInput) {
+			return errBlocked
+		}
+		return nil
+	})
+
+	err := mempool.Add(smallTx)
+	require.ErrorIs(err, ErrRejectedByFilter)
+	require.ErrorIs(err, errTooSmall)
+
+	err = mempool.Add(blockedTx)
+	require.ErrorIs(err, ErrRejectedByFilter)
+	require.ErrorIs(err, errBlocked)
+
+	require.NoError(mempool.Add(validTx))
+	require.Equal(1, mempool.Len())
+	require.Equal(maxMempoolSize-validTx.Size(), mempool.bytesAvailable)
+}
+
 func TestIterate(t *testing.T) {
 	require := require.New(t)
 
@@ -297,3 +449,35 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	err = mpool.Add(tx)
 	require.NoError(err, "should have added tx to mempool")
 }
+
+func TestSetMaxSize(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+	txs := newTxs(3, 32)
+	for _, tx := range txs {
+		require.NoError(mempool.Add(tx))
+	}
+
+	// Shrinking the mempool evicts the most recently added txs until the
+	// remaining txs fit.
+	evicted := mempool.SetMaxSize(70)
+	require.Equal([]*dummyTx{txs[2]}, evicted)
+	require.Equal(2, mempool.Len())
+	require.Equal(6, mempool.bytesAvailable)
+	require.NoError(mempool.GetDropReason(txs[2].ID()))
+
+	evicted = mempool.SetMaxSize(32)
+	require.Equal([]*dummyTx{txs[1]}, evicted)
+	_, ok := mempool.Get(txs[0].ID())
+	require.True(ok)
+
+	// The mempool is full.
+	err := mempool.Add(txs[1])
+	require.ErrorIs(err, ErrMempoolFull)
+
+	// Growing the mempool makes space for more txs without evicting any.
+	require.Empty(mempool.SetMaxSize(64))
+	require.NoError(mempool.Add(txs[1]))
+	require.Equal(2, mempool.Len())
+}
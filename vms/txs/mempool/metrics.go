@@ -14,6 +14,7 @@ var _ Metrics = (*metrics)(nil)
 type metrics struct {
 	numTxs               prometheus.Gauge
 	bytesAvailableMetric prometheus.Gauge
+	numReplacedTxs       prometheus.Counter
 }
 
 func NewMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -28,11 +29,17 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			Name:      "bytes_available",
 			Help:      "Number of bytes of space currently available in the mempool",
 		}),
+		numReplacedTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "replaced",
+			Help:      "Number of transactions replaced by a conflicting transaction paying a higher fee",
+		}),
 	}
 
 	err := utils.Err(
 		registerer.Register(m.numTxs),
 		registerer.Register(m.bytesAvailableMetric),
+		registerer.Register(m.numReplacedTxs),
 	)
 
 	return m, err
@@ -42,3 +49,7 @@ func (m *metrics) Update(numTxs, bytesAvailable int) {
 	m.numTxs.Set(float64(numTxs))
 	m.bytesAvailableMetric.Set(float64(bytesAvailable))
 }
+
+func (m *metrics) Replaced(numTxs int) {
+	m.numReplacedTxs.Add(float64(numTxs))
+}
@@ -70,6 +70,30 @@ func (b *backendVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnersh
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) ChangeDelegationFeeTx(tx *txs.ChangeDelegationFeeTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RotateValidatorNodeTx(tx *txs.RotateValidatorNodeTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) SetValidatorBLSKeyTx(tx *txs.SetValidatorBLSKeyTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) ReportMisbehaviorTx(tx *txs.ReportMisbehaviorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) SetSubnetStakingParamsTx(tx *txs.SetSubnetStakingParamsTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) ReduceSubnetValidatorWeightTx(tx *txs.ReduceSubnetValidatorWeightTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) BaseTx(tx *txs.BaseTx) error {
 	return b.baseTx(tx)
 }
@@ -115,6 +139,30 @@ func (b *backendVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessV
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddSplitRewardsPermissionlessValidatorTx(tx *txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddValidatorWithSubnetsTx(tx *txs.AddValidatorWithSubnetsTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddVestingPermissionlessValidatorTx(tx *txs.AddVestingPermissionlessValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) SetSubnetValidatorEpochTx(tx *txs.SetSubnetValidatorEpochTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	return b.baseTx(&tx.BaseTx)
 }
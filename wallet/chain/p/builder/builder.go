@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -256,6 +258,16 @@ type Builder interface {
 		rewardsOwner *secp256k1fx.OutputOwners,
 		options ...common.Option,
 	) (*txs.AddPermissionlessDelegatorTx, error)
+
+	// PreviewTx describes the funds that [utx] consumes and produces. No keys
+	// are needed, so the preview can be reviewed before the tx is signed.
+	//
+	// - [utx] is the unsigned transaction to describe. Typically, it was
+	//   built by this builder with the same [options].
+	PreviewTx(
+		utx txs.UnsignedTx,
+		options ...common.Option,
+	) (*TxPreview, error)
 }
 
 type Backend interface {
@@ -1178,13 +1190,58 @@ func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*se
 	inputSigIndices, ok := common.MatchOwners(owner, addrs, minIssuanceTime)
 	if !ok {
 		// We can't authorize the subnet
-		return nil, ErrInsufficientAuthorization
+		return nil, b.insufficientSubnetAuthorization(subnetID, owner, addrs, minIssuanceTime)
 	}
 	return &secp256k1fx.Input{
 		SigIndices: inputSigIndices,
 	}, nil
 }
 
+// insufficientSubnetAuthorization returns an error describing why [addrs]
+// can't satisfy the [owner] of [subnetID] at [minIssuanceTime], including the
+// owner addresses whose keys are missing.
+func (b *builder) insufficientSubnetAuthorization(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	addrs set.Set[ids.ShortID],
+	minIssuanceTime uint64,
+) error {
+	if owner.Locktime > minIssuanceTime {
+		return fmt.Errorf(
+			"%w: owner of subnet %q is locked until %d",
+			ErrInsufficientAuthorization,
+			subnetID,
+			owner.Locktime,
+		)
+	}
+
+	var (
+		hrp            = constants.GetHRP(b.context.NetworkID)
+		numAvailable   int
+		missingSigners = make([]string, 0, len(owner.Addrs))
+	)
+	for _, addr := range owner.Addrs {
+		if addrs.Contains(addr) {
+			numAvailable++
+			continue
+		}
+
+		addrStr, err := address.Format("P", hrp, addr[:])
+		if err != nil {
+			return err
+		}
+		missingSigners = append(missingSigners, addrStr)
+	}
+	return fmt.Errorf(
+		"%w: subnet %q requires %d signature(s) but only %d of the owner's keys are available; missing signers: [%s]",
+		ErrInsufficientAuthorization,
+		subnetID,
+		owner.Threshold,
+		numAvailable,
+		strings.Join(missingSigners, ", "),
+	)
+}
+
 func (b *builder) initCtx(tx txs.UnsignedTx) error {
 	ctx, err := NewSnowContext(b.context.NetworkID, b.context.AVAXAssetID)
 	if err != nil {
@@ -248,3 +248,13 @@ func (b *builderWithOptions) NewAddPermissionlessDelegatorTx(
 		common.UnionOptions(b.options, options)...,
 	)
 }
+
+func (b *builderWithOptions) PreviewTx(
+	utx txs.UnsignedTx,
+	options ...common.Option,
+) (*TxPreview, error) {
+	return b.builder.PreviewTx(
+		utx,
+		common.UnionOptions(b.options, options)...,
+	)
+}
@@ -0,0 +1,285 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var (
+	ErrUnsupportedTxType = errors.New("unsupported tx type")
+	ErrProducedTooMuch   = errors.New("tx produces more than it consumes")
+
+	_ txs.Visitor = (*previewVisitor)(nil)
+)
+
+// TxPreview describes the funds that an unsigned transaction consumes and
+// produces, so that the transaction can be reviewed before it is signed.
+type TxPreview struct {
+	// Inputs are the P-chain UTXOs consumed by the transaction.
+	Inputs []*avax.TransferableInput
+	// ImportedInputs are the UTXOs consumed from the shared memory of another
+	// chain.
+	ImportedInputs []*avax.TransferableInput
+	// Outputs are the P-chain outputs produced by the transaction, excluding
+	// the change.
+	Outputs []*avax.TransferableOutput
+	// ExportedOutputs are the outputs produced in the shared memory of
+	// another chain.
+	ExportedOutputs []*avax.TransferableOutput
+	// Staked are the outputs that are locked until the staking period ends.
+	Staked []*avax.TransferableOutput
+	// Change are the P-chain outputs that are returned to the change owner.
+	Change []*avax.TransferableOutput
+	// Fee is the amount of each asset that is burned by the transaction.
+	Fee map[ids.ID]uint64
+}
+
+func (b *builder) PreviewTx(
+	utx txs.UnsignedTx,
+	options ...common.Option,
+) (*TxPreview, error) {
+	visitor := &previewVisitor{}
+	if err := utx.Visit(visitor); err != nil {
+		return nil, err
+	}
+
+	var (
+		ops         = common.NewOptions(options)
+		changeOwner = ops.ChangeOwner(nil)
+		addrs       = ops.Addresses(b.addrs)
+		preview     = &TxPreview{
+			Inputs:          visitor.baseTx.Ins,
+			ImportedInputs:  visitor.importedInputs,
+			ExportedOutputs: visitor.exportedOutputs,
+			Staked:          visitor.staked,
+			Fee:             make(map[ids.ID]uint64),
+		}
+	)
+	for _, out := range visitor.baseTx.Outs {
+		if isChange(out, changeOwner, addrs) {
+			preview.Change = append(preview.Change, out)
+		} else {
+			preview.Outputs = append(preview.Outputs, out)
+		}
+	}
+
+	consumed := make(map[ids.ID]uint64)
+	for _, ins := range [][]*avax.TransferableInput{preview.Inputs, preview.ImportedInputs} {
+		for _, in := range ins {
+			assetID := in.AssetID()
+			amount, err := math.Add64(consumed[assetID], in.Input().Amount())
+			if err != nil {
+				return nil, err
+			}
+			consumed[assetID] = amount
+		}
+	}
+
+	produced := make(map[ids.ID]uint64)
+	for _, outs := range [][]*avax.TransferableOutput{visitor.baseTx.Outs, preview.ExportedOutputs, preview.Staked} {
+		for _, out := range outs {
+			assetID := out.AssetID()
+			amount, err := math.Add64(produced[assetID], out.Output().Amount())
+			if err != nil {
+				return nil, err
+			}
+			produced[assetID] = amount
+		}
+	}
+
+	for assetID, consumedAmount := range consumed {
+		fee, err := math.Sub(consumedAmount, produced[assetID])
+		if err != nil {
+			return nil, fmt.Errorf("%w of asset %s", ErrProducedTooMuch, assetID)
+		}
+		if fee != 0 {
+			preview.Fee[assetID] = fee
+		}
+	}
+	for assetID := range produced {
+		if _, ok := consumed[assetID]; !ok {
+			return nil, fmt.Errorf("%w of asset %s", ErrProducedTooMuch, assetID)
+		}
+	}
+	return preview, nil
+}
+
+// isChange returns true if [out] is an unlocked output owned by
+// [changeOwner]. If [changeOwner] is nil, the output must only be spendable
+// by [addrs].
+func isChange(
+	out *avax.TransferableOutput,
+	changeOwner *secp256k1fx.OutputOwners,
+	addrs set.Set[ids.ShortID],
+) bool {
+	transferOut, ok := out.Out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return false
+	}
+	owners := &transferOut.OutputOwners
+	if changeOwner != nil {
+		return owners.Equals(changeOwner)
+	}
+	if owners.Locktime != 0 || len(owners.Addrs) == 0 {
+		return false
+	}
+	for _, addr := range owners.Addrs {
+		if !addrs.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// previewVisitor collects the funds consumed and produced by a transaction.
+type previewVisitor struct {
+	baseTx          *txs.BaseTx
+	importedInputs  []*avax.TransferableInput
+	exportedOutputs []*avax.TransferableOutput
+	staked          []*avax.TransferableOutput
+}
+
+func (*previewVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return ErrUnsupportedTxType
+}
+
+func (*previewVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return ErrUnsupportedTxType
+}
+
+func (v *previewVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	v.baseTx = &tx.BaseTx
+	v.staked = tx.StakeOuts
+	return nil
+}
+
+func (v *previewVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	v.baseTx = &tx.BaseTx
+	v.staked = tx.StakeOuts
+	return nil
+}
+
+func (v *previewVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) ImportTx(tx *txs.ImportTx) error {
+	v.baseTx = &tx.BaseTx
+	v.importedInputs = tx.ImportedInputs
+	return nil
+}
+
+func (v *previewVisitor) ExportTx(tx *txs.ExportTx) error {
+	v.baseTx = &tx.BaseTx
+	v.exportedOutputs = tx.ExportedOutputs
+	return nil
+}
+
+func (v *previewVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	v.baseTx = &tx.BaseTx
+	v.staked = tx.StakeOuts
+	return nil
+}
+
+func (v *previewVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	v.baseTx = &tx.BaseTx
+	v.staked = tx.StakeOuts
+	return nil
+}
+
+func (v *previewVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) BaseTx(tx *txs.BaseTx) error {
+	v.baseTx = tx
+	return nil
+}
+
+func (v *previewVisitor) ChangeDelegationFeeTx(tx *txs.ChangeDelegationFeeTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) RotateValidatorNodeTx(tx *txs.RotateValidatorNodeTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	return v.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (v *previewVisitor) SetValidatorBLSKeyTx(tx *txs.SetValidatorBLSKeyTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) ReportMisbehaviorTx(tx *txs.ReportMisbehaviorTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) SetSubnetStakingParamsTx(tx *txs.SetSubnetStakingParamsTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) ReduceSubnetValidatorWeightTx(tx *txs.ReduceSubnetValidatorWeightTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
+
+func (v *previewVisitor) AddSplitRewardsPermissionlessValidatorTx(tx *txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return v.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (v *previewVisitor) AddValidatorWithSubnetsTx(tx *txs.AddValidatorWithSubnetsTx) error {
+	return v.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (v *previewVisitor) AddVestingPermissionlessValidatorTx(tx *txs.AddVestingPermissionlessValidatorTx) error {
+	return v.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (v *previewVisitor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return v.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (v *previewVisitor) SetSubnetValidatorEpochTx(tx *txs.SetSubnetValidatorEpochTx) error {
+	v.baseTx = &tx.BaseTx
+	return nil
+}
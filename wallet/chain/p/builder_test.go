@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -151,6 +152,60 @@ func TestAddSubnetValidatorTx(t *testing.T) {
 	require.Equal(expectedConsumed, consumed)
 }
 
+func TestAddSubnetValidatorTxInsufficientAuthorization(t *testing.T) {
+	var (
+		require = require.New(t)
+
+		// backend
+		utxosKey   = testKeys[1]
+		utxos      = makeTestUTXOs(utxosKey)
+		chainUTXOs = common.NewDeterministicChainUTXOs(require, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+
+		subnetID            = ids.GenerateTestID()
+		subnetAuthAddr      = testKeys[0].Address()
+		missingSubnetSigner = testKeys[2].Address()
+		subnetOwner         = &secp256k1fx.OutputOwners{
+			Threshold: 2,
+			Addrs:     []ids.ShortID{subnetAuthAddr, missingSubnetSigner},
+		}
+		subnets = map[ids.ID]*txs.Tx{
+			subnetID: {
+				Unsigned: &txs.CreateSubnetTx{
+					Owner: subnetOwner,
+				},
+			},
+		}
+
+		backend = NewBackend(testContext, chainUTXOs, subnets)
+
+		// builder
+		utxoAddr = utxosKey.Address()
+		b        = builder.New(set.Of(utxoAddr, subnetAuthAddr), testContext, backend)
+
+		// data to build the transaction
+		subnetValidator = &txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				End:    uint64(time.Now().Add(time.Hour).Unix()),
+			},
+			Subnet: subnetID,
+		}
+	)
+
+	missingAddr, err := address.Format(
+		"P",
+		constants.GetHRP(testContext.NetworkID),
+		missingSubnetSigner[:],
+	)
+	require.NoError(err)
+
+	_, err = b.NewAddSubnetValidatorTx(subnetValidator)
+	require.ErrorIs(err, builder.ErrInsufficientAuthorization)
+	require.ErrorContains(err, missingAddr)
+}
+
 func TestRemoveSubnetValidatorTx(t *testing.T) {
 	var (
 		require = require.New(t)
@@ -657,6 +712,61 @@ func TestAddPermissionlessDelegatorTx(t *testing.T) {
 	require.Equal(expectedConsumed, consumed)
 }
 
+func TestPreviewTx(t *testing.T) {
+	var (
+		require = require.New(t)
+
+		// backend
+		utxosKey   = testKeys[1]
+		utxos      = makeTestUTXOs(utxosKey)
+		chainUTXOs = common.NewDeterministicChainUTXOs(require, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+
+		// builder
+		utxoAddr = utxosKey.Address()
+		builder  = builder.New(set.Of(utxoAddr), testContext, backend)
+
+		// data to build the transaction
+		outputsToMove = []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 7 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{testKeys[0].Address()},
+				},
+			},
+		}}
+	)
+
+	utx, err := builder.NewBaseTx(outputsToMove)
+	require.NoError(err)
+
+	preview, err := builder.PreviewTx(utx)
+	require.NoError(err)
+	require.Equal(utx.Ins, preview.Inputs)
+	require.Equal(outputsToMove, preview.Outputs)
+	require.Len(preview.Change, 1)
+	require.Empty(preview.ImportedInputs)
+	require.Empty(preview.ExportedOutputs)
+	require.Empty(preview.Staked)
+	require.Equal(map[ids.ID]uint64{avaxAssetID: testContext.BaseTxFee}, preview.Fee)
+
+	// The change is only recognized if it is sent to the change owner.
+	preview, err = builder.PreviewTx(
+		utx,
+		common.WithChangeOwner(&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{testKeys[0].Address()},
+		}),
+	)
+	require.NoError(err)
+	require.Equal(utx.Outs[:1], preview.Outputs)
+	require.Equal(outputsToMove, preview.Change)
+}
+
 func makeTestUTXOs(utxosKey *secp256k1.PrivateKey) []*avax.UTXO {
 	// Note: we avoid ids.GenerateTestNodeID here to make sure that UTXO IDs won't change
 	// run by run. This simplifies checking what utxos are included in the built txs.
@@ -59,6 +59,14 @@ func (s *visitor) BaseTx(tx *txs.BaseTx) error {
 	return sign(s.tx, false, txSigners)
 }
 
+func (s *visitor) ReportMisbehaviorTx(tx *txs.ReportMisbehaviorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, false, txSigners)
+}
+
 func (s *visitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -156,6 +164,24 @@ func (s *visitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) e
 	return sign(s.tx, true, txSigners)
 }
 
+// ChangeDelegationFeeTx isn't supported because the authorization is checked
+// against the validator's rewards owner, which isn't known to the backend.
+func (*visitor) ChangeDelegationFeeTx(*txs.ChangeDelegationFeeTx) error {
+	return ErrUnsupportedTxType
+}
+
+// RotateValidatorNodeTx isn't supported because the authorization is checked
+// against the validator's rewards owner, which isn't known to the backend.
+func (*visitor) RotateValidatorNodeTx(*txs.RotateValidatorNodeTx) error {
+	return ErrUnsupportedTxType
+}
+
+// SetValidatorBLSKeyTx isn't supported because the authorization is checked
+// against the validator's rewards owner, which isn't known to the backend.
+func (*visitor) SetValidatorBLSKeyTx(*txs.SetValidatorBLSKeyTx) error {
+	return ErrUnsupportedTxType
+}
+
 func (s *visitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -169,6 +195,45 @@ func (s *visitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *visitor) SetSubnetStakingParamsTx(tx *txs.SetSubnetStakingParamsTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *visitor) ReduceSubnetValidatorWeightTx(tx *txs.ReduceSubnetValidatorWeightTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *visitor) SetSubnetValidatorEpochTx(tx *txs.SetSubnetValidatorEpochTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
 func (s *visitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -177,6 +242,39 @@ func (s *visitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidato
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *visitor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	return s.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (s *visitor) AddSplitRewardsPermissionlessValidatorTx(tx *txs.AddSplitRewardsPermissionlessValidatorTx) error {
+	return s.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (s *visitor) AddValidatorWithSubnetsTx(tx *txs.AddValidatorWithSubnetsTx) error {
+	// The subnet validators are signed first because their credentials are
+	// included in the bytes signed by this tx.
+	for _, subnetValidatorTx := range tx.SubnetValidators {
+		err := subnetValidatorTx.Unsigned.Visit(&visitor{
+			kc:      s.kc,
+			backend: s.backend,
+			ctx:     s.ctx,
+			tx:      subnetValidatorTx,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (s *visitor) AddVestingPermissionlessValidatorTx(tx *txs.AddVestingPermissionlessValidatorTx) error {
+	return s.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (s *visitor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return s.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
 func (s *visitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
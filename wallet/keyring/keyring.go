@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package keyring manages secp256k1 keys on the client.
+//
+// It replaces the flows of the deprecated node keystore: addresses are listed
+// and messages are signed locally, and the keys never leave the client. Keys
+// previously stored in the node keystore can be moved into a keyring with
+// [ImportFromKeystore] or [Keyring.ImportExportedKeys].
+package keyring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/cb58"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ json.Marshaler   = (*Keyring)(nil)
+	_ json.Unmarshaler = (*Keyring)(nil)
+
+	ErrUnknownAddress     = errors.New("unknown address")
+	ErrInvalidExportedKey = errors.New("invalid exported key")
+
+	errMissingKeyPrefix = fmt.Errorf("missing %s prefix", secp256k1.PrivateKeyPrefix)
+)
+
+// Keyring is a set of secp256k1 keys held by the client.
+//
+// Keyring is not safe for concurrent use.
+type Keyring struct {
+	kc *secp256k1fx.Keychain
+}
+
+// New returns a keyring containing [keys].
+func New(keys ...*secp256k1.PrivateKey) *Keyring {
+	return &Keyring{
+		kc: secp256k1fx.NewKeychain(keys...),
+	}
+}
+
+// Add inserts [key] into the keyring and returns its address. Adding a key
+// that is already in the keyring is a no-op.
+func (k *Keyring) Add(key *secp256k1.PrivateKey) ids.ShortID {
+	k.kc.Add(key)
+	return key.Address()
+}
+
+// ImportExportedKeys adds the keys in [keyStrs] to the keyring. Each key must
+// be formatted as it is returned by the node keystore's exportKey method, e.g.
+// "PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN".
+func (k *Keyring) ImportExportedKeys(keyStrs ...string) ([]ids.ShortID, error) {
+	keys := make([]*secp256k1.PrivateKey, len(keyStrs))
+	for i, keyStr := range keyStrs {
+		key, err := parseExportedKey(keyStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w at index %d: %w", ErrInvalidExportedKey, i, err)
+		}
+		keys[i] = key
+	}
+
+	addrs := make([]ids.ShortID, len(keys))
+	for i, key := range keys {
+		addrs[i] = k.Add(key)
+	}
+	return addrs, nil
+}
+
+func parseExportedKey(keyStr string) (*secp256k1.PrivateKey, error) {
+	if !strings.HasPrefix(keyStr, secp256k1.PrivateKeyPrefix) {
+		return nil, errMissingKeyPrefix
+	}
+	keyBytes, err := cb58.Decode(keyStr[len(secp256k1.PrivateKeyPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.ToPrivateKey(keyBytes)
+}
+
+// Keychain returns the keychain backing the keyring. It can be used to build
+// and sign transactions with the wallet.
+func (k *Keyring) Keychain() *secp256k1fx.Keychain {
+	return k.kc
+}
+
+// Addresses returns the addresses of the keys in the keyring, sorted.
+func (k *Keyring) Addresses() []ids.ShortID {
+	addrs := k.kc.Addrs.List()
+	utils.Sort(addrs)
+	return addrs
+}
+
+// ListAddresses returns the addresses of the keys in the keyring, sorted and
+// formatted for the chain with the alias [chainIDAlias] on the network with
+// the human readable part [hrp]. This matches the format returned by the node
+// keystore's listAddresses method.
+func (k *Keyring) ListAddresses(chainIDAlias string, hrp string) ([]string, error) {
+	addrs := k.Addresses()
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := address.Format(chainIDAlias, hrp, addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		addrStrs[i] = addrStr
+	}
+	return addrStrs, nil
+}
+
+// ExportKey returns the key controlling [addr].
+func (k *Keyring) ExportKey(addr ids.ShortID) (*secp256k1.PrivateKey, error) {
+	key, ok := k.kc.Get(addr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, addr)
+	}
+	return key.(*secp256k1.PrivateKey), nil
+}
+
+// Sign signs [msg] with the key controlling [addr].
+func (k *Keyring) Sign(addr ids.ShortID, msg []byte) ([]byte, error) {
+	key, ok := k.kc.Get(addr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, addr)
+	}
+	return key.Sign(msg)
+}
+
+// MarshalJSON encodes the keyring as the list of its keys, in the order they
+// were added, formatted as they are returned by the node keystore's exportKey
+// method.
+func (k *Keyring) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.kc.Keys)
+}
+
+// UnmarshalJSON replaces the keys in the keyring with the keys encoded by
+// [MarshalJSON].
+func (k *Keyring) UnmarshalJSON(b []byte) error {
+	var keys []*secp256k1.PrivateKey
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return err
+	}
+	k.kc = secp256k1fx.NewKeychain(keys...)
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keyring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var (
+	_ KeystoreClient = (*testKeystoreClient)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type testKeystoreClient struct {
+	keys      map[ids.ShortID]*secp256k1.PrivateKey
+	exportErr error
+}
+
+func (c *testKeystoreClient) ListAddresses(context.Context, api.UserPass, ...rpc.Option) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, 0, len(c.keys))
+	for addr := range c.keys {
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func (c *testKeystoreClient) ExportKey(_ context.Context, _ api.UserPass, addr ids.ShortID, _ ...rpc.Option) (*secp256k1.PrivateKey, error) {
+	return c.keys[addr], c.exportErr
+}
+
+func newKeys(t *testing.T, n int) []*secp256k1.PrivateKey {
+	keys := make([]*secp256k1.PrivateKey, n)
+	for i := range keys {
+		key, err := secp256k1.NewPrivateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	return keys
+}
+
+func TestKeyring(t *testing.T) {
+	require := require.New(t)
+
+	keys := newKeys(t, 2)
+	kr := New(keys[0])
+	require.Equal(keys[1].Address(), kr.Add(keys[1]))
+	// Adding a key twice is a no-op.
+	kr.Add(keys[1])
+
+	addrs := kr.Addresses()
+	require.Len(addrs, 2)
+	require.ElementsMatch([]ids.ShortID{keys[0].Address(), keys[1].Address()}, addrs)
+
+	addrStrs, err := kr.ListAddresses("X", constants.UnitTestHRP)
+	require.NoError(err)
+	require.Len(addrStrs, 2)
+	for i, addrStr := range addrStrs {
+		chainIDAlias, hrp, addrBytes, err := address.Parse(addrStr)
+		require.NoError(err)
+		require.Equal("X", chainIDAlias)
+		require.Equal(constants.UnitTestHRP, hrp)
+		require.Equal(addrs[i].Bytes(), addrBytes)
+	}
+
+	key, err := kr.ExportKey(keys[0].Address())
+	require.NoError(err)
+	require.Equal(keys[0], key)
+
+	_, err = kr.ExportKey(ids.GenerateTestShortID())
+	require.ErrorIs(err, ErrUnknownAddress)
+
+	msg := []byte("hello")
+	sig, err := kr.Sign(keys[1].Address(), msg)
+	require.NoError(err)
+	pk, err := secp256k1.RecoverPublicKeyFromHash(hashing.ComputeHash256(msg), sig)
+	require.NoError(err)
+	require.Equal(keys[1].Address(), pk.Address())
+
+	_, err = kr.Sign(ids.GenerateTestShortID(), msg)
+	require.ErrorIs(err, ErrUnknownAddress)
+}
+
+func TestKeyringJSON(t *testing.T) {
+	require := require.New(t)
+
+	kr := New(newKeys(t, 3)...)
+	krJSON, err := json.Marshal(kr)
+	require.NoError(err)
+
+	parsedKR := New()
+	require.NoError(json.Unmarshal(krJSON, parsedKR))
+	require.Equal(kr.Keychain().Keys, parsedKR.Keychain().Keys)
+}
+
+func TestKeyringImportExportedKeys(t *testing.T) {
+	require := require.New(t)
+
+	keys := newKeys(t, 2)
+	kr := New()
+	addrs, err := kr.ImportExportedKeys(keys[0].String(), keys[1].String())
+	require.NoError(err)
+	require.Equal([]ids.ShortID{keys[0].Address(), keys[1].Address()}, addrs)
+	require.Equal(keys, kr.Keychain().Keys)
+
+	// A malformed key aborts the import without adding any keys.
+	kr = New()
+	_, err = kr.ImportExportedKeys(keys[0].String(), "not a key")
+	require.ErrorIs(err, ErrInvalidExportedKey)
+	require.Empty(kr.Addresses())
+}
+
+func TestImportFromKeystore(t *testing.T) {
+	require := require.New(t)
+
+	keys := newKeys(t, 2)
+	client := &testKeystoreClient{
+		keys: map[ids.ShortID]*secp256k1.PrivateKey{
+			keys[0].Address(): keys[0],
+			keys[1].Address(): keys[1],
+		},
+	}
+
+	kr := New()
+	addrs, err := ImportFromKeystore(context.Background(), kr, client, api.UserPass{})
+	require.NoError(err)
+	require.ElementsMatch([]ids.ShortID{keys[0].Address(), keys[1].Address()}, addrs)
+	require.ElementsMatch(addrs, kr.Addresses())
+
+	// A failed export aborts the import without adding any keys.
+	client.exportErr = errTest
+	kr = New()
+	_, err = ImportFromKeystore(context.Background(), kr, client, api.UserPass{})
+	require.ErrorIs(err, errTest)
+	require.Empty(kr.Addresses())
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keyring
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// KeystoreClient is the subset of the deprecated keystore methods of a chain
+// client that are needed to move keys out of the node keystore. Both the AVM
+// and the platformvm clients implement it.
+type KeystoreClient interface {
+	ListAddresses(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]ids.ShortID, error)
+	ExportKey(ctx context.Context, user api.UserPass, addr ids.ShortID, options ...rpc.Option) (*secp256k1.PrivateKey, error)
+}
+
+// ImportFromKeystore exports every key controlled by [user] on the chain of
+// [client] from the node keystore and adds them to [kr]. The addresses of the
+// imported keys are returned.
+//
+// The keys are not removed from the node keystore.
+func ImportFromKeystore(
+	ctx context.Context,
+	kr *Keyring,
+	client KeystoreClient,
+	user api.UserPass,
+	options ...rpc.Option,
+) ([]ids.ShortID, error) {
+	addrs, err := client.ListAddresses(ctx, user, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list addresses: %w", err)
+	}
+
+	keys := make([]*secp256k1.PrivateKey, len(addrs))
+	for i, addr := range addrs {
+		key, err := client.ExportKey(ctx, user, addr, options...)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't export key of %s: %w", addr, err)
+		}
+		keys[i] = key
+	}
+
+	for _, key := range keys {
+		kr.Add(key)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+var (
+	ErrNotPrimaryNetwork = errors.New("delegation must be to the primary network")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
+
+// XChainDelegationCost is the amount of AVAX required to delegate from funds
+// held on the X-chain.
+type XChainDelegationCost struct {
+	// ExportAmount is the amount of AVAX that is moved to the P-chain. It
+	// covers the stake as well as the fees of the import and delegation txs.
+	ExportAmount uint64
+	// Total is the amount of AVAX that must be held on the X-chain, including
+	// the fee of the export tx.
+	Total uint64
+}
+
+// GetXChainDelegationCost calculates the AVAX required to delegate [weight]
+// to the primary network after moving the funds from the X-chain.
+func GetXChainDelegationCost(
+	xContext *xbuilder.Context,
+	pContext *pbuilder.Context,
+	weight uint64,
+) (XChainDelegationCost, error) {
+	exportAmount, err := math.Add64(weight, pContext.BaseTxFee)
+	if err != nil {
+		return XChainDelegationCost{}, err
+	}
+	exportAmount, err = math.Add64(exportAmount, pContext.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return XChainDelegationCost{}, err
+	}
+	total, err := math.Add64(exportAmount, xContext.BaseTxFee)
+	if err != nil {
+		return XChainDelegationCost{}, err
+	}
+	return XChainDelegationCost{
+		ExportAmount: exportAmount,
+		Total:        total,
+	}, nil
+}
+
+// XChainDelegationTxs are the txs issued to delegate from funds held on the
+// X-chain.
+type XChainDelegationTxs struct {
+	ExportTx    *avmtxs.Tx
+	ImportTx    *txs.Tx
+	DelegatorTx *txs.Tx
+}
+
+// IssueXChainDelegation moves AVAX from the X-chain to the P-chain and uses it
+// to delegate to [vdr] on the primary network.
+//
+// The X-chain export, the P-chain import and the delegation are issued in
+// order. The export includes enough AVAX to pay for the import and the
+// delegation, so the flow doesn't depend on any funds already held on the
+// P-chain.
+//
+// If a tx fails to be issued, the txs that were already issued are returned
+// along with the error so that the flow can be resumed.
+//
+//   - [owner] specifies the owner of the funds while they are moved to the
+//     P-chain. It must be controlled by the wallet's keychain.
+//   - [vdr] specifies the validator to delegate to.
+//   - [rewardsOwner] specifies the owner of all the rewards this delegator may
+//     accrue.
+func IssueXChainDelegation(
+	wallet Wallet,
+	owner *secp256k1fx.OutputOwners,
+	vdr *txs.SubnetValidator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*XChainDelegationTxs, error) {
+	if vdr.Subnet != constants.PrimaryNetworkID {
+		return nil, fmt.Errorf("%w: %s", ErrNotPrimaryNetwork, vdr.Subnet)
+	}
+
+	var (
+		xWallet  = wallet.X()
+		pWallet  = wallet.P()
+		xContext = xWallet.Builder().Context()
+		pContext = pWallet.Builder().Context()
+	)
+	cost, err := GetXChainDelegationCost(xContext, pContext, vdr.Wght)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the balance upfront to avoid leaving funds on the P-chain when the
+	// X-chain can't cover the whole flow.
+	balances, err := xWallet.Builder().GetFTBalance(options...)
+	if err != nil {
+		return nil, err
+	}
+	if balance := balances[xContext.AVAXAssetID]; balance < cost.Total {
+		return nil, fmt.Errorf("%w: have %d, need %d",
+			ErrInsufficientFunds,
+			balance,
+			cost.Total,
+		)
+	}
+
+	issued := &XChainDelegationTxs{}
+	issued.ExportTx, err = xWallet.IssueExportTx(
+		constants.PlatformChainID,
+		[]*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: xContext.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          cost.ExportAmount,
+				OutputOwners: *owner,
+			},
+		}},
+		options...,
+	)
+	if err != nil {
+		return issued, fmt.Errorf("failed to issue export tx: %w", err)
+	}
+
+	issued.ImportTx, err = pWallet.IssueImportTx(
+		xContext.BlockchainID,
+		owner,
+		options...,
+	)
+	if err != nil {
+		return issued, fmt.Errorf("failed to issue import tx: %w", err)
+	}
+
+	issued.DelegatorTx, err = pWallet.IssueAddPermissionlessDelegatorTx(
+		vdr,
+		pContext.AVAXAssetID,
+		rewardsOwner,
+		options...,
+	)
+	if err != nil {
+		return issued, fmt.Errorf("failed to issue delegator tx: %w", err)
+	}
+	return issued, nil
+}
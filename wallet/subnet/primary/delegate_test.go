@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+func TestGetXChainDelegationCost(t *testing.T) {
+	require := require.New(t)
+
+	xContext := &xbuilder.Context{
+		BaseTxFee: units.MilliAvax,
+	}
+	pContext := &pbuilder.Context{
+		BaseTxFee:                     2 * units.MilliAvax,
+		AddPrimaryNetworkDelegatorFee: 3 * units.MilliAvax,
+	}
+
+	cost, err := GetXChainDelegationCost(xContext, pContext, 25*units.Avax)
+	require.NoError(err)
+	require.Equal(25*units.Avax+5*units.MilliAvax, cost.ExportAmount)
+	require.Equal(25*units.Avax+6*units.MilliAvax, cost.Total)
+
+	_, err = GetXChainDelegationCost(xContext, pContext, math.MaxUint64)
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
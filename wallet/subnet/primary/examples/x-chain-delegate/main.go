@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+)
+
+func main() {
+	key := genesis.EWOQKey
+	uri := primary.LocalAPIURI
+	kc := secp256k1fx.NewKeychain(key)
+	startTime := time.Now().Add(time.Minute)
+	duration := 2 * 7 * 24 * time.Hour // 2 weeks
+	weight := 25 * units.Avax
+	nodeIDStr := "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"
+
+	nodeID, err := ids.NodeIDFromString(nodeIDStr)
+	if err != nil {
+		log.Fatalf("failed to parse node ID: %s\n", err)
+	}
+
+	ctx := context.Background()
+
+	// MakeWallet fetches the available UTXOs owned by [kc] on the network that
+	// [uri] is hosting.
+	walletSyncStartTime := time.Now()
+	wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+		URI:          uri,
+		AVAXKeychain: kc,
+		EthKeychain:  kc,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize wallet: %s\n", err)
+	}
+	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			key.Address(),
+		},
+	}
+
+	delegateStartTime := time.Now()
+	delegationTxs, err := primary.IssueXChainDelegation(
+		wallet,
+		owner,
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(startTime.Add(duration).Unix()),
+				Wght:   weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		owner,
+	)
+	if err != nil {
+		log.Fatalf("failed to delegate from the X-chain: %s\n", err)
+	}
+	log.Printf("exported %s, imported %s and delegated to %s with %s in %s\n",
+		delegationTxs.ExportTx.ID(),
+		delegationTxs.ImportTx.ID(),
+		nodeID,
+		delegationTxs.DelegatorTx.ID(),
+		time.Since(delegateStartTime),
+	)
+}